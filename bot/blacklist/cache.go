@@ -5,7 +5,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"go.uber.org/zap"
 )
 
@@ -31,13 +31,13 @@ func IsUserBlacklisted(userId uint64) bool {
 	return ok
 }
 
-func RefreshCache(ctx context.Context) error {
-	guildIds, err := dbclient.Client.ServerBlacklist.ListAll(ctx)
+func RefreshCache(ctx context.Context, services *services.Services) error {
+	guildIds, err := services.Database.ServerBlacklist.ListAll(ctx)
 	if err != nil {
 		return err
 	}
 
-	userIds, err := dbclient.Client.GlobalBlacklist.ListAll(ctx)
+	userIds, err := services.Database.GlobalBlacklist.ListAll(ctx)
 	if err != nil {
 		return err
 	}
@@ -55,11 +55,11 @@ func RefreshCache(ctx context.Context) error {
 	return nil
 }
 
-func StartCacheRefreshLoop(logger *zap.Logger) {
+func StartCacheRefreshLoop(logger *zap.Logger, services *services.Services) {
 	logger.Info("Starting blacklist cache refresh loop")
 
 	// Load cache on startup
-	if err := RefreshCache(context.Background()); err != nil {
+	if err := RefreshCache(context.Background(), services); err != nil {
 		logger.Error("Failed to refresh blacklist cache on startup", zap.Error(err))
 	}
 
@@ -68,7 +68,7 @@ func StartCacheRefreshLoop(logger *zap.Logger) {
 	for {
 		<-timer.C
 
-		if err := RefreshCache(context.Background()); err != nil {
+		if err := RefreshCache(context.Background(), services); err != nil {
 			logger.Error("Failed to refresh blacklist cache", zap.Error(err))
 			continue
 		}
@@ -18,7 +18,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -85,7 +84,7 @@ func (h *AddAdminHandler) Execute(ctx *context.ButtonContext) {
 			return
 		}
 
-		if err := dbclient.Client.Permissions.AddAdmin(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.Permissions.AddAdmin(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -95,7 +94,7 @@ func (h *AddAdminHandler) Execute(ctx *context.ButtonContext) {
 			return
 		}
 
-		if err := utils.PremiumClient.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
+		if err := ctx.Worker().Services.Premium.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -105,7 +104,7 @@ func (h *AddAdminHandler) Execute(ctx *context.ButtonContext) {
 			return
 		}
 
-		if err := dbclient.Client.RolePermissions.AddAdmin(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.RolePermissions.AddAdmin(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -174,7 +173,7 @@ func (h *AddAdminHandler) Execute(ctx *context.ButtonContext) {
 		})
 	}
 
-	openTickets, err := dbclient.Client.Tickets.GetGuildOpenTicketsExcludeThreads(ctx, ctx.GuildId())
+	openTickets, err := ctx.Worker().Services.Database.Tickets.GetGuildOpenTicketsExcludeThreads(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -192,7 +191,7 @@ func (h *AddAdminHandler) Execute(ctx *context.ButtonContext) {
 			var restError request.RestError
 			if errors.As(err, &restError) && restError.StatusCode == 404 {
 				if restError.StatusCode == 404 {
-					if err := dbclient.Client.Tickets.CloseByChannel(ctx, *ticket.ChannelId); err != nil {
+					if err := ctx.Worker().Services.Database.Tickets.CloseByChannel(ctx, *ticket.ChannelId); err != nil {
 						ctx.HandleError(err)
 						return
 					}
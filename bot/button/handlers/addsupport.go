@@ -20,7 +20,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	cmdregistry "github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -92,7 +91,7 @@ func (h *AddSupportHandler) Execute(ctx *context.ButtonContext) {
 			return
 		}
 
-		if err := dbclient.Client.Permissions.AddSupport(ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.Permissions.AddSupport(ctx.GuildId(), id); err != nil {
 			sentry.ErrorWithContext(err, ctx.ToErrorContext())
 		}
 
@@ -107,7 +106,7 @@ func (h *AddSupportHandler) Execute(ctx *context.ButtonContext) {
 			return
 		}
 
-		if err := dbclient.Client.RolePermissions.AddSupport(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.RolePermissions.AddSupport(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -175,13 +174,13 @@ func updateChannelPermissions(ctx cmdregistry.CommandContext, id uint64, mention
 		})
 	}
 
-	openTickets, err := dbclient.Client.Tickets.GetGuildOpenTicketsExcludeThreads(ctx, ctx.GuildId())
+	openTickets, err := ctx.Worker().Services.Database.Tickets.GetGuildOpenTicketsExcludeThreads(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, ctx.GuildId())
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -217,7 +216,7 @@ func updateChannelPermissions(ctx cmdregistry.CommandContext, id uint64, mention
 			var restError request.RestError
 			if errors.As(err, &restError) {
 				if restError.StatusCode == 404 {
-					if err := dbclient.Client.Tickets.CloseByChannel(ctx, *ticket.ChannelId); err != nil {
+					if err := ctx.Worker().Services.Database.Tickets.CloseByChannel(ctx, *ticket.ChannelId); err != nil {
 						ctx.HandleError(err)
 						return
 					}
@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
 
@@ -60,14 +59,14 @@ func (h *AdminDebugServerBlacklistReasonHandler) Execute(ctx *context.ButtonCont
 	IsOwnerBlacklisted := blacklist.IsUserBlacklisted(guild.OwnerId)
 	var GlobalBlacklistReason string
 	if IsOwnerBlacklisted {
-		globalBlacklist, _ := dbclient.Client.GlobalBlacklist.Get(ctx, guild.OwnerId)
+		globalBlacklist, _ := ctx.Worker().Services.Database.GlobalBlacklist.Get(ctx, guild.OwnerId)
 		if globalBlacklist != nil && globalBlacklist.Reason != nil {
 			GlobalBlacklistReason = *globalBlacklist.Reason
 		}
 	}
 
 	// Check server blacklist
-	serverBlacklist, err := dbclient.Client.ServerBlacklist.Get(ctx, guildId)
+	serverBlacklist, err := ctx.Worker().Services.Database.ServerBlacklist.Get(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -114,7 +113,7 @@ func (h *AdminDebugServerBlacklistReasonHandler) Execute(ctx *context.ButtonCont
 			} else {
 				countUserId = *serverBlacklist.RealOwnerId
 			}
-			serverCount, realCount, _ := dbclient.Client.ServerBlacklist.GetUserBlacklistedOwnerCounts(ctx, countUserId)
+			serverCount, realCount, _ := ctx.Worker().Services.Database.ServerBlacklist.GetUserBlacklistedOwnerCounts(ctx, countUserId)
 			if serverCount > 0 {
 				message += fmt.Sprintf("\nServer Owner of Blacklisted Servers: `%d`", serverCount)
 			}
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
 
@@ -56,7 +55,7 @@ func (h *AdminDebugServerEntitlementsHandler) Execute(ctx *context.ButtonContext
 	}
 
 	// Get active entitlements
-	entitlements, err := dbclient.Client.Entitlements.ListGuildSubscriptions(ctx, guildId, guild.OwnerId, 0)
+	entitlements, err := ctx.Worker().Services.Database.Entitlements.ListGuildSubscriptions(ctx, guildId, guild.OwnerId, 0)
 	if err != nil {
 		ctx.HandleError(err)
 		return
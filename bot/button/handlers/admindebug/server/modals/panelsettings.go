@@ -16,7 +16,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
 
@@ -78,7 +77,7 @@ func (h *AdminDebugServerPanelSettingsModalHandler) Execute(ctx *context.ModalCo
 	selectedValues := selectData.Values
 
 	// Get all panels for this guild
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guildId)
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -186,7 +185,7 @@ func buildPanelSettings(ctx *context.ModalContext, selectedPanel *database.Panel
 	// Form
 	form := "Disabled"
 	if selectedPanel.FormId != nil {
-		formData, ok, err := dbclient.Client.Forms.Get(ctx, *selectedPanel.FormId)
+		formData, ok, err := ctx.Worker().Services.Database.Forms.Get(ctx, *selectedPanel.FormId)
 		if err == nil && ok {
 			form = formData.Title
 		} else {
@@ -198,7 +197,7 @@ func buildPanelSettings(ctx *context.ModalContext, selectedPanel *database.Panel
 	// Exit survey
 	survey := "Disabled"
 	if selectedPanel.ExitSurveyFormId != nil {
-		surveyData, ok, err := dbclient.Client.Forms.Get(ctx, *selectedPanel.ExitSurveyFormId)
+		surveyData, ok, err := ctx.Worker().Services.Database.Forms.Get(ctx, *selectedPanel.ExitSurveyFormId)
 		if err == nil && ok {
 			survey = surveyData.Title
 		} else {
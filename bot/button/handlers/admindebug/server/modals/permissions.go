@@ -19,7 +19,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/permissionwrapper"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
@@ -89,13 +88,13 @@ func (h *AdminDebugServerPermissionsModalSubmitHandler) Execute(ctx *context.Mod
 	}
 
 	// Get guild and settings
-	settings, err := dbclient.Client.Settings.Get(ctx, guildId)
+	settings, err := ctx.Worker().Services.Database.Settings.Get(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guildId)
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -17,7 +17,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
@@ -109,32 +108,32 @@ func (h *AdminDebugServerTicketPermissionsModalSubmitHandler) Execute(ctx *conte
 	}
 
 	// Get permission data
-	adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, guildId)
+	adminUsers, err := ctx.Worker().Services.Database.Permissions.GetAdmins(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, guildId)
+	adminRoles, err := ctx.Worker().Services.Database.RolePermissions.GetAdminRoles(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, guildId)
+	supportUsers, err := ctx.Worker().Services.Database.Permissions.GetSupport(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, guildId)
+	supportRoles, err := ctx.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
 	// Get all panels to check team memberships
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guildId)
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -209,7 +208,7 @@ func checkUserTicketPermissions(ctx *context.ModalContext, worker *w.Context, gu
 		}
 
 		// Check admin roles
-		adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, guildId)
+		adminRoles, err := ctx.Worker().Services.Database.RolePermissions.GetAdminRoles(ctx, guildId)
 		if err == nil {
 			var adminRoleNames []string
 			for _, roleId := range member.Roles {
@@ -228,7 +227,7 @@ func checkUserTicketPermissions(ctx *context.ModalContext, worker *w.Context, gu
 		}
 
 		// Check support roles
-		supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, guildId)
+		supportRoles, err := ctx.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, guildId)
 		if err == nil && ticketPermLevel != "**Ticket Permission:** Admin" && ticketPermLevel != "**Ticket Permission:** Server Owner" && ticketPermLevel != "**Ticket Permission:** Admin" {
 			var supportRoleNames []string
 			for _, roleId := range member.Roles {
@@ -247,7 +246,7 @@ func checkUserTicketPermissions(ctx *context.ModalContext, worker *w.Context, gu
 		}
 
 		// Get team memberships
-		defaultTeam, teamIds, err := logic.GetMemberTeamsWithMember(ctx, guildId, userId, member)
+		defaultTeam, teamIds, err := logic.GetMemberTeamsWithMember(ctx, ctx.Worker(), guildId, userId, member)
 		if err == nil {
 			if defaultTeam {
 				lines = append(lines, "**In Default Support Team:** Yes")
@@ -258,12 +257,12 @@ func checkUserTicketPermissions(ctx *context.ModalContext, worker *w.Context, gu
 				var teamPanelDetails []string
 				for _, panel := range panels {
 					// Check if this team is assigned to this panel
-					teamUsers, err := dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
+					teamUsers, err := ctx.Worker().Services.Database.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
 					if err != nil {
 						continue
 					}
 
-					teamRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+					teamRoles, err := ctx.Worker().Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 					if err != nil {
 						continue
 					}
@@ -336,7 +335,7 @@ func checkRoleTicketPermissions(ctx *context.ModalContext, worker *w.Context, gu
 	// Check panel-specific teams
 	var teamPanels []string
 	for _, panel := range panels {
-		teamRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+		teamRoles, err := ctx.Worker().Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 		if err != nil {
 			continue
 		}
@@ -2,22 +2,19 @@ package modals
 
 import (
 	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	permcache "github.com/TicketsBot-cloud/common/permission"
-	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
-	w "github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/button/registry"
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
 
@@ -101,7 +98,7 @@ func (h *AdminDebugServerUserTicketsModalSubmitHandler) Execute(ctx *context.Mod
 	}
 
 	// Get all open tickets for the guild
-	allOpenTickets, err := dbclient.Client.Tickets.GetGuildOpenTickets(ctx, guildId)
+	allOpenTickets, err := ctx.Worker().Services.Database.Tickets.GetGuildOpenTickets(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -111,7 +108,7 @@ func (h *AdminDebugServerUserTicketsModalSubmitHandler) Execute(ctx *context.Mod
 	var results []string
 
 	for _, userId := range userIds {
-		result := checkUserTickets(ctx, worker, guildId, userId, allOpenTickets)
+		result := logic.BuildUserTicketSummary(ctx, worker, guildId, userId, allOpenTickets)
 		results = append(results, result)
 	}
 
@@ -129,70 +126,3 @@ func (h *AdminDebugServerUserTicketsModalSubmitHandler) Execute(ctx *context.Mod
 		),
 	}))
 }
-
-func checkUserTickets(ctx *context.ModalContext, worker *w.Context, guildId, userId uint64, allOpenTickets []database.Ticket) string {
-	var lines []string
-
-	// Get open ticket count
-	openCount, err := dbclient.Client.Tickets.GetOpenCountByUser(ctx, guildId, userId)
-	if err != nil {
-		openCount = 0
-	}
-
-	// Get total ticket count
-	totalCount, err := dbclient.Client.Tickets.GetTotalCountByUser(ctx, guildId, userId)
-	if err != nil {
-		totalCount = 0
-	}
-
-	// Calculate closed count
-	closedCount := totalCount - openCount
-
-	lines = append(lines, fmt.Sprintf("**Open Tickets:** %d", openCount))
-	lines = append(lines, fmt.Sprintf("**Closed Tickets:** %d", closedCount))
-	lines = append(lines, fmt.Sprintf("**Total Tickets:** %d", totalCount))
-
-	// Filter open tickets for this user
-	var userOpenTickets []database.Ticket
-	for _, ticket := range allOpenTickets {
-		if ticket.UserId == userId {
-			userOpenTickets = append(userOpenTickets, ticket)
-		}
-	}
-
-	// Show open ticket details
-	if len(userOpenTickets) > 0 {
-		var ticketDetails []string
-		for _, ticket := range userOpenTickets {
-			// Check if channel/thread still exists
-			channelExists := false
-			channelMention := fmt.Sprintf("`%d`", *ticket.ChannelId)
-
-			if ticket.ChannelId != nil {
-				_, err := worker.GetChannel(*ticket.ChannelId)
-				if err == nil {
-					channelExists = true
-				}
-			} else {
-				channelMention = "No channel"
-			}
-
-			existsStatus := "exists"
-			if !channelExists {
-				existsStatus = "deleted"
-			}
-
-			ticketDetails = append(ticketDetails, fmt.Sprintf("  • ID `%d` - %s %s", ticket.Id, channelMention, existsStatus))
-		}
-
-		if len(ticketDetails) > 10 {
-			// Limit to first 10 tickets
-			ticketDetails = ticketDetails[:10]
-			ticketDetails = append(ticketDetails, fmt.Sprintf("  • ... and %d more", len(userOpenTickets)-10))
-		}
-
-		lines = append(lines, fmt.Sprintf("\n**Open Ticket Details:**\n%s", strings.Join(ticketDetails, "\n")))
-	}
-
-	return fmt.Sprintf("**User:** <@%d>\n%s", userId, strings.Join(lines, "\n"))
-}
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry"
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 )
 
 type AdminDebugServerPanelSettingsHandler struct{}
@@ -40,7 +39,7 @@ func (h *AdminDebugServerPanelSettingsHandler) Execute(ctx *context.ButtonContex
 		return
 	}
 
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guildId)
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
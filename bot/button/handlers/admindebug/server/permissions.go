@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry"
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 )
 
 type AdminDebugServerPermissionsHandler struct{}
@@ -41,7 +40,7 @@ func (h *AdminDebugServerPermissionsHandler) Execute(ctx *context.ButtonContext)
 	}
 
 	// Get panels to build dynamic options
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guildId)
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
 
@@ -41,7 +40,7 @@ func (h *AdminDebugServerRecacheHandler) Execute(ctx *context.ButtonContext) {
 
 	guildId, err := strconv.ParseUint(strings.Replace(ctx.InteractionData.CustomId, "admin_debug_recache_", "", -1), 10, 64)
 
-	if onCooldown, cooldownTime := redis.GetRecacheCooldown(guildId); onCooldown {
+	if onCooldown, cooldownTime := ctx.Worker().Services.Redis.GetRecacheCooldown(guildId); onCooldown {
 		ctx.ReplyWith(command.NewMessageResponseWithComponents([]component.Component{
 			utils.BuildContainerWithComponents(
 				ctx,
@@ -81,7 +80,7 @@ func (h *AdminDebugServerRecacheHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	// Set the recache cooldown
-	if err := redis.SetRecacheCooldown(guildId, time.Second*30); err != nil {
+	if err := ctx.Worker().Services.Redis.SetRecacheCooldown(guildId, time.Second*30); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type BulkCancelHandler struct{}
+
+func (h *BulkCancelHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "bulk-cancel-")
+	})
+}
+
+func (h *BulkCancelHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 5,
+	}
+}
+
+func (h *BulkCancelHandler) Execute(ctx *cmdcontext.ButtonContext) {
+	id := strings.TrimPrefix(ctx.InteractionData.CustomId, "bulk-cancel-")
+
+	if _, _, err := ctx.Worker().Services.Redis.TakeBulkAction(ctx, id); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{
+		utils.BuildContainer(ctx, customisation.Red, i18n.TitleBulkAction, i18n.MessageBulkCancelled),
+	}))
+}
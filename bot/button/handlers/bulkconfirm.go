@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
+	cmdregistry "github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// bulkActionTimeout bounds how long a bulk action may run for, and is within the lifetime of the interaction token
+// used to report progress
+const bulkActionTimeout = time.Minute * 14
+
+type BulkConfirmHandler struct{}
+
+func (h *BulkConfirmHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "bulk-confirm-")
+	})
+}
+
+func (h *BulkConfirmHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 5,
+	}
+}
+
+func (h *BulkConfirmHandler) Execute(ctx *cmdcontext.ButtonContext) {
+	id := strings.TrimPrefix(ctx.InteractionData.CustomId, "bulk-confirm-")
+
+	action, ok, err := ctx.Worker().Services.Redis.TakeBulkAction(ctx, id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkExpired)
+		return
+	}
+
+	if action.GuildId != ctx.GuildId() || action.UserId != ctx.UserId() {
+		return
+	}
+
+	locked, err := ctx.Worker().Services.Redis.TakeBulkActionLock(ctx, action.GuildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !locked {
+		// Put the action back, so that it can be confirmed once the running one has finished
+		if err := ctx.Worker().Services.Redis.SetBulkAction(ctx, id, action); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkAlreadyRunning)
+		return
+	}
+
+	editBulkProgress(ctx, 0, len(action.TicketIds), logic.BulkActionResult{})
+
+	worker, premiumTier := ctx.Worker(), ctx.PremiumTier()
+	newContext := func(ticketCtx context.Context, channelId uint64) cmdregistry.CommandContext {
+		return cmdcontext.NewAutoCloseContext(ticketCtx, worker, action.GuildId, channelId, action.UserId, premiumTier)
+	}
+
+	go func() {
+		bulkCtx, cancel := context.WithTimeout(context.Background(), bulkActionTimeout)
+		defer cancel()
+
+		defer func() {
+			if err := ctx.Worker().Services.Redis.ReleaseBulkActionLock(context.Background(), action.GuildId); err != nil {
+				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			}
+		}()
+
+		_, err := logic.RunBulkAction(bulkCtx, ctx.Worker(), action, newContext, func(processed int, result logic.BulkActionResult) {
+			editBulkProgress(ctx, processed, len(action.TicketIds), result)
+		})
+		if err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{
+				utils.BuildContainer(ctx, customisation.Red, i18n.TitleBulkAction, i18n.MessageBulkStopped, err.Error()),
+			}))
+		}
+	}()
+}
+
+func editBulkProgress(ctx *cmdcontext.ButtonContext, processed, total int, result logic.BulkActionResult) {
+	title, colour := i18n.TitleBulkActionRunning, customisation.Orange
+	if processed == total {
+		title, colour = i18n.TitleBulkActionDone, customisation.Green
+	}
+
+	ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{
+		utils.BuildContainer(ctx, colour, title, i18n.MessageBulkProgress, processed, total, result.Succeeded, result.Failed, result.Skipped),
+	}))
+}
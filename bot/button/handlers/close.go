@@ -9,7 +9,6 @@ import (
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -32,7 +31,7 @@ func (h *CloseHandler) Properties() registry.Properties {
 
 func (h *CloseHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	// Get the ticket properties
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -49,7 +48,19 @@ func (h *CloseHandler) Execute(ctx *cmdcontext.ButtonContext) {
 		return
 	}
 
-	closeConfirmation, err := dbclient.Client.CloseConfirmation.Get(ctx, ctx.GuildId())
+	// If the panel requires a reason, ask for one instead of closing the ticket straight away
+	closeReasonConfig, err := logic.GetCloseReasonConfig(ctx, ctx.Worker(), ticket)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if closeReasonConfig.RequireReason {
+		ctx.Modal(buildCloseWithReasonModal(ctx, closeReasonConfig))
+		return
+	}
+
+	closeConfirmation, err := ctx.Worker().Services.Database.CloseConfirmation.Get(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -8,7 +8,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -30,7 +29,7 @@ func (h *CloseRequestAcceptHandler) Properties() registry.Properties {
 }
 
 func (h *CloseRequestAcceptHandler) Execute(ctx *context.ButtonContext) {
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -54,7 +53,7 @@ func (h *CloseRequestAcceptHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	closeRequest, ok, err := dbclient.Client.CloseRequest.Get(ctx, ticket.GuildId, ticket.Id)
+	closeRequest, ok, err := ctx.Worker().Services.Database.CloseRequest.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
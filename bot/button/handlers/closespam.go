@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/constants"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// CloseSpamHandler handles the close as spam button on the welcome message, asking staff to confirm before the ticket
+// is closed and the opener blacklisted
+type CloseSpamHandler struct{}
+
+func (h *CloseSpamHandler) Matcher() matcher.Matcher {
+	return &matcher.SimpleMatcher{
+		CustomId: "close_spam",
+	}
+}
+
+func (h *CloseSpamHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed),
+		PermissionLevel: permission.Support,
+		Timeout:         constants.TimeoutCloseTicket,
+	}
+}
+
+func (h *CloseSpamHandler) Execute(ctx *context.ButtonContext) {
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.GuildId == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	if !utils.CanClose(ctx, ctx, ticket) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseNoPermission)
+		return
+	}
+
+	config, err := ctx.Worker().Services.Database.SpamConfig.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	description := ctx.GetMessage(i18n.MessageCloseSpamConfirm)
+	if config.BlacklistDuration > 0 {
+		description += " " + ctx.GetMessage(i18n.MessageCloseSpamConfirmBlacklist, ticket.UserId, logic.FormatSpamBlacklistDuration(config.BlacklistDuration))
+	}
+
+	confirmEmbed := utils.BuildEmbedRaw(ctx.GetColour(customisation.Red), ctx.GetMessage(i18n.TitleCloseSpam), description, nil, ctx.PremiumTier())
+
+	_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponseWithComponents(confirmEmbed, []component.Component{
+		component.BuildActionRow(component.BuildButton(component.Button{
+			Label:    ctx.GetMessage(i18n.TitleCloseSpam),
+			CustomId: "close_spam_confirm",
+			Style:    component.ButtonStyleDanger,
+			Emoji:    utils.BuildEmoji("🚫"),
+		})),
+	}))
+}
+
+// CloseSpamConfirmHandler closes the ticket as spam once staff have confirmed
+type CloseSpamConfirmHandler struct{}
+
+func (h *CloseSpamConfirmHandler) Matcher() matcher.Matcher {
+	return &matcher.SimpleMatcher{
+		CustomId: "close_spam_confirm",
+	}
+}
+
+func (h *CloseSpamConfirmHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed),
+		PermissionLevel: permission.Support,
+		Timeout:         constants.TimeoutCloseTicket,
+	}
+}
+
+func (h *CloseSpamConfirmHandler) Execute(ctx *context.ButtonContext) {
+	logic.CloseTicketAsSpam(ctx.Context, ctx, nil)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -31,7 +32,7 @@ func (h *CloseWithReasonModalHandler) Properties() registry.Properties {
 }
 
 func (h *CloseWithReasonModalHandler) Execute(ctx *context.ButtonContext) {
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -47,23 +48,69 @@ func (h *CloseWithReasonModalHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	ctx.Modal(button.ResponseModal{
+	closeReasonConfig, err := logic.GetCloseReasonConfig(ctx, ctx.Worker(), ticket)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Modal(buildCloseWithReasonModal(ctx, closeReasonConfig))
+}
+
+// buildCloseWithReasonModal builds the modal for closing a ticket with a reason. If the panel has close reason presets,
+// they are offered in a select menu, and the text input becomes optional further details.
+func buildCloseWithReasonModal(ctx *context.ButtonContext, config dbclient.CloseReasonConfig) button.ResponseModal {
+	var components []component.Component
+
+	if len(config.Presets) > 0 {
+		options := make([]component.SelectOption, len(config.Presets))
+		for i, preset := range config.Presets {
+			options[i] = component.SelectOption{
+				Label: preset.Label,
+				Value: preset.Label,
+			}
+
+			if preset.Description != "" {
+				options[i].Description = utils.Ptr(preset.Description)
+			}
+		}
+
+		components = append(components, component.BuildLabel(component.Label{
+			Label: "Preset",
+			Component: component.BuildSelectMenu(component.SelectMenu{
+				CustomId:    "preset",
+				Options:     options,
+				Placeholder: "Choose a reason",
+				Required:    utils.Ptr(config.RequireReason),
+			}),
+		}))
+	}
+
+	label := i18n.Reason.GetFromGuild(ctx.Worker().Services, ctx.GuildId())
+	required := config.RequireReason
+	if len(config.Presets) > 0 {
+		label = "Details"
+		required = false
+	}
+
+	components = append(components, component.BuildLabel(component.Label{
+		Label:       label,
+		Description: utils.Ptr(i18n.Reason.GetFromGuild(ctx.Worker().Services, ctx.GuildId())),
+		Component: component.BuildInputText(component.InputText{
+			Style:       component.TextStyleParagraph,
+			CustomId:    "reason",
+			Placeholder: utils.Ptr(i18n.MessageCloseReasonPlaceholder.GetFromGuild(ctx.Worker().Services, ctx.GuildId())),
+			MinLength:   nil,
+			MaxLength:   utils.Ptr(uint32(logic.MaxCloseReasonLength)),
+			Required:    utils.Ptr(required),
+		}),
+	}))
+
+	return button.ResponseModal{
 		Data: interaction.ModalResponseData{
-			CustomId: "close_with_reason_submit",
-			Title:    i18n.TitleClose.GetFromGuild(ctx.GuildId()),
-			Components: []component.Component{
-				component.BuildLabel(component.Label{
-					Label:       i18n.Reason.GetFromGuild(ctx.GuildId()),
-					Description: utils.Ptr(i18n.Reason.GetFromGuild(ctx.GuildId())),
-					Component: component.BuildInputText(component.InputText{
-						Style:       component.TextStyleParagraph,
-						CustomId:    "reason",
-						Placeholder: utils.Ptr(i18n.MessageCloseReasonPlaceholder.GetFromGuild(ctx.GuildId())),
-						MinLength:   nil,
-						MaxLength:   utils.Ptr(uint32(1024)),
-					}),
-				}),
-			},
+			CustomId:   "close_with_reason_submit",
+			Title:      i18n.TitleClose.GetFromGuild(ctx.Worker().Services, ctx.GuildId()),
+			Components: components,
 		},
-	})
+	}
 }
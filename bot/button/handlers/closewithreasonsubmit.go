@@ -27,37 +27,44 @@ func (h *CloseWithReasonSubmitHandler) Properties() registry.Properties {
 func (h *CloseWithReasonSubmitHandler) Execute(ctx *context.ModalContext) {
 	data := ctx.Interaction.Data
 
-	// Get the reason
 	if len(data.Components) == 0 { // No action rows
 		ctx.HandleError(fmt.Errorf("No action rows found in modal components"))
 		return
 	}
 
-	actionRow := data.Components[0]
-	if len(actionRow.Components) == 0 && actionRow.Component == nil { // Text input missing
-		ctx.HandleError(fmt.Errorf("Modal missing text input"))
-		return
-	}
+	// The modal contains a preset select menu before the text input if the panel has close reason presets
+	var presetLabel, details string
+	var foundReason bool
+	for _, row := range data.Components {
+		inputs := row.Components
+		if row.Component != nil {
+			inputs = []interaction.ModalSubmitInteractionComponentData{*row.Component}
+		}
 
-	var textInput interaction.ModalSubmitInteractionComponentData
-
-	if actionRow.Component != nil {
-		textInput = *actionRow.Component
-	} else {
-		textInput = actionRow.Components[0]
+		for _, input := range inputs {
+			switch input.CustomId {
+			case "preset":
+				if len(input.Values) > 0 {
+					presetLabel = input.Values[0]
+				}
+			case "reason":
+				details = input.Value
+				foundReason = true
+			}
+		}
 	}
 
-	if textInput.CustomId != "reason" {
-		ctx.HandleError(fmt.Errorf("Text input custom ID mismatch"))
+	if !foundReason {
+		ctx.HandleError(fmt.Errorf("Modal missing text input"))
 		return
 	}
 
 	// This must be malicious
-	if len(textInput.Value) > 1024 {
+	if len(details) > logic.MaxCloseReasonLength || len(presetLabel) > logic.MaxCloseReasonPresetLabelLength {
 		ctx.HandleError(fmt.Errorf("Reason is too long"))
 		return
 	}
 
 	ctx.Ack()
-	logic.CloseTicket(ctx.Context, ctx, &textInput.Value, false)
+	logic.CloseTicket(ctx.Context, ctx, logic.BuildCloseReason(presetLabel, details), false)
 }
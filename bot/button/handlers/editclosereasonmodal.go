@@ -15,7 +15,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -67,7 +66,7 @@ func (h *EditCloseReasonModalHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	closeMetadata, _, err := dbclient.Client.CloseReason.Get(ctx.Context, guildId, ticketId)
+	closeMetadata, _, err := ctx.Worker().Services.Database.CloseReason.Get(ctx.Context, guildId, ticketId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -75,7 +74,7 @@ func (h *EditCloseReasonSubmitHandler) Execute(ctx *context.ModalContext) {
 		return
 	}
 
-	ticket, err := dbclient.Client.Tickets.Get(ctx.Context, ticketId, guildId)
+	ticket, err := ctx.Worker().Services.Database.Tickets.Get(ctx.Context, ticketId, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -85,13 +84,13 @@ func (h *EditCloseReasonSubmitHandler) Execute(ctx *context.ModalContext) {
 		return
 	}
 
-	existing, _, err := dbclient.Client.CloseReason.Get(ctx.Context, guildId, ticketId)
+	existing, _, err := ctx.Worker().Services.Database.CloseReason.Get(ctx.Context, guildId, ticketId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	if err := dbclient.Client.CloseReason.Set(ctx.Context, guildId, ticketId, database.CloseMetadata{
+	if err := ctx.Worker().Services.Database.CloseReason.Set(ctx.Context, guildId, ticketId, database.CloseMetadata{
 		Reason:   &reason,
 		ClosedBy: existing.ClosedBy,
 	}); err != nil {
@@ -99,7 +98,7 @@ func (h *EditCloseReasonSubmitHandler) Execute(ctx *context.ModalContext) {
 		return
 	}
 
-	settings, err := dbclient.Client.Settings.Get(ctx.Context, guildId)
+	settings, err := ctx.Worker().Services.Database.Settings.Get(ctx.Context, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -111,11 +110,11 @@ func (h *EditCloseReasonSubmitHandler) Execute(ctx *context.ModalContext) {
 	}
 
 	var rating *uint8
-	if r, ok, err := dbclient.Client.ServiceRatings.Get(ctx.Context, guildId, ticketId); err == nil && ok {
+	if r, ok, err := ctx.Worker().Services.Database.ServiceRatings.Get(ctx.Context, guildId, ticketId); err == nil && ok {
 		rating = &r
 	}
 
-	hasFeedback, err := dbclient.Client.ExitSurveyResponses.HasResponse(ctx.Context, guildId, ticketId)
+	hasFeedback, err := ctx.Worker().Services.Database.ExitSurveyResponses.HasResponse(ctx.Context, guildId, ticketId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
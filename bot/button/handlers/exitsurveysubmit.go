@@ -14,9 +14,7 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
-	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -59,7 +57,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		return
 	}
 
-	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, cmd.Worker().Token, cmd.Worker().RateLimiter)
+	premiumTier, err := cmd.Worker().Services.Premium.GetTierByGuildId(ctx, guildId, true, cmd.Worker().Token, cmd.Worker().RateLimiter)
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -71,7 +69,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 	}
 
 	// Get ticket
-	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, guildId)
+	ticket, err := cmd.Worker().Services.Database.Tickets.Get(ctx, ticketId, guildId)
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -81,7 +79,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		return
 	}
 
-	feedbackEnabled, err := dbclient.Client.FeedbackEnabled.Get(ctx, guildId)
+	feedbackEnabled, err := cmd.Worker().Services.Database.FeedbackEnabled.Get(ctx, guildId)
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -97,7 +95,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		return
 	}
 
-	panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+	panel, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -113,7 +111,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		return
 	}
 
-	form, ok, err := dbclient.Client.Forms.Get(ctx, *panel.ExitSurveyFormId)
+	form, ok, err := cmd.Worker().Services.Database.Forms.Get(ctx, *panel.ExitSurveyFormId)
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -124,7 +122,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		return
 	}
 
-	formInputs, err := dbclient.Client.FormInput.GetInputs(ctx, form.Id)
+	formInputs, err := cmd.Worker().Services.Database.FormInput.GetInputs(ctx, form.Id)
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -147,7 +145,7 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		responses[input.Id] = value
 	}
 
-	if err := dbclient.Client.ExitSurveyResponses.AddResponses(ctx, guildId, ticketId, *panel.ExitSurveyFormId, responses); err != nil {
+	if err := cmd.Worker().Services.Database.ExitSurveyResponses.AddResponses(ctx, guildId, ticketId, *panel.ExitSurveyFormId, responses); err != nil {
 		cmd.HandleError(err)
 		return
 	}
@@ -158,6 +156,12 @@ func (h *ExitSurveySubmitHandler) Execute(cmd *cmdcontext.ModalContext) {
 		cmd.HandleError(err)
 		return
 	}
+
+	// Include the survey answers in the low rating alert, if one was posted
+	if err := logic.UpdateFeedbackFollowUp(ctx, cmd.Worker(), ticket); err != nil {
+		cmd.HandleError(err)
+		return
+	}
 }
 
 func addViewFeedbackButton(ctx context.Context, cmd *cmdcontext.ModalContext, ticket database.Ticket) error {
@@ -167,7 +171,7 @@ func addViewFeedbackButton(ctx context.Context, cmd *cmdcontext.ModalContext, ti
 		return err
 	}
 
-	closeMetadata, ok, err := dbclient.Client.CloseReason.Get(ctx, ticket.GuildId, ticket.Id)
+	closeMetadata, ok, err := cmd.Worker().Services.Database.CloseReason.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return err
 	}
@@ -182,7 +186,7 @@ func addViewFeedbackButton(ctx context.Context, cmd *cmdcontext.ModalContext, ti
 		}
 	}
 
-	rating, ok, err := dbclient.Client.ServiceRatings.Get(ctx, ticket.GuildId, ticket.Id)
+	rating, ok, err := cmd.Worker().Services.Database.ServiceRatings.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type FeedbackFollowUpReopenHandler struct{}
+
+func (h *FeedbackFollowUpReopenHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "feedback-followup-reopen-")
+	})
+}
+
+func (h *FeedbackFollowUpReopenHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 5,
+	}
+}
+
+var feedbackFollowUpReopenPattern = regexp.MustCompile(`feedback-followup-reopen-(\d+)-(\d+)`)
+
+func (h *FeedbackFollowUpReopenHandler) Execute(ctx *context.ButtonContext) {
+	groups := feedbackFollowUpReopenPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) != 3 {
+		return
+	}
+
+	// Error may occur if guild ID in custom ID > max u64 size
+	guildId, err := strconv.ParseUint(groups[1], 10, 64)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ticketId, err := strconv.Atoi(groups[2])
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if guildId != ctx.GuildId() {
+		return
+	}
+
+	// ReopenTicket applies the ticket limit to members, so check explicitly that this is a staff member
+	permLevel, err := ctx.UserPermissionLevel(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return
+	}
+
+	logic.ReopenTicket(ctx, ctx, ticketId)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type FeedbackFollowUpResolveHandler struct{}
+
+func (h *FeedbackFollowUpResolveHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "feedback-followup-resolve-")
+	})
+}
+
+func (h *FeedbackFollowUpResolveHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:   registry.SumFlags(registry.GuildAllowed),
+		Timeout: time.Second * 5,
+	}
+}
+
+var feedbackFollowUpResolvePattern = regexp.MustCompile(`feedback-followup-resolve-(\d+)-(\d+)`)
+
+func (h *FeedbackFollowUpResolveHandler) Execute(ctx *context.ButtonContext) {
+	groups := feedbackFollowUpResolvePattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) != 3 {
+		return
+	}
+
+	// Error may occur if guild ID in custom ID > max u64 size
+	guildId, err := strconv.ParseUint(groups[1], 10, 64)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ticketId, err := strconv.Atoi(groups[2])
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if guildId != ctx.GuildId() {
+		return
+	}
+
+	permLevel, err := ctx.UserPermissionLevel(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return
+	}
+
+	followUp, ok, err := ctx.Worker().Services.Redis.GetFeedbackFollowUp(ctx, guildId, ticketId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackFollowUpExpired)
+		return
+	}
+
+	if followUp.Resolved {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackFollowUpResolved)
+		return
+	}
+
+	followUp.Resolved = true
+	followUp.ResolvedBy = utils.Ptr(ctx.UserId())
+	followUp.ResolvedAt = utils.Ptr(time.Now())
+
+	if err := ctx.Worker().Services.Redis.SetFeedbackFollowUp(ctx, guildId, ticketId, followUp); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ticket, err := ctx.Worker().Services.Database.Tickets.Get(ctx, ticketId, guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := logic.UpdateFeedbackFollowUp(ctx, ctx.Worker(), ticket); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleFeedbackFollowUp, i18n.MessageFeedbackFollowUpResolveSuccess)
+}
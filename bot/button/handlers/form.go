@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -38,7 +37,7 @@ func (h *FormHandler) Execute(ctx *context.ModalContext) {
 	customId := strings.TrimPrefix(data.CustomId, "form_") // get the custom id that is used in the database
 
 	// Form IDs aren't unique to a panel, so we submit the modal with a custom id of `form_panelcustomid`
-	panel, ok, err := dbclient.Client.Panel.GetByCustomId(ctx, ctx.GuildId(), customId)
+	panel, ok, err := ctx.Worker().Services.Database.Panel.GetByCustomId(ctx, ctx.GuildId(), customId)
 	if err != nil {
 		sentry.Error(err) // TODO: Proper context
 		return
@@ -61,7 +60,7 @@ func (h *FormHandler) Execute(ctx *context.ModalContext) {
 			return
 		}
 
-		inputs, err := dbclient.Client.FormInput.GetAllInputsByCustomId(ctx, ctx.GuildId())
+		inputs, err := ctx.Worker().Services.Database.FormInput.GetAllInputsByCustomId(ctx, ctx.GuildId())
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/bot/gdprrelay"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -40,7 +39,7 @@ func (h *GDPRAllTranscriptsHandler) Properties() registry.Properties {
 func (h *GDPRAllTranscriptsHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -64,7 +63,7 @@ func (h *GDPRSpecificTranscriptsHandler) Properties() registry.Properties {
 func (h *GDPRSpecificTranscriptsHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -88,7 +87,7 @@ func (h *GDPRAllMessagesHandler) Properties() registry.Properties {
 func (h *GDPRAllMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -112,7 +111,7 @@ func (h *GDPRSpecificMessagesHandler) Properties() registry.Properties {
 func (h *GDPRSpecificMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -13,9 +13,7 @@ import (
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/gdprrelay"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -38,7 +36,7 @@ func (h *GDPRConfirmAllTranscriptsHandler) Properties() registry.Properties {
 func (h *GDPRConfirmAllTranscriptsHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -50,7 +48,7 @@ func (h *GDPRConfirmAllTranscriptsHandler) Execute(ctx *cmdcontext.ButtonContext
 		return
 	}
 
-	guildNames := utils.FetchGuildNames(ctx, guildIds)
+	guildNames := utils.FetchGuildNames(ctx, ctx.Worker(), guildIds)
 
 	request := gdprrelay.GDPRRequest{
 		Type:               gdprrelay.RequestTypeAllTranscripts,
@@ -65,13 +63,13 @@ func (h *GDPRConfirmAllTranscriptsHandler) Execute(ctx *cmdcontext.ButtonContext
 
 	scrambledId := sha256.New()
 	fmt.Fprintf(scrambledId, "%d", ctx.UserId())
-	id, err := dbclient.Client.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "AllTranscripts", "Queued")
+	id, err := ctx.Worker().Services.Database.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "AllTranscripts", "Queued")
 	if err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
 
-	if err := gdprrelay.Publish(redis.Client, request, id); err != nil {
+	if err := gdprrelay.Publish(ctx.Worker().Services.Redis.Client, request, id); err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
@@ -113,7 +111,7 @@ func (h *GDPRConfirmSpecificTranscriptsHandler) Properties() registry.Properties
 func (h *GDPRConfirmSpecificTranscriptsHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -143,7 +141,7 @@ func (h *GDPRConfirmSpecificTranscriptsHandler) Execute(ctx *cmdcontext.ButtonCo
 		return
 	}
 
-	guildNames := utils.FetchGuildNames(ctx, []uint64{guildId})
+	guildNames := utils.FetchGuildNames(ctx, ctx.Worker(), []uint64{guildId})
 
 	request := gdprrelay.GDPRRequest{
 		Type:               gdprrelay.RequestTypeSpecificTranscripts,
@@ -159,13 +157,13 @@ func (h *GDPRConfirmSpecificTranscriptsHandler) Execute(ctx *cmdcontext.ButtonCo
 
 	scrambledId := sha256.New()
 	fmt.Fprintf(scrambledId, "%d", ctx.UserId())
-	id, err := dbclient.Client.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "SpecificTranscripts", "Queued")
+	id, err := ctx.Worker().Services.Database.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "SpecificTranscripts", "Queued")
 	if err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
 
-	if err := gdprrelay.Publish(redis.Client, request, id); err != nil {
+	if err := gdprrelay.Publish(ctx.Worker().Services.Redis.Client, request, id); err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
@@ -203,7 +201,7 @@ func (h *GDPRConfirmAllMessagesHandler) Properties() registry.Properties {
 func (h *GDPRConfirmAllMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -212,7 +210,7 @@ func (h *GDPRConfirmAllMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	userId := ctx.UserId()
 	guildIds := utils.ParseGuildIds(ctx.InteractionData.CustomId)
 
-	guildNames := utils.FetchGuildNames(ctx, guildIds)
+	guildNames := utils.FetchGuildNames(ctx, ctx.Worker(), guildIds)
 
 	request := gdprrelay.GDPRRequest{
 		Type:               gdprrelay.RequestTypeAllMessages,
@@ -227,13 +225,13 @@ func (h *GDPRConfirmAllMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 
 	scrambledId := sha256.New()
 	fmt.Fprintf(scrambledId, "%d", ctx.UserId())
-	id, err := dbclient.Client.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "AllMessages", "Queued")
+	id, err := ctx.Worker().Services.Database.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "AllMessages", "Queued")
 	if err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
 
-	if err := gdprrelay.Publish(redis.Client, request, id); err != nil {
+	if err := gdprrelay.Publish(ctx.Worker().Services.Redis.Client, request, id); err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
@@ -281,7 +279,7 @@ func (h *GDPRConfirmMessagesHandler) Properties() registry.Properties {
 func (h *GDPRConfirmMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.InteractionData.CustomId)
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -310,7 +308,7 @@ func (h *GDPRConfirmMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 		}
 	}
 
-	guildNames := utils.FetchGuildNames(ctx, []uint64{guildId})
+	guildNames := utils.FetchGuildNames(ctx, ctx.Worker(), []uint64{guildId})
 
 	request := gdprrelay.GDPRRequest{
 		Type:               gdprrelay.RequestTypeSpecificMessages,
@@ -326,13 +324,13 @@ func (h *GDPRConfirmMessagesHandler) Execute(ctx *cmdcontext.ButtonContext) {
 
 	scrambledId := sha256.New()
 	fmt.Fprintf(scrambledId, "%d", ctx.UserId())
-	id, err := dbclient.Client.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "SpecificMessages", "Queued")
+	id, err := ctx.Worker().Services.Database.GdprLogs.InsertLog(fmt.Sprintf("%x", scrambledId.Sum(nil)), "SpecificMessages", "Queued")
 	if err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
 
-	if err := gdprrelay.Publish(redis.Client, request, id); err != nil {
+	if err := gdprrelay.Publish(ctx.Worker().Services.Redis.Client, request, id); err != nil {
 		ctx.ReplyRaw(customisation.Red, "Error", i18n.GetMessage(locale, i18n.GdprErrorQueueFailed))
 		return
 	}
@@ -15,9 +15,7 @@ import (
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/gdprrelay"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -66,7 +64,7 @@ func getOwnedGuildsWithTranscripts(ctx *cmdcontext.ButtonContext, userId uint64)
 		WHERE has_transcript = true AND guild_id IN (%s)
 		GROUP BY guild_id`, placeholders)
 
-	rows, err := dbclient.Client.Tickets.Query(ctx, transcriptQuery, params...)
+	rows, err := ctx.Worker().Services.Database.Tickets.Query(ctx, transcriptQuery, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +101,7 @@ func getGuildsWithUserMessages(ctx *cmdcontext.ButtonContext, userId uint64) ([]
 		WHERE has_transcript = true AND user_id = $1
 		GROUP BY guild_id`
 
-	rows, err := dbclient.Client.Tickets.Query(ctx, query, userId)
+	rows, err := ctx.Worker().Services.Database.Tickets.Query(ctx, query, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -499,7 +497,7 @@ func (h *GDPRModalAllTranscriptsHandler) Execute(ctx *context.ModalContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.Interaction.Data.CustomId)
 	userId := ctx.UserId()
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -598,7 +596,7 @@ func (h *GDPRModalSpecificTranscriptsHandler) Execute(ctx *context.ModalContext)
 	locale := utils.ExtractLanguageFromCustomId(ctx.Interaction.Data.CustomId)
 	userId := ctx.UserId()
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -694,7 +692,7 @@ func (h *GDPRModalAllMessagesHandler) Execute(ctx *context.ModalContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.Interaction.Data.CustomId)
 	userId := ctx.UserId()
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -793,7 +791,7 @@ func (h *GDPRModalSpecificMessagesHandler) Execute(ctx *context.ModalContext) {
 	locale := utils.ExtractLanguageFromCustomId(ctx.Interaction.Data.CustomId)
 	userId := ctx.UserId()
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.Edit(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
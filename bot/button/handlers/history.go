@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry"
+	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// HistoryHandler handles the history button on the welcome message, showing the ticket opener's previous tickets
+type HistoryHandler struct{}
+
+func (h *HistoryHandler) Matcher() matcher.Matcher {
+	return &matcher.SimpleMatcher{
+		CustomId: "history",
+	}
+}
+
+func (h *HistoryHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed),
+		PermissionLevel: permission.Support,
+		Timeout:         time.Second * 5,
+	}
+}
+
+func (h *HistoryHandler) Execute(ctx *context.ButtonContext) {
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.UserId == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		return
+	}
+
+	comp, page, totalPages, err := logic.BuildTicketHistoryMessage(ctx.Context, ctx, ticket.UserId, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	_, _ = ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
+		comp,
+		logic.BuildTicketHistoryButtons(ticket.UserId, page, totalPages),
+	}))
+}
+
+// HistoryPageHandler handles the pagination buttons of the ticket history message
+type HistoryPageHandler struct{}
+
+func (h *HistoryPageHandler) Matcher() matcher.Matcher {
+	return &matcher.FuncMatcher{
+		Func: func(customId string) bool {
+			return strings.HasPrefix(customId, "history_")
+		},
+	}
+}
+
+func (h *HistoryPageHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags:           registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+		PermissionLevel: permission.Support,
+		Timeout:         time.Second * 5,
+	}
+}
+
+var historyPagePattern = regexp.MustCompile(`history_(\d+)_(\d+)`)
+
+func (h *HistoryPageHandler) Execute(ctx *context.ButtonContext) {
+	groups := historyPagePattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 3 {
+		return
+	}
+
+	userId, err := strconv.ParseUint(groups[1], 10, 64)
+	if err != nil {
+		return
+	}
+
+	page, err := strconv.Atoi(groups[2])
+	if err != nil {
+		return
+	}
+
+	comp, adjustedPage, totalPages, err := logic.BuildTicketHistoryMessage(ctx.Context, ctx, userId, page)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Components: []component.Component{
+			comp,
+			logic.BuildTicketHistoryButtons(userId, adjustedPage, totalPages),
+		},
+	})
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -45,7 +44,7 @@ func (h *JoinThreadHandler) Execute(ctx *context.ButtonContext) {
 	ticketId, _ := strconv.Atoi(groups[1])
 
 	// Get ticket
-	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.Get(ctx, ticketId, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -9,7 +9,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -51,7 +50,7 @@ func (h *LanguageSelectorHandler) Execute(ctx *context.SelectMenuContext) {
 		return
 	}
 
-	if err := dbclient.Client.ActiveLanguage.Set(ctx, ctx.GuildId(), newLocale.IsoShortCode); err != nil {
+	if err := ctx.Worker().Services.Database.ActiveLanguage.Set(ctx, ctx.GuildId(), newLocale.IsoShortCode); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -8,7 +8,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 )
 
@@ -34,7 +33,7 @@ func (h *MultiPanelHandler) Execute(ctx *context.SelectMenuContext) {
 
 	panelCustomId := ctx.InteractionData.Values[0]
 
-	panel, ok, err := dbclient.Client.Panel.GetByCustomId(ctx, ctx.GuildId(), panelCustomId)
+	panel, ok, err := ctx.Worker().Services.Database.Panel.GetByCustomId(ctx, ctx.GuildId(), panelCustomId)
 	if err != nil {
 		sentry.Error(err) // TODO: Proper context
 		return
@@ -60,7 +59,7 @@ func (h *MultiPanelHandler) Execute(ctx *context.SelectMenuContext) {
 		if panel.FormId == nil {
 			_, _ = logic.OpenTicket(ctx.Context, ctx, &panel, panel.Title, nil, outOfHoursTitle, outOfHoursWarning, outOfHoursColour)
 		} else {
-			form, ok, err := dbclient.Client.Forms.Get(ctx, *panel.FormId)
+			form, ok, err := ctx.Worker().Services.Database.Forms.Get(ctx, *panel.FormId)
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -71,13 +70,13 @@ func (h *MultiPanelHandler) Execute(ctx *context.SelectMenuContext) {
 				return
 			}
 
-			inputs, err := dbclient.Client.FormInput.GetInputs(ctx, form.Id)
+			inputs, err := ctx.Worker().Services.Database.FormInput.GetInputs(ctx, form.Id)
 			if err != nil {
 				ctx.HandleError(err)
 				return
 			}
 
-			inputOptions, err := dbclient.Client.FormInputOption.GetOptionsByForm(ctx, form.Id)
+			inputOptions, err := ctx.Worker().Services.Database.FormInputOption.GetOptionsByForm(ctx, form.Id)
 			if err != nil {
 				ctx.HandleError(err)
 				return
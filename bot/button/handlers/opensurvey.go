@@ -14,8 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
-	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -55,7 +53,7 @@ func (h *OpenSurveyHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
+	premiumTier, err := ctx.Worker().Services.Premium.GetTierByGuildId(ctx, guildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -67,7 +65,7 @@ func (h *OpenSurveyHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	// Get ticket
-	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, guildId)
+	ticket, err := ctx.Worker().Services.Database.Tickets.Get(ctx, ticketId, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -77,7 +75,7 @@ func (h *OpenSurveyHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	feedbackEnabled, err := dbclient.Client.FeedbackEnabled.Get(ctx, guildId)
+	feedbackEnabled, err := ctx.Worker().Services.Database.FeedbackEnabled.Get(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -93,7 +91,7 @@ func (h *OpenSurveyHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+	panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -109,7 +107,7 @@ func (h *OpenSurveyHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	form, ok, err := dbclient.Client.Forms.Get(ctx, *panel.ExitSurveyFormId)
+	form, ok, err := ctx.Worker().Services.Database.Forms.Get(ctx, *panel.ExitSurveyFormId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -120,13 +118,13 @@ func (h *OpenSurveyHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	formInputs, err := dbclient.Client.FormInput.GetInputs(ctx, form.Id)
+	formInputs, err := ctx.Worker().Services.Database.FormInput.GetInputs(ctx, form.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	inputOptions, err := dbclient.Client.FormInputOption.GetOptionsByForm(ctx, form.Id)
+	inputOptions, err := ctx.Worker().Services.Database.FormInputOption.GetOptionsByForm(ctx, form.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
@@ -32,7 +31,7 @@ func (h *PanelHandler) Properties() registry.Properties {
 }
 
 func (h *PanelHandler) Execute(ctx *context.ButtonContext) {
-	panel, ok, err := dbclient.Client.Panel.GetByCustomId(ctx, ctx.GuildId(), ctx.InteractionData.CustomId)
+	panel, ok, err := ctx.Worker().Services.Database.Panel.GetByCustomId(ctx, ctx.GuildId(), ctx.InteractionData.CustomId)
 	if err != nil {
 		sentry.Error(err) // TODO: Proper context
 		return
@@ -58,7 +57,7 @@ func (h *PanelHandler) Execute(ctx *context.ButtonContext) {
 		if panel.FormId == nil {
 			_, _ = logic.OpenTicket(ctx.Context, ctx, &panel, panel.Title, nil, outOfHoursTitle, outOfHoursWarning, outOfHoursColour)
 		} else {
-			form, ok, err := dbclient.Client.Forms.Get(ctx, *panel.FormId)
+			form, ok, err := ctx.Worker().Services.Database.Forms.Get(ctx, *panel.FormId)
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -69,13 +68,13 @@ func (h *PanelHandler) Execute(ctx *context.ButtonContext) {
 				return
 			}
 
-			inputs, err := dbclient.Client.FormInput.GetInputs(ctx, form.Id)
+			inputs, err := ctx.Worker().Services.Database.FormInput.GetInputs(ctx, form.Id)
 			if err != nil {
 				ctx.HandleError(err)
 				return
 			}
 
-			inputOptions, err := dbclient.Client.FormInputOption.GetOptionsByForm(ctx, form.Id)
+			inputOptions, err := ctx.Worker().Services.Database.FormInputOption.GetOptionsByForm(ctx, form.Id)
 			if err != nil {
 				ctx.HandleError(err)
 				return
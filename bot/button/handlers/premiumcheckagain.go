@@ -9,9 +9,7 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	prem "github.com/TicketsBot-cloud/worker/bot/premium"
-	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -45,7 +43,7 @@ func (h *PremiumCheckAgain) Execute(ctx *context.ButtonContext) {
 
 	ctx.EditWith(customisation.Green, i18n.MessagePremiumChecking, i18n.MessagePremiumPleaseWait)
 
-	if err := utils.PremiumClient.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
+	if err := ctx.Worker().Services.Premium.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -54,12 +52,12 @@ func (h *PremiumCheckAgain) Execute(ctx *context.ButtonContext) {
 		ctx.EditWith(customisation.Green, i18n.Success, i18n.MessagePremiumSuccessAfterCheck)
 
 		// Re-enable panels
-		if err := dbclient.Client.Panel.EnableAll(ctx, ctx.GuildId()); err != nil {
+		if err := ctx.Worker().Services.Database.Panel.EnableAll(ctx, ctx.GuildId()); err != nil {
 			ctx.HandleError(err)
 			return
 		}
 	} else {
-		entitlement, err := dbclient.Client.LegacyPremiumEntitlements.GetUserTier(ctx, ctx.UserId(), premium.PatreonGracePeriod)
+		entitlement, err := ctx.Worker().Services.Database.LegacyPremiumEntitlements.GetUserTier(ctx, ctx.UserId(), premium.PatreonGracePeriod)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -42,6 +42,6 @@ func (h *PremiumKeyButtonHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	ctx.Modal(button.ResponseModal{
-		Data: prem.BuildKeyModal(ctx.GuildId()),
+		Data: prem.BuildKeyModal(ctx.Worker().Services, ctx.GuildId()),
 	})
 }
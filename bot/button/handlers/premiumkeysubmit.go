@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	commandcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 	"github.com/google/uuid"
@@ -44,7 +43,7 @@ func (h *PremiumKeySubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 		return
 	}
 
-	tx, err := dbclient.Client.BeginTx(ctx)
+	tx, err := ctx.Worker().Services.Database.BeginTx(ctx)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -57,7 +56,7 @@ func (h *PremiumKeySubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 		tx.Rollback(ctx)
 	}()
 
-	length, skuId, ok, err := dbclient.Client.PremiumKeys.Delete(ctx, tx, parsed)
+	length, skuId, ok, err := ctx.Worker().Services.Database.PremiumKeys.Delete(ctx, tx, parsed)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -68,12 +67,12 @@ func (h *PremiumKeySubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 		return
 	}
 
-	if err := dbclient.Client.UsedKeys.Set(ctx, tx, parsed, ctx.GuildId(), ctx.UserId()); err != nil {
+	if err := ctx.Worker().Services.Database.UsedKeys.Set(ctx, tx, parsed, ctx.GuildId(), ctx.UserId()); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	sku, err := dbclient.Client.SubscriptionSkus.GetSku(ctx, tx, skuId)
+	sku, err := ctx.Worker().Services.Database.SubscriptionSkus.GetSku(ctx, tx, skuId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -90,7 +89,7 @@ func (h *PremiumKeySubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 	}
 
 	expiresAt := time.Now().Add(length)
-	if _, err := dbclient.Client.Entitlements.Create(ctx, tx, guildId, utils.Ptr(ctx.UserId()), skuId, model.EntitlementSourceKey, &expiresAt); err != nil {
+	if _, err := ctx.Worker().Services.Database.Entitlements.Create(ctx, tx, guildId, utils.Ptr(ctx.UserId()), skuId, model.EntitlementSourceKey, &expiresAt); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -101,12 +100,12 @@ func (h *PremiumKeySubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 	}
 
 	// Re-enable panels
-	if err := dbclient.Client.Panel.EnableAll(ctx, ctx.GuildId()); err != nil {
+	if err := ctx.Worker().Services.Database.Panel.EnableAll(ctx, ctx.GuildId()); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	if err := utils.PremiumClient.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
+	if err := ctx.Worker().Services.Premium.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
 		ctx.HandleError(err)
 		return
 	}
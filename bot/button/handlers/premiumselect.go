@@ -12,7 +12,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	prem "github.com/TicketsBot-cloud/worker/bot/premium"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -49,7 +48,7 @@ func (h *PremiumKeyOpenHandler) Execute(ctx *context.SelectMenuContext) {
 
 	option := ctx.InteractionData.Values[0]
 	if option == "patreon" {
-		entitlement, err := dbclient.Client.LegacyPremiumEntitlements.GetUserTier(ctx, ctx.UserId(), premium.PatreonGracePeriod)
+		entitlement, err := ctx.Worker().Services.Database.LegacyPremiumEntitlements.GetUserTier(ctx, ctx.UserId(), premium.PatreonGracePeriod)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -70,7 +69,7 @@ func (h *PremiumKeyOpenHandler) Execute(ctx *context.SelectMenuContext) {
 		ctx.Edit(prem.BuildDiscordNotFoundMessage(ctx))
 	} else if option == "key" {
 		ctx.Modal(button.ResponseModal{
-			Data: prem.BuildKeyModal(ctx.GuildId()),
+			Data: prem.BuildKeyModal(ctx.Worker().Services, ctx.GuildId()),
 		})
 
 		components := utils.Slice(component.BuildActionRow(component.BuildButton(component.Button{
@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -67,7 +66,7 @@ func (h *RateHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	rating := uint8(ratingRaw)
 
 	// Get ticket
-	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, guildId)
+	ticket, err := ctx.Worker().Services.Database.Tickets.Get(ctx, ticketId, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -77,7 +76,7 @@ func (h *RateHandler) Execute(ctx *cmdcontext.ButtonContext) {
 		return
 	}
 
-	feedbackEnabled, err := dbclient.Client.FeedbackEnabled.Get(ctx, guildId)
+	feedbackEnabled, err := ctx.Worker().Services.Database.FeedbackEnabled.Get(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -88,20 +87,20 @@ func (h *RateHandler) Execute(ctx *cmdcontext.ButtonContext) {
 		return
 	}
 
-	if err := dbclient.Client.ServiceRatings.Set(ctx, guildId, ticketId, rating); err != nil {
+	if err := ctx.Worker().Services.Database.ServiceRatings.Set(ctx, guildId, ticketId, rating); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
 	// Exit survey
-	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
+	premiumTier, err := ctx.Worker().Services.Premium.GetTierByGuildId(ctx, guildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
 	if premiumTier > premium.None && ticket.PanelId != nil {
-		panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -130,7 +129,7 @@ func (h *RateHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	}
 
 	// Add star rating to message in archive channel
-	closeMetadata, ok, err := dbclient.Client.CloseReason.Get(ctx, guildId, ticket.Id)
+	closeMetadata, ok, err := ctx.Worker().Services.Database.CloseReason.Get(ctx, guildId, ticket.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -146,13 +145,13 @@ func (h *RateHandler) Execute(ctx *cmdcontext.ButtonContext) {
 		}
 	}
 
-	settings, err := dbclient.Client.Settings.Get(ctx, guildId)
+	settings, err := ctx.Worker().Services.Database.Settings.Get(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	hasFeedback, err := dbclient.Client.ExitSurveyResponses.HasResponse(ctx, guildId, ticketId)
+	hasFeedback, err := ctx.Worker().Services.Database.ExitSurveyResponses.HasResponse(ctx, guildId, ticketId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -161,4 +160,8 @@ func (h *RateHandler) Execute(ctx *cmdcontext.ButtonContext) {
 	if err := logic.EditGuildArchiveMessageIfExists(ctx, ctx.Worker(), ticket, settings, hasFeedback, closedBy, reason, &rating); err != nil {
 		ctx.HandleError(err)
 	}
+
+	if err := logic.HandleFeedbackFollowUp(ctx, ctx.Worker(), ticket, rating); err != nil {
+		ctx.HandleError(err)
+	}
 }
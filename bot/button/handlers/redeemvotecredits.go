@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -38,9 +37,9 @@ var errNoCredits = errors.New("no credits")
 
 func (h *RedeemVoteCreditsHandler) Execute(ctx *context.ButtonContext) {
 	var credits int
-	if err := dbclient.Client.WithTx(ctx, func(tx pgx.Tx) error {
+	if err := ctx.Worker().Services.Database.WithTx(ctx, func(tx pgx.Tx) error {
 		var err error
-		credits, err = dbclient.Client.VoteCredits.Get(ctx, tx, ctx.UserId())
+		credits, err = ctx.Worker().Services.Database.VoteCredits.Get(ctx, tx, ctx.UserId())
 		if err != nil {
 			return err
 		}
@@ -50,11 +49,11 @@ func (h *RedeemVoteCreditsHandler) Execute(ctx *context.ButtonContext) {
 			return errNoCredits
 		}
 
-		if err := dbclient.Client.VoteCredits.Delete(ctx, tx, ctx.UserId()); err != nil {
+		if err := ctx.Worker().Services.Database.VoteCredits.Delete(ctx, tx, ctx.UserId()); err != nil {
 			return err
 		}
 
-		if err := dbclient.Client.Entitlements.IncreaseExpiry(
+		if err := ctx.Worker().Services.Database.Entitlements.IncreaseExpiry(
 			ctx,
 			tx,
 			utils.Ptr(ctx.GuildId()),
@@ -76,9 +75,9 @@ func (h *RedeemVoteCreditsHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	// TODO: dbclient.Client.Panels.EnableAll?
+	// TODO: ctx.Worker().Services.Database.Panels.EnableAll?
 
-	if err := utils.PremiumClient.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
+	if err := ctx.Worker().Services.Premium.DeleteCachedTier(ctx, ctx.GuildId()); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -12,7 +12,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -35,7 +34,7 @@ func (h *EditLabelsButtonHandler) Properties() registry.Properties {
 
 func (h *EditLabelsButtonHandler) Execute(ctx *context.ButtonContext) {
 	// Get ticket
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -46,13 +45,13 @@ func (h *EditLabelsButtonHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	labels, err := dbclient.Client.TicketLabels.GetByGuild(ctx, ctx.GuildId())
+	labels, err := ctx.Worker().Services.Database.TicketLabels.GetByGuild(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	ticketLabelIds, err := dbclient.Client.TicketLabelAssignments.GetByTicket(ctx, ctx.GuildId(), ticket.Id)
+	ticketLabelIds, err := ctx.Worker().Services.Database.TicketLabelAssignments.GetByTicket(ctx, ctx.GuildId(), ticket.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
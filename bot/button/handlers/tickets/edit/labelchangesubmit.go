@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/button/registry/matcher"
 	commandcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -42,7 +41,7 @@ func (h *LabelChangeSubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 	}
 
 	// Get ticket
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -53,12 +52,12 @@ func (h *LabelChangeSubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 		return
 	}
 
-	if err := dbclient.Client.TicketLabelAssignments.Replace(ctx, ctx.GuildId(), ticket.Id, labelIds); err != nil {
+	if err := ctx.Worker().Services.Database.TicketLabelAssignments.Replace(ctx, ctx.GuildId(), ticket.Id, labelIds); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	allLabels, err := dbclient.Client.TicketLabels.GetByGuild(ctx, ctx.GuildId())
+	allLabels, err := ctx.Worker().Services.Database.TicketLabels.GetByGuild(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -75,7 +74,7 @@ func (h *LabelChangeSubmitHandler) Execute(ctx *commandcontext.ModalContext) {
 
 	topicMsg := ""
 	if ticket.PanelId != nil {
-		panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -48,7 +47,7 @@ func (h *UnclaimHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	// Get ticket struct
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -67,7 +66,7 @@ func (h *UnclaimHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	// Get who claimed
-	whoClaimed, err := dbclient.Client.TicketClaims.Get(ctx, ctx.GuildId(), ticket.Id)
+	whoClaimed, err := ctx.Worker().Services.Database.TicketClaims.Get(ctx, ctx.GuildId(), ticket.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -85,7 +84,7 @@ func (h *UnclaimHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	// Set to unclaimed in DB
-	if err := dbclient.Client.TicketClaims.Delete(ctx, ctx.GuildId(), ticket.Id); err != nil {
+	if err := ctx.Worker().Services.Database.TicketClaims.Delete(ctx, ctx.GuildId(), ticket.Id); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -93,7 +92,7 @@ func (h *UnclaimHandler) Execute(ctx *context.ButtonContext) {
 	// Get panel
 	var panel *database.Panel
 	if ticket.PanelId != nil {
-		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		tmp, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -119,7 +118,7 @@ func (h *UnclaimHandler) Execute(ctx *context.ButtonContext) {
 	}
 
 	// Handle claimer access based on SwitchPanelClaimBehavior setting
-	claimSettings, err := dbclient.Client.ClaimSettings.Get(ctx, ctx.GuildId())
+	claimSettings, err := ctx.Worker().Services.Database.ClaimSettings.Get(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
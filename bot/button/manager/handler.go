@@ -172,7 +172,7 @@ func getPremiumTier(ctx context.Context, worker *worker.Context, guildId uint64)
 	if guildId == 0 {
 		return premium.None, nil
 	} else {
-		premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
+		premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
 		if err != nil {
 			return premium.None, err
 		}
@@ -54,12 +54,18 @@ func (m *ComponentInteractionManager) RegisterCommands() {
 	m.buttonRegistry = append(m.buttonRegistry,
 		new(handlers.AddAdminHandler),
 		new(handlers.AddSupportHandler),
+		new(handlers.BulkCancelHandler),
+		new(handlers.BulkConfirmHandler),
 		new(handlers.CloseHandler),
 		new(handlers.CloseWithReasonModalHandler),
 		new(handlers.EditCloseReasonModalHandler),
 		new(handlers.ClaimHandler),
+		new(handlers.FeedbackFollowUpReopenHandler),
+		new(handlers.FeedbackFollowUpResolveHandler),
 		new(handlers.UnclaimHandler),
 		new(handlers.CloseConfirmHandler),
+		new(handlers.CloseSpamHandler),
+		new(handlers.CloseSpamConfirmHandler),
 		new(handlers.CloseRequestAcceptHandler),
 		new(handlers.CloseRequestDenyHandler),
 		new(handlers.GDPRAllTranscriptsHandler),
@@ -70,6 +76,8 @@ func (m *ComponentInteractionManager) RegisterCommands() {
 		new(handlers.GDPRConfirmSpecificTranscriptsHandler),
 		new(handlers.GDPRConfirmAllMessagesHandler),
 		new(handlers.GDPRConfirmMessagesHandler),
+		new(handlers.HistoryHandler),
+		new(handlers.HistoryPageHandler),
 		new(handlers.JoinThreadHandler),
 		new(handlers.OpenSurveyHandler),
 		new(handlers.PanelHandler),
@@ -14,8 +14,6 @@ import (
 	"go.uber.org/zap"
 )
 
-var Client *cache.PgCache
-
 func Connect(logger *zap.Logger) (client cache.PgCache, err error) {
 	uri := fmt.Sprintf(
 		"postgres://%s:%s@%s/%s?pool_max_conns=%d",
@@ -123,7 +123,10 @@ func (c *SlashCommandContext) ReplyWith(response command.MessageResponse) (messa
 		return message.Message{}, err
 	}
 
-	c.responseCh <- command.ResponseMessage{Data: response.IntoApplicationCommandData()}
+	c.responseCh <- command.ResponseMessage{
+		Data:        response.IntoApplicationCommandData(),
+		Attachments: response.Attachments,
+	}
 
 	return message.Message{}, nil
 
@@ -182,7 +185,7 @@ func (c *SlashCommandContext) IsBlacklisted(ctx context.Context) (bool, error) {
 
 	// if interaction.Member is nil, it does not matter, as the member's roles are not checked
 	// if the command is not executed in a guild
-	return utils.IsBlacklisted(ctx, c.GuildId(), c.UserId(), utils.ValueOrZero(c.Interaction.Member), permLevel)
+	return utils.IsBlacklisted(ctx, c.Worker(), c.GuildId(), c.UserId(), utils.ValueOrZero(c.Interaction.Member), permLevel)
 }
 
 /// InteractionContext functions
@@ -131,5 +131,5 @@ func (c *AutoCloseContext) IsBlacklisted(ctx context.Context) (bool, error) {
 
 	// if interaction.Member is nil, it does not matter, as the member's roles are not checked
 	// if the command is not executed in a guild
-	return utils.IsBlacklisted(ctx, c.GuildId(), c.UserId(), member, permLevel)
+	return utils.IsBlacklisted(ctx, c.Worker(), c.GuildId(), c.UserId(), member, permLevel)
 }
@@ -98,7 +98,7 @@ func (c *DashboardContext) ToErrorContext() errorcontext.WorkerErrorContext {
 }
 
 func (c *DashboardContext) openDm() (uint64, bool) {
-	cachedId, err := redis.GetDMChannel(c.UserId(), c.Worker().BotId)
+	cachedId, err := c.Worker().Services.Redis.GetDMChannel(c.UserId(), c.Worker().BotId)
 	if err != nil { // We can continue
 		if err != redis.ErrNotCached {
 			sentry.ErrorWithContext(err, c.ToErrorContext())
@@ -115,7 +115,7 @@ func (c *DashboardContext) openDm() (uint64, bool) {
 	if err != nil {
 		// check for 403
 		if err, ok := err.(request.RestError); ok && err.StatusCode == 403 {
-			if err := redis.StoreNullDMChannel(c.UserId(), c.Worker().BotId); err != nil {
+			if err := c.Worker().Services.Redis.StoreNullDMChannel(c.UserId(), c.Worker().BotId); err != nil {
 				sentry.ErrorWithContext(err, c.ToErrorContext())
 			}
 
@@ -126,7 +126,7 @@ func (c *DashboardContext) openDm() (uint64, bool) {
 		return 0, false
 	}
 
-	if err := redis.StoreDMChannel(c.UserId(), ch.Id, c.Worker().BotId); err != nil {
+	if err := c.Worker().Services.Redis.StoreDMChannel(c.UserId(), ch.Id, c.Worker().BotId); err != nil {
 		sentry.ErrorWithContext(err, c.ToErrorContext())
 	}
 
@@ -181,5 +181,5 @@ func (c *DashboardContext) IsBlacklisted(ctx context.Context) (bool, error) {
 
 	// if interaction.Member is nil, it does not matter, as the member's roles are not checked
 	// if the command is not executed in a guild
-	return utils.IsBlacklisted(ctx, c.GuildId(), c.UserId(), member, permLevel)
+	return utils.IsBlacklisted(ctx, c.Worker(), c.GuildId(), c.UserId(), member, permLevel)
 }
@@ -24,6 +24,17 @@ func (m MentionableType) OverwriteType() channel.PermissionOverwriteType {
 	}
 }
 
+func (m MentionableType) String() string {
+	switch m {
+	case MentionableTypeUser:
+		return "user"
+	case MentionableTypeRole:
+		return "role"
+	default:
+		return "unknown"
+	}
+}
+
 // DetermineMentionableType TODO: Move this function to be a method on the CommandContext interface
 // DetermineMentionableType (type, ok)
 func DetermineMentionableType(ctx registry.CommandContext, id uint64) (MentionableType, bool) {
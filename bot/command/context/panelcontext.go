@@ -101,7 +101,7 @@ func (c *PanelContext) ToErrorContext() errorcontext.WorkerErrorContext {
 
 func (c *PanelContext) openDm() (uint64, bool) {
 	if c.dmChannelId == 0 {
-		cachedId, err := redis.GetDMChannel(c.UserId(), c.Worker().BotId)
+		cachedId, err := c.Worker().Services.Redis.GetDMChannel(c.UserId(), c.Worker().BotId)
 		if err != nil { // We can continue
 			if err != redis.ErrNotCached {
 				sentry.ErrorWithContext(err, c.ToErrorContext())
@@ -118,7 +118,7 @@ func (c *PanelContext) openDm() (uint64, bool) {
 		if err != nil {
 			// check for 403
 			if err, ok := err.(request.RestError); ok && err.StatusCode == 403 {
-				if err := redis.StoreNullDMChannel(c.UserId(), c.Worker().BotId); err != nil {
+				if err := c.Worker().Services.Redis.StoreNullDMChannel(c.UserId(), c.Worker().BotId); err != nil {
 					sentry.ErrorWithContext(err, c.ToErrorContext())
 				}
 
@@ -129,7 +129,7 @@ func (c *PanelContext) openDm() (uint64, bool) {
 			return 0, false
 		}
 
-		if err := redis.StoreDMChannel(c.UserId(), ch.Id, c.Worker().BotId); err != nil {
+		if err := c.Worker().Services.Redis.StoreDMChannel(c.UserId(), ch.Id, c.Worker().BotId); err != nil {
 			sentry.ErrorWithContext(err, c.ToErrorContext())
 		}
 
@@ -187,5 +187,5 @@ func (c *PanelContext) IsBlacklisted(ctx context.Context) (bool, error) {
 
 	// if interaction.Member is nil, it does not matter, as the member's roles are not checked
 	// if the command is not executed in a guild
-	return utils.IsBlacklisted(ctx, c.GuildId(), c.UserId(), member, permLevel)
+	return utils.IsBlacklisted(ctx, c.Worker(), c.GuildId(), c.UserId(), member, permLevel)
 }
@@ -21,7 +21,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/permissionwrapper"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
@@ -44,7 +43,7 @@ func NewReplyable(ctx registry.CommandContext) *Replyable {
 	var colourCodes map[customisation.Colour]int
 	if ctx.PremiumTier() > premium.None {
 		// TODO: Propagate context
-		tmp, err := customisation.GetColours(context.Background(), ctx.GuildId())
+		tmp, err := customisation.GetColours(context.Background(), ctx.Worker(), ctx.GuildId())
 		if err != nil {
 			sentry.ErrorWithContext(err, ctx.ToErrorContext())
 			colourCodes = customisation.DefaultColours
@@ -169,7 +168,7 @@ func (r *Replyable) HandleWarning(err error) {
 }
 
 func (r *Replyable) GetMessage(messageId i18n.MessageId, format ...interface{}) string {
-	return i18n.GetMessageFromGuild(r.ctx.GuildId(), messageId, format...)
+	return i18n.GetMessageFromGuild(r.ctx.Worker().Services, r.ctx.GuildId(), messageId, format...)
 }
 
 func (r *Replyable) SelectValidEmoji(customEmoji customisation.CustomEmoji, fallback string) *emoji.Emoji {
@@ -323,7 +322,7 @@ func findMissingPermissions(ctx registry.InteractionContext) ([]permission.Permi
 
 	var panel *database.Panel
 	if btnCtx, ok := ctx.(*ButtonContext); ok {
-		p, panelExists, err := dbclient.Client.Panel.GetByCustomId(context.Background(), ctx.GuildId(), btnCtx.InteractionData.CustomId)
+		p, panelExists, err := ctx.Worker().Services.Database.Panel.GetByCustomId(context.Background(), ctx.GuildId(), btnCtx.InteractionData.CustomId)
 		if err == nil && panelExists {
 			panel = &p
 			// Panel can enable threads if global setting is disabled
@@ -343,7 +342,7 @@ func findMissingPermissions(ctx registry.InteractionContext) ([]permission.Permi
 			targetChannelId = panel.TargetCategory
 		} else {
 			// Fall back to guild default category
-			targetChannelId, _ = dbclient.Client.ChannelCategory.Get(context.Background(), ctx.GuildId())
+			targetChannelId, _ = ctx.Worker().Services.Database.ChannelCategory.Get(context.Background(), ctx.GuildId())
 		}
 	}
 
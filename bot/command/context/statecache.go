@@ -7,7 +7,6 @@ import (
 
 	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 )
 
 type StateCache struct {
@@ -34,7 +33,7 @@ func (s *StateCache) Settings() (database.Settings, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
 
-	settings, err := dbclient.Client.Settings.Get(ctx, s.ctx.GuildId())
+	settings, err := s.ctx.Worker().Services.Database.Settings.Get(ctx, s.ctx.GuildId())
 	if err != nil {
 		return database.Settings{}, err
 	}
@@ -7,7 +7,7 @@ import (
 
 func LoadCommandIds(worker *worker.Context, botId uint64) (map[string]uint64, error) {
 	// Check cache first
-	cached, err := redis.LoadCommandIds(botId)
+	cached, err := worker.Services.Redis.LoadCommandIds(botId)
 	if err == nil && len(cached) > 0 {
 		return cached, nil
 	}
@@ -27,7 +27,7 @@ func LoadCommandIds(worker *worker.Context, botId uint64) (map[string]uint64, er
 		mapped[command.Name] = command.Id
 	}
 
-	if err := redis.StoreCommandIds(botId, mapped); err != nil {
+	if err := worker.Services.Redis.StoreCommandIds(botId, mapped); err != nil {
 		return nil, err
 	}
 
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -60,7 +59,7 @@ func (AdminBlacklistCommand) Execute(ctx registry.CommandContext, guildIdRaw str
 		realOwnerId = &parsed
 	}
 
-	if isBlacklisted, blacklistReason, _ := dbclient.Client.ServerBlacklist.IsBlacklisted(ctx, guildId); isBlacklisted {
+	if isBlacklisted, blacklistReason, _ := ctx.Worker().Services.Database.ServerBlacklist.IsBlacklisted(ctx, guildId); isBlacklisted {
 		ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
 			utils.BuildContainerRaw(
 				ctx,
@@ -90,7 +89,7 @@ func (AdminBlacklistCommand) Execute(ctx registry.CommandContext, guildIdRaw str
 	}
 
 	// Add to blacklist
-	if err := dbclient.Client.ServerBlacklist.Add(ctx, guildId, reason, ownerId, realOwnerId); err != nil {
+	if err := ctx.Worker().Services.Database.ServerBlacklist.Add(ctx, guildId, reason, ownerId, realOwnerId); err != nil {
 		ctx.HandleError(err)
 		return
 	}
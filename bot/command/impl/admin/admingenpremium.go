@@ -9,10 +9,10 @@ import (
 	"github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 	"github.com/google/uuid"
@@ -54,7 +54,7 @@ func (AdminGenPremiumCommand) Execute(ctx registry.CommandContext, skuIdRaw stri
 		return
 	}
 
-	tx, err := dbclient.Client.BeginTx(ctx)
+	tx, err := ctx.Worker().Services.Database.BeginTx(ctx)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -67,7 +67,7 @@ func (AdminGenPremiumCommand) Execute(ctx registry.CommandContext, skuIdRaw stri
 		tx.Rollback(ctx)
 	}()
 
-	sku, err := dbclient.Client.SubscriptionSkus.GetSku(ctx, tx, skuId)
+	sku, err := ctx.Worker().Services.Database.SubscriptionSkus.GetSku(ctx, tx, skuId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -82,7 +82,7 @@ func (AdminGenPremiumCommand) Execute(ctx registry.CommandContext, skuIdRaw stri
 	for i := 0; i < amount; i++ {
 		key := uuid.New()
 
-		if err := dbclient.Client.PremiumKeys.Create(ctx, key, time.Hour*24*time.Duration(length), skuId); err != nil {
+		if err := ctx.Worker().Services.Database.PremiumKeys.Create(ctx, key, time.Hour*24*time.Duration(length), skuId); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -119,11 +119,11 @@ func (AdminGenPremiumCommand) Execute(ctx registry.CommandContext, skuIdRaw stri
 	)))
 }
 
-func (AdminGenPremiumCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+func (AdminGenPremiumCommand) AutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
 
-	skus, err := dbclient.Client.SubscriptionSkus.Search(ctx, value, 10)
+	skus, err := worker.Services.Database.SubscriptionSkus.Search(ctx, value, 10)
 	if err != nil {
 		sentry.Error(err)
 		return nil
@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -39,7 +38,7 @@ func (c AdminListUserEntitlementsCommand) GetExecutor() any {
 
 func (AdminListUserEntitlementsCommand) Execute(ctx registry.CommandContext, userId uint64) {
 	// List entitlements that have expired in the past 30 days
-	entitlements, err := dbclient.Client.Entitlements.ListUserSubscriptions(ctx, userId, time.Hour*24*30)
+	entitlements, err := ctx.Worker().Services.Database.Entitlements.ListUserSubscriptions(ctx, userId, time.Hour*24*30)
 	if err != nil {
 		ctx.HandleError(err)
 		return
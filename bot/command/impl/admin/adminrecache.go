@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -51,7 +50,7 @@ func (AdminRecacheCommand) Execute(ctx registry.CommandContext, providedGuildId
 		guildId = ctx.GuildId()
 	}
 
-	if onCooldown, cooldownTime := redis.GetRecacheCooldown(guildId); onCooldown {
+	if onCooldown, cooldownTime := ctx.Worker().Services.Redis.GetRecacheCooldown(guildId); onCooldown {
 		ctx.ReplyWith(command.NewMessageResponseWithComponents([]component.Component{
 			utils.BuildContainerWithComponents(
 				ctx,
@@ -92,7 +91,7 @@ func (AdminRecacheCommand) Execute(ctx registry.CommandContext, providedGuildId
 		return
 	}
 
-	if err := redis.SetRecacheCooldown(guildId, time.Second*30); err != nil {
+	if err := ctx.Worker().Services.Redis.SetRecacheCooldown(guildId, time.Second*30); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -52,7 +51,7 @@ func (AdminWhitelabelAssignGuildCommand) Execute(ctx registry.CommandContext, bo
 		return
 	}
 
-	bot, err := dbclient.Client.Whitelabel.GetByBotId(ctx, botId)
+	bot, err := ctx.Worker().Services.Database.Whitelabel.GetByBotId(ctx, botId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -63,7 +62,7 @@ func (AdminWhitelabelAssignGuildCommand) Execute(ctx registry.CommandContext, bo
 		return
 	}
 
-	if err := dbclient.Client.WhitelabelGuilds.Add(ctx, botId, guildId); err != nil {
+	if err := ctx.Worker().Services.Database.WhitelabelGuilds.Add(ctx, botId, guildId); err != nil {
 		ctx.HandleError(err)
 		return
 	}
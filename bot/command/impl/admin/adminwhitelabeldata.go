@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/model"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -42,7 +41,7 @@ func (c AdminWhitelabelDataCommand) GetExecutor() interface{} {
 }
 
 func (AdminWhitelabelDataCommand) Execute(ctx registry.CommandContext, userId uint64) {
-	tier, err := utils.PremiumClient.GetTierByUser(ctx, userId, false)
+	tier, err := ctx.Worker().Services.Premium.GetTierByUser(ctx, userId, false)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -53,7 +52,7 @@ func (AdminWhitelabelDataCommand) Execute(ctx registry.CommandContext, userId ui
 		return
 	}
 
-	data, err := dbclient.Client.Whitelabel.GetByUserId(ctx, userId)
+	data, err := ctx.Worker().Services.Database.Whitelabel.GetByUserId(ctx, userId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -77,7 +76,7 @@ func (AdminWhitelabelDataCommand) Execute(ctx registry.CommandContext, userId ui
 		}
 	}
 
-	errors, err := dbclient.Client.WhitelabelErrors.GetRecent(ctx, userId, 3)
+	errors, err := ctx.Worker().Services.Database.WhitelabelErrors.GetRecent(ctx, userId, 3)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -95,7 +94,7 @@ func (AdminWhitelabelDataCommand) Execute(ctx registry.CommandContext, userId ui
 		errorsFormatted = strings.Join(strs, "\n")
 	}
 
-	guilds, err := dbclient.Client.WhitelabelGuilds.GetGuilds(ctx, data.BotId)
+	guilds, err := ctx.Worker().Services.Database.WhitelabelGuilds.GetGuilds(ctx, data.BotId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
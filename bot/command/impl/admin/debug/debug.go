@@ -20,6 +20,7 @@ func (AdminDebugCommand) Properties() registry.Properties {
 		HelperOnly:      true,
 		Children: []registry.Command{
 			AdminDebugServerCommand{},
+			AdminDebugClosesCommand{},
 		},
 	}
 }
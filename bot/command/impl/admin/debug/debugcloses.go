@@ -0,0 +1,87 @@
+package debug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type AdminDebugClosesCommand struct{}
+
+const debugClosesLimit = 15
+
+func (AdminDebugClosesCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "closes",
+		Description:     i18n.HelpAdminDebugCloses,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permcache.Everyone,
+		Category:        command.Settings,
+		HelperOnly:      true,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("guild_id", "ID of the guild", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		),
+		Timeout: time.Second * 10,
+	}
+}
+
+func (c AdminDebugClosesCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+// Execute lists tickets whose close has not completed, with the steps still outstanding
+func (AdminDebugClosesCommand) Execute(ctx registry.CommandContext, rawGuildId *string) {
+	var guildId uint64
+	if rawGuildId != nil {
+		var err error
+		guildId, err = strconv.ParseUint(*rawGuildId, 10, 64)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	entries, err := ctx.Worker().Services.Redis.ListCloseOutboxEntries(ctx, guildId, debugClosesLimit)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(entries) == 0 {
+		ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
+			utils.BuildContainerRaw(ctx, customisation.Green, "Admin - Incomplete Closes", "No tickets are stuck partway through closing."),
+		}))
+		return
+	}
+
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		status := fmt.Sprintf("attempt %d", entry.Attempts)
+		if entry.Abandoned {
+			status = fmt.Sprintf("abandoned after %d attempts", entry.Attempts)
+		}
+
+		line := fmt.Sprintf("**Guild `%d`, ticket #%d** (%s, closed <t:%d:R>)\nRemaining: `%s`",
+			entry.GuildId, entry.TicketId, status, entry.CreatedAt.Unix(), strings.Join(entry.Steps, "`, `"))
+
+		if entry.LastError != "" {
+			line += fmt.Sprintf("\nLast error: `%s`", utils.StringMax(entry.LastError, 200, "..."))
+		}
+
+		lines = append(lines, line)
+	}
+
+	ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
+		utils.BuildContainerRaw(ctx, customisation.Orange, "Admin - Incomplete Closes", strings.Join(lines, "\n\n")),
+	}))
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/permissionwrapper"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
@@ -57,7 +56,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 	}
 
 	// Get the correct bot for the target guild
-	botId, botFound, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
+	botId, botFound, err := ctx.Worker().Services.Database.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -67,7 +66,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 	var bInf application.Application
 
 	if botFound {
-		bot, err := dbclient.Client.Whitelabel.GetByBotId(ctx, botId)
+		bot, err := ctx.Worker().Services.Database.Whitelabel.GetByBotId(ctx, botId)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -86,6 +85,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 			ShardId:      0,
 			Cache:        ctx.Worker().Cache,
 			RateLimiter:  nil, // Use http-proxy ratelimit functionality
+			Services:     ctx.Worker().Services,
 		}
 
 		// Get application info for display
@@ -104,7 +104,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 	if err != nil {
 		// Check if blacklisted
 		if blacklist.IsGuildBlacklisted(guildId) {
-			serverBlacklist, _ := dbclient.Client.ServerBlacklist.Get(ctx, guildId)
+			serverBlacklist, _ := ctx.Worker().Services.Database.ServerBlacklist.Get(ctx, guildId)
 			reason := "No reason provided"
 			if serverBlacklist != nil && serverBlacklist.Reason != nil && *serverBlacklist.Reason != "" {
 				reason = *serverBlacklist.Reason
@@ -124,7 +124,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 				} else {
 					countUserId = *serverBlacklist.RealOwnerId
 				}
-				serverCount, realCount, _ := dbclient.Client.ServerBlacklist.GetUserBlacklistedOwnerCounts(ctx, countUserId)
+				serverCount, realCount, _ := ctx.Worker().Services.Database.ServerBlacklist.GetUserBlacklistedOwnerCounts(ctx, countUserId)
 				if serverCount > 0 {
 					message += fmt.Sprintf("\nServer Owner of Blacklisted Servers: `%d`", serverCount)
 				}
@@ -146,7 +146,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 		return
 	}
 
-	settings, err := dbclient.Client.Settings.Get(ctx, guild.Id)
+	settings, err := ctx.Worker().Services.Database.Settings.Get(ctx, guild.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -158,26 +158,26 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 		return
 	}
 
-	tier, source, err := utils.PremiumClient.GetTierByGuild(ctx, guild)
+	tier, source, err := ctx.Worker().Services.Premium.GetTierByGuild(ctx, guild)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
 	// Get active entitlements to find subscription owner
-	entitlements, err := dbclient.Client.Entitlements.ListGuildSubscriptions(ctx, guild.Id, guild.OwnerId, 0)
+	entitlements, err := ctx.Worker().Services.Database.Entitlements.ListGuildSubscriptions(ctx, guild.Id, guild.OwnerId, 0)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	panels, err := dbclient.Client.Panel.GetByGuild(ctx, guild.Id)
+	panels, err := ctx.Worker().Services.Database.Panel.GetByGuild(ctx, guild.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	integrations, err := dbclient.Client.CustomIntegrationGuilds.GetGuildIntegrations(ctx, guild.Id)
+	integrations, err := ctx.Worker().Services.Database.CustomIntegrationGuilds.GetGuildIntegrations(ctx, guild.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -187,7 +187,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 
 	for i := range experiments.List {
 		feature := experiments.List[i]
-		if experiments.HasFeature(ctx, guild.Id, feature) {
+		if experiments.HasFeature(ctx, ctx.Worker().Services, guild.Id, feature) {
 			featuresEnabled = append(featuresEnabled, string(feature))
 		}
 	}
@@ -254,7 +254,7 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 
 	// Add blacklist information
 	IsOwnerBlacklisted := blacklist.IsUserBlacklisted(owner.Id)
-	IsGuildBlacklisted, ServerBlacklistReason, err := dbclient.Client.ServerBlacklist.IsBlacklisted(ctx, guildId)
+	IsGuildBlacklisted, ServerBlacklistReason, err := ctx.Worker().Services.Database.ServerBlacklist.IsBlacklisted(ctx, guildId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -262,14 +262,14 @@ func (AdminDebugServerCommand) Execute(ctx registry.CommandContext, raw string)
 
 	var GlobalBlacklistReason string
 	if IsOwnerBlacklisted {
-		globalBlacklist, _ := dbclient.Client.GlobalBlacklist.Get(ctx, owner.Id)
+		globalBlacklist, _ := ctx.Worker().Services.Database.GlobalBlacklist.Get(ctx, owner.Id)
 		if globalBlacklist != nil && globalBlacklist.Reason != nil {
 			GlobalBlacklistReason = *globalBlacklist.Reason
 		}
 	}
 
 	// Check if owner was previously an owner of any blacklisted server (repeat offender)
-	serverOwnerCount, realOwnerCount, _ := dbclient.Client.ServerBlacklist.GetUserBlacklistedOwnerCounts(ctx, owner.Id)
+	serverOwnerCount, realOwnerCount, _ := ctx.Worker().Services.Database.ServerBlacklist.GetUserBlacklistedOwnerCounts(ctx, owner.Id)
 
 	// Calculate the shard ID
 	shardId := int((guild.Id >> 22) % uint64(config.Conf.Discord.SharderTotal))
@@ -7,11 +7,11 @@ import (
 	"github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/bot/gdprrelay"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -63,7 +63,7 @@ func (GDPRCommand) Execute(ctx registry.CommandContext, language *string) {
 		locale = i18n.LocaleEnglish
 	}
 
-	if !gdprrelay.IsWorkerAlive(redis.Client) {
+	if !gdprrelay.IsWorkerAlive(ctx.Worker().Services.Redis.Client) {
 		container := utils.BuildGDPRWorkerOfflineView(ctx, locale)
 		ctx.ReplyWith(command.NewMessageResponseWithComponents([]component.Component{container}))
 		return
@@ -112,7 +112,7 @@ func buildButtonRow(ctx registry.CommandContext, locale *i18n.Locale, buttons []
 	return component.BuildActionRow(buttonComponents...)
 }
 
-func (GDPRCommand) LanguageAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+func (GDPRCommand) LanguageAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
 	choices := make([]interaction.ApplicationCommandOptionChoice, 0)
 
 	for _, locale := range i18n.Locales {
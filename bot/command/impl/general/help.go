@@ -120,7 +120,7 @@ func (c HelpCommand) Execute(ctx registry.CommandContext) {
 					commandId = &tmp
 				}
 
-				formatted = append(formatted, registry.FormatHelp(cmd, ctx.GuildId(), commandId))
+				formatted = append(formatted, registry.FormatHelp(cmd, ctx.Worker().Services, ctx.GuildId(), commandId))
 			}
 
 			categoryName := string(category.(command.Category))
@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -40,8 +39,8 @@ func (c VoteCommand) GetExecutor() interface{} {
 
 func (c VoteCommand) Execute(ctx registry.CommandContext) {
 	var credits int
-	if err := dbclient.Client.WithTx(ctx, func(tx pgx.Tx) (err error) {
-		credits, err = dbclient.Client.VoteCredits.Get(ctx, tx, ctx.UserId())
+	if err := ctx.Worker().Services.Database.WithTx(ctx, func(tx pgx.Tx) (err error) {
+		credits, err = ctx.Worker().Services.Database.VoteCredits.Get(ctx, tx, ctx.UserId())
 		return
 	}); err != nil {
 		ctx.HandleError(err)
@@ -12,7 +12,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -54,121 +53,151 @@ func (BlacklistCommand) Execute(ctx registry.CommandContext, id uint64) {
 	}
 
 	if mentionableType == context.MentionableTypeUser {
-		member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), id)
+		toggleUserBlacklist(ctx, id, utils.ToSlice(usageEmbed))
+	} else if mentionableType == context.MentionableTypeRole {
+		// Check if role is staff
+		isSupport, err := ctx.Worker().Services.Database.RolePermissions.IsSupport(ctx, id)
 		if err != nil {
 			ctx.HandleError(err)
 			return
 		}
 
-		if ctx.UserId() == id {
-			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistSelf, utils.ToSlice(usageEmbed))
+		if isSupport {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed)) // TODO: Does this need a new message?
 			return
 		}
 
-		permLevel, err := permission.GetPermissionLevel(ctx, utils.ToRetriever(ctx.Worker()), member, ctx.GuildId())
+		// Check if staff is part of any team
+		isSupport, err = ctx.Worker().Services.Database.SupportTeamRoles.IsSupport(ctx, ctx.GuildId(), id)
 		if err != nil {
 			ctx.HandleError(err)
 			return
 		}
 
-		if permLevel > permission.Everyone {
-			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed))
+		if isSupport {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed)) // TODO: Does this need a new message?
 			return
 		}
 
-		isBlacklisted, err := dbclient.Client.Blacklist.IsBlacklisted(ctx, ctx.GuildId(), id)
+		isBlacklisted, err := ctx.Worker().Services.Database.RoleBlacklist.IsBlacklisted(ctx, ctx.GuildId(), id)
 		if err != nil {
-			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			ctx.HandleError(err)
 			return
 		}
 
 		if isBlacklisted {
-			if err := dbclient.Client.Blacklist.Remove(ctx, ctx.GuildId(), id); err != nil {
+			if err := ctx.Worker().Services.Database.RoleBlacklist.Remove(ctx, ctx.GuildId(), id); err != nil {
 				ctx.HandleError(err)
 				return
 			}
 
-			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemove, id)
+			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemoveRole, id)
 		} else {
-			// Limit of 250 *users*
-			count, err := dbclient.Client.Blacklist.GetBlacklistedCount(ctx, ctx.GuildId())
+			// Limit of 50 *roles*
+			count, err := ctx.Worker().Services.Database.Blacklist.GetBlacklistedCount(ctx, ctx.GuildId())
 			if err != nil {
 				ctx.HandleError(err)
 				return
 			}
 
-			if count >= 250 {
-				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistLimit, 250)
+			if count >= 50 {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistRoleLimit, 50)
 				return
 			}
 
-			if err := dbclient.Client.Blacklist.Add(ctx, ctx.GuildId(), member.User.Id); err != nil {
+			if err := ctx.Worker().Services.Database.RoleBlacklist.Add(ctx, ctx.GuildId(), id); err != nil {
 				ctx.HandleError(err)
 				return
 			}
 
-			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAdd, member.User.Id)
-		}
-	} else if mentionableType == context.MentionableTypeRole {
-		// Check if role is staff
-		isSupport, err := dbclient.Client.RolePermissions.IsSupport(ctx, id)
-		if err != nil {
-			ctx.HandleError(err)
-			return
+			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAddRole, id)
 		}
+	} else {
+		ctx.HandleError(fmt.Errorf("infallible"))
+		return
+	}
+}
 
-		if isSupport {
-			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed)) // TODO: Does this need a new message?
-			return
-		}
+// toggleUserBlacklist blacklists the user from opening tickets, or removes them from the blacklist if they already are.
+// fields are attached to error replies.
+func toggleUserBlacklist(ctx registry.CommandContext, id uint64, fields []embed.EmbedField) {
+	member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
 
-		// Check if staff is part of any team
-		isSupport, err = dbclient.Client.SupportTeamRoles.IsSupport(ctx, ctx.GuildId(), id)
-		if err != nil {
+	if ctx.UserId() == id {
+		ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistSelf, fields)
+		return
+	}
+
+	permLevel, err := permission.GetPermissionLevel(ctx, utils.ToRetriever(ctx.Worker()), member, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permLevel > permission.Everyone {
+		ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, fields)
+		return
+	}
+
+	isBlacklisted, err := ctx.Worker().Services.Database.Blacklist.IsBlacklisted(ctx, ctx.GuildId(), id)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	if isBlacklisted {
+		if err := ctx.Worker().Services.Database.SpamBlacklist.Remove(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
 
-		if isSupport {
-			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed)) // TODO: Does this need a new message?
+		if err := ctx.Worker().Services.Database.Blacklist.Remove(ctx, ctx.GuildId(), id); err != nil {
+			ctx.HandleError(err)
 			return
 		}
 
-		isBlacklisted, err := dbclient.Client.RoleBlacklist.IsBlacklisted(ctx, ctx.GuildId(), id)
-		if err != nil {
+		ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemove, id)
+		return
+	}
+
+	// A temporary blacklist from a ticket closed as spam is lifted early, rather than made permanent. Running the
+	// command again blacklists the user permanently.
+	expiresAt, spamBlacklisted, err := ctx.Worker().Services.Database.SpamBlacklist.GetExpiry(ctx, ctx.GuildId(), id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if spamBlacklisted {
+		if err := ctx.Worker().Services.Database.SpamBlacklist.Remove(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
 
-		if isBlacklisted {
-			if err := dbclient.Client.RoleBlacklist.Remove(ctx, ctx.GuildId(), id); err != nil {
-				ctx.HandleError(err)
-				return
-			}
-
-			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemoveRole, id)
-		} else {
-			// Limit of 50 *roles*
-			count, err := dbclient.Client.Blacklist.GetBlacklistedCount(ctx, ctx.GuildId())
-			if err != nil {
-				ctx.HandleError(err)
-				return
-			}
+		ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemoveTemporary, id, expiresAt.Unix())
+		return
+	}
 
-			if count >= 50 {
-				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistRoleLimit, 50)
-				return
-			}
+	// Limit of 250 *users*
+	count, err := ctx.Worker().Services.Database.Blacklist.GetBlacklistedCount(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
 
-			if err := dbclient.Client.RoleBlacklist.Add(ctx, ctx.GuildId(), id); err != nil {
-				ctx.HandleError(err)
-				return
-			}
+	if count >= 250 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistLimit, 250)
+		return
+	}
 
-			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAddRole, id)
-		}
-	} else {
-		ctx.HandleError(fmt.Errorf("infallible"))
+	if err := ctx.Worker().Services.Database.Blacklist.Add(ctx, ctx.GuildId(), member.User.Id); err != nil {
+		ctx.HandleError(err)
 		return
 	}
+
+	ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAdd, member.User.Id)
 }
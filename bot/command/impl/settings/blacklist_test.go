@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/guild"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleUserBlacklist(t *testing.T) {
+	tests := []struct {
+		name              string
+		spamBlacklisted   bool
+		blacklisted       bool
+		expectBlacklisted bool
+		expectReply       i18n.MessageId
+	}{
+		{
+			name:              "blacklists the user",
+			expectBlacklisted: true,
+			expectReply:       i18n.MessageBlacklistAdd,
+		},
+		{
+			name:            "lifts a temporary spam blacklist early",
+			spamBlacklisted: true,
+			expectReply:     i18n.MessageBlacklistRemoveTemporary,
+		},
+		{
+			name:        "removes a permanent blacklist",
+			blacklisted: true,
+			expectReply: i18n.MessageBlacklistRemove,
+		},
+		{
+			name:            "removes a permanent blacklist along with a temporary one",
+			spamBlacklisted: true,
+			blacklisted:     true,
+			expectReply:     i18n.MessageBlacklistRemove,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			guildId := h.Discord.NextId()
+			h.Discord.AddGuild(guild.Guild{Id: guildId, Name: "Test", OwnerId: h.Discord.NextId()})
+
+			target := user.User{Id: h.Discord.NextId(), Username: "target"}
+			h.Discord.AddUser(target)
+			h.Discord.AddMember(guildId, member.Member{User: target})
+			h.SetPermissionLevel(t, guildId, target.Id, permission.Everyone)
+
+			if test.spamBlacklisted {
+				require.NoError(t, h.Database.SpamBlacklist.Set(t.Context(), guildId, target.Id, time.Now().Add(time.Hour)))
+			}
+
+			if test.blacklisted {
+				require.NoError(t, h.Database.Blacklist.Add(t.Context(), guildId, target.Id))
+			}
+
+			ctx := h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permission.Admin)
+			toggleUserBlacklist(ctx, target.Id, nil)
+
+			require.Empty(t, ctx.Errors())
+			reply, ok := ctx.LastReply()
+			require.True(t, ok)
+			require.Equal(t, customisation.Green, reply.Colour)
+			require.Equal(t, test.expectReply, reply.ContentId)
+
+			blacklisted, err := h.Database.Blacklist.IsBlacklisted(t.Context(), guildId, target.Id)
+			require.NoError(t, err)
+			require.Equal(t, test.expectBlacklisted, blacklisted)
+
+			// No outcome leaves a temporary blacklist behind
+			_, spamBlacklisted, err := h.Database.SpamBlacklist.GetExpiry(t.Context(), guildId, target.Id)
+			require.NoError(t, err)
+			require.False(t, spamBlacklisted)
+		})
+	}
+}
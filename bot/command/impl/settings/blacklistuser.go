@@ -0,0 +1,48 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+)
+
+type BlacklistUserCommand struct {
+}
+
+func (BlacklistUserCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "Blacklist from tickets",
+		Type:             interaction.ApplicationCommandTypeUser,
+		PermissionLevel:  permission.Support, // Customisable level
+		Category:         command.Settings,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c BlacklistUserCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+// Execute toggles the target's blacklist status, in the same way as /blacklist
+func (BlacklistUserCommand) Execute(ctx registry.CommandContext) {
+	interaction, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	if !logic.CheckUserContextMenuPermission(ctx, func(config dbclient.UserContextMenuConfig) int {
+		return config.BlacklistLevel
+	}) {
+		return
+	}
+
+	toggleUserBlacklist(ctx, interaction.Interaction.Data.TargetId, nil)
+}
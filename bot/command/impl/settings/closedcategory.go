@@ -0,0 +1,102 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type ClosedCategoryCommand struct {
+}
+
+func (ClosedCategoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "closedcategory",
+		Description:     i18n.HelpClosedCategory,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel whose closed tickets should be kept", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.PanelAutoCompleteHandler),
+			command.NewOptionalArgument("category", "The category closed tickets should be moved to. Omit to delete closed tickets straight away", interaction.OptionTypeChannel, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("days", fmt.Sprintf("How many days closed tickets are kept for before being deleted (defaults to %d)", logic.DefaultClosedChannelRetention), interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c ClosedCategoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ClosedCategoryCommand) Execute(ctx registry.CommandContext, panelId int, categoryId *uint64, days *int) {
+	panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessagePanelNotFound)
+		return
+	}
+
+	if categoryId == nil {
+		if err := ctx.Worker().Services.Database.ClosedCategoryConfig.Delete(ctx, ctx.GuildId(), panelId); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleClosedCategory, i18n.MessageClosedCategoryDisabled, panel.Title)
+		return
+	}
+
+	config := dbclient.ClosedCategoryConfig{
+		CategoryId:      *categoryId,
+		DeleteAfterDays: logic.DefaultClosedChannelRetention,
+	}
+
+	if days != nil {
+		if *days < 1 || *days > logic.MaxClosedChannelRetention {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClosedCategoryRetention, logic.MaxClosedChannelRetention)
+			return
+		}
+
+		config.DeleteAfterDays = *days
+	}
+
+	ch, err := ctx.Worker().GetChannel(*categoryId)
+	if err != nil {
+		if restError, ok := err.(request.RestError); ok && restError.IsClientError() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClosedCategoryAccess)
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	if ch.Type != channel.ChannelTypeGuildCategory {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClosedCategoryNotCategory)
+		return
+	}
+
+	if err := ctx.Worker().Services.Database.ClosedCategoryConfig.Set(ctx, ctx.GuildId(), panelId, config); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleClosedCategory, i18n.MessageClosedCategorySuccess, panel.Title, config.CategoryId, config.DeleteAfterDays)
+}
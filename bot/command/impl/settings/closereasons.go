@@ -0,0 +1,75 @@
+package settings
+
+import (
+	"strings"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type CloseReasonsCommand struct {
+}
+
+func (CloseReasonsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "closereasons",
+		Description:     i18n.HelpCloseReasons,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			CloseReasonsAddCommand{},
+			CloseReasonsRemoveCommand{},
+			CloseReasonsListCommand{},
+			CloseReasonsRequireCommand{},
+		},
+	}
+}
+
+func (c CloseReasonsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (CloseReasonsCommand) Execute(ctx registry.CommandContext) {
+	// Can't call a parent command
+}
+
+func closeReasonsPanelArgument() command.Argument {
+	return command.NewRequiredAutocompleteableArgument("panel", "The panel whose close reasons should be changed", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.PanelAutoCompleteHandler)
+}
+
+// getCloseReasonsPanel fetches the panel, replying with an error message and returning false if it does not belong to
+// the guild
+func getCloseReasonsPanel(ctx registry.CommandContext, panelId int) (database.Panel, bool) {
+	panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Panel{}, false
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessagePanelNotFound)
+		return database.Panel{}, false
+	}
+
+	return panel, true
+}
+
+func closeReasonCategoryAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	var choices []interaction.ApplicationCommandOptionChoice
+	for _, category := range logic.CloseReasonCategories {
+		if strings.Contains(category, strings.ToLower(value)) {
+			choices = append(choices, utils.StringChoice(category))
+		}
+	}
+
+	return choices
+}
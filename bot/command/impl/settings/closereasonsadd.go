@@ -0,0 +1,97 @@
+package settings
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type CloseReasonsAddCommand struct {
+}
+
+func (CloseReasonsAddCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "add",
+		Description:     i18n.HelpCloseReasonAdd,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			closeReasonsPanelArgument(),
+			command.NewRequiredArgument("label", "The reason shown to staff when closing a ticket", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewRequiredAutocompleteableArgument("category", "The category the reason is counted under in the statistics", interaction.OptionTypeString, i18n.MessageInvalidArgument, closeReasonCategoryAutoCompleteHandler),
+			command.NewOptionalArgument("description", "A longer explanation of when the reason should be used", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c CloseReasonsAddCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (CloseReasonsAddCommand) Execute(ctx registry.CommandContext, panelId int, label, category string, description *string) {
+	panel, ok := getCloseReasonsPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	preset := dbclient.CloseReasonPreset{
+		Label:    strings.TrimSpace(label),
+		Category: strings.ToLower(strings.TrimSpace(category)),
+	}
+
+	if description != nil {
+		preset.Description = strings.TrimSpace(*description)
+	}
+
+	if preset.Label == "" || len(preset.Label) > logic.MaxCloseReasonPresetLabelLength {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonsLabelLength, logic.MaxCloseReasonPresetLabelLength)
+		return
+	}
+
+	if len(preset.Description) > logic.MaxCloseReasonPresetLabelLength {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonsDescriptionLength, logic.MaxCloseReasonPresetLabelLength)
+		return
+	}
+
+	if !slices.Contains(logic.CloseReasonCategories, preset.Category) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonsInvalidCategory, strings.Join(logic.CloseReasonCategories, ", "))
+		return
+	}
+
+	config, _, err := ctx.Worker().Services.Database.CloseReasonConfig.Get(ctx, ctx.GuildId(), panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if _, exists := logic.MatchCloseReasonPreset(config, preset.Label); exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonsAlreadyExists, panel.Title, preset.Label)
+		return
+	}
+
+	if len(config.Presets) >= logic.MaxCloseReasonPresets {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonsLimit, logic.MaxCloseReasonPresets)
+		return
+	}
+
+	config.Presets = append(config.Presets, preset)
+	if err := ctx.Worker().Services.Database.CloseReasonConfig.Set(ctx, ctx.GuildId(), panel.PanelId, config); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleCloseReasons, i18n.MessageCloseReasonsAddSuccess, preset.Label, preset.Category, panel.Title)
+}
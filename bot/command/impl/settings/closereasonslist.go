@@ -0,0 +1,79 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type CloseReasonsListCommand struct {
+}
+
+func (CloseReasonsListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "list",
+		Description:     i18n.HelpCloseReasonList,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			closeReasonsPanelArgument(),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c CloseReasonsListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (CloseReasonsListCommand) Execute(ctx registry.CommandContext, panelId int) {
+	panel, ok := getCloseReasonsPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	config, _, err := ctx.Worker().Services.Database.CloseReasonConfig.Get(ctx, ctx.GuildId(), panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.ReplyRaw(customisation.Green, ctx.GetMessage(i18n.TitleCloseReasons), formatCloseReasonConfig(ctx, panel.Title, config))
+}
+
+func formatCloseReasonConfig(ctx registry.CommandContext, panelTitle string, config dbclient.CloseReasonConfig) string {
+	var lines []string
+	if config.RequireReason {
+		lines = append(lines, ctx.GetMessage(i18n.MessageCloseReasonsListRequired, panelTitle))
+	} else {
+		lines = append(lines, ctx.GetMessage(i18n.MessageCloseReasonsListNotRequired, panelTitle))
+	}
+
+	if len(config.Presets) == 0 {
+		lines = append(lines, "", ctx.GetMessage(i18n.MessageCloseReasonsListEmpty))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, "")
+	for _, preset := range config.Presets {
+		line := fmt.Sprintf("• **%s** (%s)", preset.Label, preset.Category)
+		if preset.Description != "" {
+			line += fmt.Sprintf(": %s", preset.Description)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
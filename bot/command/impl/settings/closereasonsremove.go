@@ -0,0 +1,78 @@
+package settings
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type CloseReasonsRemoveCommand struct {
+}
+
+func (CloseReasonsRemoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "remove",
+		Description:     i18n.HelpCloseReasonRemove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			closeReasonsPanelArgument(),
+			command.NewRequiredArgument("label", "The close reason to remove", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c CloseReasonsRemoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (CloseReasonsRemoveCommand) Execute(ctx registry.CommandContext, panelId int, label string) {
+	panel, ok := getCloseReasonsPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	config, _, err := ctx.Worker().Services.Database.CloseReasonConfig.Get(ctx, ctx.GuildId(), panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	label = strings.TrimSpace(label)
+	index := slices.IndexFunc(config.Presets, func(preset dbclient.CloseReasonPreset) bool {
+		return strings.EqualFold(preset.Label, label)
+	})
+
+	if index == -1 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonsNotFound, panel.Title, label)
+		return
+	}
+
+	config.Presets = slices.Delete(config.Presets, index, index+1)
+
+	// Remove the config entirely once there is nothing left in it
+	if len(config.Presets) == 0 && !config.RequireReason {
+		err = ctx.Worker().Services.Database.CloseReasonConfig.Delete(ctx, ctx.GuildId(), panel.PanelId)
+	} else {
+		err = ctx.Worker().Services.Database.CloseReasonConfig.Set(ctx, ctx.GuildId(), panel.PanelId, config)
+	}
+
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleCloseReasons, i18n.MessageCloseReasonsRemoveSuccess, label, panel.Title)
+}
@@ -0,0 +1,61 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type CloseReasonsRequireCommand struct {
+}
+
+func (CloseReasonsRequireCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "require",
+		Description:     i18n.HelpCloseReasonRequire,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			closeReasonsPanelArgument(),
+			command.NewRequiredArgument("required", "Whether tickets from the panel must be given a reason when they are closed", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c CloseReasonsRequireCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (CloseReasonsRequireCommand) Execute(ctx registry.CommandContext, panelId int, required bool) {
+	panel, ok := getCloseReasonsPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	config, _, err := ctx.Worker().Services.Database.CloseReasonConfig.Get(ctx, ctx.GuildId(), panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	config.RequireReason = required
+	if err := ctx.Worker().Services.Database.CloseReasonConfig.Set(ctx, ctx.GuildId(), panel.PanelId, config); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if required {
+		ctx.Reply(customisation.Green, i18n.TitleCloseReasons, i18n.MessageCloseReasonsRequired, panel.Title)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleCloseReasons, i18n.MessageCloseReasonsNotRequired, panel.Title)
+	}
+}
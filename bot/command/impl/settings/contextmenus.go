@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// contextMenuLevels are the levels which the staff user context menus can be restricted to
+var contextMenuLevels = map[string]permission.PermissionLevel{
+	"support": permission.Support,
+	"admin":   permission.Admin,
+}
+
+type ContextMenusCommand struct {
+}
+
+func (ContextMenusCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "contextmenus",
+		Description:     i18n.HelpContextMenus,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalAutocompleteableArgument("open", "Who can open tickets for other users from the user menu", interaction.OptionTypeString, i18n.MessageInvalidArgument, contextMenuLevelAutoCompleteHandler),
+			command.NewOptionalAutocompleteableArgument("view_tickets", "Who can view a user's tickets from the user menu", interaction.OptionTypeString, i18n.MessageInvalidArgument, contextMenuLevelAutoCompleteHandler),
+			command.NewOptionalAutocompleteableArgument("blacklist", "Who can blacklist users from the user menu", interaction.OptionTypeString, i18n.MessageInvalidArgument, contextMenuLevelAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c ContextMenusCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ContextMenusCommand) Execute(ctx registry.CommandContext, open, viewTickets, blacklist *string) {
+	config, err := logic.GetUserContextMenuConfig(ctx, ctx.Worker(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	for _, update := range []struct {
+		value *string
+		level *int
+	}{
+		{open, &config.OpenLevel},
+		{viewTickets, &config.ViewTicketsLevel},
+		{blacklist, &config.BlacklistLevel},
+	} {
+		if update.value == nil {
+			continue
+		}
+
+		level, ok := contextMenuLevels[strings.ToLower(*update.value)]
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageContextMenusInvalidLevel)
+			return
+		}
+
+		*update.level = level.Int()
+	}
+
+	if open != nil || viewTickets != nil || blacklist != nil {
+		if err := ctx.Worker().Services.Database.UserContextMenuConfig.Set(ctx, ctx.GuildId(), config); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	lines := []string{
+		ctx.GetMessage(i18n.MessageContextMenusOpen, formatContextMenuLevel(ctx, config.OpenLevel)),
+		ctx.GetMessage(i18n.MessageContextMenusViewTickets, formatContextMenuLevel(ctx, config.ViewTicketsLevel)),
+		ctx.GetMessage(i18n.MessageContextMenusBlacklist, formatContextMenuLevel(ctx, config.BlacklistLevel)),
+	}
+
+	ctx.ReplyRaw(customisation.Green, ctx.GetMessage(i18n.TitleContextMenus), strings.Join(lines, "\n"))
+}
+
+func formatContextMenuLevel(ctx registry.CommandContext, level int) string {
+	if permission.PermissionLevel(level) >= permission.Admin {
+		return ctx.GetMessage(i18n.MessageContextMenusLevelAdmin)
+	}
+
+	return ctx.GetMessage(i18n.MessageContextMenusLevelSupport)
+}
+
+func contextMenuLevelAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	return []interaction.ApplicationCommandOptionChoice{
+		{Name: "Support representatives and admins", Value: "support"},
+		{Name: "Admins only", Value: "admin"},
+	}
+}
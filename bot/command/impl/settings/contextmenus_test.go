@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestContextMenusCommand(t *testing.T) {
+	h := testharness.New(t)
+	guildId := h.Discord.NextId()
+
+	ctx := h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permission.Admin)
+	ContextMenusCommand{}.Execute(ctx, nil, nil, strPtr("Admin"))
+
+	require.Empty(t, ctx.Errors())
+	reply, ok := ctx.LastReply()
+	require.True(t, ok)
+	require.Equal(t, customisation.Green, reply.Colour)
+
+	config, ok, err := h.Database.UserContextMenuConfig.Get(t.Context(), guildId)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, dbclient.UserContextMenuConfig{
+		OpenLevel:        permission.Support.Int(),
+		ViewTicketsLevel: permission.Support.Int(),
+		BlacklistLevel:   permission.Admin.Int(),
+	}, config)
+
+	// An invalid level leaves the config unchanged
+	ctx = h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permission.Admin)
+	ContextMenusCommand{}.Execute(ctx, strPtr("everyone"), nil, strPtr("support"))
+
+	reply, ok = ctx.LastReply()
+	require.True(t, ok)
+	require.Equal(t, customisation.Red, reply.Colour)
+
+	unchanged, _, err := h.Database.UserContextMenuConfig.Get(t.Context(), guildId)
+	require.NoError(t, err)
+	require.Equal(t, config, unchanged)
+}
+
+func TestCheckUserContextMenuPermission(t *testing.T) {
+	h := testharness.New(t)
+	guildId := h.Discord.NextId()
+
+	require.NoError(t, h.Database.UserContextMenuConfig.Set(t.Context(), guildId, dbclient.UserContextMenuConfig{
+		OpenLevel:        permission.Support.Int(),
+		ViewTicketsLevel: permission.Support.Int(),
+		BlacklistLevel:   permission.Admin.Int(),
+	}))
+
+	blacklistLevel := func(config dbclient.UserContextMenuConfig) int {
+		return config.BlacklistLevel
+	}
+
+	support := h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permission.Support)
+	require.False(t, logic.CheckUserContextMenuPermission(support, blacklistLevel))
+
+	reply, ok := support.LastReply()
+	require.True(t, ok)
+	require.Equal(t, customisation.Red, reply.Colour)
+
+	admin := h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permission.Admin)
+	require.True(t, logic.CheckUserContextMenuPermission(admin, blacklistLevel))
+	require.Empty(t, admin.Replies())
+}
@@ -0,0 +1,110 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+const defaultFeedbackFollowUpThreshold = 2
+
+type FeedbackFollowUpCommand struct {
+}
+
+func (FeedbackFollowUpCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "feedbackfollowup",
+		Description:     i18n.HelpFeedbackFollowUp,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("enabled", "Whether feedback follow-ups should be enabled", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("channel", "The channel that low rating alerts should be posted to", interaction.OptionTypeChannel, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("threshold", "Ratings at or below this number of stars raise an alert (defaults to 2)", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("reopen_button", "Whether alerts should include a button to reopen the ticket", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("thank_claimer", "Whether the claimer should be thanked by DM for positive ratings", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c FeedbackFollowUpCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (FeedbackFollowUpCommand) Execute(ctx registry.CommandContext, enabled bool, channelId *uint64, threshold *int, reopenButton *bool, thankClaimer *bool) {
+	if !enabled {
+		if err := ctx.Worker().Services.Database.FeedbackFollowUpConfig.Delete(ctx, ctx.GuildId()); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleFeedbackFollowUp, i18n.MessageFeedbackFollowUpDisabled)
+		return
+	}
+
+	config := dbclient.FeedbackFollowUpConfig{
+		Threshold:    defaultFeedbackFollowUpThreshold,
+		ReopenButton: reopenButton != nil && *reopenButton,
+		ThankClaimer: thankClaimer != nil && *thankClaimer,
+	}
+
+	if channelId == nil && !config.ThankClaimer {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackFollowUpChannelMissing)
+		return
+	}
+
+	if channelId != nil {
+		if _, err := ctx.Worker().GetChannel(*channelId); err != nil {
+			if restError, ok := err.(request.RestError); ok && restError.IsClientError() {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackFollowUpChannelAccess)
+			} else {
+				ctx.HandleError(err)
+			}
+
+			return
+		}
+
+		config.ChannelId = *channelId
+	}
+
+	if threshold != nil {
+		if *threshold < 1 || *threshold >= logic.PositiveRatingThreshold {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackFollowUpThreshold, logic.PositiveRatingThreshold-1)
+			return
+		}
+
+		config.Threshold = uint8(*threshold)
+	}
+
+	if err := ctx.Worker().Services.Database.FeedbackFollowUpConfig.Set(ctx, ctx.GuildId(), config); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	var lines []string
+	if config.ChannelId != 0 {
+		lines = append(lines, ctx.GetMessage(i18n.MessageFeedbackFollowUpAlerts, config.Threshold, config.ChannelId))
+
+		if config.ReopenButton {
+			lines = append(lines, ctx.GetMessage(i18n.MessageFeedbackFollowUpReopenButton))
+		}
+	}
+
+	if config.ThankClaimer {
+		lines = append(lines, ctx.GetMessage(i18n.MessageFeedbackFollowUpThankClaimer, logic.PositiveRatingThreshold))
+	}
+
+	ctx.ReplyRaw(customisation.Green, ctx.GetMessage(i18n.TitleFeedbackFollowUp), strings.Join(lines, "\n"))
+}
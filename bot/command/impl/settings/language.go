@@ -69,7 +69,7 @@ func (c *LanguageCommand) Execute(ctx registry.CommandContext) {
 
 	languageList = strings.TrimSuffix(languageList, "\n")
 
-	helpWanted := utils.EmbedField(ctx.GuildId(), "ℹ️ Help Wanted", i18n.MessageLanguageHelpWanted, true, config.Conf.Bot.SupportServerInvite)
+	helpWanted := utils.EmbedField(ctx.Worker().Services, ctx.GuildId(), "ℹ️ Help Wanted", i18n.MessageLanguageHelpWanted, true, config.Conf.Bot.SupportServerInvite)
 	e := utils.BuildEmbed(ctx, customisation.Green, i18n.TitleLanguage, i18n.MessageLanguageCommand, utils.ToSlice(helpWanted), languageList)
 	res := command.NewEphemeralEmbedMessageResponseWithComponents(e, buildComponents(ctx))
 
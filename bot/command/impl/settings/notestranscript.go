@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type NotesTranscriptCommand struct {
+}
+
+func (NotesTranscriptCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "notestranscript",
+		Description:     i18n.HelpNotesTranscript,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("include", "Whether to archive the staff notes thread when a ticket is closed", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c NotesTranscriptCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (NotesTranscriptCommand) Execute(ctx registry.CommandContext, include *bool) {
+	config, err := ctx.Worker().Services.Database.NotesTranscriptConfig.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if include != nil {
+		config.Excluded = !*include
+
+		if err := ctx.Worker().Services.Database.NotesTranscriptConfig.Set(ctx, ctx.GuildId(), config); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleNotesTranscript, i18n.MessageNotesTranscriptSummary, formatEnabled(ctx, !config.Excluded))
+}
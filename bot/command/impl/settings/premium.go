@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -44,7 +43,7 @@ func (PremiumCommand) Execute(ctx registry.CommandContext) {
 	// Tell user if premium is already active
 	if premiumTier > premium.None {
 		// Re-enable panels
-		if err := dbclient.Client.Panel.EnableAll(ctx, ctx.GuildId()); err != nil {
+		if err := ctx.Worker().Services.Database.Panel.EnableAll(ctx, ctx.GuildId()); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -66,7 +65,7 @@ func (PremiumCommand) Execute(ctx registry.CommandContext) {
 		}
 
 		// Check for patreon, and show server selector button if necessary
-		legacyEntitlement, err := dbclient.Client.LegacyPremiumEntitlements.GetUserTier(ctx, ctx.UserId(), premium.PatreonGracePeriod)
+		legacyEntitlement, err := ctx.Worker().Services.Database.LegacyPremiumEntitlements.GetUserTier(ctx, ctx.UserId(), premium.PatreonGracePeriod)
 		if err != nil {
 			ctx.HandleError(err)
 			return
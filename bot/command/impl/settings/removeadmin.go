@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -78,7 +77,7 @@ func (c RemoveAdminCommand) Execute(ctx registry.CommandContext, id uint64) {
 			return
 		}
 
-		if err := dbclient.Client.Permissions.RemoveAdmin(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.Permissions.RemoveAdmin(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -88,7 +87,7 @@ func (c RemoveAdminCommand) Execute(ctx registry.CommandContext, id uint64) {
 			return
 		}
 	} else if mentionableType == context.MentionableTypeRole {
-		if err := dbclient.Client.RolePermissions.RemoveAdmin(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.RolePermissions.RemoveAdmin(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
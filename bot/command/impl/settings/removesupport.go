@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -79,7 +78,7 @@ func (c RemoveSupportCommand) Execute(ctx registry.CommandContext, id uint64) {
 			return
 		}
 
-		if err := dbclient.Client.Permissions.RemoveSupport(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.Permissions.RemoveSupport(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -94,7 +93,7 @@ func (c RemoveSupportCommand) Execute(ctx registry.CommandContext, id uint64) {
 			return
 		}
 	} else if mentionableType == context.MentionableTypeRole {
-		if err := dbclient.Client.RolePermissions.RemoveSupport(ctx, ctx.GuildId(), id); err != nil {
+		if err := ctx.Worker().Services.Database.RolePermissions.RemoveSupport(ctx, ctx.GuildId(), id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
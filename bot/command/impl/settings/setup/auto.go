@@ -11,11 +11,11 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	channel_permissions "github.com/TicketsBot-cloud/gdl/permission"
 	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -60,7 +60,7 @@ func (AutoSetupCommand) Execute(ctx registry.CommandContext) {
 		supportRoleId = role.Id
 
 		// set in db
-		if err := dbclient.Client.RolePermissions.AddSupport(ctx, ctx.GuildId(), role.Id); err != nil {
+		if err := ctx.Worker().Services.Database.RolePermissions.AddSupport(ctx, ctx.GuildId(), role.Id); err != nil {
 			ctx.HandleError(err)
 		}
 
@@ -69,22 +69,22 @@ func (AutoSetupCommand) Execute(ctx registry.CommandContext) {
 			adminRoleId = role.Id
 
 			// set in db
-			if err := dbclient.Client.RolePermissions.AddAdmin(ctx, ctx.GuildId(), role.Id); err != nil {
+			if err := ctx.Worker().Services.Database.RolePermissions.AddAdmin(ctx, ctx.GuildId(), role.Id); err != nil {
 				ctx.HandleError(err)
 			}
 
-			messageContent = fmt.Sprintf("✅ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoRolesSuccess))
+			messageContent = fmt.Sprintf("✅ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoRolesSuccess))
 		default:
-			messageContent = fmt.Sprintf("❌ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoRolesFailure))
+			messageContent = fmt.Sprintf("❌ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoRolesFailure))
 		}
 	default: // an error occurred creating support role
 		failed = true
-		messageContent = fmt.Sprintf("❌ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoRolesFailure))
+		messageContent = fmt.Sprintf("❌ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoRolesFailure))
 	}
 
 	embed := embed.NewEmbed().
 		SetTitle("Setup").
-		SetColor(getColour(context.Background(), ctx.GuildId(), failed)). // TODO: Propagate context
+		SetColor(getColour(context.Background(), ctx.Worker(), ctx.GuildId(), failed)). // TODO: Propagate context
 		SetDescription(messageContent)
 
 	ctx.ReplyWithEmbed(embed)
@@ -92,14 +92,14 @@ func (AutoSetupCommand) Execute(ctx registry.CommandContext) {
 	// create transcripts channel
 	switch transcriptChannel, err := ctx.Worker().CreateGuildChannel(context.Background(), ctx.GuildId(), getTranscriptChannelData(ctx.GuildId(), supportRoleId, adminRoleId)); err {
 	case nil:
-		messageContent += fmt.Sprintf("\n✅ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoTranscriptChannelSuccess, transcriptChannel.Id))
+		messageContent += fmt.Sprintf("\n✅ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoTranscriptChannelSuccess, transcriptChannel.Id))
 
-		if err := dbclient.Client.ArchiveChannel.Set(ctx, ctx.GuildId(), utils.Ptr(transcriptChannel.Id)); err != nil {
+		if err := ctx.Worker().Services.Database.ArchiveChannel.Set(ctx, ctx.GuildId(), utils.Ptr(transcriptChannel.Id)); err != nil {
 			ctx.HandleError(err)
 		}
 	default:
 		failed = true
-		messageContent += fmt.Sprintf("\n❌ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoTranscriptChannelFailure))
+		messageContent += fmt.Sprintf("\n❌ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoTranscriptChannelFailure))
 	}
 
 	embed.SetDescription(messageContent)
@@ -117,17 +117,17 @@ func (AutoSetupCommand) Execute(ctx registry.CommandContext) {
 
 	switch category, err := ctx.Worker().CreateGuildChannel(context.Background(), ctx.GuildId(), categoryData); err {
 	case nil: // ok
-		messageContent += fmt.Sprintf("\n✅ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoCategorySuccess))
+		messageContent += fmt.Sprintf("\n✅ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoCategorySuccess))
 
-		if err := dbclient.Client.ChannelCategory.Set(ctx, ctx.GuildId(), category.Id); err != nil {
+		if err := ctx.Worker().Services.Database.ChannelCategory.Set(ctx, ctx.GuildId(), category.Id); err != nil {
 			ctx.HandleError(err)
 		}
 	default: // error
-		messageContent += fmt.Sprintf("\n❌ %s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoCategoryFailure))
+		messageContent += fmt.Sprintf("\n❌ %s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoCategoryFailure))
 	}
 
-	messageContent += fmt.Sprintf("\n\n%s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoCompleted, fmt.Sprintf("%s/manage/%d/panels", config.Conf.Bot.DashboardUrl, ctx.GuildId()), adminRoleId, supportRoleId))
-	messageContent += fmt.Sprintf("\n\n%s", i18n.GetMessageFromGuild(ctx.GuildId(), i18n.SetupAutoDocs, config.Conf.Bot.DocsUrl))
+	messageContent += fmt.Sprintf("\n\n%s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoCompleted, fmt.Sprintf("%s/manage/%d/panels", config.Conf.Bot.DashboardUrl, ctx.GuildId()), adminRoleId, supportRoleId))
+	messageContent += fmt.Sprintf("\n\n%s", i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), i18n.SetupAutoDocs, config.Conf.Bot.DocsUrl))
 
 	// update status
 	if shouldEdit {
@@ -148,7 +148,7 @@ func edit(ctx *cmdcontext.SlashCommandContext, e *embed.Embed) error {
 	return err
 }
 
-func getColour(ctx context.Context, guildId uint64, failed bool) int {
+func getColour(ctx context.Context, worker *worker.Context, guildId uint64, failed bool) int {
 	var colour customisation.Colour
 	if failed {
 		colour = customisation.Red
@@ -157,7 +157,7 @@ func getColour(ctx context.Context, guildId uint64, failed bool) int {
 	}
 
 	// ignore error, return default
-	hex, _ := customisation.GetColour(ctx, guildId, colour)
+	hex, _ := customisation.GetColour(ctx, worker, guildId, colour)
 	return hex
 }
 
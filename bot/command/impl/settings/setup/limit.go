@@ -8,7 +8,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -38,7 +37,7 @@ func (LimitSetupCommand) Execute(ctx registry.CommandContext, limit int) {
 		return
 	}
 
-	if err := dbclient.Client.TicketLimit.Set(ctx, ctx.GuildId(), uint8(limit)); err != nil {
+	if err := ctx.Worker().Services.Database.TicketLimit.Set(ctx, ctx.GuildId(), uint8(limit)); err != nil {
 		ctx.HandleError(err)
 		return
 	}
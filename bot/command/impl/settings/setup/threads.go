@@ -9,7 +9,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -53,14 +52,14 @@ func (ThreadsSetupCommand) Execute(ctx registry.CommandContext, useThreads bool,
 			return
 		}
 
-		if err := dbclient.Client.Settings.EnableThreads(ctx, ctx.GuildId(), *channelId); err != nil {
+		if err := ctx.Worker().Services.Database.Settings.EnableThreads(ctx, ctx.GuildId(), *channelId); err != nil {
 			ctx.HandleError(err)
 			return
 		}
 
 		ctx.Reply(customisation.Green, i18n.TitleSetup, i18n.SetupThreadsSuccess)
 	} else {
-		if err := dbclient.Client.Settings.DisableThreads(ctx, ctx.GuildId()); err != nil {
+		if err := ctx.Worker().Services.Database.Settings.DisableThreads(ctx, ctx.GuildId()); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -0,0 +1,99 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// spamBlacklistListLimit is the number of temporary blacklists listed, ending soonest first
+const spamBlacklistListLimit = 15
+
+type SpamCloseCommand struct {
+}
+
+func (SpamCloseCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "spamclose",
+		Description:     i18n.HelpSpamClose,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("button", "Whether to add a close as spam button to the welcome message", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("blacklist_hours", "How many hours to blacklist the opener of a spam ticket for (0 to disable)", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c SpamCloseCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (SpamCloseCommand) Execute(ctx registry.CommandContext, button *bool, blacklistHours *int) {
+	config, err := ctx.Worker().Services.Database.SpamConfig.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if button != nil {
+		config.WelcomeButton = *button
+	}
+
+	if blacklistHours != nil {
+		duration := time.Duration(*blacklistHours) * time.Hour
+		if *blacklistHours < 0 || duration > logic.MaxSpamBlacklistDuration {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSpamCloseDuration, int(logic.MaxSpamBlacklistDuration.Hours()))
+			return
+		}
+
+		config.BlacklistDuration = duration
+	}
+
+	if button != nil || blacklistHours != nil {
+		if err := ctx.Worker().Services.Database.SpamConfig.Set(ctx, ctx.GuildId(), config); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	stats, err := logic.GetSpamStats(ctx, ctx.Worker(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	blacklisted, err := ctx.Worker().Services.Database.SpamBlacklist.GetActive(ctx, ctx.GuildId(), spamBlacklistListLimit)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	lines := []string{
+		ctx.GetMessage(i18n.MessageSpamCloseButton, formatEnabled(ctx, config.WelcomeButton)),
+		ctx.GetMessage(i18n.MessageSpamCloseBlacklistDuration, logic.FormatSpamBlacklistDuration(config.BlacklistDuration)),
+		"",
+		ctx.GetMessage(i18n.MessageSpamCloseCloses, logic.SpamStatsDays, stats.Closes),
+		ctx.GetMessage(i18n.MessageSpamCloseBlacklists, logic.SpamStatsDays, stats.Blacklists),
+	}
+
+	// Staff can lift any of these early with /blacklist
+	if len(blacklisted) > 0 {
+		lines = append(lines, "", ctx.GetMessage(i18n.MessageSpamCloseBlacklisted))
+		for _, entry := range blacklisted {
+			lines = append(lines, ctx.GetMessage(i18n.MessageSpamCloseBlacklistedEntry, entry.UserId, entry.ExpiresAt.Unix()))
+		}
+	}
+
+	ctx.ReplyRaw(customisation.Green, ctx.GetMessage(i18n.TitleSpamClose), strings.Join(lines, "\n"))
+}
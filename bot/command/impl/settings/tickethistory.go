@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type TicketHistoryCommand struct {
+}
+
+func (TicketHistoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "tickethistory",
+		Description:     i18n.HelpTicketHistory,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("button", "Whether to add a history button to the welcome message", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("returning_notice", "Whether to show how many tickets the user has opened before in the welcome message", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c TicketHistoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketHistoryCommand) Execute(ctx registry.CommandContext, button, returningNotice *bool) {
+	config, err := ctx.Worker().Services.Database.TicketHistoryConfig.Get(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if button != nil {
+		config.WelcomeButton = *button
+	}
+
+	if returningNotice != nil {
+		config.ReturningUserNotice = *returningNotice
+	}
+
+	if button != nil || returningNotice != nil {
+		if err := ctx.Worker().Services.Database.TicketHistoryConfig.Set(ctx, ctx.GuildId(), config); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	lines := []string{
+		ctx.GetMessage(i18n.MessageTicketHistoryButton, formatEnabled(ctx, config.WelcomeButton)),
+		ctx.GetMessage(i18n.MessageTicketHistoryNotice, formatEnabled(ctx, config.ReturningUserNotice)),
+	}
+
+	ctx.ReplyRaw(customisation.Green, ctx.GetMessage(i18n.TitleTicketHistory), strings.Join(lines, "\n"))
+}
+
+func formatEnabled(ctx registry.CommandContext, enabled bool) string {
+	if enabled {
+		return ctx.GetMessage(i18n.Enabled)
+	}
+
+	return ctx.GetMessage(i18n.Disabled)
+}
@@ -0,0 +1,109 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/transcript"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type TranscriptExportCommand struct {
+}
+
+func (TranscriptExportCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "transcriptexport",
+		Description:     i18n.HelpTranscriptExport,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalAutocompleteableArgument("format", "The format to export transcripts in", interaction.OptionTypeString, i18n.MessageInvalidArgument, transcriptFormatAutoCompleteHandler),
+			command.NewOptionalArgument("archive_channel", "Whether to attach the transcript to the close message in the archive channel", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("dm", "Whether to attach the transcript to the close message sent to the ticket opener", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("timezone", "The timezone to show message times in, e.g. Europe/London", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c TranscriptExportCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TranscriptExportCommand) Execute(ctx registry.CommandContext, format *string, archiveChannel, dm *bool, timezone *string) {
+	config, err := logic.GetTranscriptExportConfig(ctx, ctx.Worker(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if format != nil {
+		parsed, ok := transcript.ParseFormat(strings.ToLower(*format))
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTranscriptExportFormat)
+			return
+		}
+
+		config.Format = string(parsed)
+	}
+
+	if timezone != nil {
+		location, err := time.LoadLocation(*timezone)
+		if err != nil || *timezone == "" || strings.EqualFold(*timezone, "local") {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTranscriptExportTimezone)
+			return
+		}
+
+		config.Timezone = location.String()
+	}
+
+	if archiveChannel != nil {
+		config.ArchiveChannel = *archiveChannel
+	}
+
+	if dm != nil {
+		config.DirectMessage = *dm
+	}
+
+	if format != nil || archiveChannel != nil || dm != nil || timezone != nil {
+		if err := ctx.Worker().Services.Database.TranscriptExportConfig.Set(ctx, ctx.GuildId(), config); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	lines := []string{
+		ctx.GetMessage(i18n.MessageTranscriptExportSummaryFormat, strings.ToUpper(config.Format)),
+		ctx.GetMessage(i18n.MessageTranscriptExportSummaryZone, config.Timezone),
+		ctx.GetMessage(i18n.MessageTranscriptExportSummaryArchive, formatEnabled(ctx, config.ArchiveChannel)),
+		ctx.GetMessage(i18n.MessageTranscriptExportSummaryDM, formatEnabled(ctx, config.DirectMessage)),
+	}
+
+	ctx.ReplyRaw(customisation.Green, ctx.GetMessage(i18n.TitleTranscriptExport), strings.Join(lines, "\n"))
+}
+
+func transcriptFormatAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, len(transcript.Formats))
+	for _, format := range transcript.Formats {
+		if value != "" && !strings.Contains(string(format), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  strings.ToUpper(string(format)),
+			Value: string(format),
+		})
+	}
+
+	return choices
+}
@@ -0,0 +1,260 @@
+package statistics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// maxVolumeRows is the number of rows shown in the ticket volume table before days are grouped into weeks, and
+// weeks into months, keeping the table within Discord's embed field length limit
+const maxVolumeRows = 14
+
+// filterArguments are shared by the /stats subcommands which can be narrowed down to a panel, team or time range
+func filterArguments() []command.Argument {
+	return command.Arguments(
+		command.NewOptionalAutocompleteableArgument("panel", "Only include tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.PanelAutoCompleteHandler),
+		command.NewOptionalAutocompleteableArgument("team", "Only include tickets opened from panels assigned to this team", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, teamAutoCompleteHandler),
+		command.NewOptionalAutocompleteableArgument("period", "Only include the last 7, 30 or 90 days", interaction.OptionTypeString, i18n.MessageInvalidArgument, periodAutoCompleteHandler),
+		command.NewOptionalArgument("from", "First day to include, in YYYY-MM-DD format", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+		command.NewOptionalArgument("to", "Last day to include, in YYYY-MM-DD format", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+	)
+}
+
+type statsFilters struct {
+	Range     reporting.Range
+	Filter    reporting.Filter
+	PanelName string
+	TeamName  string
+}
+
+// String describes the filters, to be shown alongside the statistics
+func (f statsFilters) String() string {
+	parts := []string{f.Range.String() + " (UTC)"}
+
+	if f.PanelName != "" {
+		parts = append(parts, fmt.Sprintf("Panel: %s", f.PanelName))
+	}
+
+	if f.TeamName != "" {
+		parts = append(parts, fmt.Sprintf("Team: %s", f.TeamName))
+	}
+
+	return strings.Join(parts, " • ")
+}
+
+func hasFilters(panelId, teamId *int, period, from, to *string) bool {
+	return panelId != nil || teamId != nil || period != nil || from != nil || to != nil
+}
+
+// parseFilters validates the filter arguments, replying with an error message and returning false if they are invalid
+func parseFilters(ctx registry.CommandContext, panelId, teamId *int, period, from, to *string) (statsFilters, bool) {
+	r, err := reporting.ParsePeriod(period, from, to, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, reporting.ErrInvalidDate):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsInvalidDate)
+		case errors.Is(err, reporting.ErrRangeInverted):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsRangeInverted)
+		case errors.Is(err, reporting.ErrRangeTooLarge):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsRangeTooLarge, int(reporting.MaxRangeLength.Hours()/24))
+		case errors.Is(err, reporting.ErrInvalidPreset):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsInvalidPeriod)
+		case errors.Is(err, reporting.ErrPresetConflict):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsPeriodConflict)
+		default:
+			ctx.HandleError(err)
+		}
+
+		return statsFilters{}, false
+	}
+
+	filters := statsFilters{
+		Range: r,
+		Filter: reporting.Filter{
+			PanelId: panelId,
+			TeamId:  teamId,
+		},
+	}
+
+	if panelId != nil {
+		panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return statsFilters{}, false
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessagePanelNotFound)
+			return statsFilters{}, false
+		}
+
+		filters.PanelName = panel.Title
+	}
+
+	if teamId != nil {
+		team, ok, err := ctx.Worker().Services.Database.SupportTeam.GetById(ctx, ctx.GuildId(), *teamId)
+		if err != nil {
+			ctx.HandleError(err)
+			return statsFilters{}, false
+		}
+
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTeamNotFound)
+			return statsFilters{}, false
+		}
+
+		filters.TeamName = team.Name
+	}
+
+	return filters, true
+}
+
+// renderVolumeTable renders the opened and closed counts of each day, grouping days into weeks or months for longer
+// ranges
+func renderVolumeTable(daily []reporting.DailyCounts) string {
+	tw := table.NewWriter()
+	tw.SetStyle(table.StyleLight)
+	tw.Style().Format.Header = text.FormatDefault
+
+	// Days are grouped into the bucket named by the returned label, and must be in order
+	header := "Date"
+	bucket := func(i int, day reporting.DailyCounts) string { return day.Date }
+	if len(daily) > maxVolumeRows*7 {
+		header = "Month"
+		bucket = func(i int, day reporting.DailyCounts) string { return day.Date[:len("2006-01")] }
+	} else if len(daily) > maxVolumeRows {
+		header = "Week Starting"
+		bucket = func(i int, day reporting.DailyCounts) string { return daily[i-i%7].Date }
+	}
+
+	tw.AppendHeader(table.Row{header, "Opened", "Closed"})
+
+	var label string
+	var opened, closed int
+	for i, day := range daily {
+		if current := bucket(i, day); current != label {
+			if i > 0 {
+				tw.AppendRow(table.Row{label, opened, closed})
+			}
+
+			label, opened, closed = current, 0, 0
+		}
+
+		opened += day.Opened
+		closed += day.Closed
+	}
+
+	if len(daily) > 0 {
+		tw.AppendRow(table.Row{label, opened, closed})
+	}
+
+	return tw.Render()
+}
+
+func formatRating(ratings reporting.RatingStats) string {
+	if ratings.Average == nil {
+		return "No data"
+	}
+
+	return fmt.Sprintf("%.1f / 5 ★", *ratings.Average)
+}
+
+// formatCloseCategories lists the number of tickets closed with each close reason category, most common first
+func formatCloseCategories(categories map[string]int) string {
+	if len(categories) == 0 {
+		return "No data"
+	}
+
+	names := slices.Collect(maps.Keys(categories))
+	slices.SortFunc(names, func(a, b string) int {
+		if categories[a] != categories[b] {
+			return categories[b] - categories[a]
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("**%s**: %d", strings.ToUpper(name[:1])+name[1:], categories[name])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatSpamStats lists the number of tickets closed as spam, and the number of openers blacklisted as a result
+func formatSpamStats(stats dbclient.SpamStats) string {
+	if stats.Closes == 0 {
+		return "No data"
+	}
+
+	return fmt.Sprintf("**Closed as spam**: %d\n**Openers blacklisted**: %d", stats.Closes, stats.Blacklists)
+}
+
+func teamAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	teams, err := worker.Services.Database.SupportTeam.Get(ctx, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, 25)
+	for _, team := range teams {
+		if value != "" && !strings.Contains(strings.ToLower(team.Name), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  team.Name,
+			Value: team.Id,
+		})
+
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return choices
+}
+
+func periodAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, len(reporting.Presets))
+	for _, preset := range reporting.Presets {
+		if value != "" && !strings.Contains(preset.Name, strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  "Last " + strconv.Itoa(preset.Days) + " days",
+			Value: preset.Name,
+		})
+	}
+
+	return choices
+}
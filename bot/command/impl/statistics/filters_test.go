@@ -0,0 +1,46 @@
+package statistics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderVolumeTable(t *testing.T) {
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		length time.Duration
+		header string
+		rows   int
+	}{
+		{"Days", time.Hour * 24 * maxVolumeRows, "Date", maxVolumeRows},
+		{"Weeks", time.Hour * 24 * maxVolumeRows * 7, "Week Starting", maxVolumeRows},
+		{"Months", reporting.MaxRangeLength, "Month", 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := reporting.Range{From: from, To: from.Add(tt.length)}
+
+			var daily []reporting.DailyCounts
+			for _, day := range r.Days() {
+				daily = append(daily, reporting.DailyCounts{Date: day.Format("2006-01-02"), Opened: 99999, Closed: 99999})
+			}
+
+			rendered := renderVolumeTable(daily)
+			require.Contains(t, rendered, tt.header)
+			// Header, the border above and below it, and the border below the last row
+			require.Len(t, strings.Split(rendered, "\n"), tt.rows+4)
+
+			// The table is sent as an embed field value, which Discord limits to 1024 characters
+			require.LessOrEqual(t, utf8.RuneCountInString(fmt.Sprintf("```\n%s\n```", rendered)), 1024)
+		})
+	}
+}
@@ -21,6 +21,9 @@ func (StatsCommand) Properties() registry.Properties {
 		Children: []registry.Command{
 			StatsUserCommand{},
 			StatsServerCommand{},
+			StatsExportCommand{},
+			StatsDigestCommand{},
+			StatsDistributionCommand{},
 		},
 		Category:    command.Statistics,
 		PremiumOnly: true,
@@ -0,0 +1,123 @@
+package statistics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+const (
+	digestFrequencyOff      = "off"
+	maxDigestResponseLimit  = 7 * 24 * 60
+	digestFrequencyExamples = "`daily`, `weekly`, `monthly` or `off`"
+)
+
+type StatsDigestCommand struct {
+}
+
+func (c StatsDigestCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "digest",
+		Description:     i18n.HelpStatsDigest,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Statistics,
+		PremiumOnly:     true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("frequency", "How often the digest should be posted, or off to disable it", interaction.OptionTypeString, i18n.MessageInvalidArgument, c.FrequencyAutoCompleteHandler),
+			command.NewOptionalArgument("channel", "The channel that the digest should be posted to", interaction.OptionTypeChannel, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("response_limit", "Tickets without a response within this many minutes are reported (defaults to 60)", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c StatsDigestCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (StatsDigestCommand) Execute(ctx registry.CommandContext, frequencyRaw string, channelId *uint64, responseLimit *int) {
+	frequencyRaw = strings.ToLower(frequencyRaw)
+
+	if frequencyRaw == digestFrequencyOff {
+		if err := ctx.Worker().Services.Database.StatsDigests.Delete(ctx, ctx.GuildId()); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleStatsDigest, i18n.MessageStatsDigestDisabled)
+		return
+	}
+
+	frequency := reporting.Frequency(frequencyRaw)
+	if !frequency.IsValid() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestFrequency, digestFrequencyExamples)
+		return
+	}
+
+	if channelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestChannelMissing)
+		return
+	}
+
+	if _, err := ctx.Worker().GetChannel(*channelId); err != nil {
+		if restError, ok := err.(request.RestError); ok && restError.IsClientError() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestChannelAccess)
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	config := dbclient.StatsDigestConfig{
+		ChannelId: *channelId,
+		Frequency: string(frequency),
+	}
+
+	if responseLimit != nil {
+		if *responseLimit <= 0 || *responseLimit > maxDigestResponseLimit {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestResponseLimit, maxDigestResponseLimit)
+			return
+		}
+
+		config.ResponseLimit = time.Duration(*responseLimit) * time.Minute
+	}
+
+	nextRun := frequency.NextRun(time.Now())
+	if err := ctx.Worker().Services.Database.StatsDigests.Set(ctx, ctx.GuildId(), config, nextRun); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleStatsDigest, i18n.MessageStatsDigestSuccess, frequency, *channelId, nextRun.Unix())
+}
+
+func (StatsDigestCommand) FrequencyAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	options := append(append([]reporting.Frequency{}, reporting.Frequencies...), digestFrequencyOff)
+
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, len(options))
+	for _, option := range options {
+		if value != "" && !strings.Contains(string(option), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  strings.ToUpper(string(option[:1])) + string(option[1:]),
+			Value: string(option),
+		})
+	}
+
+	return choices
+}
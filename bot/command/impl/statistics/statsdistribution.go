@@ -0,0 +1,193 @@
+package statistics
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/experiments"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/getsentry/sentry-go"
+)
+
+const (
+	histogramWidth         = 20
+	maxDistributionStaff   = 10
+	distributionStaffEmpty = "No staff responded to tickets during this period."
+)
+
+type StatsDistributionCommand struct {
+}
+
+func (StatsDistributionCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "distribution",
+		Description:      i18n.HelpStatsDistribution,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Statistics,
+		PremiumOnly:      true,
+		Arguments:        filterArguments(),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 30,
+	}
+}
+
+func (c StatsDistributionCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (StatsDistributionCommand) Execute(ctx registry.CommandContext, panelId, teamId *int, period, from, to *string) {
+	span := sentry.StartTransaction(ctx, "/stats distribution")
+	span.SetTag("guild", strconv.FormatUint(ctx.GuildId(), 10))
+	defer span.Finish()
+
+	filters, ok := parseFilters(ctx, panelId, teamId, period, from, to)
+	if !ok {
+		return
+	}
+
+	generateSpan := sentry.StartSpan(span.Context(), "GenerateRange")
+	report, err := reporting.GenerateRange(ctx, ctx.Worker().Services, ctx.GuildId(), filters.Range, filters.Filter)
+	generateSpan.Finish()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	summary := report.Summary
+
+	hourLabels := make([]string, 24)
+	for hour := range hourLabels {
+		hourLabels[hour] = fmt.Sprintf("%02d:00", hour)
+	}
+
+	dayLabels := make([]string, 7)
+	for day := range dayLabels {
+		dayLabels[day] = time.Weekday(day).String()[:3]
+	}
+
+	hourHistogram := renderHistogram(hourLabels, summary.OpenedByHour[:])
+	dayHistogram := renderHistogram(dayLabels, summary.OpenedByWeekday[:])
+	staffDistribution := formatStaffDistribution(report.Staff)
+
+	span = sentry.StartSpan(span.Context(), "Send Message")
+	defer span.Finish()
+
+	if experiments.HasFeature(ctx, ctx.Worker().Services, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
+		innerComponents := []component.Component{
+			component.BuildTextDisplay(component.TextDisplay{Content: "## Response Time Distribution"}),
+			component.BuildTextDisplay(component.TextDisplay{Content: fmt.Sprintf("-# %s", filters.String())}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### First Response Time\n● %s", strings.Join(formatPercentiles(summary.FirstResponseTime), "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Ticket Duration\n● %s", strings.Join(formatPercentiles(summary.ResolutionTime), "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Tickets Opened by Hour (UTC)\n```\n%s\n```", hourHistogram),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Tickets Opened by Day (UTC)\n```\n%s\n```", dayHistogram),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### First Response Time by Staff\n%s", staffDistribution),
+			}),
+		}
+
+		ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents(utils.Slice(component.BuildContainer(component.Container{
+			Components: innerComponents,
+		}))))
+	} else {
+		msgEmbed := embed.NewEmbed().
+			SetTitle("Response Time Distribution").
+			SetDescription(filters.String()).
+			SetColor(ctx.GetColour(customisation.Green)).
+			AddField("First Response Time", strings.Join(formatPercentiles(summary.FirstResponseTime), "\n"), true).
+			AddField("Ticket Duration", strings.Join(formatPercentiles(summary.ResolutionTime), "\n"), true).
+			AddField("Tickets Opened by Hour (UTC)", fmt.Sprintf("```\n%s\n```", hourHistogram), false).
+			AddField("Tickets Opened by Day (UTC)", fmt.Sprintf("```\n%s\n```", dayHistogram), false).
+			AddField("First Response Time by Staff", staffDistribution, false)
+
+		_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
+	}
+}
+
+func formatPercentiles(p reporting.Percentiles) []string {
+	return []string{
+		fmt.Sprintf("**Tickets**: %d", p.Count),
+		fmt.Sprintf("**Median**: %s", formatNullableTime(p.P50)),
+		fmt.Sprintf("**90th Percentile**: %s", formatNullableTime(p.P90)),
+		fmt.Sprintf("**99th Percentile**: %s", formatNullableTime(p.P99)),
+	}
+}
+
+// formatStaffDistribution lists the first response time percentiles of the staff members with the most responses
+func formatStaffDistribution(staff []reporting.StaffStats) string {
+	staff = slices.Clone(staff)
+	slices.SortStableFunc(staff, func(a, b reporting.StaffStats) int {
+		return b.FirstResponses - a.FirstResponses
+	})
+
+	var lines []string
+	for _, s := range staff {
+		if s.FirstResponses == 0 {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"<@%d>: %d responses • p50 %s • p90 %s • p99 %s",
+			s.UserId, s.FirstResponses,
+			formatNullableTime(s.FirstResponseTime.P50),
+			formatNullableTime(s.FirstResponseTime.P90),
+			formatNullableTime(s.FirstResponseTime.P99),
+		))
+
+		if len(lines) == maxDistributionStaff {
+			break
+		}
+	}
+
+	if len(lines) == 0 {
+		return distributionStaffEmpty
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderHistogram draws a horizontal bar for each label, scaled so that the largest count fills histogramWidth
+func renderHistogram(labels []string, counts []int) string {
+	var largest int
+	for _, count := range counts {
+		largest = max(largest, count)
+	}
+
+	lines := make([]string, len(labels))
+	for i, label := range labels {
+		var width int
+		if largest > 0 {
+			width = counts[i] * histogramWidth / largest
+		}
+
+		bar := strings.Repeat("█", width) + strings.Repeat(" ", histogramWidth-width)
+		lines[i] = fmt.Sprintf("%s %s %d", label, bar, counts[i])
+	}
+
+	return strings.Join(lines, "\n")
+}
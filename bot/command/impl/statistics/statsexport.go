@@ -0,0 +1,129 @@
+package statistics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/getsentry/sentry-go"
+)
+
+type StatsExportCommand struct {
+}
+
+func (c StatsExportCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "export",
+		Description:     i18n.HelpStatsExport,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Statistics,
+		PremiumOnly:     true,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("from", "First day to include, in YYYY-MM-DD format (defaults to 30 days ago)", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("to", "Last day to include, in YYYY-MM-DD format (defaults to today)", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalAutocompleteableArgument("format", "File format of the export (defaults to CSV)", interaction.OptionTypeString, i18n.MessageInvalidArgument, c.FormatAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 30,
+	}
+}
+
+func (c StatsExportCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (StatsExportCommand) Execute(ctx registry.CommandContext, from, to, formatRaw *string) {
+	span := sentry.StartTransaction(ctx, "/stats export")
+	span.SetTag("guild", strconv.FormatUint(ctx.GuildId(), 10))
+	defer span.Finish()
+
+	format := reporting.FormatCsv
+	if formatRaw != nil {
+		format = reporting.Format(strings.ToLower(*formatRaw))
+		if format != reporting.FormatCsv && format != reporting.FormatJson {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsExportFormat)
+			return
+		}
+	}
+
+	r, err := reporting.ParseRange(from, to, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, reporting.ErrInvalidDate):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsInvalidDate)
+		case errors.Is(err, reporting.ErrRangeInverted):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsRangeInverted)
+		case errors.Is(err, reporting.ErrRangeTooLarge):
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsExportRangeTooLarge, int(reporting.MaxRangeLength.Hours()/24))
+		default:
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	generateSpan := sentry.StartSpan(span.Context(), "Generate report")
+	report, err := reporting.Generate(ctx, ctx.Worker().Services, ctx.GuildId(), r, reporting.Filter{})
+	generateSpan.Finish()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	files, err := report.Export(format)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	attachments := make([]request.Attachment, len(files))
+	for i, file := range files {
+		attachments[i] = request.Attachment{
+			Id:       i,
+			FileName: file.Name,
+			File: request.File{
+				ContentType: file.ContentType,
+				Reader:      bytes.NewReader(file.Data),
+			},
+		}
+	}
+
+	content := fmt.Sprintf("Statistics export for %s (%d tickets opened, %d closed).", r.String(), report.Summary.Opened, report.Summary.Closed)
+	if report.Truncated {
+		content += fmt.Sprintf("\n⚠️ The export was limited to %d tickets, try a smaller date range.", reporting.MaxTickets)
+	}
+
+	res := command.NewEphemeralTextMessageResponse(content)
+	res.Attachments = attachments
+
+	_, _ = ctx.ReplyWith(res)
+}
+
+func (StatsExportCommand) FormatAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, len(reporting.Formats))
+	for _, format := range reporting.Formats {
+		if value != "" && !strings.Contains(string(format), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  strings.ToUpper(string(format)),
+			Value: string(format),
+		})
+	}
+
+	return choices
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/experiments"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -35,8 +37,9 @@ func (StatsServerCommand) Properties() registry.Properties {
 		PermissionLevel:  permission.Support,
 		Category:         command.Statistics,
 		PremiumOnly:      true,
+		Arguments:        filterArguments(),
 		DefaultEphemeral: true,
-		Timeout:          time.Second * 10,
+		Timeout:          time.Second * 30,
 	}
 }
 
@@ -44,11 +47,23 @@ func (c StatsServerCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (StatsServerCommand) Execute(ctx registry.CommandContext) {
+func (c StatsServerCommand) Execute(ctx registry.CommandContext, panelId, teamId *int, period, from, to *string) {
 	span := sentry.StartTransaction(ctx, "/stats server")
 	span.SetTag("guild", strconv.FormatUint(ctx.GuildId(), 10))
 	defer span.Finish()
 
+	// The analytics queries only cover the whole server's lifetime, so filtered statistics are calculated from the
+	// tickets themselves instead
+	if hasFilters(panelId, teamId, period, from, to) {
+		filters, ok := parseFilters(ctx, panelId, teamId, period, from, to)
+		if !ok {
+			return
+		}
+
+		c.executeFiltered(ctx, span, filters)
+		return
+	}
+
 	group, _ := errgroup.WithContext(ctx)
 
 	var totalTickets, openTickets uint64
@@ -58,7 +73,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetTotalTicketCount")
 		defer span.Finish()
 
-		totalTickets, err = dbclient.Analytics.GetTotalTicketCount(ctx, ctx.GuildId())
+		totalTickets, err = ctx.Worker().Services.Analytics.GetTotalTicketCount(ctx, ctx.GuildId())
 		return
 	})
 
@@ -67,7 +82,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetGuildOpenTickets")
 		defer span.Finish()
 
-		tickets, err := dbclient.Client.Tickets.GetGuildOpenTickets(ctx, ctx.GuildId())
+		tickets, err := ctx.Worker().Services.Database.Tickets.GetGuildOpenTickets(ctx, ctx.GuildId())
 		if err != nil {
 			return err
 		}
@@ -83,7 +98,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetAverageFeedbackRating")
 		defer span.Finish()
 
-		feedbackRating, err = dbclient.Analytics.GetAverageFeedbackRatingGuild(ctx, ctx.GuildId())
+		feedbackRating, err = ctx.Worker().Services.Analytics.GetAverageFeedbackRatingGuild(ctx, ctx.GuildId())
 		return
 	})
 
@@ -91,7 +106,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetFeedbackCount")
 		defer span.Finish()
 
-		feedbackCount, err = dbclient.Analytics.GetFeedbackCountGuild(ctx, ctx.GuildId())
+		feedbackCount, err = ctx.Worker().Services.Analytics.GetFeedbackCountGuild(ctx, ctx.GuildId())
 		return
 	})
 
@@ -101,7 +116,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetFirstResponseTimeStats")
 		defer span.Finish()
 
-		firstResponseTime, err = dbclient.Analytics.GetFirstResponseTimeStats(ctx, ctx.GuildId())
+		firstResponseTime, err = ctx.Worker().Services.Analytics.GetFirstResponseTimeStats(ctx, ctx.GuildId())
 		return
 	})
 
@@ -111,7 +126,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetTicketDurationStats")
 		defer span.Finish()
 
-		ticketDuration, err = dbclient.Analytics.GetTicketDurationStats(ctx, ctx.GuildId())
+		ticketDuration, err = ctx.Worker().Services.Analytics.GetTicketDurationStats(ctx, ctx.GuildId())
 		return
 	})
 
@@ -121,7 +136,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		span := sentry.StartSpan(span.Context(), "GetLastNTicketsPerDayGuild")
 		defer span.Finish()
 
-		counts, err := dbclient.Analytics.GetLastNTicketsPerDayGuild(ctx, ctx.GuildId(), 7)
+		counts, err := ctx.Worker().Services.Analytics.GetLastNTicketsPerDayGuild(ctx, ctx.GuildId(), 7)
 		if err != nil {
 			return err
 		}
@@ -139,6 +154,38 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		return nil
 	})
 
+	// close reason categories, which are only recorded on the tickets themselves
+	var closeCategories map[string]int
+	group.Go(func() error {
+		span := sentry.StartSpan(span.Context(), "GenerateRange")
+		defer span.Finish()
+
+		r, err := reporting.ParseRange(nil, nil, time.Now())
+		if err != nil {
+			return err
+		}
+
+		report, err := reporting.GenerateRange(ctx, ctx.Worker().Services, ctx.GuildId(), r, reporting.Filter{})
+		if err != nil {
+			return err
+		}
+
+		closeCategories = report.Summary.CloseCategories
+		return nil
+	})
+
+	// spam closes, which are only recorded in the moderation stats
+	var spamStats dbclient.SpamStats
+	group.Go(func() error {
+		stats, err := logic.GetSpamStats(ctx, ctx.Worker(), ctx.GuildId())
+		if err != nil {
+			return err
+		}
+
+		spamStats = stats
+		return nil
+	})
+
 	if err := group.Wait(); err != nil {
 		ctx.HandleError(err)
 		return
@@ -146,7 +193,7 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 
 	span = sentry.StartSpan(span.Context(), "Send Message")
 
-	if experiments.HasFeature(ctx, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
+	if experiments.HasFeature(ctx, ctx.Worker().Services, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
 		guildData, err := ctx.Guild()
 		if err != nil {
 			ctx.HandleError(err)
@@ -210,6 +257,14 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 				Content: fmt.Sprintf("### Average Ticket Duration\n● %s", strings.Join(ticketDurationStats, "\n● ")),
 			}),
 			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Close Reasons (Last 30 Days)\n%s", formatCloseCategories(closeCategories)),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Spam (Last 30 Days)\n%s", formatSpamStats(spamStats)),
+			}),
+			component.BuildSeparator(component.Separator{}),
 			component.BuildTextDisplay(component.TextDisplay{
 				Content: fmt.Sprintf(
 					"### Ticket Volume\n```\n%s\n```",
@@ -237,6 +292,8 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 			AddField("Average Ticket Duration (Total)", formatNullableTime(ticketDuration.AllTime), true).
 			AddField("Average Ticket Duration (Monthly)", formatNullableTime(ticketDuration.Monthly), true).
 			AddField("Average Ticket Duration (Weekly)", formatNullableTime(ticketDuration.Weekly), true).
+			AddField("Close Reasons (Last 30 Days)", formatCloseCategories(closeCategories), false).
+			AddField("Spam (Last 30 Days)", formatSpamStats(spamStats), false).
 			AddField("Ticket Volume", fmt.Sprintf("```\n%s\n```", ticketVolumeTable), false)
 
 		_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
@@ -245,6 +302,96 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 	span.Finish()
 }
 
+func (StatsServerCommand) executeFiltered(ctx registry.CommandContext, span *sentry.Span, filters statsFilters) {
+	generateSpan := sentry.StartSpan(span.Context(), "GenerateRange")
+	report, err := reporting.GenerateRange(ctx, ctx.Worker().Services, ctx.GuildId(), filters.Range, filters.Filter)
+	generateSpan.Finish()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	summary := report.Summary
+	ticketVolumeTable := renderVolumeTable(report.Daily)
+
+	span = sentry.StartSpan(span.Context(), "Send Message")
+	defer span.Finish()
+
+	if experiments.HasFeature(ctx, ctx.Worker().Services, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
+		mainStats := []string{
+			fmt.Sprintf("**Tickets Opened**: %d", summary.Opened),
+			fmt.Sprintf("**Tickets Closed**: %d", summary.Closed),
+			fmt.Sprintf("**Unanswered Tickets**: %d", summary.UnansweredTickets),
+			fmt.Sprintf("**Reopened Tickets**: %d", summary.ReopenedTickets),
+			fmt.Sprintf("**Feedback Rating**: %s", formatRating(summary.Ratings)),
+			fmt.Sprintf("**Feedback Count**: %d", summary.Ratings.Count),
+		}
+
+		responseTimeStats := []string{
+			fmt.Sprintf("**Average**: %s", formatNullableTime(summary.FirstResponseTime.Mean)),
+			fmt.Sprintf("**Median**: %s", formatNullableTime(summary.FirstResponseTime.P50)),
+			fmt.Sprintf("**90th Percentile**: %s", formatNullableTime(summary.FirstResponseTime.P90)),
+			fmt.Sprintf("**99th Percentile**: %s", formatNullableTime(summary.FirstResponseTime.P99)),
+		}
+
+		ticketDurationStats := []string{
+			fmt.Sprintf("**Average**: %s", formatNullableTime(summary.ResolutionTime.Mean)),
+			fmt.Sprintf("**Median**: %s", formatNullableTime(summary.ResolutionTime.P50)),
+			fmt.Sprintf("**90th Percentile**: %s", formatNullableTime(summary.ResolutionTime.P90)),
+			fmt.Sprintf("**99th Percentile**: %s", formatNullableTime(summary.ResolutionTime.P99)),
+		}
+
+		innerComponents := []component.Component{
+			component.BuildTextDisplay(component.TextDisplay{Content: "## Server Ticket Statistics"}),
+			component.BuildTextDisplay(component.TextDisplay{Content: fmt.Sprintf("-# %s", filters.String())}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("● %s", strings.Join(mainStats, "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### First Response Time\n● %s", strings.Join(responseTimeStats, "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Ticket Duration\n● %s", strings.Join(ticketDurationStats, "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Close Reasons\n%s", formatCloseCategories(summary.CloseCategories)),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Ticket Volume\n```\n%s\n```", ticketVolumeTable),
+			}),
+		}
+
+		ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents(utils.Slice(component.BuildContainer(component.Container{
+			Components: innerComponents,
+		}))))
+	} else {
+		msgEmbed := embed.NewEmbed().
+			SetTitle("Statistics").
+			SetDescription(filters.String()).
+			SetColor(ctx.GetColour(customisation.Green)).
+			AddField("Tickets Opened", strconv.Itoa(summary.Opened), true).
+			AddField("Tickets Closed", strconv.Itoa(summary.Closed), true).
+			AddField("Unanswered Tickets", strconv.Itoa(summary.UnansweredTickets), true).
+			AddField("Feedback Rating", formatRating(summary.Ratings), true).
+			AddField("Feedback Count", strconv.Itoa(summary.Ratings.Count), true).
+			AddField("Reopened Tickets", strconv.Itoa(summary.ReopenedTickets), true).
+			AddField("Average First Response Time", formatNullableTime(summary.FirstResponseTime.Mean), true).
+			AddField("Median First Response Time", formatNullableTime(summary.FirstResponseTime.P50), true).
+			AddField("90th Percentile First Response Time", formatNullableTime(summary.FirstResponseTime.P90), true).
+			AddField("Average Ticket Duration", formatNullableTime(summary.ResolutionTime.Mean), true).
+			AddField("Median Ticket Duration", formatNullableTime(summary.ResolutionTime.P50), true).
+			AddField("90th Percentile Ticket Duration", formatNullableTime(summary.ResolutionTime.P90), true).
+			AddField("Close Reasons", formatCloseCategories(summary.CloseCategories), false).
+			AddField("Ticket Volume", fmt.Sprintf("```\n%s\n```", ticketVolumeTable), false)
+
+		_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
+	}
+}
+
 func formatNullableTime(duration *time.Duration) string {
 	return utils.FormatNullableTime(duration)
 }
@@ -10,10 +10,11 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/experiments"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -33,8 +34,9 @@ func (StatsUserCommand) Properties() registry.Properties {
 		PermissionLevel: permission.Support,
 		Category:        command.Statistics,
 		PremiumOnly:     true,
-		Arguments: command.Arguments(
-			command.NewRequiredArgument("user", "User whose statistics to retrieve", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		Arguments: append(
+			command.Arguments(command.NewRequiredArgument("user", "User whose statistics to retrieve", interaction.OptionTypeUser, i18n.MessageInvalidUser)),
+			filterArguments()...,
 		),
 		DefaultEphemeral: true,
 		Timeout:          time.Second * 30,
@@ -45,7 +47,7 @@ func (c StatsUserCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
+func (c StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64, panelId, teamId *int, period, from, to *string) {
 	span := sentry.StartTransaction(ctx, "/stats user")
 	span.SetTag("guild", strconv.FormatUint(ctx.GuildId(), 10))
 	span.SetTag("user", strconv.FormatUint(userId, 10))
@@ -63,6 +65,16 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		return
 	}
 
+	if hasFilters(panelId, teamId, period, from, to) {
+		filters, ok := parseFilters(ctx, panelId, teamId, period, from, to)
+		if !ok {
+			return
+		}
+
+		c.executeFiltered(ctx, span, member, permLevel, filters)
+		return
+	}
+
 	// User stats
 	if permLevel == permission.Everyone {
 		var isBlacklisted bool
@@ -77,7 +89,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "Is Blacklisted")
 			defer span.Finish()
 
-			isBlacklisted, err = utils.IsBlacklisted(ctx, ctx.GuildId(), userId, member, permLevel)
+			isBlacklisted, err = utils.IsBlacklisted(ctx, ctx.Worker(), ctx.GuildId(), userId, member, permLevel)
 			return
 		})
 
@@ -86,7 +98,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetAllByUser")
 			defer span.Finish()
 
-			tickets, err := dbclient.Client.Tickets.GetAllByUser(ctx, ctx.GuildId(), userId)
+			tickets, err := ctx.Worker().Services.Database.Tickets.GetAllByUser(ctx, ctx.GuildId(), userId)
 			totalTickets = len(tickets)
 			return err
 		})
@@ -96,7 +108,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetOpenByUser")
 			defer span.Finish()
 
-			tickets, err := dbclient.Client.Tickets.GetOpenByUser(ctx, ctx.GuildId(), userId)
+			tickets, err := ctx.Worker().Services.Database.Tickets.GetOpenByUser(ctx, ctx.GuildId(), userId)
 			openTickets = len(tickets)
 			return err
 		})
@@ -106,7 +118,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "TicketLimit")
 			defer span.Finish()
 
-			ticketLimit, err = dbclient.Client.TicketLimit.Get(ctx, ctx.GuildId())
+			ticketLimit, err = ctx.Worker().Services.Database.TicketLimit.Get(ctx, ctx.GuildId())
 			return
 		})
 
@@ -139,7 +151,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetAverageClaimedBy")
 			defer span.Finish()
 
-			feedbackRating, err = dbclient.Client.ServiceRatings.GetAverageClaimedBy(ctx, ctx.GuildId(), userId)
+			feedbackRating, err = ctx.Worker().Services.Database.ServiceRatings.GetAverageClaimedBy(ctx, ctx.GuildId(), userId)
 			return
 		})
 
@@ -147,7 +159,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetCountClaimedBy")
 			defer span.Finish()
 
-			feedbackCount, err = dbclient.Client.ServiceRatings.GetCountClaimedBy(ctx, ctx.GuildId(), userId)
+			feedbackCount, err = ctx.Worker().Services.Database.ServiceRatings.GetCountClaimedBy(ctx, ctx.GuildId(), userId)
 			return
 		})
 
@@ -161,7 +173,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetAverageAllTimeUser")
 			defer span.Finish()
 
-			totalAR, err = dbclient.Client.FirstResponseTime.GetAverageAllTimeUser(ctx, ctx.GuildId(), userId)
+			totalAR, err = ctx.Worker().Services.Database.FirstResponseTime.GetAverageAllTimeUser(ctx, ctx.GuildId(), userId)
 			return
 		})
 
@@ -170,7 +182,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetAverageUser")
 			defer span.Finish()
 
-			monthlyAR, err = dbclient.Client.FirstResponseTime.GetAverageUser(ctx, ctx.GuildId(), userId, time.Hour*24*28)
+			monthlyAR, err = ctx.Worker().Services.Database.FirstResponseTime.GetAverageUser(ctx, ctx.GuildId(), userId, time.Hour*24*28)
 			return
 		})
 
@@ -179,7 +191,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetAverageUser")
 			defer span.Finish()
 
-			weeklyAR, err = dbclient.Client.FirstResponseTime.GetAverageUser(ctx, ctx.GuildId(), userId, time.Hour*24*7)
+			weeklyAR, err = ctx.Worker().Services.Database.FirstResponseTime.GetAverageUser(ctx, ctx.GuildId(), userId, time.Hour*24*7)
 			return
 		})
 
@@ -188,7 +200,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetParticipatedCountInterval")
 			defer span.Finish()
 
-			weeklyAnsweredTickets, err = dbclient.Client.Participants.GetParticipatedCountInterval(ctx, ctx.GuildId(), userId, time.Hour*24*7)
+			weeklyAnsweredTickets, err = ctx.Worker().Services.Database.Participants.GetParticipatedCountInterval(ctx, ctx.GuildId(), userId, time.Hour*24*7)
 			return
 		})
 
@@ -197,7 +209,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetParticipatedCountInterval")
 			defer span.Finish()
 
-			monthlyAnsweredTickets, err = dbclient.Client.Participants.GetParticipatedCountInterval(ctx, ctx.GuildId(), userId, time.Hour*24*28)
+			monthlyAnsweredTickets, err = ctx.Worker().Services.Database.Participants.GetParticipatedCountInterval(ctx, ctx.GuildId(), userId, time.Hour*24*28)
 			return
 		})
 
@@ -206,7 +218,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetParticipatedCount")
 			defer span.Finish()
 
-			totalAnsweredTickets, err = dbclient.Client.Participants.GetParticipatedCount(ctx, ctx.GuildId(), userId)
+			totalAnsweredTickets, err = ctx.Worker().Services.Database.Participants.GetParticipatedCount(ctx, ctx.GuildId(), userId)
 			return
 		})
 
@@ -215,7 +227,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetTotalTicketCountInterval")
 			defer span.Finish()
 
-			weeklyTotalTickets, err = dbclient.Client.Tickets.GetTotalTicketCountInterval(ctx, ctx.GuildId(), time.Hour*24*7)
+			weeklyTotalTickets, err = ctx.Worker().Services.Database.Tickets.GetTotalTicketCountInterval(ctx, ctx.GuildId(), time.Hour*24*7)
 			return
 		})
 
@@ -224,7 +236,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetTotalTicketCountInterval")
 			defer span.Finish()
 
-			monthlyTotalTickets, err = dbclient.Client.Tickets.GetTotalTicketCountInterval(ctx, ctx.GuildId(), time.Hour*24*28)
+			monthlyTotalTickets, err = ctx.Worker().Services.Database.Tickets.GetTotalTicketCountInterval(ctx, ctx.GuildId(), time.Hour*24*28)
 			return
 		})
 
@@ -233,7 +245,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetTotalTicketCount")
 			defer span.Finish()
 
-			totalTotalTickets, err = dbclient.Client.Tickets.GetTotalTicketCount(ctx, ctx.GuildId())
+			totalTotalTickets, err = ctx.Worker().Services.Database.Tickets.GetTotalTicketCount(ctx, ctx.GuildId())
 			return
 		})
 
@@ -242,7 +254,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetClaimedSinceCount_Weekly")
 			defer span.Finish()
 
-			weeklyClaimedTickets, err = dbclient.Client.TicketClaims.GetClaimedSinceCount(ctx, ctx.GuildId(), userId, time.Hour*24*7)
+			weeklyClaimedTickets, err = ctx.Worker().Services.Database.TicketClaims.GetClaimedSinceCount(ctx, ctx.GuildId(), userId, time.Hour*24*7)
 			return
 		})
 
@@ -251,7 +263,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetClaimedSinceCount_Monthly")
 			defer span.Finish()
 
-			monthlyClaimedTickets, err = dbclient.Client.TicketClaims.GetClaimedSinceCount(ctx, ctx.GuildId(), userId, time.Hour*24*28)
+			monthlyClaimedTickets, err = ctx.Worker().Services.Database.TicketClaims.GetClaimedSinceCount(ctx, ctx.GuildId(), userId, time.Hour*24*28)
 			return
 		})
 
@@ -260,7 +272,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 			span := sentry.StartSpan(span.Context(), "GetClaimedCount")
 			defer span.Finish()
 
-			totalClaimedTickets, err = dbclient.Client.TicketClaims.GetClaimedCount(ctx, ctx.GuildId(), userId)
+			totalClaimedTickets, err = ctx.Worker().Services.Database.TicketClaims.GetClaimedCount(ctx, ctx.GuildId(), userId)
 			return
 		})
 
@@ -278,7 +290,7 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 
 		span := sentry.StartSpan(span.Context(), "Reply")
 
-		if experiments.HasFeature(ctx, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
+		if experiments.HasFeature(ctx, ctx.Worker().Services, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
 			userData, err := ctx.Worker().GetUser(userId)
 			if err != nil {
 				ctx.HandleError(err)
@@ -386,3 +398,123 @@ func (StatsUserCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		span.Finish()
 	}
 }
+
+func (StatsUserCommand) executeFiltered(
+	ctx registry.CommandContext,
+	span *sentry.Span,
+	member member.Member,
+	permLevel permission.PermissionLevel,
+	filters statsFilters,
+) {
+	// User stats
+	if permLevel == permission.Everyone {
+		fetchSpan := sentry.StartSpan(span.Context(), "FetchTickets")
+		records, err := reporting.FetchTickets(ctx, ctx.Worker().Services, ctx.GuildId(), filters.Range, filters.Filter)
+		fetchSpan.Finish()
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		var opened int
+		for _, record := range records {
+			if record.OpenerId == member.User.Id && filters.Range.Contains(record.OpenTime) {
+				opened++
+			}
+		}
+
+		msgEmbed := embed.NewEmbed().
+			SetTitle("Statistics").
+			SetDescription(filters.String()).
+			SetColor(ctx.GetColour(customisation.Green)).
+			SetAuthor(member.User.Username, "", member.User.AvatarUrl(256)).
+			AddField("Permission Level", "Regular", true).
+			AddField("Tickets Opened", strconv.Itoa(opened), true)
+
+		_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
+		return
+	}
+
+	generateSpan := sentry.StartSpan(span.Context(), "GenerateRange")
+	report, err := reporting.GenerateRange(ctx, ctx.Worker().Services, ctx.GuildId(), filters.Range, filters.Filter)
+	generateSpan.Finish()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	staff := reporting.StaffStats{UserId: member.User.Id}
+	for _, s := range report.Staff {
+		if s.UserId == member.User.Id {
+			staff = s
+			break
+		}
+	}
+
+	var permissionLevel string
+	if permLevel == permission.Admin {
+		permissionLevel = "Admin"
+	} else {
+		permissionLevel = "Support"
+	}
+
+	span = sentry.StartSpan(span.Context(), "Reply")
+	defer span.Finish()
+
+	if experiments.HasFeature(ctx, ctx.Worker().Services, ctx.GuildId(), experiments.COMPONENTS_V2_STATISTICS) {
+		mainStats := []string{
+			fmt.Sprintf("**Username**: %s", member.User.Username),
+			fmt.Sprintf("**Permission Level**: %s", permissionLevel),
+			fmt.Sprintf("**Feedback Rating**: %s", formatRating(staff.Ratings)),
+			fmt.Sprintf("**Feedback Count**: %d", staff.Ratings.Count),
+		}
+
+		ticketStats := []string{
+			fmt.Sprintf("**Tickets Answered**: %d/%d", staff.Participated, report.Summary.Opened),
+			fmt.Sprintf("**First Responses**: %d", staff.FirstResponses),
+			fmt.Sprintf("**Claimed Tickets**: %d", staff.Claimed),
+		}
+
+		responseTimeStats := []string{
+			fmt.Sprintf("**Average**: %s", formatNullableTime(staff.FirstResponseTime.Mean)),
+			fmt.Sprintf("**Median**: %s", formatNullableTime(staff.FirstResponseTime.P50)),
+		}
+
+		innerComponents := []component.Component{
+			component.BuildTextDisplay(component.TextDisplay{Content: "## Ticket User Statistics"}),
+			component.BuildTextDisplay(component.TextDisplay{Content: fmt.Sprintf("-# %s", filters.String())}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("● %s", strings.Join(mainStats, "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### Tickets\n● %s", strings.Join(ticketStats, "\n● ")),
+			}),
+			component.BuildSeparator(component.Separator{}),
+			component.BuildTextDisplay(component.TextDisplay{
+				Content: fmt.Sprintf("### First Response Time\n● %s", strings.Join(responseTimeStats, "\n● ")),
+			}),
+		}
+
+		ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents(utils.Slice(component.BuildContainer(component.Container{
+			Components: innerComponents,
+		}))))
+	} else {
+		msgEmbed := embed.NewEmbed().
+			SetTitle("Statistics").
+			SetDescription(filters.String()).
+			SetColor(ctx.GetColour(customisation.Green)).
+			SetAuthor(member.User.Username, "", member.User.AvatarUrl(256)).
+			AddField("Permission Level", permissionLevel, true).
+			AddField("Feedback Rating", fmt.Sprintf("%s (%d ratings)", formatRating(staff.Ratings), staff.Ratings.Count), true).
+			AddBlankField(true).
+			AddField("Tickets Answered", fmt.Sprintf("%d / %d", staff.Participated, report.Summary.Opened), true).
+			AddField("First Responses", strconv.Itoa(staff.FirstResponses), true).
+			AddField("Claimed Tickets", strconv.Itoa(staff.Claimed), true).
+			AddField("Average First Response Time", formatNullableTime(staff.FirstResponseTime.Mean), true).
+			AddField("Median First Response Time", formatNullableTime(staff.FirstResponseTime.P50), true).
+			AddBlankField(true)
+
+		_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
+	}
+}
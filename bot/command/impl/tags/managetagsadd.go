@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -49,7 +48,7 @@ func (ManageTagsAddCommand) Execute(ctx registry.CommandContext, tagId, content
 	}
 
 	// Limit of 200 tags
-	count, err := dbclient.Client.Tag.GetTagCount(ctx, ctx.GuildId())
+	count, err := ctx.Worker().Services.Database.Tag.GetTagCount(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -67,7 +66,7 @@ func (ManageTagsAddCommand) Execute(ctx registry.CommandContext, tagId, content
 	}
 
 	// Verify a tag with the ID doesn't already exist
-	exists, err := dbclient.Client.Tag.Exists(ctx, ctx.GuildId(), tagId)
+	exists, err := ctx.Worker().Services.Database.Tag.Exists(ctx, ctx.GuildId(), tagId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -87,7 +86,7 @@ func (ManageTagsAddCommand) Execute(ctx registry.CommandContext, tagId, content
 		ApplicationCommandId: nil,
 	}
 
-	if err := dbclient.Client.Tag.Set(ctx, tag); err != nil {
+	if err := ctx.Worker().Services.Database.Tag.Set(ctx, tag); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -8,7 +8,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -36,7 +35,7 @@ func (c ManageTagsDeleteCommand) GetExecutor() interface{} {
 }
 
 func (ManageTagsDeleteCommand) Execute(ctx registry.CommandContext, tagId string) {
-	exists, err := dbclient.Client.Tag.Exists(ctx, ctx.GuildId(), tagId)
+	exists, err := ctx.Worker().Services.Database.Tag.Exists(ctx, ctx.GuildId(), tagId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -47,7 +46,7 @@ func (ManageTagsDeleteCommand) Execute(ctx registry.CommandContext, tagId string
 		return
 	}
 
-	if err := dbclient.Client.Tag.Delete(ctx, ctx.GuildId(), tagId); err != nil {
+	if err := ctx.Worker().Services.Database.Tag.Delete(ctx, ctx.GuildId(), tagId); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -34,7 +33,7 @@ func (c ManageTagsListCommand) GetExecutor() interface{} {
 }
 
 func (ManageTagsListCommand) Execute(ctx registry.CommandContext) {
-	ids, err := dbclient.Client.Tag.GetTagIds(ctx, ctx.GuildId())
+	ids, err := ctx.Worker().Services.Database.Tag.GetTagIds(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -12,10 +12,10 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
 	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
@@ -52,7 +52,7 @@ func (TagCommand) Execute(ctx registry.CommandContext, tagId string) {
 		Inline: false,
 	}
 
-	tag, ok, err := dbclient.Client.Tag.Get(ctx, ctx.GuildId(), tagId)
+	tag, ok, err := ctx.Worker().Services.Database.Tag.Get(ctx, ctx.GuildId(), tagId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -63,7 +63,7 @@ func (TagCommand) Execute(ctx registry.CommandContext, tagId string) {
 		return
 	}
 
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		sentry.ErrorWithContext(err, ctx.ToErrorContext())
 		return
@@ -100,27 +100,27 @@ func (TagCommand) Execute(ctx registry.CommandContext, tagId string) {
 
 	// Count user as a participant so that Tickets Answered stat includes tickets where only /tag was used
 	if ticket.GuildId != 0 {
-		if err := dbclient.Client.Participants.Set(ctx, ctx.GuildId(), ticket.Id, ctx.UserId()); err != nil {
+		if err := ctx.Worker().Services.Database.Participants.Set(ctx, ctx.GuildId(), ticket.Id, ctx.UserId()); err != nil {
 			sentry.ErrorWithContext(err, ctx.ToErrorContext())
 		}
 
-		if err := dbclient.Client.Tickets.SetStatus(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
+		if err := ctx.Worker().Services.Database.Tickets.SetStatus(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
 			sentry.ErrorWithContext(err, ctx.ToErrorContext())
 		}
 
 		if !ticket.IsThread && ctx.PremiumTier() > premium.None {
-			if err := dbclient.Client.CategoryUpdateQueue.Add(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
+			if err := ctx.Worker().Services.Database.CategoryUpdateQueue.Add(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
 				sentry.ErrorWithContext(err, ctx.ToErrorContext())
 			}
 		}
 	}
 }
 
-func (TagCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+func (TagCommand) AutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3) // TODO: Propagate context
 	defer cancel()
 
-	tagIds, err := dbclient.Client.Tag.GetContaining(ctx, data.GuildId.Value, value, 25)
+	tagIds, err := worker.Services.Database.Tag.GetContaining(ctx, data.GuildId.Value, value, 25)
 	if err != nil {
 		sentry.Error(err) // TODO: Error context
 		return nil
@@ -11,7 +11,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -38,7 +37,7 @@ func (c AddCommand) GetExecutor() interface{} {
 }
 
 func (AddCommand) Execute(ctx registry.CommandContext, id uint64) {
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -80,7 +79,7 @@ func (AddCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 	if mentionableType == context.MentionableTypeUser {
 		// Add user to ticket in DB
-		if err := dbclient.Client.TicketMembers.Add(ctx, ctx.GuildId(), ticket.Id, id); err != nil {
+		if err := ctx.Worker().Services.Database.TicketMembers.Add(ctx, ctx.GuildId(), ticket.Id, id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -102,7 +101,7 @@ func (AddCommand) Execute(ctx registry.CommandContext, id uint64) {
 				return
 			}
 		} else {
-			additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ctx.GuildId())
+			additionalPermissions, err := ctx.Worker().Services.Database.TicketPermissions.Get(ctx, ctx.GuildId())
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -132,7 +131,7 @@ func (AddCommand) Execute(ctx registry.CommandContext, id uint64) {
 		}
 	} else if mentionableType == context.MentionableTypeRole {
 		// Handle role addition
-		additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ctx.GuildId())
+		additionalPermissions, err := ctx.Worker().Services.Database.TicketPermissions.Get(ctx, ctx.GuildId())
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -0,0 +1,181 @@
+package tickets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/google/uuid"
+)
+
+// bulkPreviewLength is the number of matching tickets listed in the preview
+const bulkPreviewLength = 10
+
+type BulkCommand struct {
+}
+
+func (BulkCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "bulk",
+		Description:     i18n.HelpBulk,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Children: []registry.Command{
+			BulkCloseCommand{},
+			BulkClaimCommand{},
+			BulkMoveCommand{},
+			BulkAddLabelCommand{},
+		},
+	}
+}
+
+func (c BulkCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BulkCommand) Execute(ctx registry.CommandContext) {
+	// Cannot call parent command
+}
+
+// bulkProperties are the properties shared by the /bulk subcommands. The filter arguments are appended to the
+// subcommand's own arguments, as Discord requires required arguments to come first.
+func bulkProperties(name string, description i18n.MessageId, arguments ...command.Argument) registry.Properties {
+	return registry.Properties{
+		Name:            name,
+		Description:     description,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: append(arguments, command.Arguments(
+			command.NewOptionalAutocompleteableArgument("panel", "Only include tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.PanelAutoCompleteHandler),
+			command.NewOptionalArgument("inactive_days", "Only include tickets with no messages for at least this many days", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("opener", "Only include tickets opened by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("has_label", "Only include tickets with this label", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.LabelAutoCompleteHandler),
+			command.NewOptionalArgument("claimed", "Only include claimed tickets if true, or unclaimed tickets if false", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		)...),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 30,
+	}
+}
+
+// parseBulkFilter validates the filter arguments, replying with an error message and returning false if they are
+// invalid
+func parseBulkFilter(ctx registry.CommandContext, panelId, inactiveDays *int, openerId *uint64, labelId *int, claimed *bool) (logic.BulkTicketFilter, bool) {
+	filter := logic.BulkTicketFilter{
+		PanelId:  panelId,
+		OpenerId: openerId,
+		LabelId:  labelId,
+		Claimed:  claimed,
+	}
+
+	if inactiveDays != nil {
+		if *inactiveDays < 1 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInactiveDays)
+			return filter, false
+		}
+
+		filter.InactiveFor = time.Hour * 24 * time.Duration(*inactiveDays)
+	}
+
+	if panelId != nil {
+		panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return filter, false
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessagePanelNotFound)
+			return filter, false
+		}
+	}
+
+	if labelId != nil {
+		if _, ok, err := ctx.Worker().Services.Database.TicketLabels.Get(ctx, ctx.GuildId(), *labelId); err != nil {
+			ctx.HandleError(err)
+			return filter, false
+		} else if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelNotFound)
+			return filter, false
+		}
+	}
+
+	return filter, true
+}
+
+// previewBulkAction finds the tickets matching the filter and stores the action, replying with the tickets it will
+// apply to and buttons to confirm or cancel it
+func previewBulkAction(ctx registry.CommandContext, action redis.BulkAction, filter logic.BulkTicketFilter, summaryId i18n.MessageId, summaryArgs ...any) {
+	tickets, total, err := logic.FindBulkTickets(ctx, ctx.Worker(), ctx.GuildId(), ctx.UserId(), filter)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(tickets) == 0 {
+		ctx.Reply(customisation.Red, i18n.TitleBulkAction, i18n.MessageBulkNoTickets)
+		return
+	}
+
+	action.GuildId = ctx.GuildId()
+	action.UserId = ctx.UserId()
+	action.TicketIds = make([]int, len(tickets))
+	for i, ticket := range tickets {
+		action.TicketIds[i] = ticket.Id
+	}
+
+	id := uuid.NewString()
+	if err := ctx.Worker().Services.Redis.SetBulkAction(ctx, id, action); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	lines := []string{ctx.GetMessage(summaryId, append(summaryArgs, len(tickets))...)}
+	for _, ticket := range tickets[:min(len(tickets), bulkPreviewLength)] {
+		lines = append(lines, ctx.GetMessage(i18n.MessageBulkPreviewTicket, ticket.Id, *ticket.ChannelId, ticket.UserId))
+	}
+
+	if len(tickets) > bulkPreviewLength {
+		lines = append(lines, ctx.GetMessage(i18n.MessageBulkPreviewMore, len(tickets)-bulkPreviewLength))
+	}
+
+	if total > len(tickets) {
+		lines = append(lines, "", ctx.GetMessage(i18n.MessageBulkPreviewLimit, total, logic.MaxBulkActionTickets))
+	}
+
+	lines = append(lines, "", ctx.GetMessage(i18n.MessageBulkPreviewExpiry))
+
+	confirmStyle := component.ButtonStylePrimary
+	if action.Action == logic.BulkActionClose {
+		confirmStyle = component.ButtonStyleDanger
+	}
+
+	ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
+		utils.BuildContainerRaw(ctx, customisation.Orange, ctx.GetMessage(i18n.TitleBulkAction), strings.Join(lines, "\n")),
+		component.BuildActionRow(
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.Confirm),
+				CustomId: fmt.Sprintf("bulk-confirm-%s", id),
+				Style:    confirmStyle,
+			}),
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.Cancel),
+				CustomId: fmt.Sprintf("bulk-cancel-%s", id),
+				Style:    component.ButtonStyleSecondary,
+			}),
+		),
+	}))
+}
@@ -0,0 +1,50 @@
+package tickets
+
+import (
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type BulkAddLabelCommand struct {
+}
+
+func (BulkAddLabelCommand) Properties() registry.Properties {
+	return bulkProperties("add-label", i18n.HelpBulkAddLabel,
+		command.NewRequiredAutocompleteableArgument("label", "The label to add to the tickets", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.LabelAutoCompleteHandler),
+	)
+}
+
+func (c BulkAddLabelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BulkAddLabelCommand) Execute(ctx registry.CommandContext, targetLabelId int, panelId, inactiveDays *int, openerId *uint64, labelId *int, claimed *bool) {
+	filter, ok := parseBulkFilter(ctx, panelId, inactiveDays, openerId, labelId, claimed)
+	if !ok {
+		return
+	}
+
+	label, ok, err := ctx.Worker().Services.Database.TicketLabels.Get(ctx, ctx.GuildId(), targetLabelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLabelNotFound)
+		return
+	}
+
+	action := redis.BulkAction{
+		Action:   logic.BulkActionAddLabel,
+		TargetId: uint64(label.LabelId),
+	}
+
+	previewBulkAction(ctx, action, filter, i18n.MessageBulkPreviewAddLabel, label.Name)
+}
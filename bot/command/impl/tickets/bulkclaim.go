@@ -0,0 +1,41 @@
+package tickets
+
+import (
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type BulkClaimCommand struct {
+}
+
+func (BulkClaimCommand) Properties() registry.Properties {
+	return bulkProperties("claim", i18n.HelpBulkClaim,
+		command.NewOptionalArgument("claimer", "The staff member to claim the tickets for (defaults to you)", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+	)
+}
+
+func (c BulkClaimCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BulkClaimCommand) Execute(ctx registry.CommandContext, claimerId *uint64, panelId, inactiveDays *int, openerId *uint64, labelId *int, claimed *bool) {
+	filter, ok := parseBulkFilter(ctx, panelId, inactiveDays, openerId, labelId, claimed)
+	if !ok {
+		return
+	}
+
+	action := redis.BulkAction{
+		Action:   logic.BulkActionClaim,
+		TargetId: ctx.UserId(),
+	}
+
+	if claimerId != nil {
+		action.TargetId = *claimerId
+	}
+
+	previewBulkAction(ctx, action, filter, i18n.MessageBulkPreviewClaim, action.TargetId)
+}
@@ -0,0 +1,37 @@
+package tickets
+
+import (
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type BulkCloseCommand struct {
+}
+
+func (BulkCloseCommand) Properties() registry.Properties {
+	return bulkProperties("close", i18n.HelpBulkClose,
+		command.NewOptionalArgument("reason", "The reason the tickets were closed", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+	)
+}
+
+func (c BulkCloseCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BulkCloseCommand) Execute(ctx registry.CommandContext, reason *string, panelId, inactiveDays *int, openerId *uint64, labelId *int, claimed *bool) {
+	filter, ok := parseBulkFilter(ctx, panelId, inactiveDays, openerId, labelId, claimed)
+	if !ok {
+		return
+	}
+
+	action := redis.BulkAction{
+		Action: logic.BulkActionClose,
+		Reason: reason,
+	}
+
+	previewBulkAction(ctx, action, filter, i18n.MessageBulkPreviewClose)
+}
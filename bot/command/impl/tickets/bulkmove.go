@@ -0,0 +1,50 @@
+package tickets
+
+import (
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type BulkMoveCommand struct {
+}
+
+func (BulkMoveCommand) Properties() registry.Properties {
+	return bulkProperties("move", i18n.HelpBulkMove,
+		command.NewRequiredAutocompleteableArgument("to_panel", "The panel to switch the tickets to", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.PanelAutoCompleteHandler),
+	)
+}
+
+func (c BulkMoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BulkMoveCommand) Execute(ctx registry.CommandContext, targetPanelId int, panelId, inactiveDays *int, openerId *uint64, labelId *int, claimed *bool) {
+	filter, ok := parseBulkFilter(ctx, panelId, inactiveDays, openerId, labelId, claimed)
+	if !ok {
+		return
+	}
+
+	panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, targetPanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelInvalidPanel)
+		return
+	}
+
+	action := redis.BulkAction{
+		Action:   logic.BulkActionMove,
+		TargetId: uint64(panel.PanelId),
+	}
+
+	previewBulkAction(ctx, action, filter, i18n.MessageBulkPreviewMove, panel.Title)
+}
@@ -2,15 +2,17 @@ package tickets
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -28,6 +30,7 @@ func (c CloseCommand) Properties() registry.Properties {
 		Category:        command.Tickets,
 		Arguments: command.Arguments(
 			command.NewOptionalAutocompleteableArgument("reason", "The reason the ticket was closed", interaction.OptionTypeString, "infallible", c.AutoCompleteHandler), // should never fail
+			command.NewOptionalArgument("spam", "Close the ticket as spam, without a transcript or notifying the opener", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
 		),
 		Timeout: constants.TimeoutCloseTicket,
 	}
@@ -37,16 +40,20 @@ func (c CloseCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (CloseCommand) Execute(ctx registry.CommandContext, reason *string) {
-	logic.CloseTicket(ctx, ctx, reason, false)
+func (CloseCommand) Execute(ctx registry.CommandContext, reason *string, spam *bool) {
+	if spam != nil && *spam {
+		logic.CloseTicketAsSpam(ctx, ctx, reason)
+	} else {
+		logic.CloseTicket(ctx, ctx, reason, false)
+	}
 }
 
-func (CloseCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+func (CloseCommand) AutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
 	var reasons []string
 	var err error
 
 	// Get ticket
-	ticket, e := dbclient.Client.Tickets.GetByChannelAndGuild(context.Background(), data.ChannelId, data.GuildId.Value)
+	ticket, e := worker.Services.Database.Tickets.GetByChannelAndGuild(context.Background(), data.ChannelId, data.GuildId.Value)
 	if e != nil {
 		sentry.Error(e) // TODO: Context
 		return nil
@@ -64,14 +71,14 @@ func (CloseCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoC
 			panelId = ticket.PanelId
 		}
 
-		reasons, err = dbclient.Analytics.GetTopCloseReasons(ctx, data.GuildId.Value, panelId)
+		reasons, err = worker.Services.Analytics.GetTopCloseReasons(ctx, data.GuildId.Value, panelId)
 	} else {
 		var panelId *int
 		if ticket.Id != 0 {
 			panelId = ticket.PanelId
 		}
 
-		reasons, err = dbclient.Analytics.GetTopCloseReasonsContaining(ctx, data.GuildId.Value, panelId, value)
+		reasons, err = worker.Services.Analytics.GetTopCloseReasonsContaining(ctx, data.GuildId.Value, panelId, value)
 	}
 
 	if err != nil {
@@ -79,9 +86,32 @@ func (CloseCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoC
 		return nil
 	}
 
-	choices := make([]interaction.ApplicationCommandOptionChoice, len(reasons))
-	for i, reason := range reasons {
-		choices[i] = utils.StringChoice(reason)
+	// Offer the panel's close reason presets ahead of the most common reasons
+	var choices []interaction.ApplicationCommandOptionChoice
+	if ticket.Id != 0 {
+		closeReasonConfig, err := logic.GetCloseReasonConfig(ctx, worker, ticket)
+		if err != nil {
+			sentry.Error(err) // TODO: Context
+			return nil
+		}
+
+		for _, preset := range closeReasonConfig.Presets {
+			if strings.Contains(strings.ToLower(preset.Label), strings.ToLower(value)) {
+				choices = append(choices, utils.StringChoice(preset.Label))
+			}
+		}
+	}
+
+	for _, reason := range reasons {
+		if len(choices) >= 25 {
+			break
+		}
+
+		if !slices.ContainsFunc(choices, func(choice interaction.ApplicationCommandOptionChoice) bool {
+			return choice.Value == reason
+		}) {
+			choices = append(choices, utils.StringChoice(reason))
+		}
 	}
 
 	return choices
@@ -14,10 +14,10 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
 	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -47,7 +47,7 @@ func (c CloseRequestCommand) GetExecutor() interface{} {
 }
 
 func (CloseRequestCommand) Execute(ctx registry.CommandContext, closeDelay *int, reason *string) {
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -77,7 +77,7 @@ func (CloseRequestCommand) Execute(ctx registry.CommandContext, closeDelay *int,
 		Reason:   reason,
 	}
 
-	if err := dbclient.Client.CloseRequest.Set(ctx, closeRequest); err != nil {
+	if err := ctx.Worker().Services.Database.CloseRequest.Set(ctx, closeRequest); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -143,13 +143,13 @@ func (CloseRequestCommand) Execute(ctx registry.CommandContext, closeDelay *int,
 		ctx.ReplyPlain(ctx.GetMessage(i18n.MessageCloseRequested))
 	}
 
-	if err := dbclient.Client.Tickets.SetStatus(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
+	if err := ctx.Worker().Services.Database.Tickets.SetStatus(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
 	if !ticket.IsThread && ctx.PremiumTier() > premium.None {
-		if err := dbclient.Client.CategoryUpdateQueue.Add(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
+		if err := ctx.Worker().Services.Database.CategoryUpdateQueue.Add(ctx, ctx.GuildId(), ticket.Id, model.TicketStatusPending); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -157,6 +157,6 @@ func (CloseRequestCommand) Execute(ctx registry.CommandContext, closeDelay *int,
 }
 
 // ReasonAutoCompleteHandler TODO: Make a utility function rather than call the Close handler directly
-func (CloseRequestCommand) ReasonAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
-	return CloseCommand{}.AutoCompleteHandler(data, value)
+func (CloseRequestCommand) ReasonAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	return CloseCommand{}.AutoCompleteHandler(worker, data, value)
 }
@@ -0,0 +1,67 @@
+package tickets
+
+import (
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type HistoryCommand struct {
+}
+
+func (HistoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "history",
+		Description:     i18n.HelpHistory,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("user", "The user to view the ticket history of. Defaults to the ticket opener", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		),
+		DefaultEphemeral: true,
+		Timeout:          time.Second * 5,
+	}
+}
+
+func (c HistoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (HistoryCommand) Execute(ctx registry.CommandContext, userId *uint64) {
+	var targetId uint64
+	if userId != nil {
+		targetId = *userId
+	} else {
+		ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if ticket.UserId == 0 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTicketHistoryUserRequired)
+			return
+		}
+
+		targetId = ticket.UserId
+	}
+
+	comp, page, totalPages, err := logic.BuildTicketHistoryMessage(ctx, ctx, targetId, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	_, _ = ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
+		comp,
+		logic.BuildTicketHistoryButtons(targetId, page, totalPages),
+	}))
+}
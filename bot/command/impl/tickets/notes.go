@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -40,7 +39,7 @@ func (c NotesCommand) GetExecutor() interface{} {
 }
 
 func (NotesCommand) Execute(ctx registry.CommandContext) {
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -59,7 +58,7 @@ func (NotesCommand) Execute(ctx registry.CommandContext) {
 
 	var panel *database.Panel
 	if ticket.PanelId != nil {
-		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		tmp, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -90,7 +89,7 @@ func (NotesCommand) Execute(ctx registry.CommandContext) {
 
 		ctx.Reply(customisation.Green, i18n.Success, i18n.MessageNotesAddedToExisting, *ticket.NotesThreadId)
 	} else {
-		allowedUsers, allowedRoles, err := logic.GetAllowedStaffUsersAndRoles(ctx, ctx.GuildId(), panel)
+		allowedUsers, allowedRoles, err := logic.GetAllowedStaffUsersAndRoles(ctx, ctx.Worker(), ctx.GuildId(), panel)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -140,7 +139,7 @@ func (NotesCommand) Execute(ctx registry.CommandContext) {
 			return
 		}
 
-		if err := dbclient.Client.Tickets.SetNotesThreadId(ctx, ticket.GuildId, ticket.Id, thread.Id); err != nil {
+		if err := ctx.Worker().Services.Database.Tickets.SetNotesThreadId(ctx, ticket.GuildId, ticket.Id, thread.Id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
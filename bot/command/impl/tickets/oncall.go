@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -57,25 +56,25 @@ func (OnCallCommand) Execute(ctx registry.CommandContext) {
 	}
 
 	// Reflects *new* state
-	onCall, err := dbclient.Client.OnCall.Toggle(ctx, ctx.GuildId(), ctx.UserId())
+	onCall, err := ctx.Worker().Services.Database.OnCall.Toggle(ctx, ctx.GuildId(), ctx.UserId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	defaultTeam, teamIds, err := logic.GetMemberTeamsWithMember(ctx, ctx.GuildId(), ctx.UserId(), member)
+	defaultTeam, teamIds, err := logic.GetMemberTeamsWithMember(ctx, ctx.Worker(), ctx.GuildId(), ctx.UserId(), member)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	teams, err := dbclient.Client.SupportTeam.GetMulti(ctx, ctx.GuildId(), teamIds)
+	teams, err := ctx.Worker().Services.Database.SupportTeam.GetMulti(ctx, ctx.GuildId(), teamIds)
 	if err != nil {
 		ctx.HandleError(err)
 		return
 	}
 
-	metadata, err := dbclient.Client.GuildMetadata.Get(ctx, ctx.GuildId())
+	metadata, err := ctx.Worker().Services.Database.GuildMetadata.Get(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -114,7 +113,7 @@ func (OnCallCommand) Execute(ctx registry.CommandContext) {
 			if err := ctx.Worker().RemoveGuildMemberRole(reasonCtx, ctx.GuildId(), ctx.UserId(), *metadata.OnCallRole); err != nil {
 				// If role was deleted, clear it from database and continue
 				if restErr, ok := err.(request.RestError); ok && restErr.ApiError.Code == 10011 {
-					if err := dbclient.Client.GuildMetadata.SetOnCallRole(ctx, ctx.GuildId(), nil); err != nil {
+					if err := ctx.Worker().Services.Database.GuildMetadata.SetOnCallRole(ctx, ctx.GuildId(), nil); err != nil {
 						ctx.HandleError(err)
 						return
 					}
@@ -143,7 +142,7 @@ func (OnCallCommand) Execute(ctx registry.CommandContext) {
 			if err := ctx.Worker().RemoveGuildMemberRole(reasonCtx2, ctx.GuildId(), ctx.UserId(), *team.OnCallRole); err != nil {
 				// If role was deleted, clear it from database and continue
 				if restErr, ok := err.(request.RestError); ok && restErr.ApiError.Code == 10011 {
-					if err := dbclient.Client.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
+					if err := ctx.Worker().Services.Database.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
 						ctx.HandleError(err)
 						return
 					}
@@ -179,11 +178,11 @@ func assignOnCallRole(ctx registry.CommandContext, member member.Member, roleId
 		// If role was deleted, recreate it
 		if err, ok := err.(request.RestError); ok && err.ApiError.Code == 10011 {
 			if team == nil {
-				if err := dbclient.Client.GuildMetadata.SetOnCallRole(ctx, ctx.GuildId(), nil); err != nil {
+				if err := ctx.Worker().Services.Database.GuildMetadata.SetOnCallRole(ctx, ctx.GuildId(), nil); err != nil {
 					return err
 				}
 			} else {
-				if err := dbclient.Client.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
+				if err := ctx.Worker().Services.Database.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
 					return err
 				}
 			}
@@ -2,6 +2,7 @@ package tickets
 
 import (
 	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
@@ -9,6 +10,7 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -25,6 +27,8 @@ func (OpenCommand) Properties() registry.Properties {
 		Category:        command.Tickets,
 		Arguments: command.Arguments(
 			command.NewOptionalArgument("subject", "The subject of the ticket", interaction.OptionTypeString, "infallible"),
+			command.NewOptionalArgument("for", "Staff only: the user to open the ticket on behalf of", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("panel", "Staff only: the panel to open the ticket from, when opening on behalf of a user", interaction.OptionTypeInteger, i18n.MessageInvalidArgument, utils.PanelAutoCompleteHandler),
 		),
 		DefaultEphemeral: true,
 		Timeout:          constants.TimeoutOpenTicket,
@@ -35,7 +39,33 @@ func (c OpenCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (OpenCommand) Execute(ctx *context.SlashCommandContext, providedSubject *string) {
+func (OpenCommand) Execute(ctx *context.SlashCommandContext, providedSubject *string, forUserId *uint64, panelId *int) {
+	var subject string
+	if providedSubject != nil {
+		subject = *providedSubject
+	}
+
+	if forUserId != nil {
+		permLevel, err := ctx.UserPermissionLevel(ctx)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if permLevel < permission.Support {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+			return
+		}
+
+		openOnBehalfOf(ctx, "/open", subject, *forUserId, panelId)
+		return
+	}
+
+	if panelId != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenPanelOnBehalfOnly)
+		return
+	}
+
 	settings, err := ctx.Settings()
 	if err != nil {
 		ctx.HandleError(err)
@@ -47,10 +77,80 @@ func (OpenCommand) Execute(ctx *context.SlashCommandContext, providedSubject *st
 		return
 	}
 
-	var subject string
-	if providedSubject != nil {
-		subject = *providedSubject
+	logic.OpenTicket(ctx.Context, ctx, nil, subject, nil, nil, nil, nil)
+}
+
+// openOnBehalfOf opens a ticket with another user as the opener, and is shared by /open and the "Open ticket for user"
+// context menu, which are responsible for checking that the invoker is staff. The target's blacklist status, ticket
+// limits and panel access are evaluated rather than the staff member's. As it opens tickets in the same way as /open,
+// it is also disabled by DisableOpenCommand.
+func openOnBehalfOf(ctx *context.SlashCommandContext, commandName, subject string, userId uint64, panelId *int) {
+	settings, err := ctx.Settings()
+	if err != nil {
+		ctx.HandleError(err)
+		return
 	}
 
-	logic.OpenTicket(ctx.Context, ctx, nil, subject, nil, nil, nil, nil)
+	if settings.DisableOpenCommand {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenCommandDisabled, commandName)
+		return
+	}
+
+	target, err := ctx.Worker().GetGuildMember(ctx.GuildId(), userId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if target.User.Bot {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenForBot)
+		return
+	}
+
+	onBehalfOf := logic.NewOnBehalfOfContext(ctx, target)
+
+	blacklisted, err := onBehalfOf.IsBlacklisted(ctx)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if blacklisted {
+		ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+		return
+	}
+
+	var panel *database.Panel
+	var outOfHoursTitle, outOfHoursWarning *string
+	var outOfHoursColour *int
+	if panelId != nil {
+		p, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if p.PanelId == 0 || p.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessagePanelNotFound)
+			return
+		}
+
+		canProceed, warningTitle, warning, colour, err := logic.ValidatePanelAccess(onBehalfOf, p)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !canProceed {
+			return
+		}
+
+		panel = &p
+		outOfHoursTitle = warningTitle
+		outOfHoursWarning = warning
+		outOfHoursColour = colour
+	}
+
+	// Errors are already handled
+	_, _ = logic.OpenTicket(ctx.Context, onBehalfOf, panel, subject, nil, outOfHoursTitle, outOfHoursWarning, outOfHoursColour)
 }
@@ -0,0 +1,52 @@
+package tickets
+
+import (
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/constants"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+)
+
+type OpenForUserCommand struct {
+}
+
+func (OpenForUserCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "Open ticket for user",
+		Type:             interaction.ApplicationCommandTypeUser,
+		PermissionLevel:  permcache.Support, // Customisable level
+		Category:         command.Tickets,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+		Timeout:          constants.TimeoutOpenTicket,
+	}
+}
+
+func (c OpenForUserCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (c OpenForUserCommand) Execute(ctx registry.CommandContext) {
+	interaction, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	if !logic.CheckUserContextMenuPermission(ctx, func(config dbclient.UserContextMenuConfig) int {
+		return config.OpenLevel
+	}) {
+		return
+	}
+
+	settings, err := ctx.Settings()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	openOnBehalfOf(interaction, c.Properties().Name, "", interaction.Interaction.Data.TargetId, settings.ContextMenuPanel)
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -42,7 +41,7 @@ func (c RemoveCommand) GetExecutor() interface{} {
 
 func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 	// Get ticket struct
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -90,13 +89,13 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 		}
 
 		// Check if user is a global admin
-		adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, ctx.GuildId())
+		adminUsers, err := ctx.Worker().Services.Database.Permissions.GetAdmins(ctx, ctx.GuildId())
 		if err != nil {
 			ctx.HandleError(err)
 			return
 		}
 
-		adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, ctx.GuildId())
+		adminRoles, err := ctx.Worker().Services.Database.RolePermissions.GetAdminRoles(ctx, ctx.GuildId())
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -120,7 +119,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 		// Check panel-specific staff
 		if ticket.PanelId != nil {
-			panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+			panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -128,7 +127,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 			if panel.PanelId != 0 {
 				// Check if user is in the panel's support team
-				teamUsers, err := dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
+				teamUsers, err := ctx.Worker().Services.Database.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
 				if err != nil {
 					ctx.HandleError(err)
 					return
@@ -140,7 +139,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 				}
 
 				// Check if user has a role in the panel's support team
-				teamRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+				teamRoles, err := ctx.Worker().Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 				if err != nil {
 					ctx.HandleError(err)
 					return
@@ -155,13 +154,13 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 				// If panel includes default team, check default support
 				if panel.WithDefaultTeam {
-					supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, ctx.GuildId())
+					supportUsers, err := ctx.Worker().Services.Database.Permissions.GetSupport(ctx, ctx.GuildId())
 					if err != nil {
 						ctx.HandleError(err)
 						return
 					}
 
-					supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
+					supportRoles, err := ctx.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
 					if err != nil {
 						ctx.HandleError(err)
 						return
@@ -182,13 +181,13 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 			}
 		} else {
 			// No panel, check default support team
-			supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, ctx.GuildId())
+			supportUsers, err := ctx.Worker().Services.Database.Permissions.GetSupport(ctx, ctx.GuildId())
 			if err != nil {
 				ctx.HandleError(err)
 				return
 			}
 
-			supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
+			supportRoles, err := ctx.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -208,7 +207,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 		}
 
 		// Remove user from ticket in DB
-		if err := dbclient.Client.TicketMembers.Delete(ctx, ctx.GuildId(), ticket.Id, id); err != nil {
+		if err := ctx.Worker().Services.Database.TicketMembers.Delete(ctx, ctx.GuildId(), ticket.Id, id); err != nil {
 			ctx.HandleError(err)
 			return
 		}
@@ -248,7 +247,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 		}
 	} else if mentionableType == context.MentionableTypeRole {
 		// Verify that the role isn't a staff role for the current panel
-		adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, ctx.GuildId())
+		adminRoles, err := ctx.Worker().Services.Database.RolePermissions.GetAdminRoles(ctx, ctx.GuildId())
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -264,7 +263,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 		// Check panel-specific staff
 		if ticket.PanelId != nil {
-			panel, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+			panel, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -272,7 +271,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 			if panel.PanelId != 0 {
 				// Check if role is in the panel's support team
-				teamRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+				teamRoles, err := ctx.Worker().Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 				if err != nil {
 					ctx.HandleError(err)
 					return
@@ -287,7 +286,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 
 				// If panel includes default team, check default support roles
 				if panel.WithDefaultTeam {
-					supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
+					supportRoles, err := ctx.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
 					if err != nil {
 						ctx.HandleError(err)
 						return
@@ -303,7 +302,7 @@ func (RemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
 			}
 		} else {
 			// No panel, check default support roles
-			supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
+			supportRoles, err := ctx.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, ctx.GuildId())
 			if err != nil {
 				ctx.HandleError(err)
 				return
@@ -15,9 +15,7 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -51,7 +49,7 @@ func (RenameCommand) Execute(ctx registry.CommandContext, name string) {
 		Inline: false,
 	}
 
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -65,7 +63,7 @@ func (RenameCommand) Execute(ctx registry.CommandContext, name string) {
 
 	// Get claim information
 	var claimer *uint64
-	claimUserId, err := dbclient.Client.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	claimUserId, err := ctx.Worker().Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -217,7 +215,7 @@ func (RenameCommand) Execute(ctx registry.CommandContext, name string) {
 	// Use the actual ticket channel ID, not the current channel (which might be a notes thread)
 	ticketChannelId := *ticket.ChannelId
 
-	allowed, err := redis.TakeRenameRatelimit(ctx, ticketChannelId)
+	allowed, err := ctx.Worker().Services.Redis.TakeRenameRatelimit(ctx, ticketChannelId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -8,9 +8,9 @@ import (
 	"github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -41,7 +41,7 @@ func (ReopenCommand) Execute(ctx registry.CommandContext, ticketId int) {
 	logic.ReopenTicket(ctx, ctx, ticketId)
 }
 
-func (ReopenCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+func (ReopenCommand) AutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
 	if data.GuildId.Value == 0 {
 		return nil
 	}
@@ -53,7 +53,7 @@ func (ReopenCommand) AutoCompleteHandler(data interaction.ApplicationCommandAuto
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3) // TODO: Propagate contxet
 	defer cancel()
 
-	tickets, err := dbclient.Client.Tickets.GetClosedByUserPrefixed(ctx, data.GuildId.Value, data.Member.User.Id, value, 25)
+	tickets, err := worker.Services.Database.Tickets.GetClosedByUserPrefixed(ctx, data.GuildId.Value, data.Member.User.Id, value, 25)
 	if err != nil {
 		sentry.Error(err)
 		return nil
@@ -15,7 +15,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -76,7 +75,7 @@ func (StartTicketCommand) Execute(ctx registry.CommandContext) {
 	var outOfHoursWarning *string
 	var outOfHoursColour *int
 	if settings.ContextMenuPanel != nil {
-		p, err := dbclient.Client.Panel.GetById(ctx, *settings.ContextMenuPanel)
+		p, err := ctx.Worker().Services.Database.Panel.GetById(ctx, *settings.ContextMenuPanel)
 		if err != nil {
 			ctx.HandleError(err)
 			return
@@ -115,7 +114,7 @@ func (StartTicketCommand) Execute(ctx registry.CommandContext) {
 			}
 
 			sendMovedMessage(ctx, ticket, msg)
-			if err := dbclient.Client.TicketMembers.Add(ctx, ticket.GuildId, ticket.Id, msg.Author.Id); err != nil {
+			if err := ctx.Worker().Services.Database.TicketMembers.Add(ctx, ticket.GuildId, ticket.Id, msg.Author.Id); err != nil {
 				ctx.HandleError(err)
 				return
 			}
@@ -176,7 +175,7 @@ func addMessageSender(ctx registry.CommandContext, ticket database.Ticket, msg m
 		}
 
 		// Build permissions
-		additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ctx.GuildId())
+		additionalPermissions, err := ctx.Worker().Services.Database.TicketPermissions.Get(ctx, ctx.GuildId())
 		if err != nil {
 			return err
 		}
@@ -15,7 +15,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -40,7 +39,7 @@ func (c UnclaimCommand) GetExecutor() interface{} {
 
 func (UnclaimCommand) Execute(ctx *context.SlashCommandContext) {
 	// Get ticket struct
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
+	ticket, err := ctx.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -59,7 +58,7 @@ func (UnclaimCommand) Execute(ctx *context.SlashCommandContext) {
 	}
 
 	// Get who claimed
-	whoClaimed, err := dbclient.Client.TicketClaims.Get(ctx, ctx.GuildId(), ticket.Id)
+	whoClaimed, err := ctx.Worker().Services.Database.TicketClaims.Get(ctx, ctx.GuildId(), ticket.Id)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -82,7 +81,7 @@ func (UnclaimCommand) Execute(ctx *context.SlashCommandContext) {
 	}
 
 	// Set to unclaimed in DB
-	if err := dbclient.Client.TicketClaims.Delete(ctx, ctx.GuildId(), ticket.Id); err != nil {
+	if err := ctx.Worker().Services.Database.TicketClaims.Delete(ctx, ctx.GuildId(), ticket.Id); err != nil {
 		ctx.HandleError(err)
 		return
 	}
@@ -91,7 +90,7 @@ func (UnclaimCommand) Execute(ctx *context.SlashCommandContext) {
 	var panel *database.Panel
 	if ticket.PanelId != nil {
 		var derefPanel database.Panel
-		derefPanel, err = dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		derefPanel, err = ctx.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 
 		if derefPanel.PanelId != 0 {
 			panel = &derefPanel
@@ -115,7 +114,7 @@ func (UnclaimCommand) Execute(ctx *context.SlashCommandContext) {
 	}
 
 	// Handle claimer access based on SwitchPanelClaimBehavior setting
-	claimSettings, err := dbclient.Client.ClaimSettings.Get(ctx, ctx.GuildId())
+	claimSettings, err := ctx.Worker().Services.Database.ClaimSettings.Get(ctx, ctx.GuildId())
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -0,0 +1,62 @@
+package tickets
+
+import (
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/constants"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+type ViewUserTicketsCommand struct {
+}
+
+func (ViewUserTicketsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "View user's tickets",
+		Type:             interaction.ApplicationCommandTypeUser,
+		PermissionLevel:  permcache.Support, // Customisable level
+		Category:         command.Tickets,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+		Timeout:          constants.TimeoutOpenTicket,
+	}
+}
+
+func (c ViewUserTicketsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ViewUserTicketsCommand) Execute(ctx registry.CommandContext) {
+	interaction, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	if !logic.CheckUserContextMenuPermission(ctx, func(config dbclient.UserContextMenuConfig) int {
+		return config.ViewTicketsLevel
+	}) {
+		return
+	}
+
+	openTickets, err := ctx.Worker().Services.Database.Tickets.GetGuildOpenTickets(ctx, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	summary := logic.BuildUserTicketSummary(ctx, ctx.Worker(), ctx.GuildId(), interaction.Interaction.Data.TargetId, openTickets)
+
+	if _, err := ctx.ReplyWith(command.NewEphemeralMessageResponseWithComponents([]component.Component{
+		utils.BuildContainerRaw(ctx, customisation.Green, ctx.GetMessage(i18n.TitleUserTickets), summary),
+	})); err != nil {
+		ctx.HandleError(err)
+	}
+}
@@ -0,0 +1,313 @@
+package manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CommandDiff describes how the commands registered with Discord differ from the local registry
+type CommandDiff struct {
+	Added   []string
+	Removed []string
+	// Changed maps command names to the paths of the fields which differ, such as options.user.required
+	Changed map[string][]string
+}
+
+var (
+	commandFields = []string{"name", "description", "type", "options", "contexts", "integration_types", "name_localizations", "description_localizations", "default_member_permissions", "nsfw"}
+	optionFields  = []string{"type", "name", "description", "required", "autocomplete", "choices", "options", "channel_types", "min_value", "max_value", "min_length", "max_length", "name_localizations", "description_localizations"}
+	choiceFields  = []string{"name", "value", "name_localizations"}
+)
+
+func (d CommandDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+func (d CommandDiff) String() string {
+	if !d.HasChanges() {
+		return "No changes"
+	}
+
+	var sb strings.Builder
+	for _, name := range d.Added {
+		sb.WriteString(fmt.Sprintf("+ %s\n", name))
+	}
+
+	for _, name := range d.Removed {
+		sb.WriteString(fmt.Sprintf("- %s\n", name))
+	}
+
+	names := make([]string, 0, len(d.Changed))
+	for name := range d.Changed {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("~ %s\n", name))
+		for _, path := range d.Changed[name] {
+			sb.WriteString(fmt.Sprintf("    %s\n", path))
+		}
+	}
+
+	return sb.String()
+}
+
+// DiffCommands compares the local command payload with the commands returned by Discord. Both are compared in their
+// JSON form, so any type which marshals to the application command schema can be passed. Fields which Discord omits
+// when unset, such as required: false, are treated as equal to their zero value.
+func DiffCommands(local, remote any) (CommandDiff, error) {
+	localCommands, err := normaliseCommands(local)
+	if err != nil {
+		return CommandDiff{}, err
+	}
+
+	remoteCommands, err := normaliseCommands(remote)
+	if err != nil {
+		return CommandDiff{}, err
+	}
+
+	diff := CommandDiff{
+		Changed: make(map[string][]string),
+	}
+
+	for name, localCommand := range localCommands {
+		remoteCommand, ok := remoteCommands[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+			continue
+		}
+
+		if paths := diffValues("", localCommand, remoteCommand); len(paths) > 0 {
+			diff.Changed[name] = paths
+		}
+	}
+
+	for name := range remoteCommands {
+		if _, ok := localCommands[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+
+	if len(diff.Changed) == 0 {
+		diff.Changed = nil
+	}
+
+	return diff, nil
+}
+
+// HashCommands returns a hash of the command payload which is independent of command ordering and omitted fields, so
+// that the hash of the local registry can be compared with the hash stored when commands were last deployed
+func HashCommands(commands any) (string, error) {
+	normalised, err := normaliseCommands(commands)
+	if err != nil {
+		return "", err
+	}
+
+	// encoding/json sorts map keys, so the encoding is canonical
+	encoded, err := json.Marshal(normalised)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func normaliseCommands(commands any) (map[string]map[string]any, error) {
+	encoded, err := json.Marshal(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded []map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+
+	normalised := make(map[string]map[string]any, len(decoded))
+	for _, command := range decoded {
+		// Discord omits the type for chat input commands in some responses
+		if _, ok := command["type"]; !ok {
+			command["type"] = float64(1)
+		}
+
+		command = filterFields(command, commandFields)
+		name, _ := command["name"].(string)
+		normalised[name] = command
+	}
+
+	return normalised, nil
+}
+
+func filterFields(obj map[string]any, fields []string) map[string]any {
+	filtered := make(map[string]any)
+	for _, field := range fields {
+		value, ok := obj[field]
+		if !ok || isZero(value) {
+			continue
+		}
+
+		switch field {
+		case "options":
+			value = filterList(value, optionFields)
+		case "choices":
+			value = filterList(value, choiceFields)
+		}
+
+		filtered[field] = value
+	}
+
+	return filtered
+}
+
+func filterList(value any, fields []string) any {
+	list, ok := value.([]any)
+	if !ok {
+		return value
+	}
+
+	filtered := make([]any, len(list))
+	for i, item := range list {
+		if obj, ok := item.(map[string]any); ok {
+			filtered[i] = filterFields(obj, fields)
+		} else {
+			filtered[i] = item
+		}
+	}
+
+	return filtered
+}
+
+func isZero(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// diffValues returns the paths at which the two normalised values differ. Lists of options and choices are matched by
+// name, so that a changed option is reported by its name rather than its index.
+func diffValues(path string, local, remote any) []string {
+	localObj, localIsObj := local.(map[string]any)
+	remoteObj, remoteIsObj := remote.(map[string]any)
+	if localIsObj && remoteIsObj {
+		keys := make(map[string]struct{})
+		for key := range localObj {
+			keys[key] = struct{}{}
+		}
+
+		for key := range remoteObj {
+			keys[key] = struct{}{}
+		}
+
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+
+		sort.Strings(sorted)
+
+		var paths []string
+		for _, key := range sorted {
+			paths = append(paths, diffValues(joinPath(path, key), localObj[key], remoteObj[key])...)
+		}
+
+		return paths
+	}
+
+	localList, localIsList := local.([]any)
+	remoteList, remoteIsList := remote.([]any)
+	if (localIsList || local == nil) && (remoteIsList || remote == nil) && (isNamedList(localList) || isNamedList(remoteList)) {
+		return diffNamedLists(path, localList, remoteList)
+	}
+
+	if reflect.DeepEqual(local, remote) {
+		return nil
+	}
+
+	return []string{path}
+}
+
+func diffNamedLists(path string, local, remote []any) []string {
+	localByName, localOrder := indexByName(local)
+	remoteByName, remoteOrder := indexByName(remote)
+
+	var paths []string
+	for _, name := range localOrder {
+		if _, ok := remoteByName[name]; !ok {
+			paths = append(paths, fmt.Sprintf("%s (added)", joinPath(path, name)))
+			continue
+		}
+
+		paths = append(paths, diffValues(joinPath(path, name), localByName[name], remoteByName[name])...)
+	}
+
+	for _, name := range remoteOrder {
+		if _, ok := localByName[name]; !ok {
+			paths = append(paths, fmt.Sprintf("%s (removed)", joinPath(path, name)))
+		}
+	}
+
+	// Discord displays options in the order they are registered
+	if len(paths) == 0 && !reflect.DeepEqual(localOrder, remoteOrder) {
+		paths = append(paths, fmt.Sprintf("%s (order)", path))
+	}
+
+	return paths
+}
+
+func isNamedList(list []any) bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	obj, ok := list[0].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	_, ok = obj["name"].(string)
+	return ok
+}
+
+func indexByName(list []any) (map[string]any, []string) {
+	byName := make(map[string]any, len(list))
+	order := make([]string, 0, len(list))
+	for _, item := range list {
+		obj, _ := item.(map[string]any)
+		name, _ := obj["name"].(string)
+
+		byName[name] = item
+		order = append(order, name)
+	}
+
+	return byName, order
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
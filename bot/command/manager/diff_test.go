@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/stretchr/testify/require"
+)
+
+var testCommands = []CommandData{
+	{
+		Name:        "add",
+		Description: "Add a user to the ticket",
+		Type:        interaction.ApplicationCommandTypeChatInput,
+		Contexts:    []interaction.InteractionContextType{interaction.InteractionContextGuild},
+		Options: []CommandOption{
+			{Type: interaction.OptionTypeUser, Name: "user", Description: "User to add", Required: true},
+			{Type: interaction.OptionTypeString, Name: "reason", Description: "Reason"},
+		},
+	},
+	{
+		Name:        "close",
+		Description: "Close the ticket",
+		Type:        interaction.ApplicationCommandTypeChatInput,
+		Contexts:    []interaction.InteractionContextType{interaction.InteractionContextGuild},
+	},
+}
+
+// remote returns the commands as Discord would: with IDs and versions, and without fields which are unset
+func remote(t *testing.T, modify func([]map[string]any)) []json.RawMessage {
+	encoded, err := json.Marshal(testCommands)
+	require.NoError(t, err)
+
+	var commands []map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &commands))
+
+	for _, command := range commands {
+		command["id"] = "1"
+		command["version"] = "2"
+
+		options, _ := command["options"].([]any)
+		for _, option := range options {
+			option := option.(map[string]any)
+			if option["required"] == false {
+				delete(option, "required")
+			}
+
+			delete(option, "autocomplete")
+			delete(option, "default")
+		}
+	}
+
+	if modify != nil {
+		modify(commands)
+	}
+
+	raw := make([]json.RawMessage, len(commands))
+	for i, command := range commands {
+		raw[i], err = json.Marshal(command)
+		require.NoError(t, err)
+	}
+
+	return raw
+}
+
+func TestDiffCommandsUnchanged(t *testing.T) {
+	diff, err := DiffCommands(testCommands, remote(t, nil))
+	require.NoError(t, err)
+	require.False(t, diff.HasChanges(), diff.String())
+}
+
+func TestDiffCommandsChanges(t *testing.T) {
+	diff, err := DiffCommands(testCommands, remote(t, func(commands []map[string]any) {
+		add := commands[0]
+		add["name_localizations"] = map[string]any{"fr": "ajouter"}
+		add["contexts"] = []any{float64(0), float64(1)}
+
+		options := add["options"].([]any)
+		options[0].(map[string]any)["required"] = false
+		add["options"] = append(options, map[string]any{"type": float64(3), "name": "extra", "description": "Extra"})
+
+		commands[1] = map[string]any{"name": "old", "description": "Removed command", "type": float64(1)}
+	}))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"close"}, diff.Added)
+	require.Equal(t, []string{"old"}, diff.Removed)
+	require.Equal(t, []string{
+		"contexts",
+		"name_localizations",
+		"options.user.required",
+		"options.extra (removed)",
+	}, diff.Changed["add"])
+}
+
+func TestDiffCommandsOptionOrder(t *testing.T) {
+	diff, err := DiffCommands(testCommands, remote(t, func(commands []map[string]any) {
+		options := commands[0]["options"].([]any)
+		options[0], options[1] = options[1], options[0]
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"options (order)"}, diff.Changed["add"])
+}
+
+func TestHashCommands(t *testing.T) {
+	hash, err := HashCommands(testCommands)
+	require.NoError(t, err)
+
+	reordered := []CommandData{testCommands[1], testCommands[0]}
+	reorderedHash, err := HashCommands(reordered)
+	require.NoError(t, err)
+	require.Equal(t, hash, reorderedHash)
+
+	remoteHash, err := HashCommands(remote(t, nil))
+	require.NoError(t, err)
+	require.Equal(t, hash, remoteHash)
+
+	changed := append([]CommandData(nil), testCommands...)
+	changed[1].Description = "Close this ticket"
+	changedHash, err := HashCommands(changed)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changedHash)
+}
@@ -0,0 +1,252 @@
+package manager
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// Localizations for names, and for argument descriptions (which are declared in English), are read from keys derived
+// from the command path, e.g. slash.blacklist.add.name and slash.blacklist.add.options.user.description. Command
+// descriptions use the MessageId from the command's properties.
+
+const (
+	maxNameLength        = 32
+	maxDescriptionLength = 100
+)
+
+var chatInputNamePattern = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+func commandNameId(path []string) i18n.MessageId {
+	return i18n.MessageId(fmt.Sprintf("slash.%s.name", strings.Join(path, ".")))
+}
+
+// contextMenuNameId returns the key for a context menu command name, which may contain spaces and punctuation, e.g.
+// slash.view_user_s_tickets.name
+func contextMenuNameId(name string) i18n.MessageId {
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return '_'
+	}, name)
+
+	return commandNameId([]string{key})
+}
+
+func argumentNameId(path []string, argument string) i18n.MessageId {
+	return i18n.MessageId(fmt.Sprintf("slash.%s.options.%s.name", strings.Join(path, "."), argument))
+}
+
+func argumentDescriptionId(path []string, argument string) i18n.MessageId {
+	return i18n.MessageId(fmt.Sprintf("slash.%s.options.%s.description", strings.Join(path, "."), argument))
+}
+
+// localize returns the translations of the message, keyed by Discord locale. Locales which have not translated the
+// message, or whose translation is rejected by valid, are omitted so that Discord falls back to the default text.
+func localize(id i18n.MessageId, english string, valid func(string) bool) map[string]string {
+	var localizations map[string]string
+	for _, locale := range i18n.Locales {
+		if locale.DiscordLocale == nil {
+			continue
+		}
+
+		value, ok := i18n.GetTranslation(locale, id, english)
+		if !ok || !valid(value) {
+			continue
+		}
+
+		if localizations == nil {
+			localizations = make(map[string]string)
+		}
+
+		localizations[*locale.DiscordLocale] = value
+	}
+
+	return localizations
+}
+
+func isValidChatInputName(name string) bool {
+	return chatInputNamePattern.MatchString(name) && strings.ToLower(name) == name
+}
+
+func isValidContextMenuName(name string) bool {
+	length := utf8.RuneCountInString(name)
+	return length > 0 && length <= maxNameLength
+}
+
+func isValidDescription(description string) bool {
+	length := utf8.RuneCountInString(description)
+	return length > 0 && length <= maxDescriptionLength
+}
+
+// MissingDescriptions returns the description MessageIds of chat input commands and subcommands which are not in the
+// English locale. Their descriptions would otherwise be registered as the missing translation error, as English is the
+// default text that every other locale falls back to.
+func (cm *CommandManager) MissingDescriptions() []i18n.MessageId {
+	var missing []i18n.MessageId
+	for _, cmd := range cm.GetCommands() {
+		if properties := cmd.Properties(); properties.Type == interaction.ApplicationCommandTypeChatInput {
+			missing = appendMissingDescriptions(missing, cmd)
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i] < missing[j]
+	})
+
+	return missing
+}
+
+func appendMissingDescriptions(missing []i18n.MessageId, cmd registry.Command) []i18n.MessageId {
+	properties := cmd.Properties()
+	if properties.MessageOnly {
+		return missing
+	}
+
+	if _, ok := i18n.LocaleEnglish.Messages[properties.Description]; !ok {
+		missing = append(missing, properties.Description)
+	}
+
+	for _, child := range properties.Children {
+		missing = appendMissingDescriptions(missing, child)
+	}
+
+	return missing
+}
+
+// enforceNameCoverage removes a locale's name localizations from the command unless every name in the command has been
+// translated, and translated names do not collide. Names in a partially translated locale would otherwise appear as a
+// mix of languages, and a collision would cause Discord to reject the payload.
+func enforceNameCoverage(command *CommandData) {
+	locales := make(map[string]struct{})
+	for locale := range command.NameLocalizations {
+		locales[locale] = struct{}{}
+	}
+
+	collectNameLocales(command.Options, locales)
+
+	for locale := range locales {
+		_, ok := command.NameLocalizations[locale]
+		if ok && command.Type == interaction.ApplicationCommandTypeChatInput {
+			ok = isNameCoverageComplete(command.Options, locale)
+		}
+
+		if !ok {
+			delete(command.NameLocalizations, locale)
+			removeNameLocale(command.Options, locale)
+		}
+	}
+
+	if len(command.NameLocalizations) == 0 {
+		command.NameLocalizations = nil
+	}
+}
+
+func collectNameLocales(options []CommandOption, locales map[string]struct{}) {
+	for _, option := range options {
+		for locale := range option.NameLocalizations {
+			locales[locale] = struct{}{}
+		}
+
+		collectNameLocales(option.Options, locales)
+	}
+}
+
+func isNameCoverageComplete(options []CommandOption, locale string) bool {
+	seen := make(map[string]struct{}, len(options))
+	for _, option := range options {
+		name, ok := option.NameLocalizations[locale]
+		if !ok {
+			return false
+		}
+
+		if _, duplicate := seen[name]; duplicate {
+			return false
+		}
+
+		seen[name] = struct{}{}
+
+		if !isNameCoverageComplete(option.Options, locale) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func removeNameLocale(options []CommandOption, locale string) {
+	for i := range options {
+		delete(options[i].NameLocalizations, locale)
+		if len(options[i].NameLocalizations) == 0 {
+			options[i].NameLocalizations = nil
+		}
+
+		removeNameLocale(options[i].Options, locale)
+	}
+}
+
+// LocalizationCoverage counts how many of the names and descriptions in a command payload are localized for a locale
+type LocalizationCoverage struct {
+	Locale            string
+	Names             int
+	TotalNames        int
+	Descriptions      int
+	TotalDescriptions int
+}
+
+// GetLocalizationCoverage returns the coverage of every Discord locale which has a translation, sorted by locale
+func GetLocalizationCoverage(commands []CommandData) []LocalizationCoverage {
+	var coverage []LocalizationCoverage
+	for _, locale := range i18n.Locales {
+		if locale.DiscordLocale == nil || locale == i18n.LocaleEnglish {
+			continue
+		}
+
+		current := LocalizationCoverage{
+			Locale: *locale.DiscordLocale,
+		}
+
+		for _, command := range commands {
+			current.count(command.NameLocalizations, command.DescriptionLocalizations, command.Description != "")
+			current.countOptions(command.Options)
+		}
+
+		coverage = append(coverage, current)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].Locale < coverage[j].Locale
+	})
+
+	return coverage
+}
+
+func (c *LocalizationCoverage) countOptions(options []CommandOption) {
+	for _, option := range options {
+		c.count(option.NameLocalizations, option.DescriptionLocalizations, true)
+		c.countOptions(option.Options)
+	}
+}
+
+func (c *LocalizationCoverage) count(names, descriptions map[string]string, hasDescription bool) {
+	c.TotalNames++
+	if _, ok := names[c.Locale]; ok {
+		c.Names++
+	}
+
+	if hasDescription {
+		c.TotalDescriptions++
+		if _, ok := descriptions[c.Locale]; ok {
+			c.Descriptions++
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+type testCommand struct {
+	properties registry.Properties
+}
+
+func (c testCommand) GetExecutor() interface{} {
+	return nil
+}
+
+func (c testCommand) Properties() registry.Properties {
+	return c.properties
+}
+
+func withMessages(t *testing.T, messages map[string]map[i18n.MessageId]string) {
+	i18n.SeedIndices()
+
+	for code, localeMessages := range messages {
+		locale := i18n.MappedByIsoShortCode[code]
+		previous := locale.Messages
+		locale.Messages = localeMessages
+
+		t.Cleanup(func() {
+			locale.Messages = previous
+		})
+	}
+}
+
+func newTestManager() *CommandManager {
+	return &CommandManager{
+		registry: registry.Registry{
+			"add": testCommand{properties: registry.Properties{
+				Name:        "add",
+				Description: i18n.HelpAdd,
+				Type:        interaction.ApplicationCommandTypeChatInput,
+				Arguments: command.Arguments(
+					command.NewRequiredArgument("user", "User to add", interaction.OptionTypeUser, i18n.MessageInvalidArgument),
+					command.NewOptionalArgument("reason", "Reason", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+				),
+			}},
+			"Start Ticket": testCommand{properties: registry.Properties{
+				Name: "Start Ticket",
+				Type: interaction.ApplicationCommandTypeMessage,
+			}},
+		},
+	}
+}
+
+func findCommand(t *testing.T, commands []CommandData, name string) CommandData {
+	for _, command := range commands {
+		if command.Name == name {
+			return command
+		}
+	}
+
+	t.Fatalf("command %s not found", name)
+	return CommandData{}
+}
+
+func TestBuildCreatePayloadLocalizations(t *testing.T) {
+	withMessages(t, map[string]map[i18n.MessageId]string{
+		"en": {i18n.HelpAdd: "Add a user to the ticket"},
+		"fr": {
+			i18n.HelpAdd:                         "Ajouter un utilisateur au ticket",
+			"slash.add.name":                     "ajouter",
+			"slash.add.options.user.name":        "utilisateur",
+			"slash.add.options.user.description": "Utilisateur à ajouter",
+			"slash.add.options.reason.name":      "raison",
+			"slash.start_ticket.name":            "Ouvrir un ticket",
+		},
+		// Partially translated: the option names are missing, so no names should be localized
+		"de": {
+			i18n.HelpAdd:                  "Benutzer zum Ticket hinzufügen",
+			"slash.add.name":              "hinzufügen",
+			"slash.add.options.user.name": "benutzer",
+		},
+		// Names must be lowercase, and may not collide
+		"es": {
+			"slash.add.name":                "Añadir",
+			"slash.add.options.user.name":   "usuario",
+			"slash.add.options.reason.name": "usuario",
+		},
+	})
+
+	data, _ := newTestManager().BuildCreatePayload(false, nil)
+
+	add := findCommand(t, data, "add")
+	require.Equal(t, map[string]string{"fr": "ajouter"}, add.NameLocalizations)
+	require.Equal(t, map[string]string{
+		"fr": "Ajouter un utilisateur au ticket",
+		"de": "Benutzer zum Ticket hinzufügen",
+	}, add.DescriptionLocalizations)
+
+	require.Equal(t, "user", add.Options[0].Name)
+	require.Equal(t, map[string]string{"fr": "utilisateur"}, add.Options[0].NameLocalizations)
+	require.Equal(t, map[string]string{"fr": "Utilisateur à ajouter"}, add.Options[0].DescriptionLocalizations)
+	require.Equal(t, map[string]string{"fr": "raison"}, add.Options[1].NameLocalizations)
+	require.Nil(t, add.Options[1].DescriptionLocalizations)
+
+	startTicket := findCommand(t, data, "Start Ticket")
+	require.Equal(t, map[string]string{"fr": "Ouvrir un ticket"}, startTicket.NameLocalizations)
+	require.Nil(t, startTicket.DescriptionLocalizations)
+
+	for _, coverage := range GetLocalizationCoverage(data) {
+		if coverage.Locale == "fr" {
+			require.Equal(t, LocalizationCoverage{Locale: "fr", Names: 4, TotalNames: 4, Descriptions: 2, TotalDescriptions: 3}, coverage)
+		}
+	}
+}
+
+func TestMissingDescriptions(t *testing.T) {
+	withMessages(t, map[string]map[i18n.MessageId]string{
+		"en": {i18n.HelpAdd: "Add a user to the ticket"},
+	})
+
+	cm := newTestManager()
+	require.Empty(t, cm.MissingDescriptions())
+
+	// Context menus have no description, so only chat input commands and their subcommands are checked
+	cm.registry["panel"] = testCommand{properties: registry.Properties{
+		Name:        "panel",
+		Description: i18n.HelpPanel,
+		Type:        interaction.ApplicationCommandTypeChatInput,
+		Children: []registry.Command{
+			testCommand{properties: registry.Properties{
+				Name:        "setup",
+				Description: i18n.HelpSetup,
+				Type:        interaction.ApplicationCommandTypeChatInput,
+			}},
+		},
+	}}
+
+	require.Equal(t, []i18n.MessageId{i18n.HelpPanel, i18n.HelpSetup}, cm.MissingDescriptions())
+}
@@ -2,7 +2,6 @@ package manager
 
 import (
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
-	"github.com/TicketsBot-cloud/gdl/rest"
 	"github.com/TicketsBot-cloud/worker/bot/command/impl/admin"
 	"github.com/TicketsBot-cloud/worker/bot/command/impl/general"
 	"github.com/TicketsBot-cloud/worker/bot/command/impl/settings"
@@ -39,6 +38,11 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["addsupport"] = settings.AddSupportCommand{}
 	cm.registry["autoclose"] = settings.AutoCloseCommand{}
 	cm.registry["blacklist"] = settings.BlacklistCommand{}
+	cm.registry["closedcategory"] = settings.ClosedCategoryCommand{}
+	cm.registry["closereasons"] = settings.CloseReasonsCommand{}
+	cm.registry["Blacklist from tickets"] = settings.BlacklistUserCommand{}
+	cm.registry["contextmenus"] = settings.ContextMenusCommand{}
+	cm.registry["feedbackfollowup"] = settings.FeedbackFollowUpCommand{}
 	cm.registry["language"] = settings.LanguageCommand{}
 	cm.registry["panel"] = settings.PanelCommand{}
 	cm.registry["premium"] = settings.PremiumCommand{}
@@ -46,6 +50,10 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["removesupport"] = settings.RemoveSupportCommand{}
 	cm.registry["premium"] = settings.PremiumCommand{}
 	cm.registry["setup"] = setup.SetupCommand{}
+	cm.registry["spamclose"] = settings.SpamCloseCommand{}
+	cm.registry["tickethistory"] = settings.TicketHistoryCommand{}
+	cm.registry["transcriptexport"] = settings.TranscriptExportCommand{}
+	cm.registry["notestranscript"] = settings.NotesTranscriptCommand{}
 	cm.registry["viewstaff"] = settings.ViewStaffCommand{}
 
 	cm.registry["stats"] = statistics.StatsCommand{}
@@ -54,13 +62,16 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["tag"] = tags.TagCommand{}
 
 	cm.registry["add"] = tickets.AddCommand{}
+	cm.registry["bulk"] = tickets.BulkCommand{}
 	cm.registry["claim"] = tickets.ClaimCommand{}
 	cm.registry["close"] = tickets.CloseCommand{}
 	cm.registry["edit"] = tickets.EditCommand{}
 	cm.registry["closerequest"] = tickets.CloseRequestCommand{}
+	cm.registry["history"] = tickets.HistoryCommand{}
 	cm.registry["notes"] = tickets.NotesCommand{}
 	cm.registry["on-call"] = tickets.OnCallCommand{}
 	cm.registry["open"] = tickets.OpenCommand{}
+	cm.registry["Open ticket for user"] = tickets.OpenForUserCommand{}
 	cm.registry["Start Ticket"] = tickets.StartTicketCommand{}
 	cm.registry["remove"] = tickets.RemoveCommand{}
 	cm.registry["rename"] = tickets.RenameCommand{}
@@ -68,6 +79,7 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["switchpanel"] = tickets.SwitchPanelCommand{}
 	cm.registry["transfer"] = tickets.TransferCommand{}
 	cm.registry["unclaim"] = tickets.UnclaimCommand{}
+	cm.registry["View user's tickets"] = tickets.ViewUserTicketsCommand{}
 }
 
 func (cm *CommandManager) RunSetupFuncs() {
@@ -78,7 +90,8 @@ func (cm *CommandManager) RunSetupFuncs() {
 	}
 }
 
-func (cm *CommandManager) BuildCreatePayload(isWhitelabel bool, adminCommandGuildId *uint64) (data []rest.CreateCommandData, adminCommands []rest.CreateCommandData) {
+// BuildCreatePayload builds the commands to register with Discord, including localizations from the loaded locales
+func (cm *CommandManager) BuildCreatePayload(isWhitelabel bool, adminCommandGuildId *uint64) (data []CommandData, adminCommands []CommandData) {
 	for _, cmd := range cm.GetCommands() {
 		properties := cmd.Properties()
 
@@ -86,11 +99,16 @@ func (cm *CommandManager) BuildCreatePayload(isWhitelabel bool, adminCommandGuil
 			continue
 		}
 
-		option := buildOption(cmd)
+		option := buildOption(cmd, nil)
 
 		var description string
+		var descriptionLocalizations map[string]string
 		if properties.Type == interaction.ApplicationCommandTypeChatInput {
 			description = option.Description
+			descriptionLocalizations = option.DescriptionLocalizations
+		} else {
+			// Context menu commands have no description, and their names may contain spaces and capitals
+			option.NameLocalizations = localize(contextMenuNameId(properties.Name), properties.Name, isValidContextMenuName)
 		}
 
 		if properties.MainBotOnly && isWhitelabel {
@@ -104,14 +122,18 @@ func (cm *CommandManager) BuildCreatePayload(isWhitelabel bool, adminCommandGuil
 			contexts = []interaction.InteractionContextType{interaction.InteractionContextGuild}
 		}
 
-		cmdData := rest.CreateCommandData{
-			Name:        option.Name,
-			Description: description,
-			Options:     option.Options,
-			Type:        properties.Type,
-			Contexts:    contexts,
+		cmdData := CommandData{
+			Name:                     option.Name,
+			NameLocalizations:        option.NameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Options:                  option.Options,
+			Type:                     properties.Type,
+			Contexts:                 contexts,
 		}
 
+		enforceNameCoverage(&cmdData)
+
 		if properties.HelperOnly || properties.AdminOnly {
 			adminCommands = append(adminCommands, cmdData)
 		} else {
@@ -122,19 +144,20 @@ func (cm *CommandManager) BuildCreatePayload(isWhitelabel bool, adminCommandGuil
 	return data, adminCommands
 }
 
-func buildOption(cmd registry.Command) interaction.ApplicationCommandOption {
+func buildOption(cmd registry.Command, parent []string) CommandOption {
 	properties := cmd.Properties()
+	path := append(append([]string(nil), parent...), properties.Name)
 
 	// Required args must come before optional args
-	var required []interaction.ApplicationCommandOption
-	var optional []interaction.ApplicationCommandOption
+	var required []CommandOption
+	var optional []CommandOption
 
 	for _, child := range properties.Children {
 		if child.Properties().MessageOnly {
 			continue
 		}
 
-		option := buildOption(child)
+		option := buildOption(child, path)
 
 		if option.Required {
 			required = append(required, option)
@@ -144,15 +167,17 @@ func buildOption(cmd registry.Command) interaction.ApplicationCommandOption {
 	}
 
 	for _, argument := range properties.Arguments {
-		option := interaction.ApplicationCommandOption{
-			Type:         argument.Type,
-			Name:         argument.Name,
-			Description:  argument.Description,
-			Default:      false,
-			Required:     argument.Required,
-			Choices:      nil,
-			Autocomplete: argument.AutoCompleteHandler != nil,
-			Options:      nil,
+		option := CommandOption{
+			Type:                     argument.Type,
+			Name:                     argument.Name,
+			NameLocalizations:        localize(argumentNameId(path, argument.Name), argument.Name, isValidChatInputName),
+			Description:              argument.Description,
+			DescriptionLocalizations: localize(argumentDescriptionId(path, argument.Name), argument.Description, isValidDescription),
+			Default:                  false,
+			Required:                 argument.Required,
+			Choices:                  nil,
+			Autocomplete:             argument.AutoCompleteHandler != nil,
+			Options:                  nil,
 		}
 
 		if option.Required {
@@ -170,13 +195,15 @@ func buildOption(cmd registry.Command) interaction.ApplicationCommandOption {
 		optionType = interaction.OptionTypeSubCommandGroup
 	}
 
-	return interaction.ApplicationCommandOption{
-		Type:        optionType,
-		Name:        properties.Name,
-		Description: i18n.GetMessage(i18n.LocaleEnglish, properties.Description),
-		Default:     false,
-		Required:    false,
-		Choices:     nil,
-		Options:     options,
+	return CommandOption{
+		Type:                     optionType,
+		Name:                     properties.Name,
+		NameLocalizations:        localize(commandNameId(path), properties.Name, isValidChatInputName),
+		Description:              i18n.GetMessage(i18n.LocaleEnglish, properties.Description),
+		DescriptionLocalizations: localize(properties.Description, "", isValidDescription),
+		Default:                  false,
+		Required:                 false,
+		Choices:                  nil,
+		Options:                  options,
 	}
 }
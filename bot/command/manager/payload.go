@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+)
+
+// CommandData mirrors rest.CreateCommandData, with the addition of localizations, which gdl does not support
+type CommandData struct {
+	Id                       uint64                               `json:"id,omitempty"` // Optional: Use to rename without changing ID
+	Name                     string                               `json:"name"`
+	NameLocalizations        map[string]string                    `json:"name_localizations,omitempty"`
+	Description              string                               `json:"description"`
+	DescriptionLocalizations map[string]string                    `json:"description_localizations,omitempty"`
+	Options                  []CommandOption                      `json:"options"`
+	Type                     interaction.ApplicationCommandType   `json:"type"`
+	Contexts                 []interaction.InteractionContextType `json:"contexts,omitempty"`
+}
+
+// CommandOption mirrors interaction.ApplicationCommandOption, with the addition of localizations
+type CommandOption struct {
+	Type                     interaction.ApplicationCommandOptionType     `json:"type"`
+	Name                     string                                       `json:"name"`
+	NameLocalizations        map[string]string                            `json:"name_localizations,omitempty"`
+	Description              string                                       `json:"description"`
+	DescriptionLocalizations map[string]string                            `json:"description_localizations,omitempty"`
+	Default                  bool                                         `json:"default"`
+	Required                 bool                                         `json:"required"`
+	Choices                  []interaction.ApplicationCommandOptionChoice `json:"choices,omitempty"`
+	Autocomplete             bool                                         `json:"autocomplete"`
+	Options                  []CommandOption                              `json:"options,omitempty"`
+	ChannelTypes             []channel.ChannelType                        `json:"channel_types,omitempty"`
+}
+
+// CommandDataFromRemote converts a command fetched from Discord into the payload type, for merging with the registry
+func CommandDataFromRemote(command interaction.ApplicationCommand) CommandData {
+	return CommandData{
+		Id:          command.Id,
+		Name:        command.Name,
+		Description: command.Description,
+		Options:     convertOptions(command.Options),
+		Type:        interaction.ApplicationCommandTypeChatInput,
+		Contexts:    command.Contexts,
+	}
+}
+
+func convertOptions(options []interaction.ApplicationCommandOption) []CommandOption {
+	if options == nil {
+		return nil
+	}
+
+	converted := make([]CommandOption, len(options))
+	for i, option := range options {
+		converted[i] = CommandOption{
+			Type:         option.Type,
+			Name:         option.Name,
+			Description:  option.Description,
+			Default:      option.Default,
+			Required:     option.Required,
+			Choices:      option.Choices,
+			Autocomplete: option.Autocomplete,
+			Options:      convertOptions(option.Options),
+			ChannelTypes: option.ChannelTypes,
+		}
+	}
+
+	return converted
+}
@@ -0,0 +1,29 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+)
+
+// RegistryHash returns the hash of the global commands which would be registered for the bot
+func (cm *CommandManager) RegistryHash(isWhitelabel bool) (string, error) {
+	data, _ := cm.BuildCreatePayload(isWhitelabel, nil)
+	return HashCommands(data)
+}
+
+// CheckDeployedHash reports whether the global commands last deployed for the application, as recorded by
+// cmd/registercommands, match the local registry. If no hash has been recorded, found is false.
+func (cm *CommandManager) CheckDeployedHash(ctx context.Context, redisClient *redis.Client, applicationId uint64, isWhitelabel bool) (inSync, found bool, err error) {
+	deployed, found, err := redisClient.GetCommandHash(ctx, applicationId)
+	if err != nil || !found {
+		return false, found, err
+	}
+
+	local, err := cm.RegistryHash(isWhitelabel)
+	if err != nil {
+		return false, true, err
+	}
+
+	return local == deployed, true, nil
+}
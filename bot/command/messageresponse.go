@@ -6,6 +6,7 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
 	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
 )
 
 type MessageResponse struct {
@@ -15,6 +16,7 @@ type MessageResponse struct {
 	AllowedMentions message.AllowedMention `json:"allowed_mentions,omitempty"`
 	Flags           uint                   `json:"flags"`
 	Components      []component.Component  `json:"components,omitempty"`
+	Attachments     []request.Attachment   `json:"-"`
 }
 
 func NewTextMessageResponse(content string) MessageResponse {
@@ -91,6 +93,7 @@ func (r *MessageResponse) IntoCreateMessageData() rest.CreateMessageData {
 		AllowedMentions: r.AllowedMentions,
 		Flags:           r.Flags,
 		Components:      r.Components,
+		Attachments:     r.Attachments,
 	}
 }
 
@@ -111,6 +114,7 @@ func (r *MessageResponse) IntoWebhookBody() rest.WebhookBody {
 		AllowedMentions: r.AllowedMentions,
 		Flags:           r.Flags,
 		Components:      r.Components,
+		Attachments:     r.Attachments,
 	}
 }
 
@@ -120,6 +124,7 @@ func (r *MessageResponse) IntoWebhookEditBody() rest.WebhookEditBody {
 		Embeds:          r.Embeds,
 		AllowedMentions: r.AllowedMentions,
 		Components:      r.Components,
+		Attachments:     r.Attachments,
 	}
 
 	// Discord API doesn't remove if null
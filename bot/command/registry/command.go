@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
@@ -13,8 +14,8 @@ type Command interface {
 	Properties() Properties
 }
 
-func FormatHelp(c Command, guildId uint64, commandId *uint64) string {
-	description := i18n.GetMessageFromGuild(guildId, c.Properties().Description)
+func FormatHelp(c Command, services *services.Services, guildId uint64, commandId *uint64) string {
+	description := i18n.GetMessageFromGuild(services, guildId, c.Properties().Description)
 
 	if commandId == nil {
 		var args []string
@@ -2,6 +2,7 @@ package command
 
 import (
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
 )
 
 type Response interface {
@@ -16,9 +17,10 @@ const (
 	CommandResponseTypeModal
 )
 
-// ResponseMessage wraps a standard message reply
+// ResponseMessage wraps a standard message reply. Attachments can only be sent once the interaction has been deferred.
 type ResponseMessage struct {
-	Data interaction.ApplicationCommandCallbackData
+	Data        interaction.ApplicationCommandCallbackData
+	Attachments []request.Attachment
 }
 
 func (r ResponseMessage) CommandResponseType() ResponseType { return CommandResponseTypeMessage }
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/TicketsBot-cloud/common/sentry"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker"
 )
 
 type Colour int16
@@ -42,8 +42,8 @@ func IsValidColour(colour Colour) bool {
 	return valid
 }
 
-func GetColours(ctx context.Context, guildId uint64) (map[Colour]int, error) {
-	raw, err := dbclient.Client.CustomColours.GetAll(ctx, guildId)
+func GetColours(ctx context.Context, worker *worker.Context, guildId uint64) (map[Colour]int, error) {
+	raw, err := worker.Services.Database.CustomColours.GetAll(ctx, guildId)
 	if err != nil {
 		return DefaultColours, err
 	}
@@ -63,8 +63,8 @@ func GetColours(ctx context.Context, guildId uint64) (map[Colour]int, error) {
 }
 
 // TODO: Premium check
-func GetColour(ctx context.Context, guildId uint64, colourCode Colour) (int, error) {
-	colour, ok, err := dbclient.Client.CustomColours.Get(ctx, guildId, colourCode.Int16())
+func GetColour(ctx context.Context, worker *worker.Context, guildId uint64, colourCode Colour) (int, error) {
+	colour, ok, err := worker.Services.Database.CustomColours.Get(ctx, guildId, colourCode.Int16())
 	if err != nil {
 		return 0, err
 	}
@@ -77,8 +77,8 @@ func GetColour(ctx context.Context, guildId uint64, colourCode Colour) (int, err
 }
 
 // TODO: Premium check
-func GetColourOrDefault(ctx context.Context, guildId uint64, colourCode Colour) int {
-	colour, ok, err := dbclient.Client.CustomColours.Get(ctx, guildId, colourCode.Int16())
+func GetColourOrDefault(ctx context.Context, worker *worker.Context, guildId uint64, colourCode Colour) int {
+	colour, ok, err := worker.Services.Database.CustomColours.Get(ctx, guildId, colourCode.Int16())
 	if err != nil {
 		sentry.Error(err)
 		return GetDefaultColour(colourCode)
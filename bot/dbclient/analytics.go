@@ -9,9 +9,19 @@ import (
 	"go.uber.org/zap"
 )
 
-var Analytics *analytics.Client
+// AnalyticsClient is the subset of the Clickhouse analytics client that the worker uses
+type AnalyticsClient interface {
+	GetAverageFeedbackRatingGuild(ctx context.Context, guildId uint64) (float64, error)
+	GetFeedbackCountGuild(ctx context.Context, guildId uint64) (uint64, error)
+	GetFirstResponseTimeStats(ctx context.Context, guildId uint64) (analytics.TripleWindow, error)
+	GetLastNTicketsPerDayGuild(ctx context.Context, guildId uint64, nDays int) ([]analytics.CountOnDate, error)
+	GetTicketDurationStats(ctx context.Context, guildId uint64) (analytics.TripleWindow, error)
+	GetTopCloseReasons(ctx context.Context, guildId uint64, panelId *int) ([]string, error)
+	GetTopCloseReasonsContaining(ctx context.Context, guildId uint64, panelId *int, contains string) ([]string, error)
+	GetTotalTicketCount(ctx context.Context, guildId uint64) (uint64, error)
+}
 
-func ConnectAnalytics(logger *zap.Logger) {
+func ConnectAnalytics(logger *zap.Logger) AnalyticsClient {
 	logger.Info("Connecting to Clickhouse",
 		zap.String("address", config.Conf.Clickhouse.Address),
 		zap.String("database", config.Conf.Clickhouse.Database),
@@ -19,7 +29,7 @@ func ConnectAnalytics(logger *zap.Logger) {
 		zap.Int("threads", config.Conf.Clickhouse.Threads),
 	)
 
-	Analytics = analytics.Connect(
+	client := analytics.Connect(
 		config.Conf.Clickhouse.Address,
 		config.Conf.Clickhouse.Threads,
 		config.Conf.Clickhouse.Database,
@@ -31,8 +41,9 @@ func ConnectAnalytics(logger *zap.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	if err := Analytics.Ping(ctx); err != nil {
+	if err := client.Ping(ctx); err != nil {
 		logger.Error("Clickhouse didn't response to ping", zap.Error(err))
-		return
 	}
+
+	return client
 }
@@ -0,0 +1,207 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ClosedCategoryConfig configures a panel's channel tickets to be moved to a category and kept read-only when closed,
+// rather than being deleted straight away
+type ClosedCategoryConfig struct {
+	CategoryId      uint64
+	DeleteAfterDays int
+}
+
+type ClosedCategoryConfigStore interface {
+	Get(ctx context.Context, guildId uint64, panelId int) (ClosedCategoryConfig, bool, error)
+	Set(ctx context.Context, guildId uint64, panelId int, config ClosedCategoryConfig) error
+	Delete(ctx context.Context, guildId uint64, panelId int) error
+}
+
+type ClosedCategoryConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (ClosedCategoryConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS closed_category_config(
+	"guild_id" int8 NOT NULL,
+	"panel_id" int4 NOT NULL,
+	"category_id" int8 NOT NULL,
+	"delete_after_days" int4 NOT NULL,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "panel_id")
+);`
+}
+
+func (c *ClosedCategoryConfigTable) Get(ctx context.Context, guildId uint64, panelId int) (ClosedCategoryConfig, bool, error) {
+	query := `
+SELECT "category_id", "delete_after_days"
+FROM closed_category_config
+WHERE "guild_id" = $1 AND "panel_id" = $2;`
+
+	var config ClosedCategoryConfig
+	if err := c.QueryRow(ctx, query, guildId, panelId).Scan(&config.CategoryId, &config.DeleteAfterDays); err != nil {
+		if err == pgx.ErrNoRows {
+			return ClosedCategoryConfig{}, false, nil
+		}
+
+		return ClosedCategoryConfig{}, false, err
+	}
+
+	return config, true, nil
+}
+
+func (c *ClosedCategoryConfigTable) Set(ctx context.Context, guildId uint64, panelId int, config ClosedCategoryConfig) error {
+	query := `
+INSERT INTO closed_category_config("guild_id", "panel_id", "category_id", "delete_after_days")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "panel_id") DO UPDATE SET
+	"category_id" = EXCLUDED."category_id",
+	"delete_after_days" = EXCLUDED."delete_after_days";`
+
+	_, err := c.Exec(ctx, query, guildId, panelId, config.CategoryId, config.DeleteAfterDays)
+	return err
+}
+
+func (c *ClosedCategoryConfigTable) Delete(ctx context.Context, guildId uint64, panelId int) error {
+	_, err := c.Exec(ctx, `DELETE FROM closed_category_config WHERE "guild_id" = $1 AND "panel_id" = $2;`, guildId, panelId)
+	return err
+}
+
+// ClosedChannel records a ticket channel which has been moved to the closed category, and what is needed to restore
+// it if the ticket is reopened
+type ClosedChannel struct {
+	GuildId      uint64
+	TicketId     int
+	ChannelId    uint64
+	OriginalName string
+	// OriginalParent is nil if the channel was not in a category before it was closed
+	OriginalParent *uint64
+	DeleteAt       time.Time
+}
+
+type ClosedChannelStore interface {
+	Get(ctx context.Context, guildId uint64, ticketId int) (ClosedChannel, bool, error)
+	Set(ctx context.Context, closed ClosedChannel) error
+	Delete(ctx context.Context, guildId uint64, ticketId int) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]ClosedChannel, error)
+	Claim(ctx context.Context, closed ClosedChannel, retryAt time.Time) (bool, error)
+}
+
+type ClosedChannelTable struct {
+	*pgxpool.Pool
+}
+
+func (ClosedChannelTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS closed_channels(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"channel_id" int8 NOT NULL,
+	"original_name" varchar(100) NOT NULL,
+	"original_parent" int8,
+	"delete_at" timestamptz NOT NULL,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+CREATE INDEX IF NOT EXISTS closed_channels_delete_at ON closed_channels("delete_at");`
+}
+
+func (c *ClosedChannelTable) Get(ctx context.Context, guildId uint64, ticketId int) (ClosedChannel, bool, error) {
+	query := `
+SELECT "guild_id", "ticket_id", "channel_id", "original_name", "original_parent", "delete_at"
+FROM closed_channels
+WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	closed, err := scanClosedChannel(c.QueryRow(ctx, query, guildId, ticketId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ClosedChannel{}, false, nil
+		}
+
+		return ClosedChannel{}, false, err
+	}
+
+	return closed, true, nil
+}
+
+// Set stores the record, scheduling the channel to be deleted at closed.DeleteAt
+func (c *ClosedChannelTable) Set(ctx context.Context, closed ClosedChannel) error {
+	query := `
+INSERT INTO closed_channels("guild_id", "ticket_id", "channel_id", "original_name", "original_parent", "delete_at")
+VALUES($1, $2, $3, $4, $5, $6)
+ON CONFLICT("guild_id", "ticket_id") DO UPDATE SET
+	"channel_id" = EXCLUDED."channel_id",
+	"original_name" = EXCLUDED."original_name",
+	"original_parent" = EXCLUDED."original_parent",
+	"delete_at" = EXCLUDED."delete_at";`
+
+	_, err := c.Exec(ctx, query, closed.GuildId, closed.TicketId, closed.ChannelId, closed.OriginalName, closed.OriginalParent, closed.DeleteAt)
+	return err
+}
+
+// Delete removes the record, and so cancels the scheduled deletion, once the channel has either been deleted or
+// restored
+func (c *ClosedChannelTable) Delete(ctx context.Context, guildId uint64, ticketId int) error {
+	_, err := c.Exec(ctx, `DELETE FROM closed_channels WHERE "guild_id" = $1 AND "ticket_id" = $2;`, guildId, ticketId)
+	return err
+}
+
+// GetDue returns up to limit closed channels which are due to be deleted
+func (c *ClosedChannelTable) GetDue(ctx context.Context, now time.Time, limit int) ([]ClosedChannel, error) {
+	query := `
+SELECT "guild_id", "ticket_id", "channel_id", "original_name", "original_parent", "delete_at"
+FROM closed_channels
+WHERE "delete_at" <= $1
+ORDER BY "delete_at" ASC
+LIMIT $2;`
+
+	rows, err := c.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var due []ClosedChannel
+	for rows.Next() {
+		closed, err := scanClosedChannel(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		due = append(due, closed)
+	}
+
+	return due, rows.Err()
+}
+
+// Claim reschedules the deletion to retryAt, in case it fails, only if it is still scheduled for closed.DeleteAt. It
+// returns false if another worker has already claimed it.
+func (c *ClosedChannelTable) Claim(ctx context.Context, closed ClosedChannel, retryAt time.Time) (bool, error) {
+	query := `UPDATE closed_channels SET "delete_at" = $4 WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "delete_at" = $3;`
+
+	tag, err := c.Exec(ctx, query, closed.GuildId, closed.TicketId, closed.DeleteAt, retryAt)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanClosedChannel(row pgx.Row) (ClosedChannel, error) {
+	var closed ClosedChannel
+	err := row.Scan(
+		&closed.GuildId,
+		&closed.TicketId,
+		&closed.ChannelId,
+		&closed.OriginalName,
+		&closed.OriginalParent,
+		&closed.DeleteAt,
+	)
+
+	return closed, err
+}
@@ -0,0 +1,129 @@
+package dbclient
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// CloseReasonPreset is a close reason which staff can pick rather than typing one out
+type CloseReasonPreset struct {
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+	// Category groups presets in the statistics, e.g. resolved, duplicate or spam
+	Category string `json:"category"`
+}
+
+type CloseReasonConfig struct {
+	Presets []CloseReasonPreset
+	// RequireReason prevents tickets from the panel being closed by staff or the opener without a reason
+	RequireReason bool
+}
+
+type CloseReasonConfigStore interface {
+	Get(ctx context.Context, guildId uint64, panelId int) (CloseReasonConfig, bool, error)
+	GetByGuild(ctx context.Context, guildId uint64) (map[int]CloseReasonConfig, error)
+	Set(ctx context.Context, guildId uint64, panelId int, config CloseReasonConfig) error
+	Delete(ctx context.Context, guildId uint64, panelId int) error
+}
+
+type CloseReasonConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (CloseReasonConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS close_reason_config(
+	"guild_id" int8 NOT NULL,
+	"panel_id" int4 NOT NULL,
+	"presets" JSONB NOT NULL,
+	"require_reason" bool NOT NULL,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "panel_id")
+);`
+}
+
+func (c *CloseReasonConfigTable) Get(ctx context.Context, guildId uint64, panelId int) (CloseReasonConfig, bool, error) {
+	query := `
+SELECT "presets", "require_reason"
+FROM close_reason_config
+WHERE "guild_id" = $1 AND "panel_id" = $2;`
+
+	var config CloseReasonConfig
+	var presetsRaw string
+	if err := c.QueryRow(ctx, query, guildId, panelId).Scan(&presetsRaw, &config.RequireReason); err != nil {
+		if err == pgx.ErrNoRows {
+			return CloseReasonConfig{}, false, nil
+		}
+
+		return CloseReasonConfig{}, false, err
+	}
+
+	if err := json.Unmarshal([]byte(presetsRaw), &config.Presets); err != nil {
+		return CloseReasonConfig{}, false, err
+	}
+
+	return config, true, nil
+}
+
+// GetByGuild returns the close reason configuration of every panel in the guild which has one, keyed by panel
+func (c *CloseReasonConfigTable) GetByGuild(ctx context.Context, guildId uint64) (map[int]CloseReasonConfig, error) {
+	query := `
+SELECT "panel_id", "presets", "require_reason"
+FROM close_reason_config
+WHERE "guild_id" = $1;`
+
+	rows, err := c.Query(ctx, query, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	configs := make(map[int]CloseReasonConfig)
+	for rows.Next() {
+		var panelId int
+		var config CloseReasonConfig
+		var presetsRaw string
+		if err := rows.Scan(&panelId, &presetsRaw, &config.RequireReason); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(presetsRaw), &config.Presets); err != nil {
+			return nil, err
+		}
+
+		configs[panelId] = config
+	}
+
+	return configs, rows.Err()
+}
+
+func (c *CloseReasonConfigTable) Set(ctx context.Context, guildId uint64, panelId int, config CloseReasonConfig) error {
+	presets := config.Presets
+	if presets == nil {
+		presets = []CloseReasonPreset{}
+	}
+
+	presetsRaw, err := json.Marshal(presets)
+	if err != nil {
+		return err
+	}
+
+	query := `
+INSERT INTO close_reason_config("guild_id", "panel_id", "presets", "require_reason")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "panel_id") DO UPDATE SET
+	"presets" = EXCLUDED."presets",
+	"require_reason" = EXCLUDED."require_reason";`
+
+	_, err = c.Exec(ctx, query, guildId, panelId, string(presetsRaw), config.RequireReason)
+	return err
+}
+
+func (c *CloseReasonConfigTable) Delete(ctx context.Context, guildId uint64, panelId int) error {
+	_, err := c.Exec(ctx, `DELETE FROM close_reason_config WHERE "guild_id" = $1 AND "panel_id" = $2;`, guildId, panelId)
+	return err
+}
@@ -0,0 +1,42 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Database gives the worker access to the tables of the database module through the interfaces in stores.go, and to
+// its own queries through WorkerTables, so that they can be replaced with fakes in tests
+type Database struct {
+	Tables
+	WorkerTables
+
+	raw *database.Database
+}
+
+func NewDatabase(pool *pgxpool.Pool) *Database {
+	db := database.NewDatabase(pool)
+
+	return &Database{
+		Tables:       NewTables(db),
+		WorkerTables: NewWorkerTables(pool),
+		raw:          db,
+	}
+}
+
+// Raw returns the underlying database module client, for libraries which require it, such as permission lookups and
+// premium. It is nil if the Database was built from fakes.
+func (d *Database) Raw() *database.Database {
+	return d.raw
+}
+
+func (d *Database) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return d.raw.BeginTx(ctx)
+}
+
+func (d *Database) WithTx(ctx context.Context, f func(tx pgx.Tx) error) error {
+	return d.raw.WithTx(ctx, f)
+}
@@ -4,16 +4,13 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.uber.org/zap"
 )
 
-var Client *database.Database
-
-func Connect(logger *zap.Logger) {
+func Connect(logger *zap.Logger) *Database {
 	cfg, err := pgxpool.ParseConfig(fmt.Sprintf(
 		"postgres://%s:%s@%s/%s?pool_max_conns=%d",
 		config.Conf.Database.Username,
@@ -25,7 +22,7 @@ func Connect(logger *zap.Logger) {
 
 	if err != nil {
 		logger.Fatal("Failed to parse database config", zap.Error(err))
-		return
+		return nil
 	}
 
 	// TODO: Sentry
@@ -35,8 +32,13 @@ func Connect(logger *zap.Logger) {
 	pool, err := pgxpool.ConnectConfig(context.Background(), cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
-		return
+		return nil
+	}
+
+	if err := CreateWorkerTables(context.Background(), pool); err != nil {
+		logger.Fatal("Failed to create worker tables", zap.Error(err))
+		return nil
 	}
 
-	Client = database.NewDatabase(pool)
+	return NewDatabase(pool)
 }
@@ -0,0 +1,78 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type FeedbackFollowUpConfig struct {
+	// ChannelId is where low rating alerts are posted. Alerts are disabled if it is zero.
+	ChannelId uint64
+	// Threshold is the highest rating which triggers an alert
+	Threshold    uint8
+	ReopenButton bool
+	ThankClaimer bool
+}
+
+type FeedbackFollowUpConfigStore interface {
+	Get(ctx context.Context, guildId uint64) (FeedbackFollowUpConfig, bool, error)
+	Set(ctx context.Context, guildId uint64, config FeedbackFollowUpConfig) error
+	Delete(ctx context.Context, guildId uint64) error
+}
+
+type FeedbackFollowUpConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (FeedbackFollowUpConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS feedback_follow_up_config(
+	"guild_id" int8 NOT NULL,
+	"channel_id" int8 NOT NULL,
+	"threshold" int2 NOT NULL,
+	"reopen_button" bool NOT NULL,
+	"thank_claimer" bool NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (f *FeedbackFollowUpConfigTable) Get(ctx context.Context, guildId uint64) (FeedbackFollowUpConfig, bool, error) {
+	query := `
+SELECT "channel_id", "threshold", "reopen_button", "thank_claimer"
+FROM feedback_follow_up_config
+WHERE "guild_id" = $1;`
+
+	var config FeedbackFollowUpConfig
+	var threshold int16
+	if err := f.QueryRow(ctx, query, guildId).Scan(&config.ChannelId, &threshold, &config.ReopenButton, &config.ThankClaimer); err != nil {
+		if err == pgx.ErrNoRows {
+			return FeedbackFollowUpConfig{}, false, nil
+		}
+
+		return FeedbackFollowUpConfig{}, false, err
+	}
+
+	config.Threshold = uint8(threshold)
+	return config, true, nil
+}
+
+func (f *FeedbackFollowUpConfigTable) Set(ctx context.Context, guildId uint64, config FeedbackFollowUpConfig) error {
+	query := `
+INSERT INTO feedback_follow_up_config("guild_id", "channel_id", "threshold", "reopen_button", "thank_claimer")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("guild_id") DO UPDATE SET
+	"channel_id" = EXCLUDED."channel_id",
+	"threshold" = EXCLUDED."threshold",
+	"reopen_button" = EXCLUDED."reopen_button",
+	"thank_claimer" = EXCLUDED."thank_claimer";`
+
+	_, err := f.Exec(ctx, query, guildId, config.ChannelId, int16(config.Threshold), config.ReopenButton, config.ThankClaimer)
+	return err
+}
+
+func (f *FeedbackFollowUpConfigTable) Delete(ctx context.Context, guildId uint64) error {
+	_, err := f.Exec(ctx, `DELETE FROM feedback_follow_up_config WHERE "guild_id" = $1;`, guildId)
+	return err
+}
@@ -0,0 +1,53 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// NotesTranscriptConfig controls whether the staff notes thread is archived with the transcript. Notes are included
+// by default, so the stored flag records guilds which have opted out.
+type NotesTranscriptConfig struct {
+	Excluded bool
+}
+
+type NotesTranscriptConfigStore interface {
+	Get(ctx context.Context, guildId uint64) (NotesTranscriptConfig, error)
+	Set(ctx context.Context, guildId uint64, config NotesTranscriptConfig) error
+}
+
+type NotesTranscriptConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (NotesTranscriptConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS notes_transcript_config(
+	"guild_id" int8 NOT NULL,
+	"excluded" bool NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (n *NotesTranscriptConfigTable) Get(ctx context.Context, guildId uint64) (NotesTranscriptConfig, error) {
+	query := `SELECT "excluded" FROM notes_transcript_config WHERE "guild_id" = $1;`
+
+	var config NotesTranscriptConfig
+	if err := n.QueryRow(ctx, query, guildId).Scan(&config.Excluded); err != nil && err != pgx.ErrNoRows {
+		return NotesTranscriptConfig{}, err
+	}
+
+	return config, nil
+}
+
+func (n *NotesTranscriptConfigTable) Set(ctx context.Context, guildId uint64, config NotesTranscriptConfig) error {
+	query := `
+INSERT INTO notes_transcript_config("guild_id", "excluded")
+VALUES($1, $2)
+ON CONFLICT("guild_id") DO UPDATE SET "excluded" = EXCLUDED."excluded";`
+
+	_, err := n.Exec(ctx, query, guildId, config.Excluded)
+	return err
+}
@@ -0,0 +1,149 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PreservedAttachment is a copy of a ticket attachment uploaded to the archiver. Its size counts towards the guild's
+// attachment quota until the row is deleted or expires.
+type PreservedAttachment struct {
+	AttachmentId uint64
+	GuildId      uint64
+	TicketId     int
+	Size         int64
+}
+
+type PreservedAttachmentStore interface {
+	Get(ctx context.Context, attachmentId uint64) (string, bool, error)
+	Reserve(ctx context.Context, attachment PreservedAttachment, quota int64, expiresAt time.Time) (bool, error)
+	SetUrl(ctx context.Context, attachmentId uint64, url string, expiresAt *time.Time) error
+	Release(ctx context.Context, attachmentId uint64) error
+	DeleteByTicket(ctx context.Context, guildId uint64, ticketId int) error
+	GetUsage(ctx context.Context, guildId uint64) (int64, error)
+}
+
+type PreservedAttachmentTable struct {
+	*pgxpool.Pool
+}
+
+// Schema deletes a ticket's preserved attachments along with the ticket, so that deleting a user's data also returns
+// the storage to the guild's quota. Anything else which deletes a transcript must call DeleteByTicket.
+func (PreservedAttachmentTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS preserved_attachments(
+	"attachment_id" int8 NOT NULL,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"size" int8 NOT NULL,
+	"url" text DEFAULT NULL,
+	"expires_at" timestamptz DEFAULT NULL,
+	FOREIGN KEY("ticket_id", "guild_id") REFERENCES tickets("id", "guild_id") ON DELETE CASCADE,
+	PRIMARY KEY("attachment_id")
+);
+CREATE INDEX IF NOT EXISTS preserved_attachments_guild_ticket ON preserved_attachments("guild_id", "ticket_id");`
+}
+
+// Get returns the URL of the attachment's preserved copy, if it has finished uploading and has not expired
+func (p *PreservedAttachmentTable) Get(ctx context.Context, attachmentId uint64) (string, bool, error) {
+	query := `
+SELECT "url"
+FROM preserved_attachments
+WHERE "attachment_id" = $1 AND "url" IS NOT NULL AND ("expires_at" IS NULL OR "expires_at" > NOW());`
+
+	var url string
+	if err := p.QueryRow(ctx, query, attachmentId).Scan(&url); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	return url, true, nil
+}
+
+// Reserve counts the attachment towards the guild's quota before it is uploaded, returning false without reserving
+// anything if doing so would take the guild over quota, or if the attachment has already been reserved. The reservation
+// expires at expiresAt unless SetUrl is called, so that an upload which never finishes does not hold on to the quota.
+func (p *PreservedAttachmentTable) Reserve(ctx context.Context, attachment PreservedAttachment, quota int64, expiresAt time.Time) (bool, error) {
+	tx, err := p.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	// Serialise reservations for the guild, so that concurrent closes cannot both fit under the quota
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('preserved_attachments:' || $1::text, 0));`, attachment.GuildId); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM preserved_attachments WHERE "guild_id" = $1 AND "expires_at" <= NOW();`, attachment.GuildId); err != nil {
+		return false, err
+	}
+
+	var used int64
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(SUM("size"), 0) FROM preserved_attachments WHERE "guild_id" = $1;`, attachment.GuildId).Scan(&used); err != nil {
+		return false, err
+	}
+
+	if used+attachment.Size > quota {
+		return false, nil
+	}
+
+	query := `
+INSERT INTO preserved_attachments("attachment_id", "guild_id", "ticket_id", "size", "expires_at")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("attachment_id") DO NOTHING;`
+
+	res, err := tx.Exec(ctx, query, attachment.AttachmentId, attachment.GuildId, attachment.TicketId, attachment.Size, expiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// SetUrl records where a reserved attachment was uploaded to. A nil expiresAt keeps it, and its usage, indefinitely.
+func (p *PreservedAttachmentTable) SetUrl(ctx context.Context, attachmentId uint64, url string, expiresAt *time.Time) error {
+	query := `UPDATE preserved_attachments SET "url" = $2, "expires_at" = $3 WHERE "attachment_id" = $1;`
+
+	_, err := p.Exec(ctx, query, attachmentId, url, expiresAt)
+	return err
+}
+
+// Release returns a reservation to the quota, if the attachment could not be preserved
+func (p *PreservedAttachmentTable) Release(ctx context.Context, attachmentId uint64) error {
+	_, err := p.Exec(ctx, `DELETE FROM preserved_attachments WHERE "attachment_id" = $1;`, attachmentId)
+	return err
+}
+
+// DeleteByTicket returns the storage used by a ticket's preserved attachments to the guild's quota, for when its
+// transcript is deleted
+func (p *PreservedAttachmentTable) DeleteByTicket(ctx context.Context, guildId uint64, ticketId int) error {
+	_, err := p.Exec(ctx, `DELETE FROM preserved_attachments WHERE "guild_id" = $1 AND "ticket_id" = $2;`, guildId, ticketId)
+	return err
+}
+
+// GetUsage returns the total size of the guild's preserved and reserved attachments which have not expired
+func (p *PreservedAttachmentTable) GetUsage(ctx context.Context, guildId uint64) (int64, error) {
+	query := `
+SELECT COALESCE(SUM("size"), 0)
+FROM preserved_attachments
+WHERE "guild_id" = $1 AND ("expires_at" IS NULL OR "expires_at" > NOW());`
+
+	var used int64
+	if err := p.QueryRow(ctx, query, guildId).Scan(&used); err != nil {
+		return 0, err
+	}
+
+	return used, nil
+}
@@ -0,0 +1,90 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ReopenLinkStore interface {
+	Set(ctx context.Context, guildId uint64, previousId, newId int) error
+	GetReopenedFrom(ctx context.Context, guildId uint64, ticketId int) (int, bool, error)
+	GetReopenedFromMulti(ctx context.Context, guildId uint64, ticketIds []int) (map[int]int, error)
+	GetReopenedAs(ctx context.Context, guildId uint64, ticketId int) (int, bool, error)
+}
+
+type ReopenLinkTable struct {
+	*pgxpool.Pool
+}
+
+func (ReopenLinkTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS reopen_links(
+	"guild_id" int8 NOT NULL,
+	"previous_id" int4 NOT NULL,
+	"new_id" int4 NOT NULL,
+	UNIQUE("guild_id", "previous_id"),
+	PRIMARY KEY("guild_id", "new_id")
+);`
+}
+
+// Set records that the closed ticket previousId was reopened as the new ticket newId, so that either ticket can be
+// traced to the other
+func (r *ReopenLinkTable) Set(ctx context.Context, guildId uint64, previousId, newId int) error {
+	query := `
+INSERT INTO reopen_links("guild_id", "previous_id", "new_id")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "new_id") DO UPDATE SET "previous_id" = EXCLUDED."previous_id";`
+
+	_, err := r.Exec(ctx, query, guildId, previousId, newId)
+	return err
+}
+
+// GetReopenedFrom returns the ticket which ticketId was opened to continue, if any
+func (r *ReopenLinkTable) GetReopenedFrom(ctx context.Context, guildId uint64, ticketId int) (int, bool, error) {
+	return r.getTicketId(ctx, `SELECT "previous_id" FROM reopen_links WHERE "guild_id" = $1 AND "new_id" = $2;`, guildId, ticketId)
+}
+
+// GetReopenedFromMulti returns the ticket which each of ticketIds was opened to continue, keyed by ticket. Tickets which
+// were not opened by reopening another are omitted.
+func (r *ReopenLinkTable) GetReopenedFromMulti(ctx context.Context, guildId uint64, ticketIds []int) (map[int]int, error) {
+	query := `SELECT "new_id", "previous_id" FROM reopen_links WHERE "guild_id" = $1 AND "new_id" = ANY($2);`
+
+	rows, err := r.Query(ctx, query, guildId, ticketIds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reopenedFrom := make(map[int]int)
+	for rows.Next() {
+		var newId, previousId int
+		if err := rows.Scan(&newId, &previousId); err != nil {
+			return nil, err
+		}
+
+		reopenedFrom[newId] = previousId
+	}
+
+	return reopenedFrom, rows.Err()
+}
+
+// GetReopenedAs returns the ticket which was opened to continue ticketId, if any
+func (r *ReopenLinkTable) GetReopenedAs(ctx context.Context, guildId uint64, ticketId int) (int, bool, error) {
+	return r.getTicketId(ctx, `SELECT "new_id" FROM reopen_links WHERE "guild_id" = $1 AND "previous_id" = $2;`, guildId, ticketId)
+}
+
+func (r *ReopenLinkTable) getTicketId(ctx context.Context, query string, guildId uint64, ticketId int) (int, bool, error) {
+	var linkedId int
+	if err := r.QueryRow(ctx, query, guildId, ticketId).Scan(&linkedId); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return linkedId, true, nil
+}
@@ -0,0 +1,119 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ReportTicket is a ticket along with its first response and claimer, which the database module has no store methods
+// to fetch for many tickets at once. Ratings, close reasons and reopen links are fetched from their own stores.
+type ReportTicket struct {
+	TicketId          int
+	PanelId           *int
+	OpenerId          uint64
+	OpenTime          time.Time
+	CloseTime         *time.Time
+	FirstResponseTime *time.Duration
+	FirstResponderId  *uint64
+	ClaimedBy         *uint64
+}
+
+type ReportingStore interface {
+	GetTickets(ctx context.Context, guildId uint64, from, to time.Time, limit int, panelIds []int) ([]ReportTicket, error)
+	GetParticipation(ctx context.Context, guildId uint64, from, to time.Time, panelIds []int) (map[uint64]int, error)
+}
+
+type ReportingTable struct {
+	*pgxpool.Pool
+}
+
+// GetTickets returns up to limit tickets which were opened or closed within [from, to), newest first, so that the most
+// recent tickets are kept if there are more than limit. If panelIds is non-nil, only tickets from those panels are
+// returned.
+func (r *ReportingTable) GetTickets(ctx context.Context, guildId uint64, from, to time.Time, limit int, panelIds []int) ([]ReportTicket, error) {
+	query := `
+SELECT tickets.id,
+	tickets.panel_id,
+	tickets.user_id,
+	tickets.open_time,
+	tickets.close_time,
+	first_response_time.response_time,
+	first_response_time.user_id,
+	ticket_claims.user_id
+FROM tickets
+LEFT OUTER JOIN first_response_time
+ON tickets.guild_id = first_response_time.guild_id AND tickets.id = first_response_time.ticket_id
+LEFT OUTER JOIN ticket_claims
+ON tickets.guild_id = ticket_claims.guild_id AND tickets.id = ticket_claims.ticket_id
+WHERE tickets.guild_id = $1
+	AND tickets.open_time < $3
+	AND (tickets.open_time >= $2 OR tickets.close_time >= $2)
+	AND ($5::int[] IS NULL OR tickets.panel_id = ANY($5))
+ORDER BY tickets.id DESC
+LIMIT $4;`
+
+	rows, err := r.Query(ctx, query, guildId, from, to, limit, panelIds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tickets []ReportTicket
+	for rows.Next() {
+		var ticket ReportTicket
+		if err := rows.Scan(
+			&ticket.TicketId,
+			&ticket.PanelId,
+			&ticket.OpenerId,
+			&ticket.OpenTime,
+			&ticket.CloseTime,
+			&ticket.FirstResponseTime,
+			&ticket.FirstResponderId,
+			&ticket.ClaimedBy,
+		); err != nil {
+			return nil, err
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+// GetParticipation returns the number of tickets opened within [from, to), and from one of panelIds if it is non-nil,
+// that each user has sent a message in
+func (r *ReportingTable) GetParticipation(ctx context.Context, guildId uint64, from, to time.Time, panelIds []int) (map[uint64]int, error) {
+	query := `
+SELECT participant.user_id, COUNT(*)
+FROM participant
+INNER JOIN tickets
+ON participant.guild_id = tickets.guild_id AND participant.ticket_id = tickets.id
+WHERE participant.guild_id = $1
+	AND tickets.open_time >= $2
+	AND tickets.open_time < $3
+	AND ($4::int[] IS NULL OR tickets.panel_id = ANY($4))
+GROUP BY participant.user_id;`
+
+	rows, err := r.Query(ctx, query, guildId, from, to, panelIds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	participation := make(map[uint64]int)
+	for rows.Next() {
+		var userId uint64
+		var count int
+		if err := rows.Scan(&userId, &count); err != nil {
+			return nil, err
+		}
+
+		participation[userId] = count
+	}
+
+	return participation, rows.Err()
+}
@@ -0,0 +1,227 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// SpamConfig controls what happens when a ticket is closed as spam
+type SpamConfig struct {
+	// WelcomeButton adds a close as spam button to the welcome message
+	WelcomeButton bool
+	// BlacklistDuration is how long the opener is blacklisted for when their ticket is closed as spam. The opener is not
+	// blacklisted if it is zero.
+	BlacklistDuration time.Duration
+}
+
+type SpamConfigStore interface {
+	Get(ctx context.Context, guildId uint64) (SpamConfig, error)
+	Set(ctx context.Context, guildId uint64, config SpamConfig) error
+}
+
+type SpamConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (SpamConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS spam_config(
+	"guild_id" int8 NOT NULL,
+	"welcome_button" bool NOT NULL,
+	"blacklist_duration" interval NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (s *SpamConfigTable) Get(ctx context.Context, guildId uint64) (SpamConfig, error) {
+	query := `SELECT "welcome_button", "blacklist_duration" FROM spam_config WHERE "guild_id" = $1;`
+
+	var config SpamConfig
+	if err := s.QueryRow(ctx, query, guildId).Scan(&config.WelcomeButton, &config.BlacklistDuration); err != nil && err != pgx.ErrNoRows {
+		return SpamConfig{}, err
+	}
+
+	return config, nil
+}
+
+func (s *SpamConfigTable) Set(ctx context.Context, guildId uint64, config SpamConfig) error {
+	query := `
+INSERT INTO spam_config("guild_id", "welcome_button", "blacklist_duration")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET
+	"welcome_button" = EXCLUDED."welcome_button",
+	"blacklist_duration" = EXCLUDED."blacklist_duration";`
+
+	_, err := s.Exec(ctx, query, guildId, config.WelcomeButton, config.BlacklistDuration)
+	return err
+}
+
+// SpamBlacklistEntry is a user who is blacklisted from opening tickets until ExpiresAt, because a ticket they opened
+// was closed as spam
+type SpamBlacklistEntry struct {
+	UserId    uint64
+	ExpiresAt time.Time
+}
+
+type SpamBlacklistStore interface {
+	Set(ctx context.Context, guildId, userId uint64, expiresAt time.Time) error
+	GetExpiry(ctx context.Context, guildId, userId uint64) (time.Time, bool, error)
+	GetActive(ctx context.Context, guildId uint64, limit int) ([]SpamBlacklistEntry, error)
+	Remove(ctx context.Context, guildId, userId uint64) error
+}
+
+type SpamBlacklistTable struct {
+	*pgxpool.Pool
+}
+
+func (SpamBlacklistTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS spam_blacklist(
+	"guild_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"expires_at" timestamptz NOT NULL,
+	PRIMARY KEY("guild_id", "user_id")
+);
+CREATE INDEX IF NOT EXISTS spam_blacklist_expires_at ON spam_blacklist("expires_at");`
+}
+
+// Set blacklists the user until expiresAt, replacing any existing temporary blacklist
+func (s *SpamBlacklistTable) Set(ctx context.Context, guildId, userId uint64, expiresAt time.Time) error {
+	query := `
+INSERT INTO spam_blacklist("guild_id", "user_id", "expires_at")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "user_id") DO UPDATE SET "expires_at" = EXCLUDED."expires_at";`
+
+	_, err := s.Exec(ctx, query, guildId, userId, expiresAt)
+	return err
+}
+
+// GetExpiry returns when the user's temporary blacklist ends, if they are blacklisted
+func (s *SpamBlacklistTable) GetExpiry(ctx context.Context, guildId, userId uint64) (time.Time, bool, error) {
+	query := `SELECT "expires_at" FROM spam_blacklist WHERE "guild_id" = $1 AND "user_id" = $2 AND "expires_at" > NOW();`
+
+	var expiresAt time.Time
+	if err := s.QueryRow(ctx, query, guildId, userId).Scan(&expiresAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return time.Time{}, false, nil
+		}
+
+		return time.Time{}, false, err
+	}
+
+	return expiresAt, true, nil
+}
+
+// GetActive returns the guild's temporary blacklists which have not expired, ending soonest first. Expired blacklists
+// are deleted.
+func (s *SpamBlacklistTable) GetActive(ctx context.Context, guildId uint64, limit int) ([]SpamBlacklistEntry, error) {
+	if _, err := s.Exec(ctx, `DELETE FROM spam_blacklist WHERE "guild_id" = $1 AND "expires_at" <= NOW();`, guildId); err != nil {
+		return nil, err
+	}
+
+	query := `
+SELECT "user_id", "expires_at"
+FROM spam_blacklist
+WHERE "guild_id" = $1 AND "expires_at" > NOW()
+ORDER BY "expires_at" ASC
+LIMIT $2;`
+
+	rows, err := s.Query(ctx, query, guildId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var entries []SpamBlacklistEntry
+	for rows.Next() {
+		var entry SpamBlacklistEntry
+		if err := rows.Scan(&entry.UserId, &entry.ExpiresAt); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (s *SpamBlacklistTable) Remove(ctx context.Context, guildId, userId uint64) error {
+	_, err := s.Exec(ctx, `DELETE FROM spam_blacklist WHERE "guild_id" = $1 AND "user_id" = $2;`, guildId, userId)
+	return err
+}
+
+// SpamStats counts the tickets closed as spam, and the openers blacklisted as a result, over a number of days
+type SpamStats struct {
+	Closes     int
+	Blacklists int
+}
+
+type SpamStatsStore interface {
+	RecordClose(ctx context.Context, guildId uint64, blacklisted bool, at time.Time, expiresAt time.Time) error
+	Get(ctx context.Context, guildId uint64, from, to time.Time) (SpamStats, error)
+}
+
+type SpamStatsTable struct {
+	*pgxpool.Pool
+}
+
+// Schema keeps a row of counts per guild per day, which is deleted once it is past expires_at
+func (SpamStatsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS spam_stats(
+	"guild_id" int8 NOT NULL,
+	"day" date NOT NULL,
+	"closes" int4 NOT NULL DEFAULT 0,
+	"blacklists" int4 NOT NULL DEFAULT 0,
+	"expires_at" timestamptz NOT NULL,
+	PRIMARY KEY("guild_id", "day")
+);`
+}
+
+// RecordClose counts a ticket closed as spam towards the stats for the day of at, and whether its opener was
+// blacklisted
+func (s *SpamStatsTable) RecordClose(ctx context.Context, guildId uint64, blacklisted bool, at time.Time, expiresAt time.Time) error {
+	blacklists := 0
+	if blacklisted {
+		blacklists = 1
+	}
+
+	query := `
+INSERT INTO spam_stats("guild_id", "day", "closes", "blacklists", "expires_at")
+VALUES($1, $2, 1, $3, $4)
+ON CONFLICT("guild_id", "day") DO UPDATE SET
+	"closes" = spam_stats."closes" + 1,
+	"blacklists" = spam_stats."blacklists" + EXCLUDED."blacklists",
+	"expires_at" = EXCLUDED."expires_at";`
+
+	if _, err := s.Exec(ctx, query, guildId, spamStatsDay(at), blacklists, expiresAt); err != nil {
+		return err
+	}
+
+	_, err := s.Exec(ctx, `DELETE FROM spam_stats WHERE "guild_id" = $1 AND "expires_at" <= NOW();`, guildId)
+	return err
+}
+
+// Get sums the stats of the days from from up to and including to
+func (s *SpamStatsTable) Get(ctx context.Context, guildId uint64, from, to time.Time) (SpamStats, error) {
+	query := `
+SELECT COALESCE(SUM("closes"), 0), COALESCE(SUM("blacklists"), 0)
+FROM spam_stats
+WHERE "guild_id" = $1 AND "day" BETWEEN $2 AND $3;`
+
+	var stats SpamStats
+	if err := s.QueryRow(ctx, query, guildId, spamStatsDay(from), spamStatsDay(to)).Scan(&stats.Closes, &stats.Blacklists); err != nil {
+		return SpamStats{}, err
+	}
+
+	return stats, nil
+}
+
+func spamStatsDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
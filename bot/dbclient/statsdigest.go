@@ -0,0 +1,123 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type StatsDigestConfig struct {
+	ChannelId     uint64
+	Frequency     string
+	ResponseLimit time.Duration
+}
+
+type ScheduledStatsDigest struct {
+	GuildId uint64
+	RunAt   time.Time
+}
+
+type StatsDigestStore interface {
+	Get(ctx context.Context, guildId uint64) (StatsDigestConfig, bool, error)
+	Set(ctx context.Context, guildId uint64, config StatsDigestConfig, nextRun time.Time) error
+	Delete(ctx context.Context, guildId uint64) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]ScheduledStatsDigest, error)
+	Claim(ctx context.Context, digest ScheduledStatsDigest, nextRun time.Time) (bool, error)
+}
+
+type StatsDigestTable struct {
+	*pgxpool.Pool
+}
+
+func (StatsDigestTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS stats_digests(
+	"guild_id" int8 NOT NULL,
+	"channel_id" int8 NOT NULL,
+	"frequency" varchar(16) NOT NULL,
+	"response_limit" interval NOT NULL,
+	"next_run" timestamptz NOT NULL,
+	PRIMARY KEY("guild_id")
+);
+CREATE INDEX IF NOT EXISTS stats_digests_next_run ON stats_digests("next_run");`
+}
+
+func (s *StatsDigestTable) Get(ctx context.Context, guildId uint64) (StatsDigestConfig, bool, error) {
+	query := `SELECT "channel_id", "frequency", "response_limit" FROM stats_digests WHERE "guild_id" = $1;`
+
+	var config StatsDigestConfig
+	if err := s.QueryRow(ctx, query, guildId).Scan(&config.ChannelId, &config.Frequency, &config.ResponseLimit); err != nil {
+		if err == pgx.ErrNoRows {
+			return StatsDigestConfig{}, false, nil
+		}
+
+		return StatsDigestConfig{}, false, err
+	}
+
+	return config, true, nil
+}
+
+// Set stores the config, and schedules the first digest for nextRun
+func (s *StatsDigestTable) Set(ctx context.Context, guildId uint64, config StatsDigestConfig, nextRun time.Time) error {
+	query := `
+INSERT INTO stats_digests("guild_id", "channel_id", "frequency", "response_limit", "next_run")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("guild_id") DO UPDATE SET
+	"channel_id" = EXCLUDED."channel_id",
+	"frequency" = EXCLUDED."frequency",
+	"response_limit" = EXCLUDED."response_limit",
+	"next_run" = EXCLUDED."next_run";`
+
+	_, err := s.Exec(ctx, query, guildId, config.ChannelId, config.Frequency, config.ResponseLimit, nextRun)
+	return err
+}
+
+func (s *StatsDigestTable) Delete(ctx context.Context, guildId uint64) error {
+	_, err := s.Exec(ctx, `DELETE FROM stats_digests WHERE "guild_id" = $1;`, guildId)
+	return err
+}
+
+// GetDue returns up to limit digests which were scheduled to run at or before now
+func (s *StatsDigestTable) GetDue(ctx context.Context, now time.Time, limit int) ([]ScheduledStatsDigest, error) {
+	query := `
+SELECT "guild_id", "next_run"
+FROM stats_digests
+WHERE "next_run" <= $1
+ORDER BY "next_run" ASC
+LIMIT $2;`
+
+	rows, err := s.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var digests []ScheduledStatsDigest
+	for rows.Next() {
+		var digest ScheduledStatsDigest
+		if err := rows.Scan(&digest.GuildId, &digest.RunAt); err != nil {
+			return nil, err
+		}
+
+		digest.RunAt = digest.RunAt.UTC()
+		digests = append(digests, digest)
+	}
+
+	return digests, rows.Err()
+}
+
+// Claim reschedules the digest to nextRun, only if it is still scheduled for digest.RunAt, returning false if another
+// worker has already claimed this run. This allows every worker to poll the schedule.
+func (s *StatsDigestTable) Claim(ctx context.Context, digest ScheduledStatsDigest, nextRun time.Time) (bool, error) {
+	query := `UPDATE stats_digests SET "next_run" = $3 WHERE "guild_id" = $1 AND "next_run" = $2;`
+
+	tag, err := s.Exec(ctx, query, digest.GuildId, digest.RunAt, nextRun)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,599 @@
+// Code generated by /tools/cmd/generatedbstores.go; DO NOT EDIT.
+//go:generate go run ../../tools/cmd/generatedbstores.go
+
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/model"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type ActiveLanguageStore interface {
+	Get(ctx context.Context, guildId uint64) (language string, e error)
+	Set(ctx context.Context, guildId uint64, language string) (err error)
+}
+
+type ArchiveChannelStore interface {
+	DeleteByChannel(ctx context.Context, channelId uint64) (err error)
+	Get(ctx context.Context, guildId uint64) (archiveChannel *uint64, e error)
+	GetByPanel(ctx context.Context, guildId uint64, panelId int) (archiveChannel *uint64, e error)
+	Set(ctx context.Context, guildId uint64, archiveChannel *uint64) (err error)
+}
+
+type ArchiveDmMessagesStore interface {
+	Get(ctx context.Context, guildId uint64, ticketId int) (database.ArchiveDmMessage, bool, error)
+	Set(ctx context.Context, guildId uint64, ticketId int, messageId uint64) error
+}
+
+type ArchiveMessagesStore interface {
+	Get(ctx context.Context, guildId uint64, ticketId int) (database.ArchiveMessage, bool, error)
+	Set(ctx context.Context, guildId uint64, ticketId int, channelId, messageId uint64) error
+}
+
+type AutoCloseStore interface {
+	Get(ctx context.Context, guildId uint64) (settings database.AutoCloseSettings, e error)
+}
+
+type AutoCloseExcludeStore interface {
+	Exclude(ctx context.Context, guildId uint64, ticketId int) (err error)
+	ExcludeAll(ctx context.Context, guildId uint64) (err error)
+	IsExcluded(ctx context.Context, guildId uint64, ticketId int) (excluded bool, e error)
+}
+
+type BlacklistStore interface {
+	Add(ctx context.Context, guildId, userId uint64) (err error)
+	GetBlacklistedCount(ctx context.Context, guildId uint64) (count int, err error)
+	IsBlacklisted(ctx context.Context, guildId, userId uint64) (exists bool, e error)
+	Remove(ctx context.Context, guildId, userId uint64) (err error)
+}
+
+type CategoryUpdateQueueStore interface {
+	Add(ctx context.Context, guildId uint64, ticketId int, newStatus model.TicketStatus) error
+}
+
+type ChannelCategoryStore interface {
+	Delete(ctx context.Context, guildId uint64) (err error)
+	DeleteByChannel(ctx context.Context, channelId uint64) (err error)
+	Get(ctx context.Context, guildId uint64) (channelCategory uint64, e error)
+	Set(ctx context.Context, guildId, channelCategory uint64) (err error)
+}
+
+type ClaimSettingsStore interface {
+	Get(ctx context.Context, guildId uint64) (settings database.ClaimSettings, e error)
+}
+
+type CloseConfirmationStore interface {
+	Get(ctx context.Context, guildId uint64) (confirm bool, e error)
+}
+
+type CloseReasonStore interface {
+	Get(ctx context.Context, guildId uint64, ticketId int) (database.CloseMetadata, bool, error)
+	GetMulti(ctx context.Context, guildId uint64, ticketIds []int) (map[int]database.CloseMetadata, error)
+	Set(ctx context.Context, guildId uint64, ticketId int, data database.CloseMetadata) (err error)
+}
+
+type CloseRequestStore interface {
+	Delete(ctx context.Context, guildId uint64, ticketId int) (err error)
+	Get(ctx context.Context, guildId uint64, ticketId int) (database.CloseRequest, bool, error)
+	Set(ctx context.Context, request database.CloseRequest) (err error)
+}
+
+type CustomColoursStore interface {
+	Get(ctx context.Context, guildId uint64, colourId int16) (colourCode int, ok bool, e error)
+	GetAll(ctx context.Context, guildId uint64) (map[int16]int, error)
+}
+
+type CustomIntegrationGuildsStore interface {
+	GetGuildIntegrations(ctx context.Context, guildId uint64) ([]database.CustomIntegration, error)
+}
+
+type CustomIntegrationHeadersStore interface {
+	GetAll(ctx context.Context, integrationIds []int) (map[int][]database.CustomIntegrationHeader, error)
+}
+
+type CustomIntegrationPlaceholdersStore interface {
+	GetAllActivatedInGuild(ctx context.Context, guildId uint64) ([]database.CustomIntegrationPlaceholder, error)
+}
+
+type CustomIntegrationSecretValuesStore interface {
+	GetAll(ctx context.Context, guildId uint64, integrationIds []int) (map[int][]database.SecretWithValue, error)
+}
+
+type EmbedFieldsStore interface {
+	GetFieldsForEmbed(ctx context.Context, embedId int) ([]database.EmbedField, error)
+}
+
+type EmbedsStore interface {
+	GetEmbed(ctx context.Context, id int) (embed database.CustomEmbed, err error)
+}
+
+type EntitlementsStore interface {
+	Create(ctx context.Context, tx pgx.Tx, guildId *uint64, userId *uint64, skuId uuid.UUID, source model.EntitlementSource, expiresAt *time.Time) (model.Entitlement, error)
+	IncreaseExpiry(ctx context.Context, tx pgx.Tx, guildId, userId *uint64, skuId uuid.UUID, source model.EntitlementSource, duration time.Duration) error
+	ListGuildSubscriptions(ctx context.Context, guildId, ownerId uint64, gracePeriod time.Duration) ([]model.GuildEntitlementEntry, error)
+	ListUserSubscriptions(ctx context.Context, userId uint64, gracePeriod time.Duration) ([]model.GuildEntitlementEntry, error)
+}
+
+type ExitSurveyResponsesStore interface {
+	AddResponses(ctx context.Context, guildId uint64, ticketId int, formId int, responses map[int]string) error
+	GetResponses(ctx context.Context, guildId uint64, ticketId int) (database.ExitSurveyResponse, error)
+	HasResponse(ctx context.Context, guildId uint64, formId int) (bool, error)
+}
+
+type ExperimentStore interface {
+	GetByName(ctx context.Context, name string) (*database.Experiment, error)
+}
+
+type FeedbackEnabledStore interface {
+	Get(ctx context.Context, guildId uint64) (feedbackEnabled bool, e error)
+}
+
+type FirstResponseTimeStore interface {
+	GetAverageAllTimeUser(ctx context.Context, guildId, userId uint64) (responseTime *time.Duration, e error)
+	GetAverageUser(ctx context.Context, guildId, userId uint64, interval time.Duration) (responseTime *time.Duration, e error)
+	HasResponse(ctx context.Context, guildId uint64, ticketId int) (hasResponse bool, e error)
+	Set(ctx context.Context, guildId, userId uint64, ticketId int, responseTime time.Duration) (err error)
+}
+
+type FormInputStore interface {
+	GetAllInputsByCustomId(ctx context.Context, guildId uint64) (map[string]database.FormInput, error)
+	GetInputs(ctx context.Context, formId int) (inputs []database.FormInput, e error)
+}
+
+type FormInputOptionStore interface {
+	GetOptionsByForm(ctx context.Context, formId int) (options map[int][]database.FormInputOption, e error)
+}
+
+type FormsStore interface {
+	Get(ctx context.Context, formId int) (form database.Form, ok bool, e error)
+}
+
+type GdprLogsStore interface {
+	InsertLog(requester string, requestType string, status string) (int, error)
+}
+
+type GlobalBlacklistStore interface {
+	Get(ctx context.Context, userId uint64) (*database.GlobalBlacklistEntry, error)
+	ListAll(ctx context.Context) (users []uint64, err error)
+}
+
+type GuildLeaveTimeStore interface {
+	Delete(ctx context.Context, guildId uint64) (err error)
+	Set(ctx context.Context, guildId uint64) (err error)
+}
+
+type GuildMetadataStore interface {
+	Get(ctx context.Context, guildId uint64) (database.GuildMetadata, error)
+	SetOnCallRole(ctx context.Context, guildId uint64, roleId *uint64) (err error)
+}
+
+type LegacyPremiumEntitlementsStore interface {
+	GetUserTier(ctx context.Context, userId uint64, gracePeriod time.Duration) (*database.LegacyPremiumEntitlement, error)
+}
+
+type NamingSchemeStore interface {
+	Get(ctx context.Context, guildId uint64) (ns database.NamingScheme, e error)
+}
+
+type OnCallStore interface {
+	Toggle(ctx context.Context, guildId, userId uint64) (onCall bool, err error)
+}
+
+type PanelStore interface {
+	EnableAll(ctx context.Context, guildId uint64) (err error)
+	GetByCustomId(ctx context.Context, guildId uint64, customId string) (panel database.Panel, ok bool, e error)
+	GetByGuild(ctx context.Context, guildId uint64) (panels []database.Panel, e error)
+	GetById(ctx context.Context, panelId int) (panel database.Panel, e error)
+}
+
+type PanelAccessControlRulesStore interface {
+	GetAll(ctx context.Context, panelId int) ([]database.PanelAccessControlRule, error)
+	GetFirstMatched(ctx context.Context, panelId int, userRoles []uint64) (uint64, database.AccessControlAction, error)
+}
+
+type PanelHereMentionStore interface {
+	ShouldMentionHere(ctx context.Context, panelId int) (shouldMention bool, e error)
+}
+
+type PanelRoleMentionsStore interface {
+	DeleteAllRole(ctx context.Context, roleId uint64) (err error)
+	GetRoles(ctx context.Context, panelId int) (roles []uint64, e error)
+}
+
+type PanelSupportHoursStore interface {
+	HasSupportHours(ctx context.Context, panelId int) (bool, error)
+	IsActiveNow(ctx context.Context, panelId int) (bool, error)
+}
+
+type PanelSupportHoursSettingsStore interface {
+	Get(ctx context.Context, panelId int) (database.PanelSupportHoursSettings, bool, error)
+}
+
+type PanelTeamsStore interface {
+	GetPanelsByTeams(ctx context.Context, teamIds []int) ([]int, error)
+	GetTeamIds(ctx context.Context, panelId int) (teamIds []int, e error)
+	GetTeams(ctx context.Context, panelId int) (teams []database.SupportTeam, e error)
+}
+
+type PanelTicketPermissionsStore interface {
+	Get(ctx context.Context, panelId int) (database.TicketPermissions, error)
+}
+
+type PanelUserMentionStore interface {
+	ShouldMentionUser(ctx context.Context, panelId int) (shouldMention bool, e error)
+}
+
+type ParticipantsStore interface {
+	GetParticipatedCount(ctx context.Context, guildId, userId uint64) (count int, err error)
+	GetParticipatedCountInterval(ctx context.Context, guildId, userId uint64, interval time.Duration) (count int, err error)
+	HasParticipated(ctx context.Context, guildId uint64, ticketId int, userId uint64) (hasParticipated bool, err error)
+	Set(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error)
+	SetBulk(ctx context.Context, guildId uint64, ticketId int, userId []uint64) error
+}
+
+type PermissionsStore interface {
+	AddAdmin(ctx context.Context, guildId, userId uint64) (err error)
+	AddSupport(ctx context.Context, guildId, userId uint64) (err error)
+	GetAdmins(ctx context.Context, guildId uint64) (admins []uint64, e error)
+	GetSupport(ctx context.Context, guildId uint64) (support []uint64, e error)
+	GetSupportOnly(ctx context.Context, guildId uint64) (support []uint64, e error)
+	RemoveAdmin(ctx context.Context, guildId, userId uint64) (err error)
+	RemoveSupport(ctx context.Context, guildId, userId uint64) (err error)
+}
+
+type PremiumKeysStore interface {
+	Create(ctx context.Context, key uuid.UUID, length time.Duration, skuId uuid.UUID) (err error)
+	Delete(ctx context.Context, tx pgx.Tx, key uuid.UUID) (time.Duration, uuid.UUID, bool, error)
+}
+
+type RoleBlacklistStore interface {
+	Add(ctx context.Context, guildId, roleId uint64) (err error)
+	IsAnyBlacklisted(ctx context.Context, guildId uint64, roles []uint64) (blacklisted bool, e error)
+	IsBlacklisted(ctx context.Context, guildId, roleId uint64) (blacklisted bool, e error)
+	Remove(ctx context.Context, guildId, roleId uint64) (err error)
+}
+
+type RolePermissionsStore interface {
+	AddAdmin(ctx context.Context, guildId, roleId uint64) (err error)
+	AddSupport(ctx context.Context, guildId, roleId uint64) (err error)
+	GetAdminRoles(ctx context.Context, guildId uint64) (adminRoles []uint64, e error)
+	GetSupportRoles(ctx context.Context, guildId uint64) (supportRoles []uint64, e error)
+	GetSupportRolesOnly(ctx context.Context, guildId uint64) (supportRoles []uint64, e error)
+	IsSupport(ctx context.Context, roleId uint64) (bool, error)
+	RemoveAdmin(ctx context.Context, guildId, roleId uint64) (err error)
+	RemoveSupport(ctx context.Context, guildId, roleId uint64) (err error)
+}
+
+type ServerBlacklistStore interface {
+	Add(ctx context.Context, guildId uint64, reason *string, ownerId *uint64, realOwnerId *uint64) (err error)
+	Get(ctx context.Context, guildId uint64) (*database.ServerBlacklistEntry, error)
+	GetUserBlacklistedOwnerCounts(ctx context.Context, userId uint64) (int, int, error)
+	IsBlacklisted(ctx context.Context, guildId uint64) (bool, *string, error)
+	ListAll(ctx context.Context) ([]uint64, error)
+}
+
+type ServiceRatingsStore interface {
+	Get(ctx context.Context, guildId uint64, ticketId int) (rating uint8, ok bool, e error)
+	GetAverageClaimedBy(ctx context.Context, guildId, userId uint64) (average float32, err error)
+	GetCountClaimedBy(ctx context.Context, guildId, userId uint64) (count int, err error)
+	GetMulti(ctx context.Context, guildId uint64, ticketIds []int) (map[int]uint8, error)
+	Set(ctx context.Context, guildId uint64, ticketId int, rating uint8) (err error)
+}
+
+type SettingsStore interface {
+	DisableThreads(ctx context.Context, guildId uint64) (err error)
+	EnableThreads(ctx context.Context, guildId uint64, ticketNotificationChannel uint64) (err error)
+	Get(ctx context.Context, guildId uint64) (database.Settings, error)
+	SetOverflow(ctx context.Context, guildId uint64, enabled bool, categoryId *uint64) (err error)
+}
+
+type SubscriptionSkusStore interface {
+	GetSku(ctx context.Context, tx pgx.Tx, skuId uuid.UUID) (*model.SubscriptionSku, error)
+	Search(ctx context.Context, label string, limit int) ([]model.SubscriptionSku, error)
+}
+
+type SupportTeamStore interface {
+	Get(ctx context.Context, guildId uint64) (teams []database.SupportTeam, e error)
+	GetById(ctx context.Context, guildId uint64, id int) (database.SupportTeam, bool, error)
+	GetMulti(ctx context.Context, guildId uint64, teamIds []int) (map[int]database.SupportTeam, error)
+	SetOnCallRole(ctx context.Context, teamId int, roleId *uint64) (err error)
+}
+
+type SupportTeamMembersStore interface {
+	Get(ctx context.Context, teamId int) (members []uint64, e error)
+	GetAllSupportMembersForPanel(ctx context.Context, panelId int) (users []uint64, err error)
+	GetAllTeamsForUser(ctx context.Context, guildId, userId uint64) ([]int, error)
+}
+
+type SupportTeamPermissionsStore interface {
+	GetForTeams(ctx context.Context, teamIds []int) (map[int]database.SupportTeamPermissions, error)
+}
+
+type SupportTeamRolesStore interface {
+	DeleteAllRole(ctx context.Context, roleId uint64) (err error)
+	Get(ctx context.Context, teamId int) (roles []uint64, e error)
+	GetAllSupportRolesForPanel(ctx context.Context, panelId int) (roles []uint64, err error)
+	GetAllTeamsForRoles(ctx context.Context, guildId uint64, roleIds []uint64) ([]int, error)
+	IsSupport(ctx context.Context, guildId, roleId uint64) (isSupport bool, err error)
+}
+
+type TagStore interface {
+	Delete(ctx context.Context, guildId uint64, tagId string) (err error)
+	Exists(ctx context.Context, guildId uint64, tagId string) (exists bool, err error)
+	Get(ctx context.Context, guildId uint64, tagId string) (database.Tag, bool, error)
+	GetByApplicationCommandId(ctx context.Context, guildId, applicationCommandId uint64) (database.Tag, bool, error)
+	GetContaining(ctx context.Context, guildId uint64, substring string, limit int) (tagIds []string, e error)
+	GetTagCount(ctx context.Context, guildId uint64) (count int, err error)
+	GetTagIds(ctx context.Context, guildId uint64) (ids []string, e error)
+	Set(ctx context.Context, tag database.Tag) error
+}
+
+type TicketClaimsStore interface {
+	Delete(ctx context.Context, guildId uint64, ticketId int) (err error)
+	Get(ctx context.Context, guildId uint64, ticketId int) (userId uint64, e error)
+	GetClaimedCount(ctx context.Context, guildId, userId uint64) (count int, e error)
+	GetClaimedSinceCount(ctx context.Context, guildId, userId uint64, interval time.Duration) (count int, e error)
+	Set(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error)
+}
+
+type TicketLabelAssignmentsStore interface {
+	Add(ctx context.Context, guildId uint64, ticketId, labelId int) error
+	GetByTicket(ctx context.Context, guildId uint64, ticketId int) ([]int, error)
+	GetByTickets(ctx context.Context, guildId uint64, ticketIds []int) (map[int][]int, error)
+	Replace(ctx context.Context, guildId uint64, ticketId int, labelIds []int) error
+}
+
+type TicketLabelsStore interface {
+	Get(ctx context.Context, guildId uint64, labelId int) (database.TicketLabel, bool, error)
+	GetByGuild(ctx context.Context, guildId uint64) ([]database.TicketLabel, error)
+}
+
+type TicketLastMessageStore interface {
+	Get(ctx context.Context, guildId uint64, ticketId int) (lastMessage database.TicketLastMessage, e error)
+	Set(ctx context.Context, guildId uint64, ticketId int, messageId, userId uint64, userIsStaff bool) (err error)
+}
+
+type TicketLimitStore interface {
+	Get(ctx context.Context, guildId uint64) (limit uint8, e error)
+	Set(ctx context.Context, guildId uint64, limit uint8) (err error)
+}
+
+type TicketMembersStore interface {
+	Add(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error)
+	Delete(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error)
+	Get(ctx context.Context, guildId uint64, ticketId int) (members []uint64, e error)
+}
+
+type TicketPermissionsStore interface {
+	Get(ctx context.Context, guildId uint64) (database.TicketPermissions, error)
+}
+
+type TicketsStore interface {
+	Close(ctx context.Context, ticketId int, guildId uint64) (err error)
+	CloseByChannel(ctx context.Context, channelId uint64) (err error)
+	Create(ctx context.Context, guildId, userId uint64, isThread bool, panelId *int) (id int, err error)
+	Get(ctx context.Context, ticketId int, guildId uint64) (ticket database.Ticket, e error)
+	GetAllByUser(ctx context.Context, guildId, userId uint64) (tickets []database.Ticket, e error)
+	GetByChannel(ctx context.Context, channelId uint64) (database.Ticket, bool, error)
+	GetByChannelAndGuild(ctx context.Context, channelId, guildId uint64) (ticket database.Ticket, e error)
+	GetByOptions(ctx context.Context, options database.TicketQueryOptions) (tickets []database.Ticket, e error)
+	GetClosedByUserPrefixed(ctx context.Context, guildId, userId uint64, prefix string, limit int) (tickets []database.Ticket, e error)
+	GetGuildOpenTickets(ctx context.Context, guildId uint64) (tickets []database.Ticket, e error)
+	GetGuildOpenTicketsExcludeThreads(ctx context.Context, guildId uint64) (tickets []database.Ticket, e error)
+	GetGuildOpenTicketsWithMetadata(ctx context.Context, guildId uint64) ([]database.TicketWithMetadata, error)
+	GetOpenByUser(ctx context.Context, guildId, userId uint64) (tickets []database.Ticket, e error)
+	GetOpenCountByUser(ctx context.Context, guildId, userId uint64) (int, error)
+	GetOpenCountByUserAndPanel(ctx context.Context, guildId, userId uint64, panelId int) (int, error)
+	GetTotalCountByUser(ctx context.Context, guildId, userId uint64) (int, error)
+	GetTotalTicketCount(ctx context.Context, guildId uint64) (count int, e error)
+	GetTotalTicketCountInterval(ctx context.Context, guildId uint64, interval time.Duration) (count int, e error)
+	SetChannelId(ctx context.Context, guildId uint64, ticketId int, channelId uint64) (err error)
+	SetHasTranscript(ctx context.Context, guildId uint64, ticketId int, hasTranscript bool) (err error)
+	SetJoinMessageId(ctx context.Context, guildId uint64, ticketId int, joinMessageId *uint64) (err error)
+	SetMessageIds(ctx context.Context, guildId uint64, ticketId int, welcomeMessageId uint64, joinMessageId *uint64) (err error)
+	SetNotesThreadId(ctx context.Context, guildId uint64, ticketId int, notesThreadId uint64) error
+	SetOpen(ctx context.Context, guildId uint64, ticketId int) (err error)
+	SetPanelId(ctx context.Context, guildId uint64, ticketId, panelId int) (err error)
+	SetStatus(ctx context.Context, guildId uint64, ticketId int, status model.TicketStatus) error
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+type UsedKeysStore interface {
+	Set(ctx context.Context, tx pgx.Tx, key uuid.UUID, guildId, userId uint64) (err error)
+}
+
+type UsersCanCloseStore interface {
+	Get(ctx context.Context, guildId uint64) (usersCanClose bool, e error)
+}
+
+type VoteCreditsStore interface {
+	Delete(ctx context.Context, tx pgx.Tx, userId uint64) error
+	Get(ctx context.Context, tx pgx.Tx, userId uint64) (int, error)
+}
+
+type WebhooksStore interface {
+	Create(ctx context.Context, guildId uint64, ticketId int, webhook database.Webhook) (err error)
+	Delete(ctx context.Context, guildId uint64, ticketId int) (err error)
+}
+
+type WelcomeMessagesStore interface {
+	Get(ctx context.Context, guildId uint64) (welcomeMessage string, e error)
+}
+
+type WhitelabelStore interface {
+	GetByBotId(ctx context.Context, botId uint64) (database.WhitelabelBot, error)
+	GetByUserId(ctx context.Context, userId uint64) (database.WhitelabelBot, error)
+}
+
+type WhitelabelErrorsStore interface {
+	GetRecent(ctx context.Context, userId uint64, limit int) (errors []database.WhitelabelError, e error)
+}
+
+type WhitelabelGuildsStore interface {
+	Add(ctx context.Context, botId, guildId uint64) (err error)
+	Delete(ctx context.Context, botId, guildId uint64) (err error)
+	GetBotByGuild(ctx context.Context, guildId uint64) (botId uint64, found bool, e error)
+	GetGuilds(ctx context.Context, botId uint64) (guilds []uint64, e error)
+}
+
+// Tables is the set of database module tables used by the worker
+type Tables struct {
+	ActiveLanguage                ActiveLanguageStore
+	ArchiveChannel                ArchiveChannelStore
+	ArchiveDmMessages             ArchiveDmMessagesStore
+	ArchiveMessages               ArchiveMessagesStore
+	AutoClose                     AutoCloseStore
+	AutoCloseExclude              AutoCloseExcludeStore
+	Blacklist                     BlacklistStore
+	CategoryUpdateQueue           CategoryUpdateQueueStore
+	ChannelCategory               ChannelCategoryStore
+	ClaimSettings                 ClaimSettingsStore
+	CloseConfirmation             CloseConfirmationStore
+	CloseReason                   CloseReasonStore
+	CloseRequest                  CloseRequestStore
+	CustomColours                 CustomColoursStore
+	CustomIntegrationGuilds       CustomIntegrationGuildsStore
+	CustomIntegrationHeaders      CustomIntegrationHeadersStore
+	CustomIntegrationPlaceholders CustomIntegrationPlaceholdersStore
+	CustomIntegrationSecretValues CustomIntegrationSecretValuesStore
+	EmbedFields                   EmbedFieldsStore
+	Embeds                        EmbedsStore
+	Entitlements                  EntitlementsStore
+	ExitSurveyResponses           ExitSurveyResponsesStore
+	Experiment                    ExperimentStore
+	FeedbackEnabled               FeedbackEnabledStore
+	FirstResponseTime             FirstResponseTimeStore
+	FormInput                     FormInputStore
+	FormInputOption               FormInputOptionStore
+	Forms                         FormsStore
+	GdprLogs                      GdprLogsStore
+	GlobalBlacklist               GlobalBlacklistStore
+	GuildLeaveTime                GuildLeaveTimeStore
+	GuildMetadata                 GuildMetadataStore
+	LegacyPremiumEntitlements     LegacyPremiumEntitlementsStore
+	NamingScheme                  NamingSchemeStore
+	OnCall                        OnCallStore
+	Panel                         PanelStore
+	PanelAccessControlRules       PanelAccessControlRulesStore
+	PanelHereMention              PanelHereMentionStore
+	PanelRoleMentions             PanelRoleMentionsStore
+	PanelSupportHours             PanelSupportHoursStore
+	PanelSupportHoursSettings     PanelSupportHoursSettingsStore
+	PanelTeams                    PanelTeamsStore
+	PanelTicketPermissions        PanelTicketPermissionsStore
+	PanelUserMention              PanelUserMentionStore
+	Participants                  ParticipantsStore
+	Permissions                   PermissionsStore
+	PremiumKeys                   PremiumKeysStore
+	RoleBlacklist                 RoleBlacklistStore
+	RolePermissions               RolePermissionsStore
+	ServerBlacklist               ServerBlacklistStore
+	ServiceRatings                ServiceRatingsStore
+	Settings                      SettingsStore
+	SubscriptionSkus              SubscriptionSkusStore
+	SupportTeam                   SupportTeamStore
+	SupportTeamMembers            SupportTeamMembersStore
+	SupportTeamPermissions        SupportTeamPermissionsStore
+	SupportTeamRoles              SupportTeamRolesStore
+	Tag                           TagStore
+	TicketClaims                  TicketClaimsStore
+	TicketLabelAssignments        TicketLabelAssignmentsStore
+	TicketLabels                  TicketLabelsStore
+	TicketLastMessage             TicketLastMessageStore
+	TicketLimit                   TicketLimitStore
+	TicketMembers                 TicketMembersStore
+	TicketPermissions             TicketPermissionsStore
+	Tickets                       TicketsStore
+	UsedKeys                      UsedKeysStore
+	UsersCanClose                 UsersCanCloseStore
+	VoteCredits                   VoteCreditsStore
+	Webhooks                      WebhooksStore
+	WelcomeMessages               WelcomeMessagesStore
+	Whitelabel                    WhitelabelStore
+	WhitelabelErrors              WhitelabelErrorsStore
+	WhitelabelGuilds              WhitelabelGuildsStore
+}
+
+func NewTables(db *database.Database) Tables {
+	return Tables{
+		ActiveLanguage:                db.ActiveLanguage,
+		ArchiveChannel:                db.ArchiveChannel,
+		ArchiveDmMessages:             db.ArchiveDmMessages,
+		ArchiveMessages:               db.ArchiveMessages,
+		AutoClose:                     db.AutoClose,
+		AutoCloseExclude:              db.AutoCloseExclude,
+		Blacklist:                     db.Blacklist,
+		CategoryUpdateQueue:           db.CategoryUpdateQueue,
+		ChannelCategory:               db.ChannelCategory,
+		ClaimSettings:                 db.ClaimSettings,
+		CloseConfirmation:             db.CloseConfirmation,
+		CloseReason:                   db.CloseReason,
+		CloseRequest:                  db.CloseRequest,
+		CustomColours:                 db.CustomColours,
+		CustomIntegrationGuilds:       db.CustomIntegrationGuilds,
+		CustomIntegrationHeaders:      db.CustomIntegrationHeaders,
+		CustomIntegrationPlaceholders: db.CustomIntegrationPlaceholders,
+		CustomIntegrationSecretValues: db.CustomIntegrationSecretValues,
+		EmbedFields:                   db.EmbedFields,
+		Embeds:                        db.Embeds,
+		Entitlements:                  db.Entitlements,
+		ExitSurveyResponses:           db.ExitSurveyResponses,
+		Experiment:                    db.Experiment,
+		FeedbackEnabled:               db.FeedbackEnabled,
+		FirstResponseTime:             db.FirstResponseTime,
+		FormInput:                     db.FormInput,
+		FormInputOption:               db.FormInputOption,
+		Forms:                         db.Forms,
+		GdprLogs:                      db.GdprLogs,
+		GlobalBlacklist:               db.GlobalBlacklist,
+		GuildLeaveTime:                db.GuildLeaveTime,
+		GuildMetadata:                 db.GuildMetadata,
+		LegacyPremiumEntitlements:     db.LegacyPremiumEntitlements,
+		NamingScheme:                  db.NamingScheme,
+		OnCall:                        db.OnCall,
+		Panel:                         db.Panel,
+		PanelAccessControlRules:       db.PanelAccessControlRules,
+		PanelHereMention:              db.PanelHereMention,
+		PanelRoleMentions:             db.PanelRoleMentions,
+		PanelSupportHours:             db.PanelSupportHours,
+		PanelSupportHoursSettings:     db.PanelSupportHoursSettings,
+		PanelTeams:                    db.PanelTeams,
+		PanelTicketPermissions:        db.PanelTicketPermissions,
+		PanelUserMention:              db.PanelUserMention,
+		Participants:                  db.Participants,
+		Permissions:                   db.Permissions,
+		PremiumKeys:                   db.PremiumKeys,
+		RoleBlacklist:                 db.RoleBlacklist,
+		RolePermissions:               db.RolePermissions,
+		ServerBlacklist:               db.ServerBlacklist,
+		ServiceRatings:                db.ServiceRatings,
+		Settings:                      db.Settings,
+		SubscriptionSkus:              db.SubscriptionSkus,
+		SupportTeam:                   db.SupportTeam,
+		SupportTeamMembers:            db.SupportTeamMembers,
+		SupportTeamPermissions:        db.SupportTeamPermissions,
+		SupportTeamRoles:              db.SupportTeamRoles,
+		Tag:                           db.Tag,
+		TicketClaims:                  db.TicketClaims,
+		TicketLabelAssignments:        db.TicketLabelAssignments,
+		TicketLabels:                  db.TicketLabels,
+		TicketLastMessage:             db.TicketLastMessage,
+		TicketLimit:                   db.TicketLimit,
+		TicketMembers:                 db.TicketMembers,
+		TicketPermissions:             db.TicketPermissions,
+		Tickets:                       db.Tickets,
+		UsedKeys:                      db.UsedKeys,
+		UsersCanClose:                 db.UsersCanClose,
+		VoteCredits:                   db.VoteCredits,
+		Webhooks:                      db.Webhooks,
+		WelcomeMessages:               db.WelcomeMessages,
+		Whitelabel:                    db.Whitelabel,
+		WhitelabelErrors:              db.WhitelabelErrors,
+		WhitelabelGuilds:              db.WhitelabelGuilds,
+	}
+}
@@ -0,0 +1,30 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TicketCountsStore interface {
+	GetOpenedBefore(ctx context.Context, guildId, userId uint64, ticketId int) (int, error)
+}
+
+type TicketCountsTable struct {
+	*pgxpool.Pool
+}
+
+// GetOpenedBefore returns the number of tickets the user opened in the guild before the given ticket
+func (t *TicketCountsTable) GetOpenedBefore(ctx context.Context, guildId, userId uint64, ticketId int) (int, error) {
+	query := `
+SELECT COUNT(*)
+FROM tickets
+WHERE "guild_id" = $1 AND "user_id" = $2 AND "id" < $3;`
+
+	var count int
+	if err := t.QueryRow(ctx, query, guildId, userId, ticketId).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
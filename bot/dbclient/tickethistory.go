@@ -0,0 +1,57 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// TicketHistoryConfig controls where a ticket opener's previous tickets are surfaced in the welcome message
+type TicketHistoryConfig struct {
+	WelcomeButton       bool
+	ReturningUserNotice bool
+}
+
+type TicketHistoryConfigStore interface {
+	Get(ctx context.Context, guildId uint64) (TicketHistoryConfig, error)
+	Set(ctx context.Context, guildId uint64, config TicketHistoryConfig) error
+}
+
+type TicketHistoryConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (TicketHistoryConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_history_config(
+	"guild_id" int8 NOT NULL,
+	"welcome_button" bool NOT NULL,
+	"returning_user_notice" bool NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+// Get returns the guild's config, with both options disabled if it has not set one
+func (t *TicketHistoryConfigTable) Get(ctx context.Context, guildId uint64) (TicketHistoryConfig, error) {
+	query := `SELECT "welcome_button", "returning_user_notice" FROM ticket_history_config WHERE "guild_id" = $1;`
+
+	var config TicketHistoryConfig
+	if err := t.QueryRow(ctx, query, guildId).Scan(&config.WelcomeButton, &config.ReturningUserNotice); err != nil && err != pgx.ErrNoRows {
+		return TicketHistoryConfig{}, err
+	}
+
+	return config, nil
+}
+
+func (t *TicketHistoryConfigTable) Set(ctx context.Context, guildId uint64, config TicketHistoryConfig) error {
+	query := `
+INSERT INTO ticket_history_config("guild_id", "welcome_button", "returning_user_notice")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET
+	"welcome_button" = EXCLUDED."welcome_button",
+	"returning_user_notice" = EXCLUDED."returning_user_notice";`
+
+	_, err := t.Exec(ctx, query, guildId, config.WelcomeButton, config.ReturningUserNotice)
+	return err
+}
@@ -0,0 +1,69 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// TranscriptExportConfig controls whether a rendered transcript file is attached to the close messages
+type TranscriptExportConfig struct {
+	Format         string
+	ArchiveChannel bool
+	DirectMessage  bool
+	Timezone       string
+}
+
+type TranscriptExportConfigStore interface {
+	Get(ctx context.Context, guildId uint64) (TranscriptExportConfig, bool, error)
+	Set(ctx context.Context, guildId uint64, config TranscriptExportConfig) error
+}
+
+type TranscriptExportConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (TranscriptExportConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS transcript_export_config(
+	"guild_id" int8 NOT NULL,
+	"format" varchar(16) NOT NULL,
+	"archive_channel" bool NOT NULL,
+	"direct_message" bool NOT NULL,
+	"timezone" varchar(64) NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (t *TranscriptExportConfigTable) Get(ctx context.Context, guildId uint64) (TranscriptExportConfig, bool, error) {
+	query := `
+SELECT "format", "archive_channel", "direct_message", "timezone"
+FROM transcript_export_config
+WHERE "guild_id" = $1;`
+
+	var config TranscriptExportConfig
+	if err := t.QueryRow(ctx, query, guildId).Scan(&config.Format, &config.ArchiveChannel, &config.DirectMessage, &config.Timezone); err != nil {
+		if err == pgx.ErrNoRows {
+			return TranscriptExportConfig{}, false, nil
+		}
+
+		return TranscriptExportConfig{}, false, err
+	}
+
+	return config, true, nil
+}
+
+func (t *TranscriptExportConfigTable) Set(ctx context.Context, guildId uint64, config TranscriptExportConfig) error {
+	query := `
+INSERT INTO transcript_export_config("guild_id", "format", "archive_channel", "direct_message", "timezone")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("guild_id") DO UPDATE SET
+	"format" = EXCLUDED."format",
+	"archive_channel" = EXCLUDED."archive_channel",
+	"direct_message" = EXCLUDED."direct_message",
+	"timezone" = EXCLUDED."timezone";`
+
+	_, err := t.Exec(ctx, query, guildId, config.Format, config.ArchiveChannel, config.DirectMessage, config.Timezone)
+	return err
+}
@@ -0,0 +1,67 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// UserContextMenuConfig holds the minimum permission level required to use each of the staff user context menu
+// commands. Levels are stored in the same form as Settings.ContextMenuPermissionLevel.
+type UserContextMenuConfig struct {
+	OpenLevel        int
+	ViewTicketsLevel int
+	BlacklistLevel   int
+}
+
+type UserContextMenuConfigStore interface {
+	Get(ctx context.Context, guildId uint64) (UserContextMenuConfig, bool, error)
+	Set(ctx context.Context, guildId uint64, config UserContextMenuConfig) error
+}
+
+type UserContextMenuConfigTable struct {
+	*pgxpool.Pool
+}
+
+func (UserContextMenuConfigTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS user_context_menu_config(
+	"guild_id" int8 NOT NULL,
+	"open_level" int4 NOT NULL,
+	"view_tickets_level" int4 NOT NULL,
+	"blacklist_level" int4 NOT NULL,
+	PRIMARY KEY("guild_id")
+);`
+}
+
+func (u *UserContextMenuConfigTable) Get(ctx context.Context, guildId uint64) (UserContextMenuConfig, bool, error) {
+	query := `
+SELECT "open_level", "view_tickets_level", "blacklist_level"
+FROM user_context_menu_config
+WHERE "guild_id" = $1;`
+
+	var config UserContextMenuConfig
+	if err := u.QueryRow(ctx, query, guildId).Scan(&config.OpenLevel, &config.ViewTicketsLevel, &config.BlacklistLevel); err != nil {
+		if err == pgx.ErrNoRows {
+			return UserContextMenuConfig{}, false, nil
+		}
+
+		return UserContextMenuConfig{}, false, err
+	}
+
+	return config, true, nil
+}
+
+func (u *UserContextMenuConfigTable) Set(ctx context.Context, guildId uint64, config UserContextMenuConfig) error {
+	query := `
+INSERT INTO user_context_menu_config("guild_id", "open_level", "view_tickets_level", "blacklist_level")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id") DO UPDATE SET
+	"open_level" = EXCLUDED."open_level",
+	"view_tickets_level" = EXCLUDED."view_tickets_level",
+	"blacklist_level" = EXCLUDED."blacklist_level";`
+
+	_, err := u.Exec(ctx, query, guildId, config.OpenLevel, config.ViewTicketsLevel, config.BlacklistLevel)
+	return err
+}
@@ -0,0 +1,44 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type WhitelabelToken struct {
+	BotId uint64
+	Token string
+}
+
+type WhitelabelTokensStore interface {
+	GetAll(ctx context.Context) ([]WhitelabelToken, error)
+}
+
+type WhitelabelTokensTable struct {
+	*pgxpool.Pool
+}
+
+// GetAll returns the token of every whitelabel bot
+func (w *WhitelabelTokensTable) GetAll(ctx context.Context) ([]WhitelabelToken, error) {
+	query := `SELECT "bot_id", "token" FROM whitelabel;`
+
+	rows, err := w.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tokens []WhitelabelToken
+	for rows.Next() {
+		var token WhitelabelToken
+		if err := rows.Scan(&token.BotId, &token.Token); err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
@@ -0,0 +1,84 @@
+package dbclient
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// WorkerTables holds the queries which belong to the worker, rather than the database module. Like the stores in
+// stores.go, each is behind an interface so that it can be replaced with a fake in tests.
+type WorkerTables struct {
+	CloseReasonConfig      CloseReasonConfigStore
+	ClosedCategoryConfig   ClosedCategoryConfigStore
+	ClosedChannels         ClosedChannelStore
+	FeedbackFollowUpConfig FeedbackFollowUpConfigStore
+	NotesTranscriptConfig  NotesTranscriptConfigStore
+	PreservedAttachments   PreservedAttachmentStore
+	ReopenLinks            ReopenLinkStore
+	Reporting              ReportingStore
+	SpamBlacklist          SpamBlacklistStore
+	SpamConfig             SpamConfigStore
+	SpamStats              SpamStatsStore
+	StatsDigests           StatsDigestStore
+	TicketCounts           TicketCountsStore
+	TicketHistoryConfig    TicketHistoryConfigStore
+	TranscriptExportConfig TranscriptExportConfigStore
+	UserContextMenuConfig  UserContextMenuConfigStore
+	WhitelabelTokens       WhitelabelTokensStore
+}
+
+func NewWorkerTables(pool *pgxpool.Pool) WorkerTables {
+	return WorkerTables{
+		CloseReasonConfig:      &CloseReasonConfigTable{pool},
+		ClosedCategoryConfig:   &ClosedCategoryConfigTable{pool},
+		ClosedChannels:         &ClosedChannelTable{pool},
+		FeedbackFollowUpConfig: &FeedbackFollowUpConfigTable{pool},
+		NotesTranscriptConfig:  &NotesTranscriptConfigTable{pool},
+		PreservedAttachments:   &PreservedAttachmentTable{pool},
+		ReopenLinks:            &ReopenLinkTable{pool},
+		Reporting:              &ReportingTable{pool},
+		SpamBlacklist:          &SpamBlacklistTable{pool},
+		SpamConfig:             &SpamConfigTable{pool},
+		SpamStats:              &SpamStatsTable{pool},
+		StatsDigests:           &StatsDigestTable{pool},
+		TicketCounts:           &TicketCountsTable{pool},
+		TicketHistoryConfig:    &TicketHistoryConfigTable{pool},
+		TranscriptExportConfig: &TranscriptExportConfigTable{pool},
+		UserContextMenuConfig:  &UserContextMenuConfigTable{pool},
+		WhitelabelTokens:       &WhitelabelTokensTable{pool},
+	}
+}
+
+type table interface {
+	Schema() string
+}
+
+// CreateWorkerTables creates the tables which the worker owns, if they do not already exist. The tables of the
+// database module are created by the database module.
+func CreateWorkerTables(ctx context.Context, pool *pgxpool.Pool) error {
+	tables := []table{
+		CloseReasonConfigTable{},
+		ClosedCategoryConfigTable{},
+		ClosedChannelTable{},
+		FeedbackFollowUpConfigTable{},
+		NotesTranscriptConfigTable{},
+		PreservedAttachmentTable{},
+		ReopenLinkTable{},
+		SpamBlacklistTable{},
+		SpamConfigTable{},
+		SpamStatsTable{},
+		StatsDigestTable{},
+		TicketHistoryConfigTable{},
+		TranscriptExportConfigTable{},
+		UserContextMenuConfigTable{},
+	}
+
+	for _, t := range tables {
+		if _, err := pool.Exec(ctx, t.Schema()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
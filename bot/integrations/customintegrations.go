@@ -12,6 +12,7 @@ import (
 
 	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 )
@@ -40,6 +41,7 @@ type integrationWebhookBody struct {
 
 func Fetch(
 	ctx context.Context,
+	proxy services.IntegrationProxy,
 	integration database.CustomIntegration,
 	ticket database.Ticket,
 	secrets []database.SecretWithValue,
@@ -72,7 +74,7 @@ func Fetch(
 		headerMap[header.Name] = value
 	}
 
-	var body requestBody = nil
+	var body any = nil
 	if integration.HttpMethod == http.MethodPost {
 		postBody := integrationWebhookBody{
 			GuildId:         ticket.GuildId,
@@ -89,7 +91,7 @@ func Fetch(
 		body = postBody
 	}
 
-	res, err := SecureProxy.DoRequest(ctx, integration.HttpMethod, url, headerMap, body)
+	res, err := proxy.DoRequest(ctx, integration.HttpMethod, url, headerMap, body)
 	if err != nil {
 		return nil, err
 	}
@@ -5,12 +5,8 @@ import (
 	"github.com/TicketsBot-cloud/worker/config"
 )
 
-var (
-	WebProxy    *webproxy.WebProxy
-	SecureProxy *SecureProxyClient
-)
+var WebProxy *webproxy.WebProxy
 
 func InitIntegrations() {
 	WebProxy = webproxy.NewWebProxy(config.Conf.WebProxy.Url, config.Conf.WebProxy.AuthHeaderName, config.Conf.WebProxy.AuthHeaderValue)
-	SecureProxy = NewSecureProxy(config.Conf.Integrations.SecureProxyUrl)
 }
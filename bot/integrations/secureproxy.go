@@ -33,11 +33,7 @@ type secureProxyRequest struct {
 	JsonBody json.RawMessage   `json:"json_body,omitempty"`
 }
 
-type requestBody interface {
-	[]byte | any
-}
-
-func (p *SecureProxyClient) DoRequest(ctx context.Context, method, url string, headers map[string]string, bodyData requestBody) ([]byte, error) {
+func (p *SecureProxyClient) DoRequest(ctx context.Context, method, url string, headers map[string]string, bodyData any) ([]byte, error) {
 	body := secureProxyRequest{
 		Method:  method,
 		Url:     url,
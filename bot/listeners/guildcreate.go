@@ -15,7 +15,6 @@ import (
 	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/blacklist"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
 	"github.com/TicketsBot-cloud/worker/config"
 )
@@ -44,12 +43,12 @@ func OnGuildCreate(worker *worker.Context, e events.GuildCreate) {
 			sendIntroMessage(ctx, worker, e.Guild, inviter)
 		}
 
-		if err := dbclient.Client.GuildLeaveTime.Delete(ctx, e.Guild.Id); err != nil {
+		if err := worker.Services.Database.GuildLeaveTime.Delete(ctx, e.Guild.Id); err != nil {
 			sentry.Error(err)
 		}
 
 		// Add guild owner as ticket admin
-		if err := dbclient.Client.Permissions.AddAdmin(ctx, e.Guild.Id, e.Guild.OwnerId); err != nil {
+		if err := worker.Services.Database.Permissions.AddAdmin(ctx, e.Guild.Id, e.Guild.OwnerId); err != nil {
 			sentry.Error(err)
 		}
 
@@ -61,7 +60,7 @@ func OnGuildCreate(worker *worker.Context, e events.GuildCreate) {
 			}
 
 			if permission.HasPermissionRaw(role.Permissions, permission.Administrator) {
-				if err := dbclient.Client.RolePermissions.AddAdmin(ctx, e.Guild.Id, role.Id); err != nil { // TODO: Bulk
+				if err := worker.Services.Database.RolePermissions.AddAdmin(ctx, e.Guild.Id, role.Id); err != nil { // TODO: Bulk
 					sentry.Error(err)
 				}
 			}
@@ -79,7 +78,7 @@ func sendIntroMessage(ctx context.Context, worker *worker.Context, guild guild.G
 	msg := embed.NewEmbed().
 		SetTitle("Tickets").
 		SetDescription(fmt.Sprintf("Thank you for inviting Tickets to your server! Below is a quick guide on setting up the bot, please don't hesitate to contact us in our [support server](%s) if you need any assistance!", config.Conf.Bot.SupportServerInvite)).
-		SetColor(customisation.GetColourOrDefault(ctx, guild.Id, customisation.Green)).
+		SetColor(customisation.GetColourOrDefault(ctx, worker, guild.Id, customisation.Green)).
 		AddField("Setup", fmt.Sprintf("You can setup the bot using `/setup`, or you can use the [web dashboard](%s) which has additional options", config.Conf.Bot.DashboardUrl), false).
 		AddField("Ticket Panels", fmt.Sprintf("Ticket panels are a commonly used feature of the bot. You can read about them [here](%s/panels), or create one on the [web dashboard](%s/manage/%d/panels)", config.Conf.Bot.FrontpageUrl, config.Conf.Bot.DashboardUrl, guild.Id), false).
 		AddField("Adding Staff", "To allow staff to answer tickets, you must let the bot know about them first. You can do this through\n`/addsupport [@User / @Role]` and `/addadmin [@User / @Role]`. While both Support and Admin can access the dashboard, Bot Admins can change the settings of the bot.", false).
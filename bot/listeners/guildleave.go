@@ -7,7 +7,6 @@ import (
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/gdl/gateway/payloads/events"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
 )
 
@@ -27,17 +26,17 @@ func OnGuildLeave(worker *worker.Context, e events.GuildDelete) {
 		statsd.Client.IncrementKey(statsd.KeyLeaves)
 
 		if worker.IsWhitelabel {
-			if err := dbclient.Client.WhitelabelGuilds.Delete(ctx, worker.BotId, e.Guild.Id); err != nil {
+			if err := worker.Services.Database.WhitelabelGuilds.Delete(ctx, worker.BotId, e.Guild.Id); err != nil {
 				sentry.Error(err)
 			}
 		}
 
 		// Exclude from autoclose
-		if err := dbclient.Client.AutoCloseExclude.ExcludeAll(ctx, e.Guild.Id); err != nil {
+		if err := worker.Services.Database.AutoCloseExclude.ExcludeAll(ctx, e.Guild.Id); err != nil {
 			sentry.Error(err)
 		}
 
-		if err := dbclient.Client.GuildLeaveTime.Set(ctx, e.Guild.Id); err != nil {
+		if err := worker.Services.Database.GuildLeaveTime.Set(ctx, e.Guild.Id); err != nil {
 			sentry.Error(err)
 		}
 	}
@@ -9,7 +9,6 @@ import (
 	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/gdl/gateway/payloads/events"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 )
 
 func OnGuildUpdate(worker *worker.Context, e events.GuildUpdate) {
@@ -24,7 +23,7 @@ func OnGuildUpdate(worker *worker.Context, e events.GuildUpdate) {
 	if err != nil {
 		if errors.Is(err, cache.ErrNotFound) {
 			// If we don't have a cached owner, just add the current one as admin
-			if err := dbclient.Client.Permissions.AddAdmin(ctx, e.Guild.Id, e.Guild.OwnerId); err != nil {
+			if err := worker.Services.Database.Permissions.AddAdmin(ctx, e.Guild.Id, e.Guild.OwnerId); err != nil {
 				sentry.Error(err)
 			}
 			return
@@ -36,16 +35,16 @@ func OnGuildUpdate(worker *worker.Context, e events.GuildUpdate) {
 	// Check if ownership changed
 	if oldOwnerId != e.Guild.OwnerId {
 		// Add new owner as ticket admin
-		if err := dbclient.Client.Permissions.AddAdmin(ctx, e.Guild.Id, e.Guild.OwnerId); err != nil {
+		if err := worker.Services.Database.Permissions.AddAdmin(ctx, e.Guild.Id, e.Guild.OwnerId); err != nil {
 			sentry.Error(err)
 		}
 
 		// Remove old owner as ticket admin and support
 		// Note: They may still have admin/support access through their roles with Administrator permission
-		if err := dbclient.Client.Permissions.RemoveAdmin(ctx, e.Guild.Id, oldOwnerId); err != nil {
+		if err := worker.Services.Database.Permissions.RemoveAdmin(ctx, e.Guild.Id, oldOwnerId); err != nil {
 			sentry.Error(err)
 		}
-		if err := dbclient.Client.Permissions.RemoveSupport(ctx, e.Guild.Id, oldOwnerId); err != nil {
+		if err := worker.Services.Database.Permissions.RemoveSupport(ctx, e.Guild.Id, oldOwnerId); err != nil {
 			sentry.Error(err)
 		}
 	}
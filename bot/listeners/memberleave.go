@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/worker"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
@@ -20,7 +19,7 @@ func OnMemberLeave(worker *worker.Context, e events.GuildMemberRemove) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15) // TODO: Propagate context
 	defer cancel()
 
-	if err := dbclient.Client.Permissions.RemoveSupport(ctx, e.GuildId, e.User.Id); err != nil {
+	if err := worker.Services.Database.Permissions.RemoveSupport(ctx, e.GuildId, e.User.Id); err != nil {
 		sentry.Error(err)
 	}
 
@@ -29,19 +28,19 @@ func OnMemberLeave(worker *worker.Context, e events.GuildMemberRemove) {
 	}
 
 	// auto close
-	settings, err := dbclient.Client.AutoClose.Get(ctx, e.GuildId)
+	settings, err := worker.Services.Database.AutoClose.Get(ctx, e.GuildId)
 	if err != nil {
 		sentry.Error(err)
 	} else {
 		// check setting is enabled
 		if settings.Enabled && settings.OnUserLeave != nil && *settings.OnUserLeave {
 			// get open tickets by user
-			tickets, err := dbclient.Client.Tickets.GetOpenByUser(ctx, e.GuildId, e.User.Id)
+			tickets, err := worker.Services.Database.Tickets.GetOpenByUser(ctx, e.GuildId, e.User.Id)
 			if err != nil {
 				sentry.Error(err)
 			} else {
 				for _, ticket := range tickets {
-					isExcluded, err := dbclient.Client.AutoCloseExclude.IsExcluded(ctx, e.GuildId, ticket.Id)
+					isExcluded, err := worker.Services.Database.AutoCloseExclude.IsExcluded(ctx, e.GuildId, ticket.Id)
 					if err != nil {
 						sentry.Error(err)
 						continue
@@ -57,7 +56,7 @@ func OnMemberLeave(worker *worker.Context, e events.GuildMemberRemove) {
 					}
 
 					// get premium status
-					premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+					premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
 					if err != nil {
 						sentry.Error(err)
 						return
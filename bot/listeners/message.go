@@ -14,7 +14,6 @@ import (
 	"github.com/TicketsBot-cloud/gdl/gateway/payloads/events"
 	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
 	"github.com/TicketsBot-cloud/worker/bot/redis"
@@ -42,7 +41,7 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 
 	// Delete pin notification messages in ticket channels.
 	if e.Type == message.MessageTypeChannelPinnedMessage && e.Author.Id == worker.BotId {
-		ticket, ok, err := dbclient.Client.Tickets.GetByChannel(span.Context(), e.ChannelId)
+		ticket, ok, err := worker.Services.Database.Tickets.GetByChannel(span.Context(), e.ChannelId)
 		if err == nil && ok && ticket.Id != 0 {
 			sentry.WithSpan0(span.Context(), "Delete pin notification", func(span *sentry.Span) {
 				if err := worker.DeleteMessage(e.ChannelId, e.Id); err != nil {
@@ -53,7 +52,7 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 		return
 	}
 
-	ticket, isTicket, err := getTicket(span.Context(), e.ChannelId)
+	ticket, isTicket, err := getTicket(span.Context(), worker, e.ChannelId)
 	if err != nil {
 		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 		return
@@ -70,13 +69,13 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 	if e.Author.Id != worker.BotId && !e.Author.Bot {
 		// set participants, for logging
 		sentry.WithSpan0(span.Context(), "Add participant", func(span *sentry.Span) {
-			if err := dbclient.Client.Participants.Set(ctx, e.GuildId, ticket.Id, e.Author.Id); err != nil {
+			if err := worker.Services.Database.Participants.Set(ctx, e.GuildId, ticket.Id, e.Author.Id); err != nil {
 				sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 			}
 		})
 
 		isStaffCached, err = sentry.WithSpan2(span.Context(), "Update ticket last activity", func(span *sentry.Span) (*bool, error) {
-			v, err := isStaff(ctx, e, ticket)
+			v, err := isStaff(ctx, worker, e, ticket)
 			return &v, err
 		})
 
@@ -88,15 +87,30 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 			// Create a new context with timeout for the database operation to avoid deadline exceeded errors
 			updateCtx, updateCancel := context.WithTimeout(context.Background(), time.Second*3)
 			defer updateCancel()
-			if err := updateLastMessage(updateCtx, e, ticket, *isStaffCached); err != nil {
+			if err := updateLastMessage(updateCtx, worker, e, ticket, *isStaffCached); err != nil {
 				sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 			}
 
 			if *isStaffCached { // check the user is staff
 				// We don't have to check for previous responses due to ON CONFLICT DO NOTHING
 				sentry.WithSpan0(span.Context(), "Set first response time", func(span *sentry.Span) {
-					if err := dbclient.Client.FirstResponseTime.Set(ctx, e.GuildId, e.Author.Id, ticket.Id, time.Now().Sub(ticket.OpenTime)); err != nil {
+					responseTime := time.Now().Sub(ticket.OpenTime)
+
+					// Only guilds with histograms enabled pay for the extra lookup of whether this is the first response
+					var isFirstResponse bool
+					if prometheus.HasGuildHistograms(e.GuildId) {
+						hasResponse, err := worker.Services.Database.FirstResponseTime.HasResponse(ctx, e.GuildId, ticket.Id)
+						if err != nil {
+							sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+						} else {
+							isFirstResponse = !hasResponse
+						}
+					}
+
+					if err := worker.Services.Database.FirstResponseTime.Set(ctx, e.GuildId, e.Author.Id, ticket.Id, responseTime); err != nil {
 						sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+					} else if isFirstResponse {
+						prometheus.ObserveFirstResponseTime(e.GuildId, responseTime)
 					}
 				})
 			}
@@ -104,7 +118,7 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 	}
 
 	premiumTier, err := sentry.WithSpan2(span.Context(), "Get premium tier", func(span *sentry.Span) (premium.PremiumTier, error) {
-		return utils.PremiumClient.GetTierByGuildId(ctx, e.GuildId, true, worker.Token, worker.RateLimiter)
+		return worker.Services.Premium.GetTierByGuildId(ctx, e.GuildId, true, worker.Token, worker.RateLimiter)
 	})
 	if err != nil {
 		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
@@ -121,7 +135,7 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 
 			prometheus.ForwardedDashboardMessages.Inc()
 
-			return chatrelay.PublishMessage(redis.Client, data)
+			return chatrelay.PublishMessage(worker.Services.Redis.Client, data)
 		}); err != nil {
 			sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 		}
@@ -132,7 +146,7 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 			if isStaffCached != nil {
 				userIsStaff = *isStaffCached
 			} else {
-				tmp, err := isStaff(ctx, e, ticket)
+				tmp, err := isStaff(ctx, worker, e, ticket)
 				if err != nil {
 					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 					return
@@ -149,13 +163,13 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 			}
 
 			if ticket.Status != newStatus {
-				if err := dbclient.Client.Tickets.SetStatus(ctx, e.GuildId, ticket.Id, newStatus); err != nil {
+				if err := worker.Services.Database.Tickets.SetStatus(ctx, e.GuildId, ticket.Id, newStatus); err != nil {
 					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 				}
 
 				if !ticket.IsThread {
 					if err := sentry.WithSpan1(span.Context(), "Update status update queue", func(span *sentry.Span) error {
-						return dbclient.Client.CategoryUpdateQueue.Add(ctx, e.GuildId, ticket.Id, newStatus)
+						return worker.Services.Database.CategoryUpdateQueue.Add(ctx, e.GuildId, ticket.Id, newStatus)
 					}); err != nil {
 						sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 					}
@@ -165,24 +179,24 @@ func OnMessage(worker *worker.Context, e events.MessageCreate) {
 	}
 }
 
-func updateLastMessage(ctx context.Context, msg events.MessageCreate, ticket database.Ticket, isStaff bool) error {
+func updateLastMessage(ctx context.Context, worker *worker.Context, msg events.MessageCreate, ticket database.Ticket, isStaff bool) error {
 	span := sentry.StartSpan(ctx, "Update last message")
 	defer span.Finish()
 
 	// If last message was sent by staff, don't reset the timer
-	lastMessage, err := dbclient.Client.TicketLastMessage.Get(ctx, ticket.GuildId, ticket.Id)
+	lastMessage, err := worker.Services.Database.TicketLastMessage.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return err
 	}
 
 	// No last message, or last message was before we started storing user IDs
 	if lastMessage.UserId == nil {
-		return dbclient.Client.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, msg.Author.Id, false)
+		return worker.Services.Database.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, msg.Author.Id, false)
 	}
 
 	// If the last message was sent by the ticket opener, we can skip the rest of the logic, and update straight away
 	if ticket.UserId == msg.Author.Id {
-		return dbclient.Client.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, msg.Author.Id, false)
+		return worker.Services.Database.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, msg.Author.Id, false)
 	}
 
 	// If the last message *and* this message were sent by staff members, then do not reset the timer
@@ -190,17 +204,17 @@ func updateLastMessage(ctx context.Context, msg events.MessageCreate, ticket dat
 		return nil
 	}
 
-	return dbclient.Client.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, msg.Author.Id, isStaff)
+	return worker.Services.Database.TicketLastMessage.Set(ctx, ticket.GuildId, ticket.Id, msg.Id, msg.Author.Id, isStaff)
 }
 
 // This method should not be used for anything requiring elevated privileges
-func isStaff(ctx context.Context, msg events.MessageCreate, ticket database.Ticket) (bool, error) {
+func isStaff(ctx context.Context, worker *worker.Context, msg events.MessageCreate, ticket database.Ticket) (bool, error) {
 	// If the user is the ticket opener, they are not staff
 	if msg.Author.Id == ticket.UserId {
 		return false, nil
 	}
 
-	members, err := dbclient.Client.TicketMembers.Get(ctx, ticket.GuildId, ticket.Id)
+	members, err := worker.Services.Database.TicketMembers.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return false, err
 	}
@@ -212,9 +226,9 @@ func isStaff(ctx context.Context, msg events.MessageCreate, ticket database.Tick
 	return true, nil
 }
 
-func getTicket(ctx context.Context, channelId uint64) (database.Ticket, bool, error) {
+func getTicket(ctx context.Context, worker *worker.Context, channelId uint64) (database.Ticket, bool, error) {
 	isTicket, err := sentry.WithSpan2(ctx, "IsTicketChannel redis lookup", func(span *sentry.Span) (bool, error) {
-		return redis.IsTicketChannel(ctx, channelId)
+		return worker.Services.Redis.IsTicketChannel(ctx, channelId)
 	})
 
 	cacheHit := err == nil
@@ -228,7 +242,7 @@ func getTicket(ctx context.Context, channelId uint64) (database.Ticket, bool, er
 
 	// Either cache miss or the ticket *does* exist, so we need to fetch the object from the database
 	ticket, err := sentry.WithSpan2(ctx, "Get ticket by channel", func(span *sentry.Span) (database.Ticket, error) {
-		ticket, ok, err := dbclient.Client.Tickets.GetByChannel(ctx, channelId)
+		ticket, ok, err := worker.Services.Database.Tickets.GetByChannel(ctx, channelId)
 		if err != nil {
 			return database.Ticket{}, err
 		}
@@ -244,7 +258,7 @@ func getTicket(ctx context.Context, channelId uint64) (database.Ticket, bool, er
 		return database.Ticket{}, false, err
 	}
 
-	if err := redis.SetTicketChannelStatus(ctx, channelId, ticket.Id != 0); err != nil {
+	if err := worker.Services.Redis.SetTicketChannelStatus(ctx, channelId, ticket.Id != 0); err != nil {
 		return database.Ticket{}, false, err
 	}
 
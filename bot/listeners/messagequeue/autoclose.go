@@ -6,22 +6,19 @@ import (
 	"github.com/TicketsBot-cloud/common/autoclose"
 	"github.com/TicketsBot-cloud/common/sentry"
 	gdlUtils "github.com/TicketsBot-cloud/gdl/utils"
-	"github.com/TicketsBot-cloud/worker/bot/cache"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
-	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"go.uber.org/zap"
 )
 
 const AutoCloseReason = "Automatically closed due to inactivity"
 
-func ListenAutoClose(logger *zap.Logger) {
+func ListenAutoClose(logger *zap.Logger, services *services.Services) {
 	ch := make(chan autoclose.Ticket)
-	go autoclose.Listen(redis.Client, ch)
+	go autoclose.Listen(services.Redis.Client, ch)
 
 	for acTicket := range ch {
 		statsd.Client.IncrementKey(statsd.AutoClose)
@@ -37,7 +34,7 @@ func ListenAutoClose(logger *zap.Logger) {
 			)
 
 			// get ticket
-			ticket, err := dbclient.Client.Tickets.Get(ctx, acTicket.TicketId, acTicket.GuildId)
+			ticket, err := services.Database.Tickets.Get(ctx, acTicket.TicketId, acTicket.GuildId)
 			if err != nil {
 				logger.Error("Failed to fetch ticket for autoclose",
 					zap.Int("ticket_id", acTicket.TicketId),
@@ -49,7 +46,7 @@ func ListenAutoClose(logger *zap.Logger) {
 			}
 
 			// get worker
-			worker, err := buildContext(ctx, ticket, cache.Client)
+			worker, err := buildContext(ctx, ticket, services)
 			if err != nil {
 				logger.Error("Failed to build worker context for autoclose",
 					zap.Int("ticket_id", acTicket.TicketId),
@@ -70,7 +67,7 @@ func ListenAutoClose(logger *zap.Logger) {
 			}
 
 			// get premium status
-			premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+			premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
 			if err != nil {
 				logger.Error("Failed to get premium tier for autoclose",
 					zap.Int("ticket_id", acTicket.TicketId),
@@ -0,0 +1,91 @@
+package messagequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"go.uber.org/zap"
+)
+
+const (
+	closedChannelPollInterval = time.Minute
+	closedChannelBatchSize    = 50
+	closedChannelRetryDelay   = time.Hour
+)
+
+// ListenClosedChannels deletes ticket channels which have been kept in the closed category for their retention period.
+// Each deletion is claimed atomically, so it is safe for every worker to run this loop.
+func ListenClosedChannels(logger *zap.Logger, services *services.Services) {
+	timer := time.NewTicker(closedChannelPollInterval)
+
+	for {
+		<-timer.C
+
+		due, err := services.Database.ClosedChannels.GetDue(context.Background(), time.Now(), closedChannelBatchSize)
+		if err != nil {
+			logger.Error("Failed to fetch closed channels due for deletion", zap.Error(err))
+			sentry.Error(err)
+			continue
+		}
+
+		for _, closed := range due {
+			closed := closed
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+				defer cancel()
+
+				if err := deleteClosedChannel(ctx, services, closed); err != nil {
+					logger.Error("Failed to delete closed ticket channel",
+						zap.Uint64("guild_id", closed.GuildId),
+						zap.Int("ticket_id", closed.TicketId),
+						zap.Error(err),
+					)
+					sentry.Error(err)
+				}
+			}()
+		}
+	}
+}
+
+func deleteClosedChannel(ctx context.Context, services *services.Services, closed dbclient.ClosedChannel) error {
+	claimed, err := services.Database.ClosedChannels.Claim(ctx, closed, time.Now().Add(closedChannelRetryDelay))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	ticket, err := services.Database.Tickets.Get(ctx, closed.TicketId, closed.GuildId)
+	if err != nil {
+		return err
+	}
+
+	// The ticket has been reopened in place, so the channel must be kept
+	if ticket.Id != 0 && ticket.Open {
+		return services.Database.ClosedChannels.Delete(ctx, closed.GuildId, closed.TicketId)
+	}
+
+	worker, err := buildGuildContext(ctx, closed.GuildId, services)
+	if err != nil {
+		return err
+	}
+
+	reasonCtx := request.WithAuditReason(ctx, fmt.Sprintf("Ticket %d closed channel retention period ended", closed.TicketId))
+	if _, err := worker.DeleteChannel(reasonCtx, closed.ChannelId); err != nil {
+		var restError request.RestError
+		if !errors.As(err, &restError) || restError.StatusCode != http.StatusNotFound {
+			return err
+		}
+	}
+
+	return services.Database.ClosedChannels.Delete(ctx, closed.GuildId, closed.TicketId)
+}
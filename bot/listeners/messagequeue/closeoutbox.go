@@ -0,0 +1,123 @@
+package messagequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"go.uber.org/zap"
+)
+
+const (
+	closeOutboxPollInterval = time.Second * 30
+	closeOutboxBatchSize    = 50
+)
+
+// ListenCloseOutbox polls the close outbox, retrying the steps of any ticket closes which did not complete. Each
+// attempt is claimed atomically, so it is safe for every worker to run this loop.
+func ListenCloseOutbox(logger *zap.Logger, services *services.Services) {
+	timer := time.NewTicker(closeOutboxPollInterval)
+
+	for {
+		<-timer.C
+
+		entries, err := services.Redis.GetDueCloseOutboxEntries(context.Background(), time.Now(), closeOutboxBatchSize)
+		if err != nil {
+			logger.Error("Failed to fetch due close outbox entries", zap.Error(err))
+			sentry.Error(err)
+			continue
+		}
+
+		for _, scheduled := range entries {
+			scheduled := scheduled
+			go func() {
+				if err := processCloseOutboxEntry(context.Background(), logger, services, scheduled); err != nil {
+					logger.Error("Failed to process close outbox entry",
+						zap.Uint64("guild_id", scheduled.GuildId),
+						zap.Int("ticket_id", scheduled.TicketId),
+						zap.Error(err),
+					)
+					sentry.Error(err)
+				}
+			}()
+		}
+	}
+}
+
+func processCloseOutboxEntry(ctx context.Context, logger *zap.Logger, services *services.Services, scheduled redis.ScheduledCloseOutboxEntry) error {
+	// Read the entry before claiming it, as the claim must cover the time budget of its remaining steps
+	entry, ok, err := services.Redis.GetCloseOutboxEntry(ctx, scheduled.GuildId, scheduled.TicketId)
+	if err != nil {
+		return err
+	}
+
+	timeout := logic.CloseOutboxAttemptTimeout(entry)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	claimed, err := services.Redis.ClaimCloseOutboxEntry(ctx, scheduled, time.Now().Add(timeout))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	if !ok {
+		return services.Redis.DeleteCloseOutboxEntry(ctx, scheduled.GuildId, scheduled.TicketId)
+	}
+
+	ticket, err := services.Database.Tickets.Get(ctx, entry.TicketId, entry.GuildId)
+	if err != nil {
+		return err
+	}
+
+	// The ticket may have been deleted, or reopened since
+	if ticket.Id == 0 || ticket.Open {
+		return services.Redis.DeleteCloseOutboxEntry(ctx, entry.GuildId, entry.TicketId)
+	}
+
+	worker, err := buildGuildContext(ctx, entry.GuildId, services)
+	if err != nil {
+		return err
+	}
+
+	premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, entry.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	var channelId uint64
+	if ticket.ChannelId != nil {
+		channelId = *ticket.ChannelId
+	}
+
+	cc := cmdcontext.NewAutoCloseContext(ctx, worker, entry.GuildId, channelId, entry.ClosedBy, premiumTier)
+
+	settings, err := cc.Settings()
+	if err != nil {
+		return err
+	}
+
+	failed, err := logic.ProcessCloseOutboxEntry(ctx, cc, ticket, settings, entry, logic.TranscriptExports{}, false)
+	if err != nil {
+		return err
+	}
+
+	for step, err := range failed {
+		logger.Warn("Close outbox step failed",
+			zap.Uint64("guild_id", entry.GuildId),
+			zap.Int("ticket_id", entry.TicketId),
+			zap.String("step", step),
+			zap.Int("attempt", entry.Attempts+1),
+			zap.Error(err),
+		)
+	}
+
+	return nil
+}
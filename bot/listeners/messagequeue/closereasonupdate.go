@@ -5,11 +5,9 @@ import (
 	"encoding/json"
 
 	"github.com/TicketsBot-cloud/common/sentry"
-	"github.com/TicketsBot-cloud/worker/bot/cache"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 )
 
 const closeReasonUpdateChannel = "tickets:close_reason_update"
@@ -19,8 +17,8 @@ type CloseReasonUpdatePayload struct {
 	TicketId int    `json:"ticket_id"`
 }
 
-func ListenCloseReasonUpdate() {
-	pubsub := redis.Client.Subscribe(context.Background(), closeReasonUpdateChannel)
+func ListenCloseReasonUpdate(services *services.Services) {
+	pubsub := services.Redis.Subscribe(context.Background(), closeReasonUpdateChannel)
 	defer pubsub.Close()
 
 	for msg := range pubsub.Channel() {
@@ -35,7 +33,7 @@ func ListenCloseReasonUpdate() {
 			ctx, cancel := context.WithTimeout(context.Background(), constants.TimeoutCloseTicket)
 			defer cancel()
 
-			ticket, err := dbclient.Client.Tickets.Get(ctx, payload.TicketId, payload.GuildId)
+			ticket, err := services.Database.Tickets.Get(ctx, payload.TicketId, payload.GuildId)
 			if err != nil {
 				sentry.Error(err)
 				return
@@ -45,13 +43,13 @@ func ListenCloseReasonUpdate() {
 				return
 			}
 
-			workerCtx, err := buildContext(ctx, ticket, cache.Client)
+			workerCtx, err := buildContext(ctx, ticket, services)
 			if err != nil {
 				sentry.Error(err)
 				return
 			}
 
-			closeMetadata, _, err := dbclient.Client.CloseReason.Get(ctx, payload.GuildId, payload.TicketId)
+			closeMetadata, _, err := services.Database.CloseReason.Get(ctx, payload.GuildId, payload.TicketId)
 			if err != nil {
 				sentry.Error(err)
 				return
@@ -62,18 +60,18 @@ func ListenCloseReasonUpdate() {
 				closedBy = *closeMetadata.ClosedBy
 			}
 
-			settings, err := dbclient.Client.Settings.Get(ctx, payload.GuildId)
+			settings, err := services.Database.Settings.Get(ctx, payload.GuildId)
 			if err != nil {
 				sentry.Error(err)
 				return
 			}
 
 			var rating *uint8
-			if r, ok, err := dbclient.Client.ServiceRatings.Get(ctx, payload.GuildId, payload.TicketId); err == nil && ok {
+			if r, ok, err := services.Database.ServiceRatings.Get(ctx, payload.GuildId, payload.TicketId); err == nil && ok {
 				rating = &r
 			}
 
-			hasFeedback, err := dbclient.Client.ExitSurveyResponses.HasResponse(ctx, payload.GuildId, payload.TicketId)
+			hasFeedback, err := services.Database.ExitSurveyResponses.HasResponse(ctx, payload.GuildId, payload.TicketId)
 			if err != nil {
 				sentry.Error(err)
 				return
@@ -6,20 +6,17 @@ import (
 	"github.com/TicketsBot-cloud/common/closerequest"
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/database"
-	"github.com/TicketsBot-cloud/worker/bot/cache"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
-	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"go.uber.org/zap"
 )
 
-func ListenCloseRequestTimer(logger *zap.Logger) {
+func ListenCloseRequestTimer(logger *zap.Logger, services *services.Services) {
 	ch := make(chan database.CloseRequest)
-	go closerequest.Listen(redis.Client, ch)
+	go closerequest.Listen(services.Redis.Client, ch)
 
 	for request := range ch {
 		statsd.Client.IncrementKey(statsd.AutoClose)
@@ -36,7 +33,7 @@ func ListenCloseRequestTimer(logger *zap.Logger) {
 			)
 
 			// get ticket
-			ticket, err := dbclient.Client.Tickets.Get(ctx, request.TicketId, request.GuildId)
+			ticket, err := services.Database.Tickets.Get(ctx, request.TicketId, request.GuildId)
 			if err != nil {
 				logger.Error("Failed to fetch ticket",
 					zap.Int("ticket_id", request.TicketId),
@@ -48,7 +45,7 @@ func ListenCloseRequestTimer(logger *zap.Logger) {
 			}
 
 			// get worker
-			worker, err := buildContext(ctx, ticket, cache.Client)
+			worker, err := buildContext(ctx, ticket, services)
 			if err != nil {
 				logger.Error("Failed to build worker context",
 					zap.Int("ticket_id", request.TicketId),
@@ -69,7 +66,7 @@ func ListenCloseRequestTimer(logger *zap.Logger) {
 			}
 
 			// get premium status
-			premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+			premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
 			if err != nil {
 				logger.Error("Failed to get premium tier",
 					zap.Int("ticket_id", request.TicketId),
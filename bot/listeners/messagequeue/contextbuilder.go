@@ -4,19 +4,23 @@ import (
 	"context"
 
 	"github.com/TicketsBot-cloud/database"
-	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/config"
 )
 
-func buildContext(ctx context.Context, ticket database.Ticket, cache *cache.PgCache) (*worker.Context, error) {
+func buildContext(ctx context.Context, ticket database.Ticket, services *services.Services) (*worker.Context, error) {
+	return buildGuildContext(ctx, ticket.GuildId, services)
+}
+
+func buildGuildContext(ctx context.Context, guildId uint64, services *services.Services) (*worker.Context, error) {
 	worker := &worker.Context{
-		Cache:       cache,
+		Cache:       services.Cache,
 		RateLimiter: nil, // Use http-proxy ratelimiting functionality
+		Services:    services,
 	}
 
-	whitelabelBotId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, ticket.GuildId)
+	whitelabelBotId, isWhitelabel, err := services.Database.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
@@ -24,7 +28,7 @@ func buildContext(ctx context.Context, ticket database.Ticket, cache *cache.PgCa
 	worker.IsWhitelabel = isWhitelabel
 
 	if isWhitelabel {
-		res, err := dbclient.Client.Whitelabel.GetByBotId(ctx, whitelabelBotId)
+		res, err := services.Database.Whitelabel.GetByBotId(ctx, whitelabelBotId)
 		if err != nil {
 			return nil, err
 		}
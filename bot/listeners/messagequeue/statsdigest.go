@@ -0,0 +1,284 @@
+package messagequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/reporting"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/experiments"
+	"go.uber.org/zap"
+)
+
+const (
+	statsDigestPollInterval = time.Minute
+	statsDigestBatchSize    = 50
+	statsDigestTimeout      = time.Minute
+)
+
+// ListenStatisticsDigest polls the digest schedule, posting any digests which are due. Each run is claimed atomically,
+// so it is safe for every worker to run this loop.
+func ListenStatisticsDigest(logger *zap.Logger, services *services.Services) {
+	timer := time.NewTicker(statsDigestPollInterval)
+
+	for {
+		<-timer.C
+
+		now := time.Now().UTC()
+
+		digests, err := services.Database.StatsDigests.GetDue(context.Background(), now, statsDigestBatchSize)
+		if err != nil {
+			logger.Error("Failed to fetch due statistics digests", zap.Error(err))
+			sentry.Error(err)
+			continue
+		}
+
+		for _, digest := range digests {
+			digest := digest
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), statsDigestTimeout)
+				defer cancel()
+
+				if err := processStatsDigest(ctx, logger, services, digest, now); err != nil {
+					logger.Error("Failed to process statistics digest",
+						zap.Uint64("guild_id", digest.GuildId),
+						zap.Error(err),
+					)
+					sentry.Error(err)
+				}
+			}()
+		}
+	}
+}
+
+func processStatsDigest(ctx context.Context, logger *zap.Logger, services *services.Services, scheduled dbclient.ScheduledStatsDigest, now time.Time) error {
+	config, ok, err := services.Database.StatsDigests.Get(ctx, scheduled.GuildId)
+	if err != nil {
+		return err
+	}
+
+	frequency := reporting.Frequency(config.Frequency)
+	if !ok || !frequency.IsValid() {
+		return services.Database.StatsDigests.Delete(ctx, scheduled.GuildId)
+	}
+
+	// If the worker was down for longer than one period, skip straight to the next run rather than catching up
+	claimed, err := services.Database.StatsDigests.Claim(ctx, scheduled, frequency.NextRun(now))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	worker, err := buildGuildContext(ctx, scheduled.GuildId, services)
+	if err != nil {
+		return err
+	}
+
+	premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, scheduled.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	if premiumTier == premium.None {
+		logger.Debug("Skipping statistics digest for guild without premium", zap.Uint64("guild_id", scheduled.GuildId))
+		return nil
+	}
+
+	digest, err := reporting.GenerateDigest(ctx, services, scheduled.GuildId, frequency, scheduled.RunAt, config.ResponseLimit)
+	if err != nil {
+		return err
+	}
+
+	var data rest.CreateMessageData
+	if experiments.HasFeature(ctx, worker.Services, scheduled.GuildId, experiments.COMPONENTS_V2_STATISTICS) {
+		data = buildStatsDigestComponents(ctx, worker, scheduled.GuildId, digest)
+	} else {
+		data = rest.CreateMessageData{
+			Embeds: utils.Slice(buildStatsDigestEmbed(ctx, worker, scheduled.GuildId, digest)),
+		}
+	}
+
+	if _, err := worker.CreateMessageComplex(config.ChannelId, data); err != nil {
+		return err
+	}
+
+	logger.Info("Posted statistics digest",
+		zap.Uint64("guild_id", scheduled.GuildId),
+		zap.String("frequency", config.Frequency),
+	)
+
+	return nil
+}
+
+func buildStatsDigestComponents(ctx context.Context, worker *worker.Context, guildId uint64, digest reporting.Digest) rest.CreateMessageData {
+	header := []component.Component{
+		component.BuildTextDisplay(component.TextDisplay{Content: fmt.Sprintf("## %s", statsDigestTitle(digest))}),
+		component.BuildTextDisplay(component.TextDisplay{
+			Content: fmt.Sprintf("● %s", strings.Join(statsDigestOverview(digest), "\n● ")),
+		}),
+	}
+
+	var topSection []component.Component
+	if guild, err := worker.GetGuild(guildId); err == nil && guild.IconUrl() != "" {
+		topSection = []component.Component{
+			component.BuildSection(component.Section{
+				Accessory: component.BuildThumbnail(component.Thumbnail{
+					Media: component.UnfurledMediaItem{
+						Url: guild.IconUrl(),
+					},
+				}),
+				Components: header,
+			}),
+		}
+	} else {
+		topSection = header
+	}
+
+	innerComponents := append(topSection, []component.Component{
+		component.BuildSeparator(component.Separator{}),
+		component.BuildTextDisplay(component.TextDisplay{
+			Content: fmt.Sprintf("### Median Times\n● %s", strings.Join(statsDigestTimes(digest), "\n● ")),
+		}),
+		component.BuildSeparator(component.Separator{}),
+		component.BuildTextDisplay(component.TextDisplay{
+			Content: fmt.Sprintf("### Top Responders\n%s", statsDigestTopResponders(digest)),
+		}),
+		component.BuildSeparator(component.Separator{}),
+		component.BuildTextDisplay(component.TextDisplay{
+			Content: fmt.Sprintf("### Response Limit\n● %s", statsDigestBreaches(digest)),
+		}),
+		component.BuildTextDisplay(component.TextDisplay{
+			Content: fmt.Sprintf("-# %s", statsDigestFooter(digest)),
+		}),
+	}...)
+
+	return rest.CreateMessageData{
+		Flags: message.SumFlags(message.FlagComponentsV2),
+		Components: utils.Slice(component.BuildContainer(component.Container{
+			AccentColor: utils.Ptr(customisation.GetColourOrDefault(ctx, worker, guildId, customisation.Green)),
+			Components:  innerComponents,
+		})),
+	}
+}
+
+func buildStatsDigestEmbed(ctx context.Context, worker *worker.Context, guildId uint64, digest reporting.Digest) *embed.Embed {
+	return embed.NewEmbed().
+		SetTitle(statsDigestTitle(digest)).
+		SetColor(customisation.GetColourOrDefault(ctx, worker, guildId, customisation.Green)).
+		AddField("Overview", strings.Join(statsDigestOverview(digest), "\n"), false).
+		AddField("Median Times", strings.Join(statsDigestTimes(digest), "\n"), false).
+		AddField("Top Responders", statsDigestTopResponders(digest), false).
+		AddField("Response Limit", statsDigestBreaches(digest), false).
+		SetFooter(statsDigestFooter(digest), "")
+}
+
+func statsDigestTitle(digest reporting.Digest) string {
+	switch digest.Frequency {
+	case reporting.FrequencyWeekly:
+		return "Weekly Ticket Digest"
+	case reporting.FrequencyMonthly:
+		return "Monthly Ticket Digest"
+	default:
+		return "Daily Ticket Digest"
+	}
+}
+
+func statsDigestOverview(digest reporting.Digest) []string {
+	return []string{
+		fmt.Sprintf("**Tickets Opened**: %s", formatCountComparison(digest.Opened)),
+		fmt.Sprintf("**Tickets Closed**: %s", formatCountComparison(digest.Closed)),
+		fmt.Sprintf("**Feedback Rating**: %s", formatRatingComparison(digest.Rating)),
+		fmt.Sprintf("**Feedback Count**: %s", formatCountComparison(digest.RatingCount)),
+	}
+}
+
+func statsDigestTimes(digest reporting.Digest) []string {
+	return []string{
+		fmt.Sprintf("**First Response Time**: %s", formatDurationComparison(digest.FirstResponseTime)),
+		fmt.Sprintf("**Resolution Time**: %s", formatDurationComparison(digest.ResolutionTime)),
+	}
+}
+
+func statsDigestTopResponders(digest reporting.Digest) string {
+	if len(digest.TopResponders) == 0 {
+		return "No tickets were answered during this period."
+	}
+
+	lines := make([]string, len(digest.TopResponders))
+	for i, staff := range digest.TopResponders {
+		lines[i] = fmt.Sprintf(
+			"%d. <@%d>: %d first responses (median %s), %d claimed",
+			i+1, staff.UserId, staff.FirstResponses, utils.FormatNullableTime(staff.FirstResponseTime.P50), staff.Claimed,
+		)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func statsDigestBreaches(digest reporting.Digest) string {
+	return fmt.Sprintf(
+		"**Tickets without a response within %s**: %s",
+		utils.FormatTime(digest.ResponseLimit),
+		formatCountComparison(digest.Breaches),
+	)
+}
+
+func statsDigestFooter(digest reporting.Digest) string {
+	footer := fmt.Sprintf("%s (UTC), compared with %s", digest.Current.String(), digest.Previous.String())
+	if digest.Truncated {
+		footer += fmt.Sprintf(". Limited to %d tickets.", reporting.MaxTickets)
+	}
+
+	return footer
+}
+
+func formatCountComparison(c reporting.Comparison[int]) string {
+	if c.Previous == 0 {
+		return fmt.Sprintf("%d (previously %d)", c.Current, c.Previous)
+	}
+
+	change := float64(c.Current-c.Previous) / float64(c.Previous) * 100
+	return fmt.Sprintf("%d (%s from %d)", c.Current, formatChange(change, "%.0f%%"), c.Previous)
+}
+
+func formatDurationComparison(c reporting.Comparison[*time.Duration]) string {
+	return fmt.Sprintf("%s (previously %s)", utils.FormatNullableTime(c.Current), utils.FormatNullableTime(c.Previous))
+}
+
+func formatRatingComparison(c reporting.Comparison[*float64]) string {
+	if c.Current == nil {
+		return "No data"
+	}
+
+	if c.Previous == nil {
+		return fmt.Sprintf("%.1f / 5 ★", *c.Current)
+	}
+
+	return fmt.Sprintf("%.1f / 5 ★ (%s)", *c.Current, formatChange(*c.Current-*c.Previous, "%.1f"))
+}
+
+func formatChange(change float64, format string) string {
+	switch {
+	case change > 0:
+		return "▲ " + fmt.Sprintf(format, change)
+	case change < 0:
+		return "▼ " + fmt.Sprintf(format, -change)
+	default:
+		return "no change"
+	}
+}
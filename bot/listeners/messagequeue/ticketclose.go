@@ -6,21 +6,18 @@ import (
 	"github.com/TicketsBot-cloud/common/closerelay"
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/cache"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/errorcontext"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
-	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/config"
 )
 
 // TODO: Make this good
-func ListenTicketClose() {
+func ListenTicketClose(services *services.Services) {
 	ch := make(chan closerelay.TicketClose)
-	go closerelay.Listen(redis.Client, ch)
+	go closerelay.Listen(services.Redis.Client, ch)
 
 	for payload := range ch {
 		payload := payload
@@ -34,7 +31,7 @@ func ListenTicketClose() {
 			}
 
 			// Get the ticket struct
-			ticket, err := dbclient.Client.Tickets.Get(ctx, payload.TicketId, payload.GuildId)
+			ticket, err := services.Database.Tickets.Get(ctx, payload.TicketId, payload.GuildId)
 			if err != nil {
 				sentry.Error(err)
 				return
@@ -55,13 +52,13 @@ func ListenTicketClose() {
 			var token string
 			var botId uint64
 			{
-				whiteLabelBotId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, payload.GuildId)
+				whiteLabelBotId, isWhitelabel, err := services.Database.WhitelabelGuilds.GetBotByGuild(ctx, payload.GuildId)
 				if err != nil {
 					sentry.ErrorWithContext(err, errorContext)
 				}
 
 				if isWhitelabel {
-					bot, err := dbclient.Client.Whitelabel.GetByBotId(ctx, whiteLabelBotId)
+					bot, err := services.Database.Whitelabel.GetByBotId(ctx, whiteLabelBotId)
 					if err != nil {
 						sentry.ErrorWithContext(err, errorContext)
 						return
@@ -82,12 +79,13 @@ func ListenTicketClose() {
 			workerCtx := &worker.Context{
 				Token:        token,
 				IsWhitelabel: botId != 0,
-				Cache:        cache.Client, // TODO: Less hacky
-				RateLimiter:  nil,          // Use http-proxy ratelimit functionality
+				Cache:        services.Cache,
+				RateLimiter:  nil, // Use http-proxy ratelimit functionality
+				Services:     services,
 			}
 
 			// Get whether the guild is premium for log archiver
-			premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, payload.GuildId, true, token, workerCtx.RateLimiter)
+			premiumTier, err := workerCtx.Services.Premium.GetTierByGuildId(ctx, payload.GuildId, true, token, workerCtx.RateLimiter)
 			if err != nil {
 				sentry.ErrorWithContext(err, errorContext)
 				return
@@ -96,7 +94,7 @@ func ListenTicketClose() {
 			// if ticket didnt open in the first place, no channel ID is assigned.
 			// we should close it here, or it wont get closed at all
 			if ticket.ChannelId == nil {
-				if err := dbclient.Client.Tickets.Close(ctx, payload.TicketId, payload.GuildId); err != nil {
+				if err := services.Database.Tickets.Close(ctx, payload.TicketId, payload.GuildId); err != nil {
 					sentry.ErrorWithContext(err, errorContext)
 				}
 				return
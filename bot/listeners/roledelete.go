@@ -7,7 +7,6 @@ import (
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/gdl/gateway/payloads/events"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/errorcontext"
 	"golang.org/x/sync/errgroup"
 )
@@ -21,15 +20,15 @@ func OnRoleDelete(worker *worker.Context, e events.GuildRoleDelete) {
 	group, _ := errgroup.WithContext(context.Background())
 
 	group.Go(func() error {
-		return dbclient.Client.RolePermissions.RemoveSupport(ctx, e.GuildId, e.RoleId)
+		return worker.Services.Database.RolePermissions.RemoveSupport(ctx, e.GuildId, e.RoleId)
 	})
 
 	group.Go(func() error {
-		return dbclient.Client.SupportTeamRoles.DeleteAllRole(ctx, e.RoleId)
+		return worker.Services.Database.SupportTeamRoles.DeleteAllRole(ctx, e.RoleId)
 	})
 
 	group.Go(func() error {
-		return dbclient.Client.PanelRoleMentions.DeleteAllRole(ctx, e.RoleId)
+		return worker.Services.Database.PanelRoleMentions.DeleteAllRole(ctx, e.RoleId)
 	})
 
 	if err := group.Wait(); err != nil {
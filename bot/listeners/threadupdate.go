@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/worker"
 	cmdcontext "github.com/TicketsBot-cloud/worker/bot/command/context"
 	"github.com/TicketsBot-cloud/worker/bot/constants"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/errorcontext"
 	"github.com/TicketsBot-cloud/worker/bot/logic"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
@@ -24,13 +23,13 @@ func OnThreadUpdate(worker *worker.Context, e events.ThreadUpdate) {
 		return
 	}
 
-	settings, err := dbclient.Client.Settings.Get(ctx, e.GuildId)
+	settings, err := worker.Services.Database.Settings.Get(ctx, e.GuildId)
 	if err != nil {
 		sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{Guild: e.GuildId})
 		return
 	}
 
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, e.Id, e.GuildId)
+	ticket, err := worker.Services.Database.Tickets.GetByChannelAndGuild(ctx, e.Id, e.GuildId)
 	if err != nil {
 		sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{Guild: e.GuildId})
 		return
@@ -48,7 +47,7 @@ func OnThreadUpdate(worker *worker.Context, e events.ThreadUpdate) {
 
 	var panel *database.Panel
 	if ticket.PanelId != nil {
-		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		tmp, err := worker.Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{Guild: e.GuildId})
 			return
@@ -59,7 +58,7 @@ func OnThreadUpdate(worker *worker.Context, e events.ThreadUpdate) {
 		}
 	}
 
-	premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, e.GuildId, true, worker.Token, worker.RateLimiter)
+	premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, e.GuildId, true, worker.Token, worker.RateLimiter)
 	if err != nil {
 		sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{Guild: e.GuildId})
 		return
@@ -67,7 +66,7 @@ func OnThreadUpdate(worker *worker.Context, e events.ThreadUpdate) {
 
 	// Handle thread being unarchived
 	if !ticket.Open && !e.ThreadMetadata.Archived && !e.ThreadMetadata.Locked {
-		if err := dbclient.Client.Tickets.SetOpen(ctx, ticket.GuildId, ticket.Id); err != nil {
+		if err := worker.Services.Database.Tickets.SetOpen(ctx, ticket.GuildId, ticket.Id); err != nil {
 			sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{Guild: e.GuildId})
 			return
 		}
@@ -87,7 +86,7 @@ func OnThreadUpdate(worker *worker.Context, e events.ThreadUpdate) {
 				return
 			}
 
-			if err := dbclient.Client.Tickets.SetJoinMessageId(ctx, ticket.GuildId, ticket.Id, &msg.Id); err != nil {
+			if err := worker.Services.Database.Tickets.SetJoinMessageId(ctx, ticket.GuildId, ticket.Id, &msg.Id); err != nil {
 				sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{Guild: e.GuildId})
 				return
 			}
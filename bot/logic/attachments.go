@@ -0,0 +1,326 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TicketsBot-cloud/archiverclient"
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/config"
+	"golang.org/x/sync/errgroup"
+)
+
+// AttachmentLimits bounds which attachments are preserved when a ticket is closed
+type AttachmentLimits struct {
+	MaxFileSize int64
+	// AllowedTypes are MIME type prefixes. Any type is allowed if it is empty.
+	AllowedTypes []string
+	// Quota is the total size of the attachments that may be preserved for a guild
+	Quota int64
+}
+
+const (
+	mebibyte = 1024 * 1024
+	gibibyte = 1024 * mebibyte
+)
+
+var attachmentLimitsByTier = map[premium.PremiumTier]AttachmentLimits{
+	premium.None: {
+		MaxFileSize:  5 * mebibyte,
+		AllowedTypes: []string{"image/"},
+		Quota:        250 * mebibyte,
+	},
+	premium.Premium: {
+		MaxFileSize:  25 * mebibyte,
+		AllowedTypes: []string{"image/", "video/", "audio/", "text/", "application/pdf"},
+		Quota:        5 * gibibyte,
+	},
+	premium.Whitelabel: {
+		MaxFileSize: 50 * mebibyte,
+		Quota:       25 * gibibyte,
+	},
+}
+
+func GetAttachmentLimits(tier premium.PremiumTier) AttachmentLimits {
+	if limits, ok := attachmentLimitsByTier[tier]; ok {
+		return limits
+	}
+
+	return attachmentLimitsByTier[premium.None]
+}
+
+// Allows returns whether an attachment of the given size and type may be preserved
+func (l AttachmentLimits) Allows(size int64, contentType string) bool {
+	if size <= 0 || size > l.MaxFileSize {
+		return false
+	}
+
+	if len(l.AllowedTypes) == 0 {
+		return true
+	}
+
+	for _, allowed := range l.AllowedTypes {
+		if strings.HasPrefix(contentType, allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	// AttachmentPreserveTimeout is the time budget for preserving a ticket's attachments, which is done in the
+	// background from the close outbox, as it may take much longer than the rest of the close
+	AttachmentPreserveTimeout     = time.Minute * 10
+	attachmentPreserveConcurrency = 4
+	attachmentDownloadTimeout     = time.Second * 30
+)
+
+var attachmentHttpClient = &http.Client{
+	Timeout: attachmentDownloadTimeout,
+}
+
+// preserveTranscriptAttachments preserves the attachments in the ticket's stored transcript and notes, and replaces
+// them with copies which point at the preserved attachments. If the transcript has since been deleted, the storage
+// used by any attachments which were already preserved is returned to the guild's quota.
+func preserveTranscriptAttachments(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) error {
+	archiver := cmd.Worker().Services.Archiver
+	if !archiver.PreservesAttachments() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, AttachmentPreserveTimeout)
+	defer cancel()
+
+	transcript, err := archiver.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		if errors.Is(err, archiverclient.ErrNotFound) {
+			return cmd.Worker().Services.Database.PreservedAttachments.DeleteByTicket(ctx, ticket.GuildId, ticket.Id)
+		}
+
+		return err
+	}
+
+	if PreserveAttachments(ctx, cmd, ticket, transcript.Messages) {
+		if err := archiver.Replace(ctx, ticket.GuildId, ticket.Id, transcript); err != nil {
+			return err
+		}
+	}
+
+	notes, err := archiver.GetNotes(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		if errors.Is(err, archiverclient.ErrNotFound) || errors.Is(err, services.ErrNotesArchiveUnavailable) {
+			return nil
+		}
+
+		return err
+	}
+
+	if PreserveAttachments(ctx, cmd, ticket, notes.Messages) {
+		return archiver.ReplaceNotes(ctx, ticket.GuildId, ticket.Id, notes)
+	}
+
+	return nil
+}
+
+// hasAttachments returns whether any of the messages have attachments which could be preserved
+func hasAttachments(msgs ...[]message.Message) bool {
+	for _, batch := range msgs {
+		for _, msg := range batch {
+			if len(msg.Attachments) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// PreserveAttachments uploads copies of the attachments in msgs to the archiver, and rewrites their URLs to point at
+// the preserved copies, as Discord CDN links expire, returning whether any were rewritten. Attachments which are over
+// the guild's limits, would take it over its quota, or fail to download keep their CDN URLs.
+func PreserveAttachments(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, msgs []v2.Message) bool {
+	if !cmd.Worker().Services.Archiver.PreservesAttachments() {
+		return false
+	}
+
+	tier := cmd.PremiumTier()
+	limits := GetAttachmentLimits(tier)
+
+	var group errgroup.Group
+	group.SetLimit(attachmentPreserveConcurrency)
+
+	var preserved atomic.Bool
+	for i := range msgs {
+		for j := range msgs[i].Attachments {
+			attachment := &msgs[i].Attachments[j]
+
+			group.Go(func() error {
+				url, err := preserveAttachment(ctx, cmd, ticket, tier, limits, *attachment)
+				if err != nil {
+					sentry.ErrorWithContext(err, cmd.ToErrorContext())
+					return nil
+				}
+
+				if url != "" && url != attachment.Url {
+					attachment.Url = url
+					attachment.ProxyUrl = url
+					preserved.Store(true)
+				}
+
+				return nil
+			})
+		}
+	}
+
+	_ = group.Wait()
+	return preserved.Load()
+}
+
+// preserveAttachment returns the URL of the preserved copy, or an empty string if the attachment is not allowed to be
+// preserved
+func preserveAttachment(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	tier premium.PremiumTier,
+	limits AttachmentLimits,
+	attachment channel.Attachment,
+) (string, error) {
+	// Reuse the copy uploaded by a previous attempt
+	if url, ok, err := cmd.Worker().Services.Database.PreservedAttachments.Get(ctx, attachment.Id); err != nil {
+		return "", err
+	} else if ok {
+		return url, nil
+	}
+
+	// The filename is checked before downloading to skip attachments which are plainly not allowed, but the contents
+	// are checked again once downloaded
+	filenameType := mime.TypeByExtension(strings.ToLower(filepath.Ext(attachment.Filename)))
+	if filenameType == "" {
+		filenameType = "application/octet-stream"
+	}
+
+	size := int64(attachment.Size)
+	if !limits.Allows(size, filenameType) {
+		return "", nil
+	}
+
+	reservation := dbclient.PreservedAttachment{
+		AttachmentId: attachment.Id,
+		GuildId:      ticket.GuildId,
+		TicketId:     ticket.Id,
+		Size:         size,
+	}
+
+	reserved, err := cmd.Worker().Services.Database.PreservedAttachments.Reserve(ctx, reservation, limits.Quota, time.Now().Add(AttachmentPreserveTimeout))
+	if err != nil {
+		return "", err
+	}
+
+	if !reserved {
+		return "", nil
+	}
+
+	url, err := downloadAndStoreAttachment(ctx, cmd, ticket, limits, attachment, filenameType)
+	if err != nil || url == "" {
+		if releaseErr := cmd.Worker().Services.Database.PreservedAttachments.Release(ctx, attachment.Id); releaseErr != nil {
+			return "", releaseErr
+		}
+
+		return "", err
+	}
+
+	var expiresAt *time.Time
+	if retention := config.Conf.Archiver.AttachmentRetention; retention > 0 {
+		expiresAt = utils.Ptr(time.Now().Add(retention))
+	}
+
+	if err := cmd.Worker().Services.Database.PreservedAttachments.SetUrl(ctx, attachment.Id, url, expiresAt); err != nil {
+		if releaseErr := cmd.Worker().Services.Database.PreservedAttachments.Release(ctx, attachment.Id); releaseErr != nil {
+			return "", releaseErr
+		}
+
+		return "", err
+	}
+
+	prometheus.LogAttachmentPreserved(tier.String(), attachment.Size)
+	return url, nil
+}
+
+// attachmentContentType returns the type detected from the attachment's contents. The type implied by the filename
+// is only trusted when the contents are not recognised and any type may be preserved, as a file can be renamed to get
+// around the allowed types.
+func attachmentContentType(limits AttachmentLimits, filenameType string, data []byte) string {
+	detected, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if detected == "application/octet-stream" && len(limits.AllowedTypes) == 0 {
+		return filenameType
+	}
+
+	return detected
+}
+
+func downloadAndStoreAttachment(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	limits AttachmentLimits,
+	attachment channel.Attachment,
+	filenameType string,
+) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachment.Url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := attachmentHttpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	// The attachment may have been deleted, in which case there is nothing to preserve
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusForbidden {
+		return "", nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download attachment %d: status %d", attachment.Id, res.StatusCode)
+	}
+
+	// Don't trust the size reported by Discord when reading the body
+	data, err := io.ReadAll(io.LimitReader(res.Body, limits.MaxFileSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(data)) > limits.MaxFileSize || len(data) != attachment.Size {
+		return "", nil
+	}
+
+	contentType := attachmentContentType(limits, filenameType, data)
+	if !limits.Allows(int64(len(data)), contentType) {
+		return "", nil
+	}
+
+	return cmd.Worker().Services.Archiver.StoreAttachment(ctx, ticket.GuildId, ticket.Id, attachment.Id, attachment.Filename, contentType, data)
+}
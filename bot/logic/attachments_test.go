@@ -0,0 +1,208 @@
+package logic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentLimits(t *testing.T) {
+	free := GetAttachmentLimits(premium.None)
+	require.True(t, free.Allows(1024, "image/png"))
+	require.False(t, free.Allows(1024, "application/pdf"))
+	require.False(t, free.Allows(free.MaxFileSize+1, "image/png"))
+
+	paid := GetAttachmentLimits(premium.Premium)
+	require.True(t, paid.Allows(1024, "application/pdf"))
+	require.False(t, paid.Allows(1024, "application/zip"))
+
+	require.True(t, GetAttachmentLimits(premium.Whitelabel).Allows(1024, "application/zip"))
+}
+
+func newAttachmentServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/expired.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// A text file which has been renamed to look like an image
+		if r.URL.Path == "/renamed.png" {
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+			return
+		}
+
+		_, _ = w.Write(append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 92)...))
+	}))
+
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPreserveAttachments(t *testing.T) {
+	h := testharness.New(t)
+	server := newAttachmentServer(t)
+
+	guildId := h.Discord.NextId()
+	ctx := h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permcache.Admin)
+	ticket := database.Ticket{GuildId: guildId, Id: 1}
+
+	msgs := []v2.Message{
+		{
+			Attachments: []channel.Attachment{
+				{Id: 1, Filename: "image.png", Size: 100, Url: server.URL + "/image.png"},
+				// Free guilds can only preserve images
+				{Id: 2, Filename: "document.pdf", Size: 100, Url: server.URL + "/document.pdf"},
+				{Id: 3, Filename: "expired.png", Size: 100, Url: server.URL + "/expired.png"},
+				// The contents are checked, rather than trusting the filename
+				{Id: 4, Filename: "renamed.png", Size: 100, Url: server.URL + "/renamed.png"},
+			},
+		},
+	}
+
+	require.True(t, PreserveAttachments(t.Context(), ctx, ticket, msgs))
+
+	require.Equal(t, fmt.Sprintf("https://archive.test/attachments/%d/1/1/image.png", guildId), msgs[0].Attachments[0].Url)
+	require.Equal(t, server.URL+"/document.pdf", msgs[0].Attachments[1].Url)
+	require.Equal(t, server.URL+"/expired.png", msgs[0].Attachments[2].Url)
+	require.Equal(t, server.URL+"/renamed.png", msgs[0].Attachments[3].Url)
+
+	_, ok := h.Archiver.Attachment(1)
+	require.True(t, ok)
+
+	// Only the preserved attachment counts towards the quota
+	used, err := h.Database.PreservedAttachments.GetUsage(t.Context(), guildId)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), used)
+
+	// Retrying reuses the preserved copy rather than uploading it again
+	retry := []v2.Message{
+		{Attachments: []channel.Attachment{{Id: 1, Filename: "image.png", Size: 100, Url: server.URL + "/image.png"}}},
+	}
+
+	require.True(t, PreserveAttachments(t.Context(), ctx, ticket, retry))
+	require.Equal(t, msgs[0].Attachments[0].Url, retry[0].Attachments[0].Url)
+
+	used, err = h.Database.PreservedAttachments.GetUsage(t.Context(), guildId)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), used)
+
+	// Nothing is rewritten once every attachment points at its preserved copy
+	require.False(t, PreserveAttachments(t.Context(), ctx, ticket, retry))
+}
+
+func TestCloseTicketPreservesAttachmentsInBackground(t *testing.T) {
+	h := testharness.New(t)
+	config.Conf.Transcripts.SpoolDirectory = t.TempDir()
+	server := newAttachmentServer(t)
+
+	g := newTicketGuild(t, h, permcache.Everyone)
+	ticket := g.openTicket(t, h, false)
+
+	h.Discord.AddMessage(*ticket.ChannelId, message.Message{
+		Author:      user.User{Id: g.UserId},
+		Attachments: []channel.Attachment{{Id: 1, Filename: "image.png", Size: 100, Url: server.URL + "/image.png"}},
+	})
+
+	staffId := g.addMember(t, h, permcache.Support)
+	ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, staffId, permcache.Support)
+	ctx.SettingsValue.StoreTranscripts = true
+
+	CloseTicket(t.Context(), ctx, nil, false)
+	require.Empty(t, ctx.Errors())
+
+	// The transcript is stored with the CDN link, and the attachment is left for the outbox
+	transcript, err := h.Archiver.Get(t.Context(), g.GuildId, ticket.Id)
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/image.png", transcript.Messages[len(transcript.Messages)-1].Attachments[0].Url)
+
+	_, ok := h.Archiver.Attachment(1)
+	require.False(t, ok)
+
+	entry, ok, err := h.Services.Redis.GetCloseOutboxEntry(t.Context(), g.GuildId, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{CloseStepAttachments}, entry.Steps)
+	require.Zero(t, entry.Attempts)
+
+	stored, err := h.Database.Tickets.Get(t.Context(), ticket.Id, g.GuildId)
+	require.NoError(t, err)
+
+	failed, err := ProcessCloseOutboxEntry(t.Context(), ctx, stored, ctx.SettingsValue, entry, TranscriptExports{}, false)
+	require.NoError(t, err)
+	require.Empty(t, failed)
+
+	transcript, err = h.Archiver.Get(t.Context(), g.GuildId, ticket.Id)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		fmt.Sprintf("https://archive.test/attachments/%d/%d/1/image.png", g.GuildId, ticket.Id),
+		transcript.Messages[len(transcript.Messages)-1].Attachments[0].Url,
+	)
+
+	_, ok, err = h.Services.Redis.GetCloseOutboxEntry(t.Context(), g.GuildId, ticket.Id)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestPreserveTranscriptAttachmentsDeleted(t *testing.T) {
+	h := testharness.New(t)
+
+	guildId := h.Discord.NextId()
+	ctx := h.NewCommandContext(guildId, h.Discord.NextId(), h.Discord.NextId(), permcache.Admin)
+	ticket := database.Ticket{GuildId: guildId, Id: 1}
+
+	// An earlier attempt preserved an attachment before the transcript was deleted
+	reservation := dbclient.PreservedAttachment{AttachmentId: 1, GuildId: guildId, TicketId: ticket.Id, Size: 100}
+	reserved, err := h.Database.PreservedAttachments.Reserve(t.Context(), reservation, 1000, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.NoError(t, h.Database.PreservedAttachments.SetUrl(t.Context(), 1, "https://archive.test/1", nil))
+
+	require.NoError(t, preserveTranscriptAttachments(t.Context(), ctx, ticket))
+
+	used, err := h.Database.PreservedAttachments.GetUsage(t.Context(), guildId)
+	require.NoError(t, err)
+	require.Zero(t, used)
+}
+
+func TestReservePreservedAttachmentQuota(t *testing.T) {
+	h := testharness.New(t)
+	store := h.Database.PreservedAttachments
+
+	ok, err := store.Reserve(t.Context(), dbclient.PreservedAttachment{AttachmentId: 1, GuildId: 1, TicketId: 1, Size: 60}, 100, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = store.Reserve(t.Context(), dbclient.PreservedAttachment{AttachmentId: 2, GuildId: 1, TicketId: 1, Size: 60}, 100, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	used, err := store.GetUsage(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(60), used)
+
+	// A reservation which was never completed no longer counts once it expires
+	ok, err = store.Reserve(t.Context(), dbclient.PreservedAttachment{AttachmentId: 3, GuildId: 2, TicketId: 1, Size: 60}, 100, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	used, err = store.GetUsage(t.Context(), 2)
+	require.NoError(t, err)
+	require.Zero(t, used)
+}
@@ -0,0 +1,282 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/constants"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+const (
+	BulkActionClose    = "close"
+	BulkActionClaim    = "claim"
+	BulkActionMove     = "move"
+	BulkActionAddLabel = "add_label"
+)
+
+const (
+	// MaxBulkActionTickets is the most tickets a single bulk action may process
+	MaxBulkActionTickets = 100
+	// bulkActionInterval is the pause between tickets, so that a bulk action does not exhaust the guild's rate limits
+	bulkActionInterval      = time.Second * 2
+	bulkActionProgressEvery = 5
+)
+
+// BulkTicketFilter narrows down the open tickets which a bulk action applies to. Nil and zero fields do not filter.
+type BulkTicketFilter struct {
+	PanelId *int
+	// InactiveFor only matches tickets which have had no messages for at least this long
+	InactiveFor time.Duration
+	OpenerId    *uint64
+	LabelId     *int
+	Claimed     *bool
+}
+
+type BulkActionResult struct {
+	Succeeded int
+	Failed    int
+	// Skipped counts tickets which were closed in the meantime, or which the action did not apply to
+	Skipped int
+}
+
+// BulkContextFactory builds the context that the action is carried out through for a ticket, acting as the staff
+// member who started the bulk action
+type BulkContextFactory func(ctx context.Context, channelId uint64) registry.CommandContext
+
+// FindBulkTickets returns up to MaxBulkActionTickets open tickets matching the filter which userId has permission to
+// act on, oldest first. The total number of matching tickets is also returned, before being limited.
+func FindBulkTickets(ctx context.Context, worker *worker.Context, guildId, userId uint64, filter BulkTicketFilter) ([]database.Ticket, int, error) {
+	tickets, err := worker.Services.Database.Tickets.GetGuildOpenTicketsWithMetadata(ctx, guildId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var labels map[int][]int
+	if filter.LabelId != nil && len(tickets) > 0 {
+		ticketIds := make([]int, len(tickets))
+		for i, ticket := range tickets {
+			ticketIds[i] = ticket.Id
+		}
+
+		labels, err = worker.Services.Database.TicketLabelAssignments.GetByTickets(ctx, guildId, ticketIds)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var permitted []database.Ticket
+	var total int
+	for _, ticket := range filterBulkTickets(tickets, labels, filter, time.Now()) {
+		hasPermission, err := HasPermissionForTicket(ctx, worker, ticket, userId)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if !hasPermission {
+			continue
+		}
+
+		total++
+		if len(permitted) < MaxBulkActionTickets {
+			permitted = append(permitted, ticket)
+		}
+	}
+
+	return permitted, total, nil
+}
+
+func filterBulkTickets(tickets []database.TicketWithMetadata, labels map[int][]int, filter BulkTicketFilter, now time.Time) []database.Ticket {
+	var matched []database.Ticket
+	for _, ticket := range tickets {
+		if ticket.ChannelId == nil {
+			continue
+		}
+
+		if filter.PanelId != nil && (ticket.PanelId == nil || *ticket.PanelId != *filter.PanelId) {
+			continue
+		}
+
+		if filter.OpenerId != nil && ticket.Ticket.UserId != *filter.OpenerId {
+			continue
+		}
+
+		if filter.Claimed != nil && (ticket.ClaimedBy != nil) != *filter.Claimed {
+			continue
+		}
+
+		if filter.LabelId != nil && !slices.Contains(labels[ticket.Id], *filter.LabelId) {
+			continue
+		}
+
+		if filter.InactiveFor > 0 {
+			lastActivity := ticket.OpenTime
+			if ticket.LastMessageTime != nil {
+				lastActivity = *ticket.LastMessageTime
+			}
+
+			if now.Sub(lastActivity) < filter.InactiveFor {
+				continue
+			}
+		}
+
+		matched = append(matched, ticket.Ticket)
+	}
+
+	slices.SortFunc(matched, func(a, b database.Ticket) int {
+		return a.Id - b.Id
+	})
+
+	return matched
+}
+
+// RunBulkAction carries out the action on each of its tickets in turn, pausing between tickets. progress is called
+// every few tickets with the number processed so far, and once more when every ticket has been processed.
+func RunBulkAction(ctx context.Context, worker *worker.Context, action redis.BulkAction, newContext BulkContextFactory, progress func(processed int, result BulkActionResult)) (BulkActionResult, error) {
+	var result BulkActionResult
+
+	var panel database.Panel
+	if action.Action == BulkActionMove {
+		var err error
+		panel, err = worker.Services.Database.Panel.GetById(ctx, int(action.TargetId))
+		if err != nil {
+			return result, err
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != action.GuildId {
+			return result, errors.New("bulk move target panel no longer exists")
+		}
+	}
+
+	for i, ticketId := range action.TicketIds {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(bulkActionInterval):
+			}
+		}
+
+		ticketCtx, cancel := context.WithTimeout(ctx, constants.TimeoutCloseTicket)
+		ok, err := runBulkActionTicket(ticketCtx, worker, action, panel, ticketId, newContext)
+		cancel()
+
+		switch {
+		case err != nil:
+			result.Failed++
+		case ok:
+			result.Succeeded++
+		default:
+			result.Skipped++
+		}
+
+		if (i+1)%bulkActionProgressEvery == 0 && i+1 < len(action.TicketIds) {
+			progress(i+1, result)
+		}
+	}
+
+	progress(len(action.TicketIds), result)
+	return result, nil
+}
+
+// runBulkActionTicket carries out the action on a single ticket, returning false if the action did not apply to it
+func runBulkActionTicket(ctx context.Context, worker *worker.Context, action redis.BulkAction, panel database.Panel, ticketId int, newContext BulkContextFactory) (bool, error) {
+	ticket, err := worker.Services.Database.Tickets.Get(ctx, ticketId, action.GuildId)
+	if err != nil {
+		return false, err
+	}
+
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		return false, nil
+	}
+
+	cmd := newContext(ctx, *ticket.ChannelId)
+
+	switch action.Action {
+	case BulkActionClose:
+		CloseTicket(ctx, cmd, action.Reason, false)
+
+		// CloseTicket reports failures to the context, so check whether the ticket was closed
+		ticket, err = worker.Services.Database.Tickets.Get(ctx, ticketId, action.GuildId)
+		if err != nil {
+			return false, err
+		}
+
+		if ticket.Open {
+			return false, fmt.Errorf("ticket %d was not closed", ticketId)
+		}
+
+		return true, nil
+	case BulkActionClaim:
+		if ticket.IsThread {
+			return false, nil
+		}
+
+		claimer, err := worker.Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+		if err != nil {
+			return false, err
+		}
+
+		if claimer == action.TargetId {
+			return false, nil
+		}
+
+		if err := ClaimTicket(ctx, cmd, ticket, action.TargetId); err != nil {
+			return false, err
+		}
+
+		_ = UpdateWelcomeMessageClaimButton(ctx, cmd.Worker(), cmd, ticket, true)
+
+		embed := utils.BuildEmbed(cmd, customisation.Green, i18n.TitleClaimed, i18n.MessageClaimed, nil, fmt.Sprintf("<@%d>", action.TargetId))
+		_, _ = cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, embed)
+
+		return true, nil
+	case BulkActionMove:
+		if ticket.PanelId != nil && *ticket.PanelId == panel.PanelId {
+			return false, nil
+		}
+
+		SwitchPanel(ctx, cmd, ticket, panel)
+
+		// SwitchPanel reports failures to the context, so check whether the ticket was moved
+		ticket, err = worker.Services.Database.Tickets.Get(ctx, ticketId, action.GuildId)
+		if err != nil {
+			return false, err
+		}
+
+		if ticket.PanelId == nil || *ticket.PanelId != panel.PanelId {
+			return false, fmt.Errorf("ticket %d was not moved", ticketId)
+		}
+
+		embed := utils.BuildEmbed(cmd, customisation.Green, i18n.TitlePanelSwitched, i18n.MessageSwitchPanelSuccess, nil, panel.Title, action.UserId)
+		_, _ = cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, embed)
+
+		return true, nil
+	case BulkActionAddLabel:
+		labelIds, err := worker.Services.Database.TicketLabelAssignments.GetByTicket(ctx, ticket.GuildId, ticket.Id)
+		if err != nil {
+			return false, err
+		}
+
+		if slices.Contains(labelIds, int(action.TargetId)) {
+			return false, nil
+		}
+
+		if err := worker.Services.Database.TicketLabelAssignments.Add(ctx, ticket.GuildId, ticket.Id, int(action.TargetId)); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown bulk action %s", action.Action)
+	}
+}
@@ -0,0 +1,59 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterBulkTickets(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	ticket := func(id int, panelId *int, openerId uint64, claimedBy *uint64, lastMessage *time.Time) database.TicketWithMetadata {
+		return database.TicketWithMetadata{
+			Ticket: database.Ticket{
+				Id:        id,
+				ChannelId: utils.Ptr(uint64(100 + id)),
+				UserId:    openerId,
+				PanelId:   panelId,
+				OpenTime:  now.Add(-time.Hour * 24 * 5),
+			},
+			TicketLastMessage: database.TicketLastMessage{LastMessageTime: lastMessage},
+			ClaimedBy:         claimedBy,
+		}
+	}
+
+	tickets := []database.TicketWithMetadata{
+		ticket(3, utils.Ptr(1), 10, nil, utils.Ptr(now.Add(-time.Hour))),
+		ticket(1, utils.Ptr(1), 11, utils.Ptr(uint64(20)), nil),
+		ticket(2, utils.Ptr(2), 10, nil, utils.Ptr(now.Add(-time.Hour*24*3))),
+		{Ticket: database.Ticket{Id: 4, UserId: 10}}, // No channel
+	}
+
+	labels := map[int][]int{1: {7}, 2: {7, 8}}
+
+	ids := func(filter BulkTicketFilter) []int {
+		var ids []int
+		for _, ticket := range filterBulkTickets(tickets, labels, filter, now) {
+			ids = append(ids, ticket.Id)
+		}
+
+		return ids
+	}
+
+	require.Equal(t, []int{1, 2, 3}, ids(BulkTicketFilter{}))
+	require.Equal(t, []int{1, 3}, ids(BulkTicketFilter{PanelId: utils.Ptr(1)}))
+	require.Equal(t, []int{2, 3}, ids(BulkTicketFilter{OpenerId: utils.Ptr(uint64(10))}))
+	require.Equal(t, []int{1}, ids(BulkTicketFilter{Claimed: utils.Ptr(true)}))
+	require.Equal(t, []int{2, 3}, ids(BulkTicketFilter{Claimed: utils.Ptr(false)}))
+	require.Equal(t, []int{2}, ids(BulkTicketFilter{LabelId: utils.Ptr(8)}))
+
+	// Tickets without messages are inactive since they were opened
+	require.Equal(t, []int{1, 2}, ids(BulkTicketFilter{InactiveFor: time.Hour * 24 * 2}))
+	require.Equal(t, []int{1}, ids(BulkTicketFilter{InactiveFor: time.Hour * 24 * 4}))
+
+	require.Equal(t, []int{2}, ids(BulkTicketFilter{OpenerId: utils.Ptr(uint64(10)), LabelId: utils.Ptr(7)}))
+}
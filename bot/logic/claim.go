@@ -16,7 +16,6 @@ import (
 	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/i18n"
 	"golang.org/x/sync/errgroup"
 )
@@ -36,7 +35,7 @@ func ClaimTicket(ctx context.Context, cmd registry.CommandContext, ticket databa
 	// Get panel
 	var panel *database.Panel
 	if ticket.PanelId != nil {
-		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		tmp, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			return err
 		}
@@ -47,7 +46,7 @@ func ClaimTicket(ctx context.Context, cmd registry.CommandContext, ticket databa
 	}
 
 	// Set to claimed in DB
-	if err := dbclient.Client.TicketClaims.Set(ctx, ticket.GuildId, ticket.Id, userId); err != nil {
+	if err := cmd.Worker().Services.Database.TicketClaims.Set(ctx, ticket.GuildId, ticket.Id, userId); err != nil {
 		return err
 	}
 
@@ -104,7 +103,7 @@ func ClaimTicket(ctx context.Context, cmd registry.CommandContext, ticket databa
 // GenerateClaimedOverwrites If support reps can still view and type, returns (nil, nil)
 func GenerateClaimedOverwrites(ctx context.Context, worker *worker.Context, ticket database.Ticket, claimer uint64) ([]channel.PermissionOverwrite, error) {
 	// Get claim settings for guild
-	claimSettings, err := dbclient.Client.ClaimSettings.Get(ctx, ticket.GuildId)
+	claimSettings, err := worker.Services.Database.ClaimSettings.Get(ctx, ticket.GuildId)
 	if err != nil {
 		return nil, err
 	}
@@ -113,17 +112,17 @@ func GenerateClaimedOverwrites(ctx context.Context, worker *worker.Context, tick
 		return nil, nil
 	}
 
-	adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, ticket.GuildId)
+	adminUsers, err := worker.Services.Database.Permissions.GetAdmins(ctx, ticket.GuildId)
 	if err != nil {
 		return nil, err
 	}
 
-	adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, ticket.GuildId)
+	adminRoles, err := worker.Services.Database.RolePermissions.GetAdminRoles(ctx, ticket.GuildId)
 	if err != nil {
 		return nil, err
 	}
 
-	additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, ticket.GuildId)
+	additionalPermissions, err := worker.Services.Database.TicketPermissions.Get(ctx, ticket.GuildId)
 	if err != nil {
 		return nil, err
 	}
@@ -140,12 +139,12 @@ func GenerateClaimedOverwrites(ctx context.Context, worker *worker.Context, tick
 
 	// Support can view the ticket, but can't type
 	if !claimSettings.SupportCanType {
-		supportUsers, err := dbclient.Client.Permissions.GetSupportOnly(ctx, ticket.GuildId)
+		supportUsers, err := worker.Services.Database.Permissions.GetSupportOnly(ctx, ticket.GuildId)
 		if err != nil {
 			return nil, err
 		}
 
-		supportRoles, err := dbclient.Client.RolePermissions.GetSupportRolesOnly(ctx, ticket.GuildId)
+		supportRoles, err := worker.Services.Database.RolePermissions.GetSupportRolesOnly(ctx, ticket.GuildId)
 		if err != nil {
 			return nil, err
 		}
@@ -155,7 +154,7 @@ func GenerateClaimedOverwrites(ctx context.Context, worker *worker.Context, tick
 
 			// Get users for support teams of panel
 			group.Go(func() error {
-				userIds, err := dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, *ticket.PanelId)
+				userIds, err := worker.Services.Database.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, *ticket.PanelId)
 				if err != nil {
 					return err
 				}
@@ -166,7 +165,7 @@ func GenerateClaimedOverwrites(ctx context.Context, worker *worker.Context, tick
 
 			// Get roles for support teams of panel
 			group.Go(func() error {
-				roleIds, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, *ticket.PanelId)
+				roleIds, err := worker.Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, *ticket.PanelId)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"testing"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/permission"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimTicket(t *testing.T) {
+	tests := []struct {
+		name             string
+		useThreads       bool
+		claimSettings    *database.ClaimSettings
+		expectClaimed    bool
+		expectOverwrites bool
+		expectReply      i18n.MessageId
+	}{
+		{
+			name:             "support can view but not type by default",
+			expectClaimed:    true,
+			expectOverwrites: true,
+		},
+		{
+			name: "support cannot view",
+			claimSettings: &database.ClaimSettings{
+				SupportCanView: false,
+				SupportCanType: false,
+			},
+			expectClaimed:    true,
+			expectOverwrites: true,
+		},
+		{
+			name: "overwrites are unchanged when support can type",
+			claimSettings: &database.ClaimSettings{
+				SupportCanView: true,
+				SupportCanType: true,
+			},
+			expectClaimed: true,
+		},
+		{
+			name:        "threads cannot be claimed",
+			useThreads:  true,
+			expectReply: i18n.MessageClaimThread,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			g := newTicketGuild(t, h, permcache.Everyone)
+			ticket := g.openTicket(t, h, test.useThreads)
+
+			if test.claimSettings != nil {
+				require.NoError(t, h.Database.ClaimSettings.Set(t.Context(), g.GuildId, *test.claimSettings))
+			}
+
+			before, ok := h.Discord.Channel(*ticket.ChannelId)
+			require.True(t, ok)
+
+			claimerId := g.addMember(t, h, permcache.Support)
+			ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, claimerId, permcache.Support)
+
+			require.NoError(t, ClaimTicket(t.Context(), ctx, ticket, claimerId))
+			require.Empty(t, ctx.Errors())
+
+			claimedBy, err := h.Database.TicketClaims.Get(t.Context(), g.GuildId, ticket.Id)
+			require.NoError(t, err)
+
+			if !test.expectClaimed {
+				require.Zero(t, claimedBy)
+
+				reply, ok := ctx.LastReply()
+				require.True(t, ok)
+				require.Equal(t, test.expectReply, reply.ContentId)
+				return
+			}
+
+			require.Equal(t, claimerId, claimedBy)
+
+			after, ok := h.Discord.Channel(*ticket.ChannelId)
+			require.True(t, ok)
+
+			if !test.expectOverwrites {
+				require.Equal(t, before.PermissionOverwrites, after.PermissionOverwrites)
+				return
+			}
+
+			claimer, ok := findOverwrite(after.PermissionOverwrites, claimerId)
+			require.True(t, ok)
+			require.Equal(t, channel.PermissionTypeMember, claimer.Type)
+			require.True(t, permission.HasPermissionRaw(claimer.Allow, permission.SendMessages))
+
+			opener, ok := findOverwrite(after.PermissionOverwrites, g.UserId)
+			require.True(t, ok)
+			require.True(t, permission.HasPermissionRaw(opener.Allow, permission.ViewChannel))
+
+			everyone, ok := findOverwrite(after.PermissionOverwrites, g.GuildId)
+			require.True(t, ok)
+			require.True(t, permission.HasPermissionRaw(everyone.Deny, permission.ViewChannel))
+		})
+	}
+}
+
+func findOverwrite(overwrites []channel.PermissionOverwrite, id uint64) (channel.PermissionOverwrite, bool) {
+	for _, overwrite := range overwrites {
+		if overwrite.Id == id {
+			return overwrite, true
+		}
+	}
+
+	return channel.PermissionOverwrite{}, false
+}
@@ -5,32 +5,49 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/TicketsBot-cloud/common/collections"
-	"github.com/TicketsBot-cloud/common/permission"
+	permcache "github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/common/sentry"
 	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
 	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
-	"github.com/TicketsBot-cloud/gdl/objects/member"
 	"github.com/TicketsBot-cloud/gdl/rest"
 	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/bot/dbclient"
-	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
+	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/TicketsBot-cloud/worker/bot/transcript"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
 
 func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *string, bypassPermissionCheck bool) {
+	closeTicket(ctx, cmd, reason, bypassPermissionCheck, false)
+}
+
+// CloseTicketAsSpam closes the ticket without storing a transcript or sending the opener a DM, and blacklists the
+// opener if the guild has configured a blacklist duration. Only staff may close tickets as spam.
+func CloseTicketAsSpam(ctx context.Context, cmd registry.CommandContext, reason *string) {
+	if !hasCloseReason(reason) {
+		reason = utils.Ptr(SpamCloseReason)
+	}
+
+	closeTicket(ctx, cmd, reason, false, true)
+}
+
+func closeTicket(ctx context.Context, cmd registry.CommandContext, reason *string, bypassPermissionCheck, spam bool) {
 	var success bool
 	errorContext := cmd.ToErrorContext()
 
 	// Get ticket struct
-	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx, cmd.ChannelId(), cmd.GuildId())
+	ticket, err := cmd.Worker().Services.Database.Tickets.GetByChannelAndGuild(ctx, cmd.ChannelId(), cmd.GuildId())
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -43,7 +60,7 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 
 	defer func() {
 		if !success {
-			if err := dbclient.Client.AutoCloseExclude.Exclude(ctx, ticket.GuildId, ticket.Id); err != nil {
+			if err := cmd.Worker().Services.Database.AutoCloseExclude.Exclude(ctx, ticket.GuildId, ticket.Id); err != nil {
 				sentry.ErrorWithContext(err, errorContext)
 			}
 		}
@@ -54,6 +71,33 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		return
 	}
 
+	if spam {
+		permLevel, err := cmd.UserPermissionLevel(ctx)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		if permLevel < permcache.Support {
+			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageCloseSpamNoPermission)
+			return
+		}
+	}
+
+	// Automatic closes never have to give a reason, and the dashboard has no way to prompt for one
+	if !bypassPermissionCheck && cmd.Source() != registry.SourceDashboard && !hasCloseReason(reason) {
+		closeReasonConfig, err := GetCloseReasonConfig(ctx, cmd.Worker(), ticket)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		if closeReasonConfig.RequireReason {
+			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageCloseReasonRequired)
+			return
+		}
+	}
+
 	member, err := cmd.Member()
 	if err != nil {
 		cmd.HandleError(err)
@@ -76,7 +120,7 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		}
 
 		if !channelExists {
-			if err := dbclient.Client.Tickets.Close(ctx, ticket.Id, ticket.GuildId); err != nil {
+			if err := cmd.Worker().Services.Database.Tickets.Close(ctx, ticket.Id, ticket.GuildId); err != nil {
 				cmd.HandleError(err)
 				return
 			}
@@ -85,102 +129,139 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		}
 	}
 
-	// Archive
-	if settings.StoreTranscripts {
-		msgs := make([]message.Message, 0, 50)
-
-		const limit = 100
+	exportConfig, err := GetTranscriptExportConfig(ctx, cmd.Worker(), cmd.GuildId())
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
 
-		lastId := uint64(0)
-		lastChunkSize := limit
+	// Tickets closed as spam are not worth keeping a transcript of
+	if spam {
+		settings.StoreTranscripts = false
+		exportConfig = dbclient.TranscriptExportConfig{}
+	}
 
+	var msgs []message.Message
+	var spool *transcript.Spool
+	if settings.StoreTranscripts || TranscriptExportEnabled(exportConfig) {
 		// Use the actual ticket channel ID, not the current channel (which might be a notes thread)
-		archiveChannelId := *ticket.ChannelId
+		msgs, spool, err = collectTicketMessages(ctx, cmd, ticket, *ticket.ChannelId)
+		if err != nil {
+			var restError request.RestError
+			if errors.As(err, &restError) && restError.StatusCode == 403 {
+				if err := cmd.Worker().Services.Database.AutoCloseExclude.ExcludeAll(ctx, cmd.GuildId()); err != nil {
+					sentry.ErrorWithContext(err, errorContext)
+				}
+			}
 
-		retries := 0
-		for lastChunkSize == limit {
-			chunk, err := cmd.Worker().GetChannelMessages(archiveChannelId, rest.GetChannelMessagesData{
-				Before: lastId,
-				Limit:  limit,
-			})
+			cmd.HandleError(err)
+			return
+		}
 
-			if err != nil {
-				var restError request.RestError
-				if errors.As(err, &restError) && restError.StatusCode == 403 {
-					if err := dbclient.Client.AutoCloseExclude.ExcludeAll(ctx, cmd.GuildId()); err != nil {
-						sentry.ErrorWithContext(err, errorContext)
-					}
-				}
+		// The spool is kept on disk if the close fails, so that the next attempt can resume from it
+		defer spool.Close()
+	}
 
-				if errors.As(err, &restError) && restError.StatusCode == http.StatusTooManyRequests {
-					if retries < 5 {
-						retries++
-						time.Sleep(time.Duration(retries*2) * time.Second)
-						continue
-					}
-				}
+	// The notes thread is deleted along with the channel, so collect it too. Notes are only ever stored or rendered
+	// separately from the transcript, and only shown to staff.
+	var notes []message.Message
+	if ticket.NotesThreadId != nil && (settings.StoreTranscripts || exportConfig.ArchiveChannel) {
+		notesConfig, err := cmd.Worker().Services.Database.NotesTranscriptConfig.Get(ctx, cmd.GuildId())
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
 
+		if !notesConfig.Excluded {
+			notes, err = collectNotesMessages(cmd, *ticket.NotesThreadId)
+			if err != nil {
 				cmd.HandleError(err)
 				return
 			}
-
-			retries = 0 // reset retries after a successful call
-			lastChunkSize = len(chunk)
-
-			if lastChunkSize > 0 {
-				lastId = chunk[len(chunk)-1].Id
-				msgs = append(msgs, chunk...)
-			}
 		}
+	}
 
-		// Reverse messages
-		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
-			msgs[i], msgs[j] = msgs[j], msgs[i]
+	// Render the transcript files while we still have the messages. Failure to do so should not prevent the ticket
+	// from being closed.
+	var exports TranscriptExports
+	if TranscriptExportEnabled(exportConfig) {
+		exports, err = BuildTranscriptExports(ctx, cmd, ticket, exportConfig, msgs, notes)
+		if err != nil {
+			sentry.ErrorWithContext(err, errorContext)
 		}
+	}
 
+	// Archive
+	if settings.StoreTranscripts {
 		// Update participants, incase the websocket gateway missed any messages
 		participants := collections.NewSet[uint64]()
 		for _, msg := range msgs {
 			participants.Add(msg.Author.Id)
 		}
 
-		if err := dbclient.Client.Participants.SetBulk(ctx, cmd.GuildId(), ticket.Id, participants.Collect()); err != nil {
+		if err := cmd.Worker().Services.Database.Participants.SetBulk(ctx, cmd.GuildId(), ticket.Id, participants.Collect()); err != nil {
 			cmd.HandleError(err)
 			return
 		}
 
-		if err := utils.ArchiverClient.Store(ctx, cmd.GuildId(), ticket.Id, msgs); err != nil {
+		if err := cmd.Worker().Services.Archiver.Store(ctx, cmd.GuildId(), ticket.Id, msgs); err != nil {
 			cmd.HandleError(err)
 			return
 		}
 
-		if err := dbclient.Client.Tickets.SetHasTranscript(ctx, cmd.GuildId(), ticket.Id, true); err != nil {
+		if len(notes) > 0 {
+			err := cmd.Worker().Services.Archiver.StoreNotes(ctx, cmd.GuildId(), ticket.Id, notes)
+			if err != nil && !errors.Is(err, services.ErrNotesArchiveUnavailable) {
+				cmd.HandleError(err)
+				return
+			}
+		}
+
+		if err := cmd.Worker().Services.Database.Tickets.SetHasTranscript(ctx, cmd.GuildId(), ticket.Id, true); err != nil {
 			cmd.HandleError(err)
 			return
 		}
 	}
 
 	// Set ticket state as closed and delete channel
-	if err := dbclient.Client.Tickets.Close(ctx, ticket.Id, cmd.GuildId()); err != nil {
+	if err := cmd.Worker().Services.Database.Tickets.Close(ctx, ticket.Id, cmd.GuildId()); err != nil {
 		cmd.HandleError(err)
 		return
 	}
 
 	success = true
 	ticket.CloseTime = utils.Ptr(time.Now())
+	prometheus.ObserveResolutionTime(ticket.GuildId, ticket.CloseTime.Sub(ticket.OpenTime))
 
-	// set close reason + user
-	closeMetadata := database.CloseMetadata{
-		Reason: reason,
+	if spool != nil {
+		if err := spool.Remove(); err != nil {
+			sentry.ErrorWithContext(err, errorContext)
+		}
 	}
 
-	if cmd.UserId() != cmd.Worker().BotId {
-		closeMetadata.ClosedBy = utils.Ptr(cmd.UserId())
+	// Record the remaining steps before carrying them out, so that anything which fails, or is interrupted, is retried
+	// in the background rather than leaving the ticket half-closed
+	var entry redis.CloseOutboxEntry
+	if spam {
+		entry = NewSpamCloseOutboxEntry(ticket, cmd.UserId(), member.User.Username, reason)
+	} else {
+		entry = NewCloseOutboxEntry(ticket, cmd.UserId(), member.User.Username, reason)
 	}
 
-	if err := dbclient.Client.CloseReason.Set(ctx, cmd.GuildId(), ticket.Id, closeMetadata); err != nil {
-		cmd.HandleError(err)
-		return
+	// Discord CDN links expire, so store copies of attachments and point the stored transcript at them. This can take
+	// a while, so is done in the background.
+	if settings.StoreTranscripts && cmd.Worker().Services.Archiver.PreservesAttachments() && hasAttachments(msgs, notes) {
+		entry.Steps = append(entry.Steps, CloseStepAttachments)
+	}
+
+	if err := cmd.Worker().Services.Redis.SaveCloseOutboxEntry(ctx, entry, time.Now().Add(CloseOutboxClaimTimeout)); err != nil {
+		sentry.ErrorWithContext(err, errorContext)
+	}
+
+	if spam {
+		if err := handleSpamClose(ctx, cmd, ticket); err != nil {
+			sentry.ErrorWithContext(err, errorContext)
+		}
 	}
 
 	if ticket.IsThread {
@@ -223,20 +304,6 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 				cmd.ReplyWithFieldsPermanent(customisation.Green, i18n.TitleTicketClosed, i18n.MessageCloseSuccess, fields, cmd.UserId())
 			}
 		}
-
-		data := rest.ModifyChannelData{
-			ThreadMetadataModifyData: &rest.ThreadMetadataModifyData{
-				Archived: utils.Ptr(true),
-				Locked:   utils.Ptr(true),
-			},
-		}
-
-		auditReason := fmt.Sprintf("Ticket %d closed by %s", ticket.Id, member.User.Username)
-		reasonCtx := request.WithAuditReason(context.Background(), auditReason)
-		if _, err := cmd.Worker().ModifyChannel(reasonCtx, *ticket.ChannelId, data); err != nil {
-			cmd.HandleError(err)
-			return
-		}
 	} else {
 		// For button interactions, we need to acknowledge before deleting the channel
 		// since we won't be able to send a message response after the channel is deleted
@@ -246,200 +313,18 @@ func CloseTicket(ctx context.Context, cmd registry.CommandContext, reason *strin
 		if acker, ok := cmd.(acknowledger); ok {
 			acker.Ack()
 		}
-
-		auditReason := fmt.Sprintf("Ticket %d closed by %s", ticket.Id, member.User.Username)
-		reasonCtx := request.WithAuditReason(context.Background(), auditReason)
-		if _, err := cmd.Worker().DeleteChannel(reasonCtx, *ticket.ChannelId); err != nil {
-			// Check if we should exclude this from autoclose
-			var restError request.RestError
-			if errors.As(err, &restError) && restError.StatusCode == 403 {
-				if err := dbclient.Client.AutoCloseExclude.Exclude(ctx, ticket.GuildId, ticket.Id); err != nil {
-					sentry.ErrorWithContext(err, errorContext)
-				}
-			}
-
-			cmd.HandleError(err)
-			return
-		}
-	}
-
-	// Save space - delete the webhook
-	if !ticket.IsThread {
-		go dbclient.Client.Webhooks.Delete(ctx, cmd.GuildId(), ticket.Id)
-	}
-
-	if err := dbclient.Client.CloseRequest.Delete(ctx, ticket.GuildId, ticket.Id); err != nil {
-		sentry.ErrorWithContext(err, cmd.ToErrorContext())
-	}
-
-	// Delete join thread button
-	if ticket.IsThread && ticket.JoinMessageId != nil {
-		// Determine which notification channel was used
-		// Priority: Panel-specific notification channel > Global notification channel
-		var notificationChannel *uint64
-
-		// Get panel if this ticket has one
-		var panel *database.Panel
-		if ticket.PanelId != nil {
-			p, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
-			if err == nil && p.PanelId != 0 {
-				panel = &p
-			}
-		}
-
-		if panel != nil && panel.TicketNotificationChannel != nil {
-			notificationChannel = panel.TicketNotificationChannel
-		} else if settings.TicketNotificationChannel != nil {
-			notificationChannel = settings.TicketNotificationChannel
-		}
-
-		if notificationChannel != nil {
-			_ = cmd.Worker().DeleteMessage(*notificationChannel, *ticket.JoinMessageId)
-			if err := dbclient.Client.Tickets.SetJoinMessageId(ctx, ticket.GuildId, ticket.Id, nil); err != nil {
-				sentry.ErrorWithContext(err, errorContext)
-			}
-		}
-	}
-
-	sendCloseEmbed(ctx, cmd, errorContext, member, settings, ticket, reason)
-}
-
-func sendCloseEmbed(ctx context.Context, cmd registry.CommandContext, errorContext sentry.ErrorContext, member member.Member, settings database.Settings, ticket database.Ticket, reason *string) {
-	// Send logs to archive channel
-	var archiveChannelId *uint64
-
-	if ticket.PanelId != nil {
-		acId, err := dbclient.Client.ArchiveChannel.GetByPanel(ctx, ticket.GuildId, *ticket.PanelId)
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
-			return
-		}
-		archiveChannelId = acId
-	} else {
-		acId, err := dbclient.Client.ArchiveChannel.Get(ctx, ticket.GuildId)
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
-			return
-		}
-		archiveChannelId = acId
 	}
 
-	var archiveChannelExists bool
-	if archiveChannelId != nil {
-		if _, err := cmd.Worker().GetChannel(*archiveChannelId); err == nil {
-			archiveChannelExists = true
-		}
+	failed, err := ProcessCloseOutboxEntry(ctx, cmd, ticket, settings, entry, exports, true)
+	if err != nil {
+		sentry.ErrorWithContext(err, errorContext)
 	}
 
-	if archiveChannelExists && archiveChannelId != nil {
-		componentBuilders := [][]CloseEmbedElement{
-			{
-				TranscriptLinkElement(settings.StoreTranscripts),
-				ThreadLinkElement(ticket.IsThread && ticket.ChannelId != nil),
-				EditCloseReasonElement(),
-			},
-		}
-
-		closeEmbed, closeComponents := BuildCloseEmbed(ctx, cmd.Worker(), ticket, member.User.Id, reason, nil, componentBuilders)
-
-		data := rest.CreateMessageData{
-			Embeds:     utils.Slice(closeEmbed),
-			Components: closeComponents,
-		}
-
-		msg, err := cmd.Worker().CreateMessageComplex(*archiveChannelId, data)
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
+	for step, err := range failed {
+		if step == CloseStepChannel {
+			cmd.HandleError(err)
 		} else {
-			// Add message to archive
-			if err := dbclient.Client.ArchiveMessages.Set(ctx, ticket.GuildId, ticket.Id, *archiveChannelId, msg.Id); err != nil {
-				cmd.HandleError(err)
-				return
-			}
-		}
-	}
-
-	// Notify user and send logs in DMs
-	// This mutates state!
-	dmChannel, ok := getDmChannel(cmd, ticket.UserId)
-	if ok {
-		guild, err := cmd.Guild()
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
-			return
-		}
-
-		feedbackEnabled, err := dbclient.Client.FeedbackEnabled.Get(ctx, cmd.GuildId())
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
-			return
-		}
-
-		// Only offer to take feedback if the user has sent a message
-		hasSentMessage, err := dbclient.Client.Participants.HasParticipated(ctx, cmd.GuildId(), ticket.Id, ticket.UserId)
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
-			return
-		}
-
-		openerMember, err := cmd.Worker().GetGuildMember(cmd.GuildId(), ticket.UserId)
-		if err != nil {
-			var restError request.RestError
-			if errors.As(err, &restError) {
-				if restError.StatusCode != 404 { // User left the server
-					sentry.ErrorWithContext(err, errorContext)
-					return
-				}
-			} else {
-				sentry.ErrorWithContext(err, errorContext)
-				return
-			}
-		}
-
-		// Only offer to take feedback if the user is *not* staff
-		permLevel, err := permission.GetPermissionLevel(ctx, utils.ToRetriever(cmd.Worker()), openerMember, cmd.GuildId())
-		if err != nil {
-			sentry.ErrorWithContext(err, errorContext)
-			return
-		}
-
-		statsd.Client.IncrementKey(statsd.KeyDirectMessage)
-
-		componentBuilders := [][]CloseEmbedElement{
-			{
-				TranscriptLinkElement(settings.StoreTranscripts),
-				ThreadLinkElement(ticket.IsThread && ticket.ChannelId != nil),
-			},
-			{
-				FeedbackRowElement(feedbackEnabled && hasSentMessage && permLevel == permission.Everyone),
-			},
-		}
-
-		closeEmbed, closeComponents := BuildCloseEmbed(ctx, cmd.Worker(), ticket, member.User.Id, reason, nil, componentBuilders)
-		closeEmbed.SetAuthor(guild.Name, "", fmt.Sprintf("https://cdn.discordapp.com/icons/%d/%s.png", guild.Id, guild.Icon))
-
-		// Use message content to tell users why they can't rate a ticket
-		var content string
-		if feedbackEnabled {
-			if permLevel > permission.Everyone {
-				content = "-# " + cmd.GetMessage(i18n.MessageCloseCantRateStaff, guild.Name)
-			} else if !hasSentMessage {
-				content = "-# " + cmd.GetMessage(i18n.MessageCloseCantRateEmpty)
-			}
-		}
-
-		data := rest.CreateMessageData{
-			Content:    content,
-			Embeds:     utils.Slice(closeEmbed),
-			Components: closeComponents,
-		}
-
-		if msg, err := cmd.Worker().CreateMessageComplex(dmChannel, data); err != nil {
 			sentry.ErrorWithContext(err, errorContext)
-		} else {
-			if err := dbclient.Client.ArchiveDmMessages.Set(ctx, ticket.GuildId, ticket.Id, msg.Id); err != nil {
-				sentry.ErrorWithContext(err, errorContext)
-			}
 		}
 	}
 }
@@ -450,7 +335,7 @@ func getDmChannel(ctx registry.CommandContext, userId uint64) (uint64, bool) {
 		return 0, false
 	}
 
-	cachedId, err := redis.GetDMChannel(userId, ctx.Worker().BotId)
+	cachedId, err := ctx.Worker().Services.Redis.GetDMChannel(userId, ctx.Worker().BotId)
 	if err != nil { // We can continue
 		if err != redis.ErrNotCached {
 			sentry.ErrorWithContext(err, ctx.ToErrorContext())
@@ -467,7 +352,7 @@ func getDmChannel(ctx registry.CommandContext, userId uint64) (uint64, bool) {
 	if err != nil {
 		// check for 403
 		if err, ok := err.(request.RestError); ok && err.StatusCode == 403 {
-			if err := redis.StoreNullDMChannel(userId, ctx.Worker().BotId); err != nil {
+			if err := ctx.Worker().Services.Redis.StoreNullDMChannel(userId, ctx.Worker().BotId); err != nil {
 				sentry.ErrorWithContext(err, ctx.ToErrorContext())
 			}
 
@@ -478,7 +363,7 @@ func getDmChannel(ctx registry.CommandContext, userId uint64) (uint64, bool) {
 		return 0, false
 	}
 
-	if err := redis.StoreDMChannel(userId, ch.Id, ctx.Worker().BotId); err != nil {
+	if err := ctx.Worker().Services.Redis.StoreDMChannel(userId, ch.Id, ctx.Worker().BotId); err != nil {
 		sentry.ErrorWithContext(err, ctx.ToErrorContext())
 	}
 
@@ -502,3 +387,132 @@ func checkChannelExists(ctx registry.CommandContext, ticket database.Ticket) (bo
 
 	return true, nil
 }
+
+// collectTicketMessages returns every message in the channel, oldest first. Pages are spooled to disk as they are
+// fetched, so that if the close times out or fails, the next attempt on the same host resumes from the oldest message
+// fetched so far rather than starting over. Every message is still returned at once, as the archiver only accepts a
+// complete transcript. The caller should remove the spool once the ticket has been closed.
+func collectTicketMessages(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, channelId uint64) ([]message.Message, *transcript.Spool, error) {
+	spool, err := transcript.OpenSpool(config.Conf.Transcripts.SpoolDirectory, ticket.GuildId, ticket.Id, config.Conf.Transcripts.SpoolExpiry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for !spool.Progress().Complete {
+		if err := ctx.Err(); err != nil {
+			_ = spool.Close()
+			return nil, nil, err
+		}
+
+		page, err := fetchMessagePage(cmd, channelId, rest.GetChannelMessagesData{
+			Before: spool.Progress().OldestId,
+			Limit:  messagePageSize,
+		})
+		if err != nil {
+			_ = spool.Close()
+			return nil, nil, err
+		}
+
+		if err := spool.Append(page); err != nil {
+			_ = spool.Close()
+			return nil, nil, err
+		}
+
+		if len(page) < messagePageSize {
+			if err := spool.MarkComplete(); err != nil {
+				_ = spool.Close()
+				return nil, nil, err
+			}
+		}
+	}
+
+	msgs, err := spool.Messages()
+	if err != nil {
+		_ = spool.Close()
+		return nil, nil, err
+	}
+
+	// Pick up anything sent since a previous attempt started collecting
+	if spool.Resumed() {
+		after := spool.Progress().NewestId
+		for {
+			page, err := fetchMessagePage(cmd, channelId, rest.GetChannelMessagesData{
+				After: after,
+				Limit: messagePageSize,
+			})
+			if err != nil {
+				_ = spool.Close()
+				return nil, nil, err
+			}
+
+			// Pages are returned newest first
+			for i := len(page) - 1; i >= 0; i-- {
+				msgs = append(msgs, page[i])
+			}
+
+			if len(page) < messagePageSize {
+				break
+			}
+
+			after = page[0].Id
+		}
+	}
+
+	return msgs, spool, nil
+}
+
+// collectNotesMessages returns every message in the staff notes thread, oldest first. Notes threads are small, so
+// they are not spooled. A notes thread which has already been deleted has no messages.
+func collectNotesMessages(cmd registry.CommandContext, threadId uint64) ([]message.Message, error) {
+	var notes []message.Message
+
+	var before uint64
+	for {
+		page, err := fetchMessagePage(cmd, threadId, rest.GetChannelMessagesData{
+			Before: before,
+			Limit:  messagePageSize,
+		})
+		if err != nil {
+			var restError request.RestError
+			if errors.As(err, &restError) && restError.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		notes = append(notes, page...)
+
+		if len(page) < messagePageSize {
+			break
+		}
+
+		before = page[len(page)-1].Id
+	}
+
+	// Pages are returned newest first
+	slices.Reverse(notes)
+	return notes, nil
+}
+
+const messagePageSize = 100
+
+// fetchMessagePage fetches a single page of messages, retrying if we are ratelimited
+func fetchMessagePage(cmd registry.CommandContext, channelId uint64, data rest.GetChannelMessagesData) ([]message.Message, error) {
+	retries := 0
+	for {
+		page, err := cmd.Worker().GetChannelMessages(channelId, data)
+		if err != nil {
+			var restError request.RestError
+			if errors.As(err, &restError) && restError.StatusCode == http.StatusTooManyRequests && retries < 5 {
+				retries++
+				time.Sleep(time.Duration(retries*2) * time.Second)
+				continue
+			}
+
+			return nil, err
+		}
+
+		return page, nil
+	}
+}
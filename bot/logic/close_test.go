@@ -0,0 +1,252 @@
+package logic
+
+import (
+	"testing"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseTicket(t *testing.T) {
+	tests := []struct {
+		name             string
+		closer           permcache.PermissionLevel // The opener closes the ticket if -1
+		usersCantClose   bool
+		storeTranscripts bool
+		spam             bool
+		expectClosed     bool
+		expectReply      i18n.MessageId
+	}{
+		{
+			name:         "opener closes their ticket",
+			closer:       -1,
+			expectClosed: true,
+		},
+		{
+			name:             "staff close stores a transcript",
+			closer:           permcache.Support,
+			storeTranscripts: true,
+			expectClosed:     true,
+		},
+		{
+			name:        "other members cannot close",
+			closer:      permcache.Everyone,
+			expectReply: i18n.MessageCloseNoPermission,
+		},
+		{
+			name:           "opener cannot close when users cannot close",
+			closer:         -1,
+			usersCantClose: true,
+			expectReply:    i18n.MessageCloseNoPermission,
+		},
+		{
+			name:        "opener cannot close as spam",
+			closer:      -1,
+			spam:        true,
+			expectReply: i18n.MessageCloseSpamNoPermission,
+		},
+		{
+			name:             "spam close does not store a transcript",
+			closer:           permcache.Support,
+			storeTranscripts: true,
+			spam:             true,
+			expectClosed:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			config.Conf.Transcripts.SpoolDirectory = t.TempDir()
+
+			g := newTicketGuild(t, h, permcache.Everyone)
+			ticket := g.openTicket(t, h, false)
+
+			if test.usersCantClose {
+				require.NoError(t, h.Database.UsersCanClose.Set(t.Context(), g.GuildId, false))
+			}
+
+			closerId, closerLevel := g.UserId, permcache.Everyone
+			if test.closer >= 0 {
+				closerLevel = test.closer
+				closerId = g.addMember(t, h, closerLevel)
+			}
+
+			ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, closerId, closerLevel)
+			ctx.SettingsValue.StoreTranscripts = test.storeTranscripts
+
+			if test.spam {
+				CloseTicketAsSpam(t.Context(), ctx, nil)
+			} else {
+				CloseTicket(t.Context(), ctx, utils.Ptr("Resolved"), false)
+			}
+
+			require.Empty(t, ctx.Errors())
+
+			stored, err := h.Database.Tickets.Get(t.Context(), ticket.Id, g.GuildId)
+			require.NoError(t, err)
+
+			_, channelExists := h.Discord.Channel(*ticket.ChannelId)
+			if !test.expectClosed {
+				require.True(t, stored.Open)
+				require.True(t, channelExists)
+
+				reply, ok := ctx.LastReply()
+				require.True(t, ok)
+				require.Equal(t, test.expectReply, reply.ContentId)
+
+				return
+			}
+
+			require.False(t, stored.Open)
+			require.NotNil(t, stored.CloseTime)
+			require.False(t, channelExists)
+
+			transcript, hasTranscript := h.Archiver.Transcript(g.GuildId, ticket.Id)
+			expectTranscript := test.storeTranscripts && !test.spam
+			require.Equal(t, expectTranscript, hasTranscript)
+			require.Equal(t, expectTranscript, stored.HasTranscript)
+
+			if expectTranscript {
+				require.NotEmpty(t, transcript)
+				require.Equal(t, *ticket.WelcomeMessageId, transcript[0].Id)
+			}
+		})
+	}
+}
+
+func TestCloseTicketOutsideTicketChannel(t *testing.T) {
+	h := testharness.New(t)
+	g := newTicketGuild(t, h, permcache.Support)
+
+	ctx := h.NewCommandContext(g.GuildId, g.ChannelId, g.UserId, permcache.Support)
+	CloseTicket(t.Context(), ctx, nil, false)
+
+	require.Empty(t, ctx.Errors())
+
+	reply, ok := ctx.LastReply()
+	require.True(t, ok)
+	require.Equal(t, i18n.MessageNotATicketChannel, reply.ContentId)
+}
+
+func TestCloseTicketNotesTranscript(t *testing.T) {
+	tests := []struct {
+		name        string
+		excluded    bool
+		expectNotes bool
+	}{
+		{
+			name:        "notes are archived by default",
+			expectNotes: true,
+		},
+		{
+			name:     "notes are not archived when excluded",
+			excluded: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			config.Conf.Transcripts.SpoolDirectory = t.TempDir()
+
+			g := newTicketGuild(t, h, permcache.Everyone)
+			ticket := g.openTicket(t, h, false)
+			staffId := g.addMember(t, h, permcache.Support)
+
+			notesThreadId := h.Discord.NextId()
+			h.Discord.AddChannel(channel.Channel{
+				Id:       notesThreadId,
+				GuildId:  g.GuildId,
+				ParentId: objects.NewNullableSnowflake(*ticket.ChannelId),
+				Name:     "notes",
+				Type:     channel.ChannelTypeGuildPrivateThread,
+			})
+
+			note := h.Discord.AddMessage(notesThreadId, message.Message{
+				Author:  user.User{Id: staffId, Username: "member"},
+				Content: "The opener has been warned before",
+			})
+
+			require.NoError(t, h.Database.Tickets.SetNotesThreadId(t.Context(), g.GuildId, ticket.Id, notesThreadId))
+			require.NoError(t, h.Database.NotesTranscriptConfig.Set(t.Context(), g.GuildId, dbclient.NotesTranscriptConfig{
+				Excluded: test.excluded,
+			}))
+
+			ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, staffId, permcache.Support)
+			ctx.SettingsValue.StoreTranscripts = true
+
+			CloseTicket(t.Context(), ctx, nil, false)
+			require.Empty(t, ctx.Errors())
+
+			_, hasTranscript := h.Archiver.Transcript(g.GuildId, ticket.Id)
+			require.True(t, hasTranscript)
+
+			notes, hasNotes := h.Archiver.Notes(g.GuildId, ticket.Id)
+			require.Equal(t, test.expectNotes, hasNotes)
+
+			if test.expectNotes {
+				require.Len(t, notes, 1)
+				require.Equal(t, note.Id, notes[0].Id)
+				require.Equal(t, note.Content, notes[0].Content)
+			}
+		})
+	}
+}
+
+func TestCloseReopenClose(t *testing.T) {
+	h := testharness.New(t)
+	config.Conf.Transcripts.SpoolDirectory = t.TempDir()
+
+	g := newTicketGuild(t, h, permcache.Everyone)
+	staffId := g.addMember(t, h, permcache.Support)
+
+	archiveChannelId := h.Discord.NextId()
+	h.Discord.AddChannel(channel.Channel{Id: archiveChannelId, GuildId: g.GuildId, Name: "archive", Type: channel.ChannelTypeGuildText})
+	require.NoError(t, h.Database.ArchiveChannel.Set(t.Context(), g.GuildId, utils.Ptr(archiveChannelId)))
+
+	// Keep the channel in the closed category, so that the ticket can be reopened in place
+	panel := h.Database.Panels.Add(database.Panel{GuildId: g.GuildId, Title: "Support"})
+	require.NoError(t, h.Database.ClosedCategoryConfig.Set(t.Context(), g.GuildId, panel.PanelId, dbclient.ClosedCategoryConfig{
+		CategoryId:      h.Discord.NextId(),
+		DeleteAfterDays: 7,
+	}))
+
+	openCtx := h.NewCommandContext(g.GuildId, g.ChannelId, g.UserId, permcache.Everyone)
+	ticket, err := OpenTicket(t.Context(), openCtx, &panel, "Help", nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, openCtx.Errors())
+
+	for i := 1; i <= 2; i++ {
+		ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, staffId, permcache.Support)
+		CloseTicket(t.Context(), ctx, nil, false)
+		require.Empty(t, ctx.Errors())
+
+		// Both the archive channel and the opener are sent the close message for every close
+		require.Len(t, h.Discord.Messages(archiveChannelId), i)
+
+		dmChannel, ok := h.Discord.DMChannel(g.UserId)
+		require.True(t, ok)
+		require.Len(t, h.Discord.Messages(dmChannel.Id), i)
+
+		if i == 1 {
+			reopenCtx := h.NewCommandContext(g.GuildId, g.ChannelId, g.UserId, permcache.Everyone)
+			ReopenTicket(t.Context(), reopenCtx, ticket.Id)
+			require.Empty(t, reopenCtx.Errors())
+
+			stored, err := h.Database.Tickets.Get(t.Context(), ticket.Id, g.GuildId)
+			require.NoError(t, err)
+			require.True(t, stored.Open)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/permission"
+	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+)
+
+const (
+	ClosedChannelPrefix           = "closed-"
+	DefaultClosedChannelRetention = 7
+	MaxClosedChannelRetention     = 90
+)
+
+// getClosedCategoryConfig returns the closed category configured for the ticket's panel, if the ticket is a channel
+// ticket which should be moved there when closed rather than deleted
+func getClosedCategoryConfig(ctx context.Context, worker *worker.Context, ticket database.Ticket) (dbclient.ClosedCategoryConfig, bool, error) {
+	if ticket.IsThread || ticket.PanelId == nil {
+		return dbclient.ClosedCategoryConfig{}, false, nil
+	}
+
+	return worker.Services.Database.ClosedCategoryConfig.Get(ctx, ticket.GuildId, *ticket.PanelId)
+}
+
+// moveToClosedCategory moves the ticket channel to the closed category, renames it with the closed prefix and removes
+// the opener's, and any added members', permission to send messages. The channel is then scheduled to be deleted once the retention period has
+// passed. The original name and category are recorded before the channel is modified, so that retries, and reopening
+// the ticket, restore the channel correctly.
+func moveToClosedCategory(ctx, reasonCtx context.Context, cmd registry.CommandContext, ticket database.Ticket, config dbclient.ClosedCategoryConfig) error {
+	closed, ok, err := cmd.Worker().Services.Database.ClosedChannels.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		ch, err := cmd.Worker().GetChannel(*ticket.ChannelId)
+		if err != nil {
+			return err
+		}
+
+		closed = dbclient.ClosedChannel{
+			GuildId:        ticket.GuildId,
+			TicketId:       ticket.Id,
+			ChannelId:      ch.Id,
+			OriginalName:   ch.Name,
+			OriginalParent: utils.NilIfZero(ch.ParentId.Value),
+			DeleteAt:       time.Now().Add(time.Hour * 24 * time.Duration(config.DeleteAfterDays)),
+		}
+
+		if err := cmd.Worker().Services.Database.ClosedChannels.Set(ctx, closed); err != nil {
+			return err
+		}
+	}
+
+	name, err := buildClosedChannelName(ctx, cmd, ticket)
+	if err != nil {
+		return err
+	}
+
+	data := rest.ModifyChannelData{
+		Name:     name,
+		ParentId: config.CategoryId,
+	}
+
+	if _, err := cmd.Worker().ModifyChannel(reasonCtx, *ticket.ChannelId, data); err != nil {
+		return err
+	}
+
+	participants, err := getTicketParticipants(ctx, cmd.Worker(), ticket)
+	if err != nil {
+		return err
+	}
+
+	for _, userId := range participants {
+		if err := cmd.Worker().EditChannelPermissions(reasonCtx, *ticket.ChannelId, buildClosedParticipantOverwrite(userId)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreClosedChannel moves a ticket channel back out of the closed category, restoring its name and the opener's and
+// added members' permissions, and cancels its scheduled deletion. Channels which were not in a category before being closed are moved
+// back to the top level.
+func RestoreClosedChannel(ctx, reasonCtx context.Context, cmd registry.CommandContext, ticket database.Ticket, closed dbclient.ClosedChannel) error {
+	data := rest.ModifyChannelData{
+		Name:     closed.OriginalName,
+		ParentId: utils.ValueOrZero(closed.OriginalParent),
+	}
+
+	if _, err := cmd.Worker().ModifyChannel(reasonCtx, closed.ChannelId, data); err != nil {
+		return err
+	}
+
+	if closed.OriginalParent == nil {
+		if _, err := cmd.Worker().RemoveChannelParent(reasonCtx, closed.ChannelId); err != nil {
+			return err
+		}
+	}
+
+	additionalPermissions, err := cmd.Worker().Services.Database.TicketPermissions.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	participants, err := getTicketParticipants(ctx, cmd.Worker(), ticket)
+	if err != nil {
+		return err
+	}
+
+	for _, userId := range participants {
+		if err := cmd.Worker().EditChannelPermissions(reasonCtx, closed.ChannelId, BuildUserOverwrite(userId, additionalPermissions)); err != nil {
+			return err
+		}
+	}
+
+	return cmd.Worker().Services.Database.ClosedChannels.Delete(ctx, ticket.GuildId, ticket.Id)
+}
+
+func buildClosedChannelName(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) (string, error) {
+	var panel *database.Panel
+	if ticket.PanelId != nil {
+		p, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
+		if err != nil {
+			return "", err
+		}
+
+		if p.PanelId != 0 {
+			panel = &p
+		}
+	}
+
+	claimer, err := cmd.Worker().Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := GenerateChannelName(ctx, cmd.Worker(), panel, ticket.GuildId, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
+	if err != nil {
+		return "", err
+	}
+
+	return utils.StringMax(ClosedChannelPrefix+name, 100), nil
+}
+
+// getTicketParticipants returns the opener of the ticket, followed by the members which have been added to it
+func getTicketParticipants(ctx context.Context, worker *worker.Context, ticket database.Ticket) ([]uint64, error) {
+	members, err := worker.Services.Database.TicketMembers.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	participants := []uint64{ticket.UserId}
+	for _, userId := range members {
+		if userId != ticket.UserId {
+			participants = append(participants, userId)
+		}
+	}
+
+	return participants, nil
+}
+
+// buildClosedParticipantOverwrite lets a participant read the closed ticket, but not send anything in it
+func buildClosedParticipantOverwrite(userId uint64) channel.PermissionOverwrite {
+	return channel.PermissionOverwrite{
+		Id:   userId,
+		Type: channel.PermissionTypeMember,
+		Allow: permission.BuildPermissions(
+			permission.ViewChannel,
+			permission.ReadMessageHistory,
+		),
+		Deny: permission.BuildPermissions(
+			permission.SendMessages,
+			permission.SendTTSMessages,
+			permission.SendVoiceMessages,
+			permission.AddReactions,
+			permission.AttachFiles,
+			permission.UseApplicationCommands,
+		),
+	}
+}
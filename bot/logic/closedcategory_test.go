@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects"
+	"github.com/TicketsBot-cloud/gdl/permission"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClosedCategoryConfig(t *testing.T) {
+	h := testharness.New(t)
+	guildId := h.Discord.NextId()
+
+	config := dbclient.ClosedCategoryConfig{CategoryId: h.Discord.NextId(), DeleteAfterDays: 3}
+	require.NoError(t, h.Database.ClosedCategoryConfig.Set(t.Context(), guildId, 5, config))
+
+	ticket := database.Ticket{GuildId: guildId, Id: 1, PanelId: utils.Ptr(5)}
+
+	stored, ok, err := getClosedCategoryConfig(t.Context(), h.Worker, ticket)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, config, stored)
+
+	// Threads are archived rather than moved, whatever the panel configures
+	ticket.IsThread = true
+	_, ok, err = getClosedCategoryConfig(t.Context(), h.Worker, ticket)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Tickets from other panels, or without a panel, are deleted as usual
+	for _, panelId := range []*int{utils.Ptr(6), nil} {
+		_, ok, err = getClosedCategoryConfig(t.Context(), h.Worker, database.Ticket{GuildId: guildId, Id: 1, PanelId: panelId})
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+}
+
+func TestRestoreClosedChannel(t *testing.T) {
+	tests := []struct {
+		name           string
+		originalParent bool
+	}{
+		{name: "moves the channel back to its category", originalParent: true},
+		{name: "moves channels without a category out of the closed category", originalParent: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			g := newTicketGuild(t, h, permcache.Everyone)
+			ticket := g.openTicket(t, h, false)
+
+			var originalParent *uint64
+			if test.originalParent {
+				originalParent = utils.Ptr(h.Discord.NextId())
+			}
+
+			ch, ok := h.Discord.Channel(*ticket.ChannelId)
+			require.True(t, ok)
+
+			closed := dbclient.ClosedChannel{
+				GuildId:        g.GuildId,
+				TicketId:       ticket.Id,
+				ChannelId:      ch.Id,
+				OriginalName:   ch.Name,
+				OriginalParent: originalParent,
+				DeleteAt:       time.Now().Add(time.Hour),
+			}
+			require.NoError(t, h.Database.ClosedChannels.Set(t.Context(), closed))
+
+			ch.Name = ClosedChannelPrefix + ch.Name
+			ch.ParentId = objects.NewNullableSnowflake(h.Discord.NextId())
+			h.Discord.AddChannel(ch)
+
+			ctx := h.NewCommandContext(g.GuildId, ch.Id, g.UserId, permcache.Support)
+			require.NoError(t, RestoreClosedChannel(t.Context(), t.Context(), ctx, ticket, closed))
+
+			restored, ok := h.Discord.Channel(ch.Id)
+			require.True(t, ok)
+			require.Equal(t, closed.OriginalName, restored.Name)
+
+			if test.originalParent {
+				require.False(t, restored.ParentId.IsNull)
+				require.Equal(t, *originalParent, restored.ParentId.Value)
+			} else {
+				require.True(t, restored.ParentId.IsNull)
+			}
+
+			_, ok, err := h.Database.ClosedChannels.Get(t.Context(), g.GuildId, ticket.Id)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestClosedParticipantOverwrite(t *testing.T) {
+	overwrite := buildClosedParticipantOverwrite(1)
+
+	require.NotZero(t, overwrite.Allow&permission.BuildPermissions(permission.ViewChannel))
+	require.NotZero(t, overwrite.Allow&permission.BuildPermissions(permission.ReadMessageHistory))
+	require.NotZero(t, overwrite.Deny&permission.BuildPermissions(permission.SendMessages))
+	require.Zero(t, overwrite.Allow&overwrite.Deny)
+}
+
+func TestClosedCategoryParticipantPermissions(t *testing.T) {
+	h := testharness.New(t)
+	g := newTicketGuild(t, h, permcache.Everyone)
+	ticket := g.openTicket(t, h, false)
+
+	added := h.Discord.NextId()
+	require.NoError(t, h.Database.TicketMembers.Add(t.Context(), g.GuildId, ticket.Id, added))
+
+	ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, g.UserId, permcache.Support)
+	config := dbclient.ClosedCategoryConfig{CategoryId: h.Discord.NextId(), DeleteAfterDays: 3}
+	require.NoError(t, moveToClosedCategory(t.Context(), t.Context(), ctx, ticket, config))
+
+	ch, ok := h.Discord.Channel(*ticket.ChannelId)
+	require.True(t, ok)
+
+	sendMessages := permission.BuildPermissions(permission.SendMessages)
+	for _, userId := range []uint64{g.UserId, added} {
+		overwrite, ok := findOverwrite(ch.PermissionOverwrites, userId)
+		require.True(t, ok)
+		require.NotZero(t, overwrite.Deny&sendMessages)
+	}
+
+	closed, ok, err := h.Database.ClosedChannels.Get(t.Context(), g.GuildId, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, RestoreClosedChannel(t.Context(), t.Context(), ctx, ticket, closed))
+
+	ch, ok = h.Discord.Channel(*ticket.ChannelId)
+	require.True(t, ok)
+
+	for _, userId := range []uint64{g.UserId, added} {
+		overwrite, ok := findOverwrite(ch.PermissionOverwrites, userId)
+		require.True(t, ok)
+		require.NotZero(t, overwrite.Allow&sendMessages)
+		require.Zero(t, overwrite.Deny&sendMessages)
+	}
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 )
@@ -144,7 +143,7 @@ func BuildCloseEmbed(
 
 	var claimedBy string
 	{
-		claimUserId, err := dbclient.Client.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+		claimUserId, err := worker.Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
 		if err != nil {
 			sentry.Error(err)
 		}
@@ -219,7 +218,7 @@ func EditDMMessageIfExists(
 	reason *string,
 	rating *uint8,
 ) error {
-	dmMessage, ok, err := dbclient.Client.ArchiveDmMessages.Get(ctx, ticket.GuildId, ticket.Id)
+	dmMessage, ok, err := w.Services.Database.ArchiveDmMessages.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return err
 	}
@@ -228,12 +227,12 @@ func EditDMMessageIfExists(
 		return nil
 	}
 
-	feedbackEnabled, err := dbclient.Client.FeedbackEnabled.Get(ctx, ticket.GuildId)
+	feedbackEnabled, err := w.Services.Database.FeedbackEnabled.Get(ctx, ticket.GuildId)
 	if err != nil {
 		return err
 	}
 
-	hasSentMessage, err := dbclient.Client.Participants.HasParticipated(ctx, ticket.GuildId, ticket.Id, ticket.UserId)
+	hasSentMessage, err := w.Services.Database.Participants.HasParticipated(ctx, ticket.GuildId, ticket.Id, ticket.UserId)
 	if err != nil {
 		return err
 	}
@@ -300,7 +299,7 @@ func EditGuildArchiveMessageIfExists(
 	reason *string,
 	rating *uint8,
 ) error {
-	archiveMessage, ok, err := dbclient.Client.ArchiveMessages.Get(ctx, ticket.GuildId, ticket.Id)
+	archiveMessage, ok, err := worker.Services.Database.ArchiveMessages.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,499 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/google/uuid"
+)
+
+// The steps carried out after a ticket has been marked as closed in the database. Each step is safe to run more than
+// once, so that a close which fails partway through can be retried from the outbox.
+const (
+	CloseStepCloseReason    = "close_reason"
+	CloseStepChannel        = "channel"
+	CloseStepWebhook        = "webhook"
+	CloseStepCloseRequest   = "close_request"
+	CloseStepJoinMessage    = "join_message"
+	CloseStepArchiveMessage = "archive_message"
+	CloseStepDirectMessage  = "direct_message"
+	CloseStepAttachments    = "attachments"
+)
+
+// backgroundCloseSteps are too slow to run while responding to the close, so are always left for the outbox to run
+var backgroundCloseSteps = []string{CloseStepAttachments}
+
+const (
+	// CloseOutboxClaimTimeout is how long an attempt has to complete before another worker may retry the entry
+	CloseOutboxClaimTimeout = time.Minute * 2
+	closeOutboxMaxAttempts  = 8
+	closeOutboxBaseBackoff  = time.Second * 30
+	closeOutboxMaxBackoff   = time.Hour
+)
+
+// CloseOutboxAttemptTimeout returns how long an attempt at the entry's remaining steps may take, allowing for the time
+// budget of any background steps
+func CloseOutboxAttemptTimeout(entry redis.CloseOutboxEntry) time.Duration {
+	if slices.Contains(entry.Steps, CloseStepAttachments) {
+		return CloseOutboxClaimTimeout + AttachmentPreserveTimeout
+	}
+
+	return CloseOutboxClaimTimeout
+}
+
+// NewCloseOutboxEntry builds an entry containing every step which applies to the ticket
+func NewCloseOutboxEntry(ticket database.Ticket, closedBy uint64, closedByName string, reason *string) redis.CloseOutboxEntry {
+	steps := []string{CloseStepCloseReason}
+
+	if ticket.ChannelId != nil {
+		steps = append(steps, CloseStepChannel)
+	}
+
+	if !ticket.IsThread {
+		steps = append(steps, CloseStepWebhook)
+	}
+
+	steps = append(steps, CloseStepCloseRequest)
+
+	if ticket.IsThread && ticket.JoinMessageId != nil {
+		steps = append(steps, CloseStepJoinMessage)
+	}
+
+	steps = append(steps, CloseStepArchiveMessage, CloseStepDirectMessage)
+
+	now := time.Now()
+	return redis.CloseOutboxEntry{
+		Id:           uuid.NewString(),
+		GuildId:      ticket.GuildId,
+		TicketId:     ticket.Id,
+		ClosedBy:     closedBy,
+		ClosedByName: closedByName,
+		Reason:       reason,
+		Steps:        steps,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// NewSpamCloseOutboxEntry builds an entry for a ticket closed as spam, which skips the DM to the opener
+func NewSpamCloseOutboxEntry(ticket database.Ticket, closedBy uint64, closedByName string, reason *string) redis.CloseOutboxEntry {
+	entry := NewCloseOutboxEntry(ticket, closedBy, closedByName, reason)
+	entry.Steps = slices.DeleteFunc(entry.Steps, func(step string) bool {
+		return step == CloseStepDirectMessage
+	})
+	entry.Spam = true
+
+	return entry
+}
+
+// ProcessCloseOutboxEntry runs each of the entry's outstanding steps, then deletes the entry if they all succeeded, or
+// saves it with the steps that failed so that they are retried with backoff. Transcript exports are only attached to
+// the archive and DM messages if they are passed, i.e. on the first attempt. If inline is set, the entry is being
+// processed while responding to the close, so background steps are skipped and scheduled to run from the outbox
+// straight away. The errors of the steps which failed are returned, keyed by step; the error is only non-nil if the
+// outbox itself could not be updated.
+func ProcessCloseOutboxEntry(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	settings database.Settings,
+	entry redis.CloseOutboxEntry,
+	exports TranscriptExports,
+	inline bool,
+) (map[string]error, error) {
+	failed := make(map[string]error)
+
+	var remaining []string
+	for _, step := range entry.Steps {
+		if inline && slices.Contains(backgroundCloseSteps, step) {
+			remaining = append(remaining, step)
+			continue
+		}
+
+		if err := runCloseStep(ctx, cmd, ticket, settings, entry, exports, step); err != nil {
+			failed[step] = err
+			remaining = append(remaining, step)
+		}
+	}
+
+	if len(remaining) == 0 {
+		return nil, cmd.Worker().Services.Redis.DeleteCloseOutboxEntry(ctx, entry.GuildId, entry.TicketId)
+	}
+
+	// Only skipped background steps remain, which is not a failed attempt
+	if len(failed) == 0 {
+		entry.Steps = remaining
+		entry.UpdatedAt = time.Now()
+		return nil, cmd.Worker().Services.Redis.SaveCloseOutboxEntry(ctx, entry, time.Now())
+	}
+
+	errs := make([]string, 0, len(remaining))
+	for _, step := range remaining {
+		errs = append(errs, fmt.Sprintf("%s: %s", step, failed[step].Error()))
+	}
+
+	entry.Steps = remaining
+	entry.Attempts++
+	entry.LastError = strings.Join(errs, "; ")
+	entry.UpdatedAt = time.Now()
+	entry.Abandoned = entry.Attempts >= closeOutboxMaxAttempts
+
+	// The transcript will not be updated to reference any attachments which were preserved, so they no longer count
+	// towards the quota
+	if entry.Abandoned && slices.Contains(remaining, CloseStepAttachments) {
+		if err := cmd.Worker().Services.Database.PreservedAttachments.DeleteByTicket(ctx, entry.GuildId, entry.TicketId); err != nil {
+			return failed, err
+		}
+	}
+
+	if err := cmd.Worker().Services.Redis.SaveCloseOutboxEntry(ctx, entry, time.Now().Add(closeOutboxBackoff(entry.Attempts))); err != nil {
+		return failed, err
+	}
+
+	return failed, nil
+}
+
+func closeOutboxBackoff(attempts int) time.Duration {
+	backoff := closeOutboxBaseBackoff
+	for i := 1; i < attempts && backoff < closeOutboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, closeOutboxMaxBackoff)
+}
+
+func runCloseStep(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	settings database.Settings,
+	entry redis.CloseOutboxEntry,
+	exports TranscriptExports,
+	step string,
+) error {
+	switch step {
+	case CloseStepCloseReason:
+		closeMetadata := database.CloseMetadata{
+			Reason: entry.Reason,
+		}
+
+		if entry.ClosedBy != cmd.Worker().BotId {
+			closeMetadata.ClosedBy = utils.Ptr(entry.ClosedBy)
+		}
+
+		return cmd.Worker().Services.Database.CloseReason.Set(ctx, ticket.GuildId, ticket.Id, closeMetadata)
+	case CloseStepChannel:
+		return closeTicketChannel(ctx, cmd, ticket, entry)
+	case CloseStepWebhook:
+		return cmd.Worker().Services.Database.Webhooks.Delete(ctx, ticket.GuildId, ticket.Id)
+	case CloseStepCloseRequest:
+		return cmd.Worker().Services.Database.CloseRequest.Delete(ctx, ticket.GuildId, ticket.Id)
+	case CloseStepJoinMessage:
+		return deleteJoinMessage(ctx, cmd, ticket, settings)
+	case CloseStepArchiveMessage:
+		return sendArchiveCloseMessage(ctx, cmd, ticket, settings, entry, exports)
+	case CloseStepDirectMessage:
+		return sendDirectCloseMessage(ctx, cmd, ticket, settings, entry, exports)
+	case CloseStepAttachments:
+		return preserveTranscriptAttachments(ctx, cmd, ticket)
+	default:
+		// Drop steps we don't recognise, rather than retrying them forever
+		return nil
+	}
+}
+
+// closeTicketChannel archives and locks thread tickets, and deletes channel tickets, or moves them to the closed
+// category if the panel has one configured. A channel which no longer exists is treated as already closed.
+func closeTicketChannel(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, entry redis.CloseOutboxEntry) error {
+	auditReason := fmt.Sprintf("Ticket %d closed by %s", ticket.Id, entry.ClosedByName)
+	reasonCtx := request.WithAuditReason(context.Background(), auditReason)
+
+	closedCategory, moveToCategory, err := getClosedCategoryConfig(ctx, cmd.Worker(), ticket)
+	if err != nil {
+		return err
+	}
+
+	if ticket.IsThread {
+		data := rest.ModifyChannelData{
+			ThreadMetadataModifyData: &rest.ThreadMetadataModifyData{
+				Archived: utils.Ptr(true),
+				Locked:   utils.Ptr(true),
+			},
+		}
+
+		_, err = cmd.Worker().ModifyChannel(reasonCtx, *ticket.ChannelId, data)
+	} else if moveToCategory {
+		err = moveToClosedCategory(ctx, reasonCtx, cmd, ticket, closedCategory)
+	} else {
+		_, err = cmd.Worker().DeleteChannel(reasonCtx, *ticket.ChannelId)
+	}
+
+	if err != nil {
+		var restError request.RestError
+		if errors.As(err, &restError) {
+			if restError.StatusCode == http.StatusNotFound {
+				return nil
+			}
+
+			// Check if we should exclude this from autoclose
+			if restError.StatusCode == http.StatusForbidden {
+				if err := cmd.Worker().Services.Database.AutoCloseExclude.Exclude(ctx, ticket.GuildId, ticket.Id); err != nil {
+					return err
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// deleteJoinMessage deletes the join thread button from the notification channel it was posted in
+func deleteJoinMessage(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, settings database.Settings) error {
+	if ticket.JoinMessageId == nil {
+		return nil
+	}
+
+	// Determine which notification channel was used
+	// Priority: Panel-specific notification channel > Global notification channel
+	var notificationChannel *uint64
+
+	// Get panel if this ticket has one
+	var panel *database.Panel
+	if ticket.PanelId != nil {
+		p, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
+		if err != nil {
+			return err
+		}
+
+		if p.PanelId != 0 {
+			panel = &p
+		}
+	}
+
+	if panel != nil && panel.TicketNotificationChannel != nil {
+		notificationChannel = panel.TicketNotificationChannel
+	} else if settings.TicketNotificationChannel != nil {
+		notificationChannel = settings.TicketNotificationChannel
+	}
+
+	if notificationChannel == nil {
+		return nil
+	}
+
+	_ = cmd.Worker().DeleteMessage(*notificationChannel, *ticket.JoinMessageId)
+	return cmd.Worker().Services.Database.Tickets.SetJoinMessageId(ctx, ticket.GuildId, ticket.Id, nil)
+}
+
+// sendArchiveCloseMessage sends the close embed to the archive channel, unless it has already been sent for this close
+func sendArchiveCloseMessage(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	settings database.Settings,
+	entry redis.CloseOutboxEntry,
+	exports TranscriptExports,
+) error {
+	if sent, err := cmd.Worker().Services.Redis.IsCloseOutboxStepSent(ctx, entry.Id, CloseStepArchiveMessage); err != nil {
+		return err
+	} else if sent {
+		return nil
+	}
+
+	var archiveChannelId *uint64
+	if ticket.PanelId != nil {
+		acId, err := cmd.Worker().Services.Database.ArchiveChannel.GetByPanel(ctx, ticket.GuildId, *ticket.PanelId)
+		if err != nil {
+			return err
+		}
+		archiveChannelId = acId
+	} else {
+		acId, err := cmd.Worker().Services.Database.ArchiveChannel.Get(ctx, ticket.GuildId)
+		if err != nil {
+			return err
+		}
+		archiveChannelId = acId
+	}
+
+	if archiveChannelId == nil {
+		return nil
+	}
+
+	if _, err := cmd.Worker().GetChannel(*archiveChannelId); err != nil {
+		var restError request.RestError
+		if errors.As(err, &restError) && restError.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	componentBuilders := [][]CloseEmbedElement{
+		{
+			TranscriptLinkElement(settings.StoreTranscripts && !entry.Spam),
+			ThreadLinkElement(ticket.IsThread && ticket.ChannelId != nil),
+			EditCloseReasonElement(),
+		},
+	}
+
+	closeEmbed, closeComponents := BuildCloseEmbed(ctx, cmd.Worker(), ticket, entry.ClosedBy, entry.Reason, nil, componentBuilders)
+
+	data := rest.CreateMessageData{
+		Embeds:     utils.Slice(closeEmbed),
+		Components: closeComponents,
+	}
+
+	if exports.Staff != nil {
+		data.Attachments = []request.Attachment{transcriptExportAttachment(*exports.Staff)}
+	}
+
+	msg, err := cmd.Worker().CreateMessageComplex(*archiveChannelId, data)
+	if err != nil {
+		return err
+	}
+
+	// Add message to archive. If we cannot record it, remove the message so that the retry does not post a duplicate.
+	if err := recordArchiveCloseMessage(ctx, cmd.Worker(), ticket, entry, *archiveChannelId, msg.Id); err != nil {
+		_ = cmd.Worker().DeleteMessage(*archiveChannelId, msg.Id)
+		return err
+	}
+
+	return nil
+}
+
+func recordArchiveCloseMessage(ctx context.Context, worker *worker.Context, ticket database.Ticket, entry redis.CloseOutboxEntry, channelId, messageId uint64) error {
+	if err := worker.Services.Database.ArchiveMessages.Set(ctx, ticket.GuildId, ticket.Id, channelId, messageId); err != nil {
+		return err
+	}
+
+	return worker.Services.Redis.MarkCloseOutboxStepSent(ctx, entry.Id, CloseStepArchiveMessage)
+}
+
+// sendDirectCloseMessage notifies the ticket opener in DMs, unless they have already been notified of this close
+func sendDirectCloseMessage(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	settings database.Settings,
+	entry redis.CloseOutboxEntry,
+	exports TranscriptExports,
+) error {
+	if sent, err := cmd.Worker().Services.Redis.IsCloseOutboxStepSent(ctx, entry.Id, CloseStepDirectMessage); err != nil {
+		return err
+	} else if sent {
+		return nil
+	}
+
+	// This mutates state!
+	dmChannel, ok := getDmChannel(cmd, ticket.UserId)
+	if !ok {
+		return nil
+	}
+
+	guild, err := cmd.Guild()
+	if err != nil {
+		return err
+	}
+
+	feedbackEnabled, err := cmd.Worker().Services.Database.FeedbackEnabled.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	// Only offer to take feedback if the user has sent a message
+	hasSentMessage, err := cmd.Worker().Services.Database.Participants.HasParticipated(ctx, ticket.GuildId, ticket.Id, ticket.UserId)
+	if err != nil {
+		return err
+	}
+
+	openerMember, err := cmd.Worker().GetGuildMember(ticket.GuildId, ticket.UserId)
+	if err != nil {
+		var restError request.RestError
+		if !errors.As(err, &restError) || restError.StatusCode != http.StatusNotFound { // User left the server
+			return err
+		}
+	}
+
+	// Only offer to take feedback if the user is *not* staff
+	permLevel, err := permission.GetPermissionLevel(ctx, utils.ToRetriever(cmd.Worker()), openerMember, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	statsd.Client.IncrementKey(statsd.KeyDirectMessage)
+
+	componentBuilders := [][]CloseEmbedElement{
+		{
+			TranscriptLinkElement(settings.StoreTranscripts),
+			ThreadLinkElement(ticket.IsThread && ticket.ChannelId != nil),
+		},
+		{
+			FeedbackRowElement(feedbackEnabled && hasSentMessage && permLevel == permission.Everyone),
+		},
+	}
+
+	closeEmbed, closeComponents := BuildCloseEmbed(ctx, cmd.Worker(), ticket, entry.ClosedBy, entry.Reason, nil, componentBuilders)
+	closeEmbed.SetAuthor(guild.Name, "", fmt.Sprintf("https://cdn.discordapp.com/icons/%d/%s.png", guild.Id, guild.Icon))
+
+	// Use message content to tell users why they can't rate a ticket
+	var content string
+	if feedbackEnabled {
+		if permLevel > permission.Everyone {
+			content = "-# " + cmd.GetMessage(i18n.MessageCloseCantRateStaff, guild.Name)
+		} else if !hasSentMessage {
+			content = "-# " + cmd.GetMessage(i18n.MessageCloseCantRateEmpty)
+		}
+	}
+
+	data := rest.CreateMessageData{
+		Content:    content,
+		Embeds:     utils.Slice(closeEmbed),
+		Components: closeComponents,
+	}
+
+	if exports.Opener != nil {
+		data.Attachments = []request.Attachment{transcriptExportAttachment(*exports.Opener)}
+	}
+
+	msg, err := cmd.Worker().CreateMessageComplex(dmChannel, data)
+	if err != nil {
+		var restError request.RestError
+		if errors.As(err, &restError) && restError.StatusCode == http.StatusForbidden {
+			// The user has DMs disabled, retrying will not help
+			return nil
+		}
+
+		return err
+	}
+
+	if err := recordDirectCloseMessage(ctx, cmd.Worker(), ticket, entry, msg.Id); err != nil {
+		_ = cmd.Worker().DeleteMessage(dmChannel, msg.Id)
+		return err
+	}
+
+	return nil
+}
+
+func recordDirectCloseMessage(ctx context.Context, worker *worker.Context, ticket database.Ticket, entry redis.CloseOutboxEntry, messageId uint64) error {
+	if err := worker.Services.Database.ArchiveDmMessages.Set(ctx, ticket.GuildId, ticket.Id, messageId); err != nil {
+		return err
+	}
+
+	return worker.Services.Redis.MarkCloseOutboxStepSent(ctx, entry.Id, CloseStepDirectMessage)
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCloseOutboxEntrySteps(t *testing.T) {
+	channel := NewCloseOutboxEntry(database.Ticket{GuildId: 1, Id: 2, ChannelId: utils.Ptr(uint64(3))}, 4, "staff", nil)
+	require.Equal(t, []string{
+		CloseStepCloseReason,
+		CloseStepChannel,
+		CloseStepWebhook,
+		CloseStepCloseRequest,
+		CloseStepArchiveMessage,
+		CloseStepDirectMessage,
+	}, channel.Steps)
+
+	thread := NewCloseOutboxEntry(database.Ticket{
+		GuildId:       1,
+		Id:            2,
+		ChannelId:     utils.Ptr(uint64(3)),
+		IsThread:      true,
+		JoinMessageId: utils.Ptr(uint64(5)),
+	}, 4, "staff", nil)
+	require.Equal(t, []string{
+		CloseStepCloseReason,
+		CloseStepChannel,
+		CloseStepCloseRequest,
+		CloseStepJoinMessage,
+		CloseStepArchiveMessage,
+		CloseStepDirectMessage,
+	}, thread.Steps)
+}
+
+func TestNewSpamCloseOutboxEntrySteps(t *testing.T) {
+	entry := NewSpamCloseOutboxEntry(database.Ticket{GuildId: 1, Id: 2, ChannelId: utils.Ptr(uint64(3))}, 4, "staff", utils.Ptr(SpamCloseReason))
+	require.True(t, entry.Spam)
+	require.Equal(t, []string{
+		CloseStepCloseReason,
+		CloseStepChannel,
+		CloseStepWebhook,
+		CloseStepCloseRequest,
+		CloseStepArchiveMessage,
+	}, entry.Steps)
+}
+
+func TestCloseOutboxBackoff(t *testing.T) {
+	require.Equal(t, closeOutboxBaseBackoff, closeOutboxBackoff(1))
+	require.Equal(t, closeOutboxBaseBackoff*4, closeOutboxBackoff(3))
+	require.Equal(t, closeOutboxMaxBackoff, closeOutboxBackoff(20))
+	require.LessOrEqual(t, closeOutboxBackoff(closeOutboxMaxAttempts), time.Hour)
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+)
+
+const (
+	CloseReasonCategoryResolved  = "resolved"
+	CloseReasonCategoryDuplicate = "duplicate"
+	CloseReasonCategorySpam      = "spam"
+	CloseReasonCategoryInvalid   = "invalid"
+	CloseReasonCategoryOther     = "other"
+)
+
+var CloseReasonCategories = []string{
+	CloseReasonCategoryResolved,
+	CloseReasonCategoryDuplicate,
+	CloseReasonCategorySpam,
+	CloseReasonCategoryInvalid,
+	CloseReasonCategoryOther,
+}
+
+const (
+	// MaxCloseReasonPresets is the most presets a panel may have, as they are offered in a single select menu
+	MaxCloseReasonPresets = 25
+	// MaxCloseReasonPresetLabelLength is Discord's limit on the length of select menu option labels
+	MaxCloseReasonPresetLabelLength = 100
+	// MaxCloseReasonLength is the longest close reason which can be given through the close with reason modal
+	MaxCloseReasonLength = 1024
+)
+
+// GetCloseReasonConfig returns the close reason presets of the ticket's panel. Tickets without a panel have no presets,
+// and do not require a reason.
+func GetCloseReasonConfig(ctx context.Context, worker *worker.Context, ticket database.Ticket) (dbclient.CloseReasonConfig, error) {
+	if ticket.PanelId == nil {
+		return dbclient.CloseReasonConfig{}, nil
+	}
+
+	config, _, err := worker.Services.Database.CloseReasonConfig.Get(ctx, ticket.GuildId, *ticket.PanelId)
+	return config, err
+}
+
+// MatchCloseReasonPreset returns the preset which the close reason was built from. Reasons are either the preset's
+// label, or the label followed by a colon and further details.
+func MatchCloseReasonPreset(config dbclient.CloseReasonConfig, reason string) (dbclient.CloseReasonPreset, bool) {
+	reason = strings.TrimSpace(reason)
+
+	for _, preset := range config.Presets {
+		if strings.EqualFold(reason, preset.Label) {
+			return preset, true
+		}
+
+		if len(reason) > len(preset.Label) && strings.EqualFold(reason[:len(preset.Label)], preset.Label) && reason[len(preset.Label)] == ':' {
+			return preset, true
+		}
+	}
+
+	return dbclient.CloseReasonPreset{}, false
+}
+
+// BuildCloseReason combines the label of the chosen preset, if any, with the further details given. Nil is returned if
+// neither was given.
+func BuildCloseReason(presetLabel, details string) *string {
+	details = strings.TrimSpace(details)
+
+	var reason string
+	switch {
+	case presetLabel != "" && details != "":
+		reason = fmt.Sprintf("%s: %s", presetLabel, details)
+	case presetLabel != "":
+		reason = presetLabel
+	case details != "":
+		reason = details
+	default:
+		return nil
+	}
+
+	return utils.Ptr(utils.StringMax(reason, MaxCloseReasonLength))
+}
+
+func hasCloseReason(reason *string) bool {
+	return reason != nil && strings.TrimSpace(*reason) != ""
+}
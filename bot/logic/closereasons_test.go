@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCloseReason(t *testing.T) {
+	require.Nil(t, BuildCloseReason("", "  "))
+	require.Equal(t, "Fixed", *BuildCloseReason("Fixed", ""))
+	require.Equal(t, "Restarted the server", *BuildCloseReason("", " Restarted the server "))
+	require.Equal(t, "Fixed: Restarted the server", *BuildCloseReason("Fixed", "Restarted the server"))
+	require.Len(t, *BuildCloseReason("Fixed", strings.Repeat("x", MaxCloseReasonLength)), MaxCloseReasonLength)
+
+	// Reasons built from a preset can be matched back to it for the statistics
+	config := dbclient.CloseReasonConfig{Presets: []dbclient.CloseReasonPreset{{Label: "Fixed", Category: CloseReasonCategoryResolved}}}
+	preset, ok := MatchCloseReasonPreset(config, *BuildCloseReason("Fixed", "Restarted the server"))
+	require.True(t, ok)
+	require.Equal(t, CloseReasonCategoryResolved, preset.Category)
+}
+
+func TestMatchCloseReasonPreset(t *testing.T) {
+	config := dbclient.CloseReasonConfig{Presets: []dbclient.CloseReasonPreset{
+		{Label: "Fixed", Category: CloseReasonCategoryResolved},
+		{Label: "Fixed elsewhere", Category: CloseReasonCategoryDuplicate},
+	}}
+
+	for reason, category := range map[string]string{
+		"Fixed":                   CloseReasonCategoryResolved,
+		" fixed ":                 CloseReasonCategoryResolved,
+		"Fixed: restarted":        CloseReasonCategoryResolved,
+		"Fixed elsewhere":         CloseReasonCategoryDuplicate,
+		"Fixed elsewhere: see #1": CloseReasonCategoryDuplicate,
+	} {
+		preset, ok := MatchCloseReasonPreset(config, reason)
+		require.True(t, ok, reason)
+		require.Equal(t, category, preset.Category, reason)
+	}
+
+	for _, reason := range []string{"", "Fixe", "Fixed it", "Not fixed"} {
+		_, ok := MatchCloseReasonPreset(config, reason)
+		require.False(t, ok, reason)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/gdl/rest"
 	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 )
 
@@ -199,7 +198,7 @@ func RemoveOnCallRoles(ctx context.Context, cmd registry.CommandContext, userId
 		return err
 	}
 
-	metadata, err := dbclient.Client.GuildMetadata.Get(ctx, cmd.GuildId())
+	metadata, err := cmd.Worker().Services.Database.GuildMetadata.Get(ctx, cmd.GuildId())
 	if err != nil {
 		return err
 	}
@@ -212,7 +211,7 @@ func RemoveOnCallRoles(ctx context.Context, cmd registry.CommandContext, userId
 		}
 	}
 
-	teams, err := dbclient.Client.SupportTeam.Get(ctx, cmd.GuildId())
+	teams, err := cmd.Worker().Services.Database.SupportTeam.Get(ctx, cmd.GuildId())
 	if err != nil {
 		return err
 	}
@@ -230,7 +229,7 @@ func RemoveOnCallRoles(ctx context.Context, cmd registry.CommandContext, userId
 
 func RecreateOnCallRole(ctx context.Context, cmd registry.CommandContext, team *database.SupportTeam) error {
 	if team == nil {
-		metadata, err := dbclient.Client.GuildMetadata.Get(ctx, cmd.GuildId())
+		metadata, err := cmd.Worker().Services.Database.GuildMetadata.Get(ctx, cmd.GuildId())
 		if err != nil {
 			return err
 		}
@@ -239,7 +238,7 @@ func RecreateOnCallRole(ctx context.Context, cmd registry.CommandContext, team *
 			return nil
 		}
 
-		if err := dbclient.Client.GuildMetadata.SetOnCallRole(ctx, cmd.GuildId(), nil); err != nil {
+		if err := cmd.Worker().Services.Database.GuildMetadata.SetOnCallRole(ctx, cmd.GuildId(), nil); err != nil {
 			return nil
 		}
 
@@ -261,7 +260,7 @@ func RecreateOnCallRole(ctx context.Context, cmd registry.CommandContext, team *
 		}
 
 		// Delete role
-		if err := dbclient.Client.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
+		if err := cmd.Worker().Services.Database.SupportTeam.SetOnCallRole(ctx, team.Id, nil); err != nil {
 			return err
 		}
 
@@ -301,11 +300,11 @@ func CreateOnCallRole(ctx context.Context, cmd registry.CommandContext, team *da
 	}
 
 	if team == nil {
-		if err := dbclient.Client.GuildMetadata.SetOnCallRole(ctx, cmd.GuildId(), &role.Id); err != nil {
+		if err := cmd.Worker().Services.Database.GuildMetadata.SetOnCallRole(ctx, cmd.GuildId(), &role.Id); err != nil {
 			return 0, err
 		}
 	} else {
-		if err := dbclient.Client.SupportTeam.SetOnCallRole(ctx, team.Id, &role.Id); err != nil {
+		if err := cmd.Worker().Services.Database.SupportTeam.SetOnCallRole(ctx, team.Id, &role.Id); err != nil {
 			return 0, err
 		}
 	}
@@ -0,0 +1,264 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// PositiveRatingThreshold is the lowest rating for which the claimer is thanked, if enabled
+const PositiveRatingThreshold = 4
+
+// HandleFeedbackFollowUp applies the guild's follow-up rules to a rating which has just been stored: low ratings
+// raise an alert in the configured staff channel, and positive ratings may thank the claimer.
+func HandleFeedbackFollowUp(ctx context.Context, worker *worker.Context, ticket database.Ticket, rating uint8) error {
+	config, ok, err := worker.Services.Database.FeedbackFollowUpConfig.Get(ctx, ticket.GuildId)
+	if err != nil || !ok {
+		return err
+	}
+
+	// The user may change their rating, in which case the existing alert is updated rather than a new one posted
+	followUp, hasFollowUp, err := worker.Services.Redis.GetFeedbackFollowUp(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	if hasFollowUp {
+		followUp.Rating = rating
+		if err := worker.Services.Redis.SetFeedbackFollowUp(ctx, ticket.GuildId, ticket.Id, followUp); err != nil {
+			return err
+		}
+
+		return UpdateFeedbackFollowUp(ctx, worker, ticket)
+	}
+
+	if config.ChannelId != 0 && rating <= config.Threshold {
+		return sendFeedbackAlert(ctx, worker, ticket, config, rating)
+	}
+
+	if config.ThankClaimer && rating >= PositiveRatingThreshold {
+		return thankClaimer(ctx, worker, ticket, rating)
+	}
+
+	return nil
+}
+
+// UpdateFeedbackFollowUp re-renders the alert for the ticket, if one exists, for example after the exit survey has been
+// completed or the follow-up has been resolved
+func UpdateFeedbackFollowUp(ctx context.Context, worker *worker.Context, ticket database.Ticket) error {
+	followUp, ok, err := worker.Services.Redis.GetFeedbackFollowUp(ctx, ticket.GuildId, ticket.Id)
+	if err != nil || !ok {
+		return err
+	}
+
+	config, _, err := worker.Services.Database.FeedbackFollowUpConfig.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	e, components, err := buildFeedbackAlert(ctx, worker, ticket, config, followUp)
+	if err != nil {
+		return err
+	}
+
+	_, err = worker.EditMessage(followUp.ChannelId, followUp.MessageId, rest.EditMessageData{
+		Embeds:     utils.Slice(e),
+		Components: components,
+	})
+
+	return err
+}
+
+func sendFeedbackAlert(ctx context.Context, worker *worker.Context, ticket database.Ticket, config dbclient.FeedbackFollowUpConfig, rating uint8) error {
+	followUp := redis.FeedbackFollowUp{
+		ChannelId: config.ChannelId,
+		Rating:    rating,
+	}
+
+	e, components, err := buildFeedbackAlert(ctx, worker, ticket, config, followUp)
+	if err != nil {
+		return err
+	}
+
+	msg, err := worker.CreateMessageComplex(config.ChannelId, rest.CreateMessageData{
+		Embeds:     utils.Slice(e),
+		Components: components,
+	})
+	if err != nil {
+		return err
+	}
+
+	followUp.MessageId = msg.Id
+	return worker.Services.Redis.SetFeedbackFollowUp(ctx, ticket.GuildId, ticket.Id, followUp)
+}
+
+func buildFeedbackAlert(
+	ctx context.Context,
+	worker *worker.Context,
+	ticket database.Ticket,
+	config dbclient.FeedbackFollowUpConfig,
+	followUp redis.FeedbackFollowUp,
+) (*embed.Embed, []component.Component, error) {
+	settings, err := worker.Services.Database.Settings.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claimerId, err := worker.Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closeMetadata, _, err := worker.Services.Database.CloseReason.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	surveyResponse, err := worker.Services.Database.ExitSurveyResponses.GetResponses(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	colourCode := customisation.Red
+	if followUp.Resolved {
+		colourCode = customisation.Green
+	}
+
+	colour, err := utils.GetColourForGuild(ctx, worker, colourCode, ticket.GuildId)
+	if err != nil {
+		sentry.Error(err)
+		colour = colourCode.Default()
+	}
+
+	claimedBy := "Not claimed"
+	if claimerId != 0 {
+		claimedBy = fmt.Sprintf("<@%d>", claimerId)
+	}
+
+	reason := "No reason specified"
+	if closeMetadata.Reason != nil {
+		reason = utils.StringMax(*closeMetadata.Reason, 1024)
+	}
+
+	// TODO: Translate titles
+	e := embed.NewEmbed().
+		SetTitle("Low Rating Received").
+		SetColor(colour).
+		AddField(formatTitle("Ticket ID", customisation.EmojiId, worker.IsWhitelabel), strconv.Itoa(ticket.Id), true).
+		AddField(formatTitle("Opened By", customisation.EmojiOpen, worker.IsWhitelabel), fmt.Sprintf("<@%d>", ticket.UserId), true).
+		AddField(formatTitle("Claimed By", customisation.EmojiClaim, worker.IsWhitelabel), claimedBy, true).
+		AddField(formatTitle("Rating", customisation.EmojiRating, worker.IsWhitelabel), fmt.Sprintf("%d ⭐", followUp.Rating), true).
+		AddField(formatTitle("Open Time", customisation.EmojiOpenTime, worker.IsWhitelabel), message.BuildTimestamp(ticket.OpenTime, message.TimestampStyleShortDateTime), true).
+		AddBlankField(true).
+		AddField(formatTitle("Reason", customisation.EmojiReason, worker.IsWhitelabel), reason, false)
+
+	// Embeds are limited to 25 fields, so leave room for the status field
+	for i, answer := range surveyResponse.Responses {
+		if i >= 16 {
+			break
+		}
+
+		question := "Unknown Question"
+		if answer.Question != nil {
+			question = utils.StringMax(*answer.Question, 256)
+		}
+
+		response := "No response"
+		if len(answer.Response) > 0 {
+			response = utils.StringMax(answer.Response, 1024)
+		}
+
+		e.AddField(question, response, false)
+	}
+
+	if followUp.Resolved && followUp.ResolvedBy != nil && followUp.ResolvedAt != nil {
+		e.AddField("Status", fmt.Sprintf("Resolved by <@%d> %s", *followUp.ResolvedBy, message.BuildTimestamp(*followUp.ResolvedAt, message.TimestampStyleRelativeTime)), false)
+	} else {
+		e.AddField("Status", "Awaiting follow-up", false)
+	}
+
+	buttons := utils.Slice(component.BuildButton(component.Button{
+		Label:    "Mark Resolved",
+		CustomId: fmt.Sprintf("feedback-followup-resolve-%d-%d", ticket.GuildId, ticket.Id),
+		Style:    component.ButtonStyleSuccess,
+		Emoji:    utils.BuildEmoji("✔️"),
+		Disabled: followUp.Resolved,
+	}))
+
+	if config.ReopenButton && ticket.IsThread && ticket.ChannelId != nil && !followUp.Resolved {
+		buttons = append(buttons, component.BuildButton(component.Button{
+			Label:    "Reopen Ticket",
+			CustomId: fmt.Sprintf("feedback-followup-reopen-%d-%d", ticket.GuildId, ticket.Id),
+			Style:    component.ButtonStyleSecondary,
+			Emoji:    utils.BuildEmoji("🔓"),
+		}))
+	}
+
+	buttons = append(buttons, TranscriptLinkElement(settings.StoreTranscripts)(worker, ticket)...)
+	buttons = append(buttons, ThreadLinkElement(ticket.IsThread && ticket.ChannelId != nil)(worker, ticket)...)
+
+	return e, utils.Slice(component.BuildActionRow(buttons...)), nil
+}
+
+func thankClaimer(ctx context.Context, worker *worker.Context, ticket database.Ticket, rating uint8) error {
+	claimerId, err := worker.Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil || claimerId == 0 {
+		return err
+	}
+
+	// Only thank the claimer once, even if the user changes their rating
+	first, err := worker.Services.Redis.MarkClaimerThanked(ctx, ticket.GuildId, ticket.Id)
+	if err != nil || !first {
+		return err
+	}
+
+	guild, err := worker.GetGuild(ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	colour, err := utils.GetColourForGuild(ctx, worker, customisation.Green, ticket.GuildId)
+	if err != nil {
+		sentry.Error(err)
+		colour = customisation.Green.Default()
+	}
+
+	e := embed.NewEmbed().
+		SetTitle(i18n.GetMessageFromGuild(worker.Services, ticket.GuildId, i18n.MessageFeedbackFollowUpThankTitle)).
+		SetColor(colour).
+		SetAuthor(guild.Name, "", guild.IconUrl()).
+		SetDescription(i18n.GetMessageFromGuild(worker.Services, ticket.GuildId, i18n.MessageFeedbackFollowUpThank, ticket.Id, rating, ticket.UserId))
+
+	dmChannel, err := worker.CreateDM(claimerId)
+	if err != nil {
+		return ignoreClientError(err)
+	}
+
+	_, err = worker.CreateMessageEmbed(dmChannel.Id, e)
+	return ignoreClientError(err)
+}
+
+// ignoreClientError discards errors caused by the recipient, such as the user having their DMs closed
+func ignoreClientError(err error) error {
+	var restError request.RestError
+	if errors.As(err, &restError) && restError.IsClientError() {
+		return nil
+	}
+
+	return err
+}
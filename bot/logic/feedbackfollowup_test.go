@@ -0,0 +1,146 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleFeedbackFollowUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *dbclient.FeedbackFollowUpConfig
+		claimed      bool
+		rating       uint8
+		expectAlert  bool
+		expectThanks bool
+	}{
+		{
+			name:   "nothing happens without a config",
+			rating: 1,
+		},
+		{
+			name:        "low ratings raise an alert",
+			config:      &dbclient.FeedbackFollowUpConfig{Threshold: 2},
+			rating:      2,
+			expectAlert: true,
+		},
+		{
+			name:   "ratings above the threshold do not raise an alert",
+			config: &dbclient.FeedbackFollowUpConfig{Threshold: 2},
+			rating: 3,
+		},
+		{
+			name:         "positive ratings thank the claimer",
+			config:       &dbclient.FeedbackFollowUpConfig{Threshold: 2, ThankClaimer: true},
+			claimed:      true,
+			rating:       PositiveRatingThreshold,
+			expectThanks: true,
+		},
+		{
+			name:    "the claimer is not thanked unless enabled",
+			config:  &dbclient.FeedbackFollowUpConfig{Threshold: 2},
+			claimed: true,
+			rating:  5,
+		},
+		{
+			name:   "unclaimed tickets have nobody to thank",
+			config: &dbclient.FeedbackFollowUpConfig{ThankClaimer: true},
+			rating: 5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			g := newTicketGuild(t, h, permcache.Everyone)
+			ticket := newClosedTicket(h, g)
+
+			alertChannelId := h.Discord.NextId()
+			h.Discord.AddChannel(channel.Channel{Id: alertChannelId, GuildId: g.GuildId, Name: "feedback", Type: channel.ChannelTypeGuildText})
+
+			if test.config != nil {
+				config := *test.config
+				if config.Threshold > 0 {
+					config.ChannelId = alertChannelId
+				}
+
+				require.NoError(t, h.Database.FeedbackFollowUpConfig.Set(t.Context(), g.GuildId, config))
+			}
+
+			claimerId := g.addMember(t, h, permcache.Support)
+			if test.claimed {
+				require.NoError(t, h.Database.TicketClaims.Set(t.Context(), g.GuildId, ticket.Id, claimerId))
+			}
+
+			require.NoError(t, HandleFeedbackFollowUp(t.Context(), h.Worker, ticket, test.rating))
+
+			alerts := h.Discord.Messages(alertChannelId)
+			followUp, hasFollowUp, err := h.Services.Redis.GetFeedbackFollowUp(t.Context(), g.GuildId, ticket.Id)
+			require.NoError(t, err)
+			require.Equal(t, test.expectAlert, hasFollowUp)
+
+			if test.expectAlert {
+				require.Len(t, alerts, 1)
+				require.Equal(t, alerts[0].Id, followUp.MessageId)
+				require.Equal(t, test.rating, followUp.Rating)
+			} else {
+				require.Empty(t, alerts)
+			}
+
+			dm, hasDm := h.Discord.DMChannel(claimerId)
+			require.Equal(t, test.expectThanks, hasDm)
+			if test.expectThanks {
+				require.Len(t, h.Discord.Messages(dm.Id), 1)
+			}
+		})
+	}
+}
+
+func TestHandleFeedbackFollowUpChangedRating(t *testing.T) {
+	h := testharness.New(t)
+	g := newTicketGuild(t, h, permcache.Everyone)
+	ticket := newClosedTicket(h, g)
+
+	alertChannelId := h.Discord.NextId()
+	h.Discord.AddChannel(channel.Channel{Id: alertChannelId, GuildId: g.GuildId, Name: "feedback", Type: channel.ChannelTypeGuildText})
+
+	require.NoError(t, h.Database.FeedbackFollowUpConfig.Set(t.Context(), g.GuildId, dbclient.FeedbackFollowUpConfig{
+		ChannelId: alertChannelId,
+		Threshold: 2,
+	}))
+
+	require.NoError(t, HandleFeedbackFollowUp(t.Context(), h.Worker, ticket, 1))
+	require.NoError(t, HandleFeedbackFollowUp(t.Context(), h.Worker, ticket, 5))
+
+	// The existing alert is updated, rather than a second one being posted
+	alerts := h.Discord.Messages(alertChannelId)
+	require.Len(t, alerts, 1)
+
+	followUp, ok, err := h.Services.Redis.GetFeedbackFollowUp(t.Context(), g.GuildId, ticket.Id)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint8(5), followUp.Rating)
+	require.Equal(t, alerts[0].Id, followUp.MessageId)
+
+	require.Contains(t, alerts[0].Embeds[0].Fields, &embed.EmbedField{Name: formatTitle("Rating", customisation.EmojiRating, h.Worker.IsWhitelabel), Value: "5 ⭐", Inline: true})
+}
+
+func newClosedTicket(h *testharness.Harness, g ticketGuild) database.Ticket {
+	openTime := time.Now().Add(-time.Hour)
+	return h.Database.Tickets.Add(database.Ticket{
+		GuildId:   g.GuildId,
+		UserId:    g.UserId,
+		OpenTime:  openTime,
+		CloseTime: utils.Ptr(openTime.Add(time.Minute * 30)),
+	})
+}
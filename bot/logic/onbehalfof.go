@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"context"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+)
+
+// OnBehalfOfContext wraps a staff member's interaction so that OpenTicket treats another member as the opener. The
+// opener's ticket limit, cooldown, permission level and blacklist status are used, while replies are still sent in
+// response to the staff member's interaction.
+type OnBehalfOfContext struct {
+	registry.InteractionContext
+	Opener member.Member
+}
+
+var _ registry.InteractionContext = (*OnBehalfOfContext)(nil)
+
+func NewOnBehalfOfContext(cmd registry.InteractionContext, opener member.Member) *OnBehalfOfContext {
+	return &OnBehalfOfContext{
+		InteractionContext: cmd,
+		Opener:             opener,
+	}
+}
+
+// StaffId returns the ID of the staff member who is opening the ticket
+func (c *OnBehalfOfContext) StaffId() uint64 {
+	return c.InteractionContext.UserId()
+}
+
+func (c *OnBehalfOfContext) UserId() uint64 {
+	return c.Opener.User.Id
+}
+
+func (c *OnBehalfOfContext) Member() (member.Member, error) {
+	return c.Opener, nil
+}
+
+func (c *OnBehalfOfContext) User() (user.User, error) {
+	return c.Opener.User, nil
+}
+
+func (c *OnBehalfOfContext) UserPermissionLevel(ctx context.Context) (permcache.PermissionLevel, error) {
+	return permcache.GetPermissionLevel(ctx, utils.ToRetriever(c.Worker()), c.Opener, c.GuildId())
+}
+
+func (c *OnBehalfOfContext) IsBlacklisted(ctx context.Context) (bool, error) {
+	permLevel, err := c.UserPermissionLevel(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return utils.IsBlacklisted(ctx, c.Worker(), c.GuildId(), c.UserId(), c.Opener, permLevel)
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
 	"github.com/TicketsBot-cloud/worker/bot/permissionwrapper"
@@ -46,7 +45,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 	lockCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
-	mu, err := redis.TakeTicketOpenLock(lockCtx, cmd.GuildId())
+	mu, err := cmd.Worker().Services.Redis.TakeTicketOpenLock(lockCtx, cmd.GuildId())
 	if err != nil {
 		cmd.HandleError(err)
 		return database.Ticket{}, err
@@ -83,7 +82,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 	span = sentry.StartSpan(rootSpan.Context(), "Ticket ratelimit")
 
-	ok, err := redis.TakeTicketRateLimitToken(redis.Client, cmd.GuildId())
+	ok, err := cmd.Worker().Services.Redis.TakeTicketRateLimitToken(cmd.GuildId())
 	if err != nil {
 		cmd.HandleError(err)
 		return database.Ticket{}, err
@@ -110,7 +109,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 		if permLevel < permcache.Support {
 			cooldownDuration := time.Duration(panel.CooldownSeconds) * time.Second
-			canOpen, remaining, err := redis.TakePanelCooldownToken(ctx, cmd.GuildId(), panel.PanelId, cmd.UserId(), cooldownDuration)
+			canOpen, remaining, err := cmd.Worker().Services.Redis.TakePanelCooldownToken(ctx, cmd.GuildId(), panel.PanelId, cmd.UserId(), cooldownDuration)
 			if err != nil {
 				cmd.HandleError(err)
 				span.Finish()
@@ -203,7 +202,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		category = panel.TargetCategory
 	} else { // else we can just use the default category
 		var err error
-		category, err = dbclient.Client.ChannelCategory.Get(ctx, cmd.GuildId())
+		category, err = cmd.Worker().Services.Database.ChannelCategory.Get(ctx, cmd.GuildId())
 		if err != nil {
 			cmd.HandleError(err)
 			return database.Ticket{}, err
@@ -221,7 +220,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 			if restError, ok := err.(request.RestError); ok && restError.StatusCode == 404 {
 				if panel == nil {
-					if err := dbclient.Client.ChannelCategory.Delete(ctx, cmd.GuildId()); err != nil {
+					if err := cmd.Worker().Services.Database.ChannelCategory.Delete(ctx, cmd.GuildId()); err != nil {
 						cmd.HandleError(err)
 					}
 				} // TODO: Else, set panel category to 0
@@ -268,7 +267,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 	// Create channel
 	span = sentry.StartSpan(rootSpan.Context(), "Create ticket in database")
-	ticketId, err := dbclient.Client.Tickets.Create(ctx, cmd.GuildId(), cmd.UserId(), isThread, panelId)
+	ticketId, err := cmd.Worker().Services.Database.Tickets.Create(ctx, cmd.GuildId(), cmd.UserId(), isThread, panelId)
 	if err != nil {
 		cmd.HandleError(err)
 		return database.Ticket{}, err
@@ -296,6 +295,13 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		auditReason = fmt.Sprintf("Ticket %d opened by %s", ticketId, member.User.Username)
 	}
 
+	// Staff opening a ticket on behalf of another user are given access to it alongside the opener
+	var otherUsers []uint64
+	if onBehalfOf, ok := cmd.(*OnBehalfOfContext); ok {
+		otherUsers = append(otherUsers, onBehalfOf.StaffId())
+		auditReason = fmt.Sprintf("Ticket %d opened on behalf of %s", ticketId, onBehalfOf.Opener.User.Username)
+	}
+
 	var ch channel.Channel
 	var joinMessageId *uint64
 	if isThread {
@@ -306,7 +312,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 			cmd.HandleError(err)
 
 			// To prevent tickets getting in a glitched state, we should mark it as closed (or delete it completely?)
-			if err := dbclient.Client.Tickets.Close(ctx, ticketId, cmd.GuildId()); err != nil {
+			if err := cmd.Worker().Services.Database.Tickets.Close(ctx, ticketId, cmd.GuildId()); err != nil {
 				cmd.HandleError(err)
 			}
 
@@ -319,6 +325,12 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		if err := cmd.Worker().AddThreadMember(ch.Id, cmd.UserId()); err != nil {
 			cmd.HandleError(err)
 		}
+
+		for _, userId := range otherUsers {
+			if err := cmd.Worker().AddThreadMember(ch.Id, userId); err != nil {
+				cmd.HandleError(err)
+			}
+		}
 		span.Finish()
 
 		// Determine which notification channel to use
@@ -347,7 +359,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		}
 	} else {
 		span = sentry.StartSpan(rootSpan.Context(), "Build permission overwrites")
-		overwrites, err := CreateOverwrites(ctx, cmd, cmd.UserId(), panel, category)
+		overwrites, err := CreateOverwrites(ctx, cmd, cmd.UserId(), panel, category, otherUsers...)
 		if err != nil {
 			cmd.HandleError(err)
 			return database.Ticket{}, err
@@ -372,13 +384,13 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 			cmd.HandleError(err)
 
 			// To prevent tickets getting in a glitched state, we should mark it as closed (or delete it completely?)
-			if err := dbclient.Client.Tickets.Close(ctx, ticketId, cmd.GuildId()); err != nil {
+			if err := cmd.Worker().Services.Database.Tickets.Close(ctx, ticketId, cmd.GuildId()); err != nil {
 				cmd.HandleError(err)
 			}
 
 			var restError request.RestError
 			if errors.As(err, &restError) && restError.ApiError.FirstErrorCode() == "CHANNEL_PARENT_MAX_CHANNELS" {
-				canRefresh, err := redis.TakeChannelRefetchToken(ctx, cmd.GuildId())
+				canRefresh, err := cmd.Worker().Services.Redis.TakeChannelRefetchToken(ctx, cmd.GuildId())
 				if err != nil {
 					cmd.HandleError(err)
 					return database.Ticket{}, err
@@ -405,7 +417,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		ch = tmp
 	}
 
-	if err := dbclient.Client.Tickets.SetChannelId(ctx, cmd.GuildId(), ticketId, ch.Id); err != nil {
+	if err := cmd.Worker().Services.Database.Tickets.SetChannelId(ctx, cmd.GuildId(), ticketId, ch.Id); err != nil {
 		cmd.HandleError(err)
 		return database.Ticket{}, err
 	}
@@ -447,7 +459,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		externalPlaceholderCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 		defer cancel()
 
-		additionalPlaceholders, err := fetchCustomIntegrationPlaceholders(externalPlaceholderCtx, ticket, formAnswersToMap(formData))
+		additionalPlaceholders, err := fetchCustomIntegrationPlaceholders(externalPlaceholderCtx, cmd.Worker(), ticket, formAnswersToMap(formData))
 		if err != nil {
 			// TODO: Log for integration author and server owner on the dashboard, rather than spitting out a message.
 			// A failing integration should not block the ticket creation process.
@@ -468,7 +480,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		// Update message IDs in DB
 		span = sentry.StartSpan(rootSpan.Context(), "Update ticket properties in database")
 		defer span.Finish()
-		if err := dbclient.Client.Tickets.SetMessageIds(ctx, cmd.GuildId(), ticketId, welcomeMessageId, joinMessageId); err != nil {
+		if err := cmd.Worker().Services.Database.Tickets.SetMessageIds(ctx, cmd.GuildId(), ticketId, welcomeMessageId, joinMessageId); err != nil {
 			return err
 		}
 
@@ -478,7 +490,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 	// Send mentions
 	group.Go(func() error {
 		span := sentry.StartSpan(rootSpan.Context(), "Load guild metadata from database")
-		metadata, err := dbclient.Client.GuildMetadata.Get(ctx, cmd.GuildId())
+		metadata, err := cmd.Worker().Services.Database.GuildMetadata.Get(ctx, cmd.GuildId())
 		span.Finish()
 		if err != nil {
 			return err
@@ -499,7 +511,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 				}
 
 				span := sentry.StartSpan(rootSpan.Context(), "Get teams from database")
-				teams, err := dbclient.Client.PanelTeams.GetTeams(ctx, panel.PanelId)
+				teams, err := cmd.Worker().Services.Database.PanelTeams.GetTeams(ctx, panel.PanelId)
 				span.Finish()
 				if err != nil {
 					return err
@@ -516,7 +528,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		if panel != nil {
 			// roles
 			span := sentry.StartSpan(rootSpan.Context(), "Get panel role mentions from database")
-			roles, err := dbclient.Client.PanelRoleMentions.GetRoles(ctx, panel.PanelId)
+			roles, err := cmd.Worker().Services.Database.PanelRoleMentions.GetRoles(ctx, panel.PanelId)
 			span.Finish()
 			if err != nil {
 				return err
@@ -532,7 +544,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 			// user
 			span = sentry.StartSpan(rootSpan.Context(), "Get panel user mention setting from database")
-			shouldMentionUser, err := dbclient.Client.PanelUserMention.ShouldMentionUser(ctx, panel.PanelId)
+			shouldMentionUser, err := cmd.Worker().Services.Database.PanelUserMention.ShouldMentionUser(ctx, panel.PanelId)
 			span.Finish()
 			if err != nil {
 				return err
@@ -544,7 +556,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 
 			// here
 			span = sentry.StartSpan(rootSpan.Context(), "Get panel here mention setting from database")
-			shouldMentionHere, err := dbclient.Client.PanelHereMention.ShouldMentionHere(ctx, panel.PanelId)
+			shouldMentionHere, err := cmd.Worker().Services.Database.PanelHereMention.ShouldMentionHere(ctx, panel.PanelId)
 			span.Finish()
 			if err != nil {
 				return err
@@ -611,7 +623,7 @@ func OpenTicket(ctx context.Context, cmd registry.InteractionContext, panel *dat
 		span := sentry.StartSpan(rootSpan.Context(), "Send out-of-hours warning")
 		defer span.Finish()
 
-		colourHex := customisation.GetColourOrDefault(ctx, cmd.GuildId(), customisation.Red)
+		colourHex := customisation.GetColourOrDefault(ctx, cmd.Worker(), cmd.GuildId(), customisation.Red)
 		if outOfHoursColour != nil {
 			colourHex = *outOfHoursColour
 		}
@@ -671,7 +683,7 @@ func checkChannelLimitAndDetermineParentId(
 		if !canRetry {
 			return 0, errGuildChannelLimitReached
 		} else {
-			canRefresh, err := redis.TakeChannelRefetchToken(ctx, guildId)
+			canRefresh, err := worker.Services.Redis.TakeChannelRefetchToken(ctx, guildId)
 			if err != nil {
 				return 0, err
 			}
@@ -702,7 +714,7 @@ func checkChannelLimitAndDetermineParentId(
 				*settings.OverflowCategoryId == categoryId
 
 			if canRetry {
-				canRefresh, err := redis.TakeChannelRefetchToken(ctx, guildId)
+				canRefresh, err := worker.Services.Redis.TakeChannelRefetchToken(ctx, guildId)
 				if err != nil {
 					return 0, err
 				}
@@ -747,7 +759,7 @@ func checkChannelLimitAndDetermineParentId(
 					if !utils.ContainsFunc(channels, func(c channel.Channel) bool {
 						return c.Id == categoryId
 					}) {
-						if err := dbclient.Client.Settings.SetOverflow(ctx, guildId, false, nil); err != nil {
+						if err := worker.Services.Database.Settings.SetOverflow(ctx, guildId, false, nil); err != nil {
 							return 0, err
 						}
 
@@ -803,19 +815,19 @@ func getTicketLimit(ctx context.Context, cmd registry.CommandContext, panel *dat
 		ticketLimit = *panel.TicketLimit
 
 		group.Go(func() (err error) {
-			openTicketCount, err = dbclient.Client.Tickets.GetOpenCountByUserAndPanel(
+			openTicketCount, err = cmd.Worker().Services.Database.Tickets.GetOpenCountByUserAndPanel(
 				ctx, cmd.GuildId(), cmd.UserId(), panel.PanelId)
 			return
 		})
 	} else {
 		// Use global limit and count all tickets
 		group.Go(func() (err error) {
-			ticketLimit, err = dbclient.Client.TicketLimit.Get(ctx, cmd.GuildId())
+			ticketLimit, err = cmd.Worker().Services.Database.TicketLimit.Get(ctx, cmd.GuildId())
 			return
 		})
 
 		group.Go(func() (err error) {
-			openTicketCount, err = dbclient.Client.Tickets.GetOpenCountByUser(ctx, cmd.GuildId(), cmd.UserId())
+			openTicketCount, err = cmd.Worker().Services.Database.Tickets.GetOpenCountByUser(ctx, cmd.GuildId(), cmd.UserId())
 			return
 		})
 	}
@@ -895,7 +907,7 @@ func createWebhook(ctx context.Context, c registry.CommandContext, ticketId int,
 
 		span = sentry.StartSpan(root.Context(), "Store webhook in database")
 		defer span.Finish()
-		if err := dbclient.Client.Webhooks.Create(ctx, guildId, ticketId, dbWebhook); err != nil {
+		if err := c.Worker().Services.Database.Webhooks.Create(ctx, guildId, ticketId, dbWebhook); err != nil {
 			sentry.ErrorWithContext(err, c.ToErrorContext())
 			return nil // Silently fail
 		}
@@ -904,7 +916,7 @@ func createWebhook(ctx context.Context, c registry.CommandContext, ticketId int,
 	return nil
 }
 
-func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, userId uint64, panel *database.Panel, categoryId uint64, otherUsers ...uint64) ([]channel.PermissionOverwrite, error) {
+func CreateOverwrites(ctx context.Context, cmd registry.CommandContext, userId uint64, panel *database.Panel, categoryId uint64, otherUsers ...uint64) ([]channel.PermissionOverwrite, error) {
 	overwrites := []channel.PermissionOverwrite{ // @everyone
 		{
 			Id:    cmd.GuildId(),
@@ -915,14 +927,14 @@ func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, user
 	}
 
 	// Build permissions
-	additionalPermissions, err := dbclient.Client.TicketPermissions.Get(ctx, cmd.GuildId())
+	additionalPermissions, err := cmd.Worker().Services.Database.TicketPermissions.Get(ctx, cmd.GuildId())
 	if err != nil {
 		return nil, err
 	}
 
 	// Apply panel-level grants on top of global settings (OR logic: panel can only add permissions)
 	if panel != nil {
-		panelPerms, err := dbclient.Client.PanelTicketPermissions.Get(ctx, panel.PanelId)
+		panelPerms, err := cmd.Worker().Services.Database.PanelTicketPermissions.Get(ctx, panel.PanelId)
 		if err != nil {
 			return nil, err
 		}
@@ -983,12 +995,12 @@ func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, user
 
 	// Default team (ticket admins + ticket support) — always StandardPermissions
 	if panel == nil || panel.WithDefaultTeam {
-		supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, cmd.GuildId())
+		supportUsers, err := cmd.Worker().Services.Database.Permissions.GetSupport(ctx, cmd.GuildId())
 		if err != nil {
 			return nil, err
 		}
 
-		supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, cmd.GuildId())
+		supportRoles, err := cmd.Worker().Services.Database.RolePermissions.GetSupportRoles(ctx, cmd.GuildId())
 		if err != nil {
 			return nil, err
 		}
@@ -1021,13 +1033,13 @@ func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, user
 
 	// Panel-specific custom teams — per-team permissions
 	if panel != nil {
-		panelTeamIds, err := dbclient.Client.PanelTeams.GetTeamIds(ctx, panel.PanelId)
+		panelTeamIds, err := cmd.Worker().Services.Database.PanelTeams.GetTeamIds(ctx, panel.PanelId)
 		if err != nil {
 			return nil, err
 		}
 
 		if len(panelTeamIds) > 0 {
-			teamPermsMap, err := dbclient.Client.SupportTeamPermissions.GetForTeams(ctx, panelTeamIds)
+			teamPermsMap, err := cmd.Worker().Services.Database.SupportTeamPermissions.GetForTeams(ctx, panelTeamIds)
 			if err != nil {
 				return nil, err
 			}
@@ -1049,12 +1061,12 @@ func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, user
 					}
 				}
 
-				userIds, err := dbclient.Client.SupportTeamMembers.Get(ctx, teamId)
+				userIds, err := cmd.Worker().Services.Database.SupportTeamMembers.Get(ctx, teamId)
 				if err != nil {
 					return nil, err
 				}
 
-				roleIds, err := dbclient.Client.SupportTeamRoles.Get(ctx, teamId)
+				roleIds, err := cmd.Worker().Services.Database.SupportTeamRoles.Get(ctx, teamId)
 				if err != nil {
 					return nil, err
 				}
@@ -1078,14 +1090,14 @@ func CreateOverwrites(ctx context.Context, cmd registry.InteractionContext, user
 
 // GetAllowedStaffUsersAndRoles returns the default team (ticket admins + ticket support) users and roles.
 // Panel-specific custom teams are handled separately in CreateOverwrites with per-team permission support.
-func GetAllowedStaffUsersAndRoles(ctx context.Context, guildId uint64, panel *database.Panel) ([]uint64, []uint64, error) {
+func GetAllowedStaffUsersAndRoles(ctx context.Context, worker *worker.Context, guildId uint64, panel *database.Panel) ([]uint64, []uint64, error) {
 	allowedUsers := make([]uint64, 0)
 	allowedRoles := make([]uint64, 0)
 
 	// Only return default team members
 	if panel == nil || panel.WithDefaultTeam {
 		// Get support reps & admins
-		supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, guildId)
+		supportUsers, err := worker.Services.Database.Permissions.GetSupport(ctx, guildId)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1093,7 +1105,7 @@ func GetAllowedStaffUsersAndRoles(ctx context.Context, guildId uint64, panel *da
 		allowedUsers = append(allowedUsers, supportUsers...)
 
 		// Get support roles & admin roles
-		supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, guildId)
+		supportRoles, err := worker.Services.Database.RolePermissions.GetSupportRoles(ctx, guildId)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1107,7 +1119,7 @@ func GetAllowedStaffUsersAndRoles(ctx context.Context, guildId uint64, panel *da
 
 		// Get users for support teams of panel
 		group.Go(func() error {
-			userIds, err := dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
+			userIds, err := worker.Services.Database.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
 			if err != nil {
 				return err
 			}
@@ -1118,7 +1130,7 @@ func GetAllowedStaffUsersAndRoles(ctx context.Context, guildId uint64, panel *da
 
 		// Get roles for support teams of panel
 		group.Go(func() error {
-			roleIds, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+			roleIds, err := worker.Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 			if err != nil {
 				return err
 			}
@@ -1139,7 +1151,7 @@ func GetIntegrationRoleId(rootCtx context.Context, worker *worker.Context, guild
 	ctx, cancel := context.WithTimeout(rootCtx, time.Second*3)
 	defer cancel()
 
-	cachedId, err := redis.GetIntegrationRole(ctx, guildId, worker.BotId)
+	cachedId, err := worker.Services.Redis.GetIntegrationRole(ctx, guildId, worker.BotId)
 	if err == nil {
 		return &cachedId, nil
 	} else if !errors.Is(err, redis.ErrIntegrationRoleNotCached) {
@@ -1156,7 +1168,7 @@ func GetIntegrationRoleId(rootCtx context.Context, worker *worker.Context, guild
 			ctx, cancel := context.WithTimeout(rootCtx, time.Second*3)
 			defer cancel() // defer is okay here as we return in every case
 
-			if err := redis.SetIntegrationRole(ctx, guildId, worker.BotId, role.Id); err != nil {
+			if err := worker.Services.Redis.SetIntegrationRole(ctx, guildId, worker.BotId, role.Id); err != nil {
 				return nil, err
 			}
 
@@ -1173,12 +1185,12 @@ func GenerateChannelName(ctx context.Context, worker *worker.Context, panel *dat
 
 	// Use server default naming scheme
 	if panel == nil || panel.NamingScheme == nil {
-		namingScheme, err := dbclient.Client.NamingScheme.Get(ctx, guildId)
+		namingScheme, err := worker.Services.Database.NamingScheme.Get(ctx, guildId)
 		if err != nil {
 			return "", err
 		}
 
-		strTicket := strings.ToLower(i18n.GetMessageFromGuild(guildId, i18n.Ticket))
+		strTicket := strings.ToLower(i18n.GetMessageFromGuild(worker.Services, guildId, i18n.Ticket))
 		if namingScheme == database.Username {
 			user, err := worker.GetUser(openerId)
 
@@ -1447,7 +1459,7 @@ func buildJoinThreadMessage(
 		title = "Ticket Reopened"
 	}
 
-	e := utils.BuildEmbedRaw(customisation.GetColourOrDefault(ctx, guildId, colour), title, fmt.Sprintf("%s with ID: %d has been opened. Press the button below to join it.", name, ticketId), nil, premiumTier)
+	e := utils.BuildEmbedRaw(customisation.GetColourOrDefault(ctx, worker, guildId, colour), title, fmt.Sprintf("%s with ID: %d has been opened. Press the button below to join it.", name, ticketId), nil, premiumTier)
 	e.AddField(customisation.PrefixWithEmoji("Opened By", customisation.EmojiOpen, !worker.IsWhitelabel), customisation.PrefixWithEmoji(fmt.Sprintf("<@%d>", openerId), customisation.EmojiBulletLine, !worker.IsWhitelabel), true)
 	e.AddField(customisation.PrefixWithEmoji("Panel", customisation.EmojiPanel, !worker.IsWhitelabel), customisation.PrefixWithEmoji(panelName, customisation.EmojiBulletLine, !worker.IsWhitelabel), true)
 	e.AddField(customisation.PrefixWithEmoji("Staff In Ticket", customisation.EmojiStaff, !worker.IsWhitelabel), customisation.PrefixWithEmoji(strconv.Itoa(len(staffMembers)), customisation.EmojiBulletLine, !worker.IsWhitelabel), true)
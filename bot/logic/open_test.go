@@ -0,0 +1,184 @@
+package logic
+
+import (
+	"testing"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/guild"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+// ticketGuild is a guild with a channel for opening tickets from, and a member who opens them
+type ticketGuild struct {
+	GuildId   uint64
+	ChannelId uint64
+	UserId    uint64
+}
+
+func newTicketGuild(t *testing.T, h *testharness.Harness, permissionLevel permcache.PermissionLevel) ticketGuild {
+	g := ticketGuild{
+		GuildId:   h.Discord.NextId(),
+		ChannelId: h.Discord.NextId(),
+		UserId:    h.Discord.NextId(),
+	}
+
+	h.Discord.AddGuild(guild.Guild{Id: g.GuildId, Name: "Test", OwnerId: h.Discord.NextId()})
+	h.Discord.AddChannel(channel.Channel{Id: g.ChannelId, GuildId: g.GuildId, Name: "panels", Type: channel.ChannelTypeGuildText})
+
+	opener := user.User{Id: g.UserId, Username: "opener"}
+	h.Discord.AddUser(opener)
+	h.Discord.AddMember(g.GuildId, member.Member{User: opener})
+	h.Discord.AddMember(g.GuildId, member.Member{User: h.Bot})
+
+	h.SetPermissionLevel(t, g.GuildId, g.UserId, permissionLevel)
+	return g
+}
+
+// addMember adds another member to the guild, with the given permission level
+func (g ticketGuild) addMember(t *testing.T, h *testharness.Harness, permissionLevel permcache.PermissionLevel) uint64 {
+	u := user.User{Id: h.Discord.NextId(), Username: "member"}
+	h.Discord.AddUser(u)
+	h.Discord.AddMember(g.GuildId, member.Member{User: u})
+
+	h.SetPermissionLevel(t, g.GuildId, u.Id, permissionLevel)
+	return u.Id
+}
+
+// openTicket opens a ticket for the guild's opener, failing the test if it could not be opened
+func (g ticketGuild) openTicket(t *testing.T, h *testharness.Harness, useThreads bool) database.Ticket {
+	ctx := h.NewCommandContext(g.GuildId, g.ChannelId, g.UserId, permcache.Everyone)
+	ctx.SettingsValue.UseThreads = useThreads
+
+	ticket, err := OpenTicket(t.Context(), ctx, nil, "Help", nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, ctx.Errors())
+
+	ticket, err = h.Database.Tickets.Get(t.Context(), ticket.Id, g.GuildId)
+	require.NoError(t, err)
+	require.NotNil(t, ticket.ChannelId)
+
+	return ticket
+}
+
+func TestOpenTicket(t *testing.T) {
+	tests := []struct {
+		name            string
+		permissionLevel permcache.PermissionLevel
+		useThreads      bool
+		openTickets     int
+		panel           *database.Panel
+		expectOpened    bool
+		expectReply     i18n.MessageId
+	}{
+		{
+			name:            "opens a channel",
+			permissionLevel: permcache.Everyone,
+			expectOpened:    true,
+			expectReply:     i18n.MessageTicketOpened,
+		},
+		{
+			name:            "opens a thread",
+			permissionLevel: permcache.Everyone,
+			useThreads:      true,
+			expectOpened:    true,
+			expectReply:     i18n.MessageTicketOpened,
+		},
+		{
+			name:            "refuses at the ticket limit",
+			permissionLevel: permcache.Everyone,
+			openTickets:     5,
+			expectReply:     i18n.MessageTicketLimitReached,
+		},
+		{
+			name:            "staff are exempt from the ticket limit",
+			permissionLevel: permcache.Support,
+			openTickets:     5,
+			expectOpened:    true,
+			expectReply:     i18n.MessageTicketOpened,
+		},
+		{
+			name:            "refuses from a disabled panel",
+			permissionLevel: permcache.Everyone,
+			panel:           &database.Panel{Title: "Support", Disabled: true},
+			expectReply:     i18n.MessageOpenPanelDisabled,
+		},
+		{
+			name:            "uses the panel title as the subject",
+			permissionLevel: permcache.Everyone,
+			panel:           &database.Panel{Title: "Support"},
+			expectOpened:    true,
+			expectReply:     i18n.MessageTicketOpened,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			g := newTicketGuild(t, h, test.permissionLevel)
+
+			for i := 0; i < test.openTickets; i++ {
+				h.Database.Tickets.Add(database.Ticket{GuildId: g.GuildId, UserId: g.UserId, Open: true})
+			}
+
+			var panel *database.Panel
+			if test.panel != nil {
+				stored := *test.panel
+				stored.GuildId = g.GuildId
+				stored = h.Database.Panels.Add(stored)
+				panel = &stored
+			}
+
+			ctx := h.NewCommandContext(g.GuildId, g.ChannelId, g.UserId, test.permissionLevel)
+			ctx.SettingsValue.UseThreads = test.useThreads
+
+			ticket, _ := OpenTicket(t.Context(), ctx, panel, "Help", nil, nil, nil, nil)
+			require.Empty(t, ctx.Errors())
+
+			reply, ok := ctx.LastReply()
+			require.True(t, ok)
+			require.Equal(t, test.expectReply, reply.ContentId)
+
+			tickets := h.Database.Tickets.All(g.GuildId)
+			if !test.expectOpened {
+				require.Len(t, tickets, test.openTickets)
+				return
+			}
+
+			require.Len(t, tickets, test.openTickets+1)
+			stored := tickets[len(tickets)-1]
+			require.Equal(t, ticket.Id, stored.Id)
+			require.True(t, stored.Open)
+			require.Equal(t, g.UserId, stored.UserId)
+			require.Equal(t, test.useThreads, stored.IsThread)
+			require.NotNil(t, stored.ChannelId)
+			require.NotNil(t, stored.WelcomeMessageId)
+
+			ch, ok := h.Discord.Channel(*stored.ChannelId)
+			require.True(t, ok)
+			if test.useThreads {
+				require.Equal(t, channel.ChannelTypeGuildPrivateThread, ch.Type)
+				require.Contains(t, h.Discord.ThreadMembers(ch.Id), g.UserId)
+			} else {
+				require.Equal(t, channel.ChannelTypeGuildText, ch.Type)
+
+				expectedTopic := "Help"
+				if panel != nil {
+					expectedTopic = panel.Title
+				}
+
+				require.Equal(t, expectedTopic, ch.Topic)
+			}
+
+			// The welcome message is the first message sent in the ticket
+			messages := h.Discord.Messages(ch.Id)
+			require.NotEmpty(t, messages)
+			require.Equal(t, *stored.WelcomeMessageId, messages[0].Id)
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/member"
 	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"golang.org/x/sync/errgroup"
 )
@@ -22,7 +21,7 @@ func HasPermissionForTicket(ctx context.Context, worker *worker.Context, ticket
 	}
 
 	// Check claim status
-	claimedBy, err := dbclient.Client.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	claimedBy, err := worker.Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
 	if err != nil {
 		return false, err
 	}
@@ -39,7 +38,7 @@ func HasPermissionForTicket(ctx context.Context, worker *worker.Context, ticket
 	// Get panel for ticket
 	var panel *database.Panel
 	if ticket.PanelId != nil {
-		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		tmp, err := worker.Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			return false, err
 		}
@@ -73,7 +72,7 @@ func IsAdminForGuild(ctx context.Context, worker *worker.Context, guildId uint64
 	}
 
 	// Get admin users and roles
-	adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, guildId)
+	adminUsers, err := worker.Services.Database.Permissions.GetAdmins(ctx, guildId)
 	if err != nil {
 		return false, err
 	}
@@ -82,7 +81,7 @@ func IsAdminForGuild(ctx context.Context, worker *worker.Context, guildId uint64
 		return true, nil
 	}
 
-	adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, guildId)
+	adminRoles, err := worker.Services.Database.RolePermissions.GetAdminRoles(ctx, guildId)
 	if err != nil {
 		return false, err
 	}
@@ -114,12 +113,12 @@ func HasPermissionForPanel(ctx context.Context, worker *worker.Context, guildId
 	}
 
 	// Get admin users and roles
-	adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, guildId)
+	adminUsers, err := worker.Services.Database.Permissions.GetAdmins(ctx, guildId)
 	if err != nil {
 		return false, err
 	}
 
-	adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, guildId)
+	adminRoles, err := worker.Services.Database.RolePermissions.GetAdminRoles(ctx, guildId)
 	if err != nil {
 		return false, err
 	}
@@ -137,12 +136,12 @@ func HasPermissionForPanel(ctx context.Context, worker *worker.Context, guildId
 	}
 
 	if panel == nil {
-		return IsInDefaultTeam(ctx, guildId, userId, member)
+		return IsInDefaultTeam(ctx, worker, guildId, userId, member)
 	}
 
 	// Check default team, if assigned to panel
 	if panel.WithDefaultTeam {
-		hasPermission, err := IsInDefaultTeam(ctx, guildId, userId, member)
+		hasPermission, err := IsInDefaultTeam(ctx, worker, guildId, userId, member)
 		if err != nil {
 			return false, err
 		}
@@ -153,7 +152,7 @@ func HasPermissionForPanel(ctx context.Context, worker *worker.Context, guildId
 	}
 
 	// Check whether user is part of a team directly
-	teamUsers, err := dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
+	teamUsers, err := worker.Services.Database.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
 	if err != nil {
 		return false, err
 	}
@@ -163,7 +162,7 @@ func HasPermissionForPanel(ctx context.Context, worker *worker.Context, guildId
 	}
 
 	// Check whether user has any of the roles
-	teamRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+	teamRoles, err := worker.Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 	if err != nil {
 		return false, err
 	}
@@ -177,9 +176,9 @@ func HasPermissionForPanel(ctx context.Context, worker *worker.Context, guildId
 	return false, nil
 }
 
-func IsInDefaultTeam(ctx context.Context, guildId, userId uint64, member member.Member) (bool, error) {
+func IsInDefaultTeam(ctx context.Context, worker *worker.Context, guildId, userId uint64, member member.Member) (bool, error) {
 	// Check users
-	supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, guildId)
+	supportUsers, err := worker.Services.Database.Permissions.GetSupport(ctx, guildId)
 	if err != nil {
 		return false, err
 	}
@@ -189,7 +188,7 @@ func IsInDefaultTeam(ctx context.Context, guildId, userId uint64, member member.
 	}
 
 	// Check roles
-	supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, guildId)
+	supportRoles, err := worker.Services.Database.RolePermissions.GetSupportRoles(ctx, guildId)
 	if err != nil {
 		return false, err
 	}
@@ -215,7 +214,7 @@ func FilterStaffMembers(
 ) ([]uint64, error) {
 	var panel *database.Panel
 	if ticket.PanelId != nil {
-		tmp, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+		tmp, err := worker.Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 		if err != nil {
 			return nil, err
 		}
@@ -227,22 +226,22 @@ func FilterStaffMembers(
 
 	// Retrieve permissions data
 	// Get admin users and roles
-	adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, guildId)
+	adminUsers, err := worker.Services.Database.Permissions.GetAdmins(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
 
-	adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, guildId)
+	adminRoles, err := worker.Services.Database.RolePermissions.GetAdminRoles(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
 
-	supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, guildId)
+	supportUsers, err := worker.Services.Database.Permissions.GetSupport(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
 
-	supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, guildId)
+	supportRoles, err := worker.Services.Database.RolePermissions.GetSupportRoles(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
@@ -250,13 +249,13 @@ func FilterStaffMembers(
 	var teamUsers, teamRoles []uint64
 	if panel != nil {
 		// Check whether user is part of a team directly
-		teamUsers, err = dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
+		teamUsers, err = worker.Services.Database.SupportTeamMembers.GetAllSupportMembersForPanel(ctx, panel.PanelId)
 		if err != nil {
 			return nil, err
 		}
 
 		// Check whether user has any of the roles
-		teamRoles, err = dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
+		teamRoles, err = worker.Services.Database.SupportTeamRoles.GetAllSupportRolesForPanel(ctx, panel.PanelId)
 		if err != nil {
 			return nil, err
 		}
@@ -362,17 +361,17 @@ func GetMemberTeams(ctx context.Context, worker *worker.Context, guildId, userId
 		return false, nil, err
 	}
 
-	return GetMemberTeamsWithMember(ctx, guildId, userId, member)
+	return GetMemberTeamsWithMember(ctx, worker, guildId, userId, member)
 }
 
-func GetMemberTeamsWithMember(ctx context.Context, guildId, userId uint64, member member.Member) (bool, []int, error) {
+func GetMemberTeamsWithMember(ctx context.Context, worker *worker.Context, guildId, userId uint64, member member.Member) (bool, []int, error) {
 	// Determine whether the user is part of the default support team
-	supportUsers, err := dbclient.Client.Permissions.GetSupport(ctx, guildId)
+	supportUsers, err := worker.Services.Database.Permissions.GetSupport(ctx, guildId)
 	if err != nil {
 		return false, nil, err
 	}
 
-	supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ctx, guildId)
+	supportRoles, err := worker.Services.Database.RolePermissions.GetSupportRoles(ctx, guildId)
 	if err != nil {
 		return false, nil, err
 	}
@@ -382,7 +381,7 @@ func GetMemberTeamsWithMember(ctx context.Context, guildId, userId uint64, membe
 	// Retrieve IDs of additional support teams
 	teamIds := collections.NewSet[int]() // Use set to eliminate duplicate entries
 
-	userTeamIds, err := dbclient.Client.SupportTeamMembers.GetAllTeamsForUser(ctx, guildId, userId)
+	userTeamIds, err := worker.Services.Database.SupportTeamMembers.GetAllTeamsForUser(ctx, guildId, userId)
 	if err != nil {
 		return false, nil, err
 	}
@@ -391,7 +390,7 @@ func GetMemberTeamsWithMember(ctx context.Context, guildId, userId uint64, membe
 		teamIds.Add(id)
 	}
 
-	roleTeamIds, err := dbclient.Client.SupportTeamRoles.GetAllTeamsForRoles(ctx, guildId, member.Roles)
+	roleTeamIds, err := worker.Services.Database.SupportTeamRoles.GetAllTeamsForRoles(ctx, guildId, member.Roles)
 	if err != nil {
 		return false, nil, err
 	}
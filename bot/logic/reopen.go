@@ -17,7 +17,7 @@ import (
 
 func ReopenTicket(ctx context.Context, cmd registry.CommandContext, ticketId int) {
 	// Get the ticket first so we can check per-panel limits
-	ticket, err := dbclient.Client.Tickets.Get(ctx, ticketId, cmd.GuildId())
+	ticket, err := cmd.Worker().Services.Database.Tickets.Get(ctx, ticketId, cmd.GuildId())
 	if err != nil {
 		cmd.HandleError(err)
 		return
@@ -39,7 +39,7 @@ func ReopenTicket(ctx context.Context, cmd registry.CommandContext, ticketId int
 		// Fetch panel if ticket has one for per-panel limit check
 		var panel *database.Panel
 		if ticket.PanelId != nil {
-			p, err := dbclient.Client.Panel.GetById(ctx, *ticket.PanelId)
+			p, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
 			if err != nil {
 				cmd.HandleError(err)
 				return
@@ -55,20 +55,20 @@ func ReopenTicket(ctx context.Context, cmd registry.CommandContext, ticketId int
 		if panel != nil && panel.TicketLimit != nil && *panel.TicketLimit > 0 {
 			// Use per-panel limit and count only panel tickets
 			ticketLimit = *panel.TicketLimit
-			openTicketCount, err = dbclient.Client.Tickets.GetOpenCountByUserAndPanel(ctx, cmd.GuildId(), cmd.UserId(), panel.PanelId)
+			openTicketCount, err = cmd.Worker().Services.Database.Tickets.GetOpenCountByUserAndPanel(ctx, cmd.GuildId(), cmd.UserId(), panel.PanelId)
 			if err != nil {
 				cmd.HandleError(err)
 				return
 			}
 		} else {
 			// Use global limit and count all tickets
-			ticketLimit, err = dbclient.Client.TicketLimit.Get(ctx, cmd.GuildId())
+			ticketLimit, err = cmd.Worker().Services.Database.TicketLimit.Get(ctx, cmd.GuildId())
 			if err != nil {
 				cmd.HandleError(err)
 				return
 			}
 
-			openTicketCount, err = dbclient.Client.Tickets.GetOpenCountByUser(ctx, cmd.GuildId(), cmd.UserId())
+			openTicketCount, err = cmd.Worker().Services.Database.Tickets.GetOpenCountByUser(ctx, cmd.GuildId(), cmd.UserId())
 			if err != nil {
 				cmd.HandleError(err)
 				return
@@ -99,28 +99,55 @@ func ReopenTicket(ctx context.Context, cmd registry.CommandContext, ticketId int
 		return
 	}
 
-	// Only allow reopening threads
+	// A ticket which has already been continued in a new ticket should not be continued again
+	reopenedAs, ok, err := cmd.Worker().Services.Database.ReopenLinks.GetReopenedAs(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	if ok {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenAlreadyReopened, reopenedAs)
+		return
+	}
+
+	// Channel tickets can be restored while they are being kept in the closed category, otherwise the channel has been
+	// deleted and the ticket is continued in a new one
 	if !ticket.IsThread {
-		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenNotThread)
+		closed, ok, err := cmd.Worker().Services.Database.ClosedChannels.Get(ctx, ticket.GuildId, ticket.Id)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		if !ok || ticket.ChannelId == nil {
+			reopenAsNewTicket(ctx, cmd, ticket)
+			return
+		}
+
+		reopenClosedChannel(ctx, cmd, ticket, closed)
 		return
 	}
 
-	// Ensure channel still exists
+	// Ensure thread still exists
 	if ticket.ChannelId == nil {
-		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenThreadDeleted)
+		reopenAsNewTicket(ctx, cmd, ticket)
 		return
 	}
 
 	ch, err := cmd.Worker().GetChannel(*ticket.ChannelId)
 	if err != nil {
 		if err, ok := err.(request.RestError); ok && err.StatusCode == 404 {
-			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenThreadDeleted)
+			reopenAsNewTicket(ctx, cmd, ticket)
 			return
 		}
+
+		cmd.HandleError(err)
+		return
 	}
 
 	if ch.Id == 0 {
-		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenThreadDeleted)
+		reopenAsNewTicket(ctx, cmd, ticket)
 		return
 	}
 
@@ -131,13 +158,7 @@ func ReopenTicket(ctx context.Context, cmd registry.CommandContext, ticketId int
 		},
 	}
 
-	member, err := cmd.Member()
-	auditReason := fmt.Sprintf("Reopened ticket %d", ticket.Id)
-	if err == nil {
-		auditReason = fmt.Sprintf("Reopened ticket %d by %s", ticket.Id, member.User.Username)
-	}
-
-	reasonCtx := request.WithAuditReason(ctx, auditReason)
+	reasonCtx := request.WithAuditReason(ctx, buildReopenAuditReason(cmd, ticket))
 	if _, err := cmd.Worker().ModifyChannel(reasonCtx, *ticket.ChannelId, data); err != nil {
 		if err, ok := err.(request.RestError); ok && err.StatusCode == 404 {
 			cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenThreadDeleted)
@@ -156,3 +177,102 @@ func ReopenTicket(ctx context.Context, cmd registry.CommandContext, ticketId int
 		return
 	}
 }
+
+// reopenClosedChannel restores a channel ticket which is being kept in the closed category, and marks it as open. Unlike
+// threads, there is no channel update to mark the ticket as open in response to, so it is done here.
+func reopenClosedChannel(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, closed dbclient.ClosedChannel) {
+	reasonCtx := request.WithAuditReason(ctx, buildReopenAuditReason(cmd, ticket))
+	if err := RestoreClosedChannel(ctx, reasonCtx, cmd, ticket, closed); err != nil {
+		if err, ok := err.(request.RestError); ok && err.StatusCode == 404 {
+			if err := cmd.Worker().Services.Database.ClosedChannels.Delete(ctx, ticket.GuildId, ticket.Id); err != nil {
+				cmd.HandleError(err)
+				return
+			}
+
+			reopenAsNewTicket(ctx, cmd, ticket)
+			return
+		}
+
+		cmd.HandleError(err)
+		return
+	}
+
+	if err := cmd.Worker().Services.Database.Tickets.SetOpen(ctx, ticket.GuildId, ticket.Id); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	cmd.Reply(customisation.Green, i18n.Success, i18n.MessageReopenSuccess, ticket.Id, *ticket.ChannelId)
+
+	embedData := utils.BuildEmbed(cmd, customisation.Green, i18n.TitleReopened, i18n.MessageReopenedTicket, nil, cmd.UserId())
+	if _, err := cmd.Worker().CreateMessageEmbed(*ticket.ChannelId, embedData); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+}
+
+// reopenAsNewTicket continues a ticket whose channel or thread has been deleted in a new ticket, opened from the same
+// panel by the same member, and posts a summary of the previous ticket into it. The two tickets are linked, so that the
+// transcript of the new ticket shows which ticket it continues.
+func reopenAsNewTicket(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) {
+	interactionCtx, ok := cmd.(registry.InteractionContext)
+	if !ok {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenThreadDeleted)
+		return
+	}
+
+	var panel *database.Panel
+	if ticket.PanelId != nil {
+		p, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *ticket.PanelId)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		if p.PanelId != 0 {
+			panel = &p
+		}
+	}
+
+	// Staff reopen the ticket on behalf of its opener, so that the new ticket belongs to them
+	var openCtx registry.InteractionContext = interactionCtx
+	if ticket.UserId != cmd.UserId() {
+		opener, err := cmd.Worker().GetGuildMember(ticket.GuildId, ticket.UserId)
+		if err != nil {
+			if err, ok := err.(request.RestError); ok && err.StatusCode == 404 {
+				cmd.Reply(customisation.Red, i18n.Error, i18n.MessageReopenOpenerLeft)
+				return
+			}
+
+			cmd.HandleError(err)
+			return
+		}
+
+		openCtx = NewOnBehalfOfContext(interactionCtx, opener)
+	}
+
+	// OpenTicket replies with the reason if the ticket could not be opened
+	newTicket, err := OpenTicket(ctx, openCtx, panel, fmt.Sprintf("Reopened from ticket #%d", ticket.Id), nil, nil, nil, nil)
+	if err != nil || newTicket.Id == 0 || newTicket.ChannelId == nil {
+		return
+	}
+
+	if err := cmd.Worker().Services.Database.ReopenLinks.Set(ctx, ticket.GuildId, ticket.Id, newTicket.Id); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	if err := postReopenSummary(ctx, cmd, ticket, *newTicket.ChannelId); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+}
+
+func buildReopenAuditReason(cmd registry.CommandContext, ticket database.Ticket) string {
+	member, err := cmd.Member()
+	if err != nil {
+		return fmt.Sprintf("Reopened ticket %d", ticket.Id)
+	}
+
+	return fmt.Sprintf("Reopened ticket %d by %s", ticket.Id, member.User.Username)
+}
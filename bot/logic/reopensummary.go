@@ -0,0 +1,123 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TicketsBot-cloud/archiverclient"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+)
+
+const (
+	// reopenSummaryMessageCount is the number of messages from the end of the previous ticket's transcript which are
+	// shown in the summary
+	reopenSummaryMessageCount  = 5
+	reopenSummaryMessageLength = 200
+)
+
+// reopenSummary holds what is known about the previous ticket when it is reopened as a new ticket
+type reopenSummary struct {
+	Previous    database.Ticket
+	CloseReason *database.CloseMetadata
+	Rating      *uint8
+	// Transcript is nil if no transcript was stored for the previous ticket
+	Transcript *v2.Transcript
+}
+
+// postReopenSummary posts the close reason, rating and final messages of the previous ticket into the channel of the
+// ticket which was opened to continue it
+func postReopenSummary(ctx context.Context, cmd registry.CommandContext, previous database.Ticket, channelId uint64) error {
+	summary := reopenSummary{Previous: previous}
+
+	closeMetadata, ok, err := cmd.Worker().Services.Database.CloseReason.Get(ctx, previous.GuildId, previous.Id)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		summary.CloseReason = &closeMetadata
+	}
+
+	rating, ok, err := cmd.Worker().Services.Database.ServiceRatings.Get(ctx, previous.GuildId, previous.Id)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		summary.Rating = &rating
+	}
+
+	// The summary is still useful without the messages, so do not fail if the transcript cannot be fetched
+	transcript, err := cmd.Worker().Services.Archiver.Get(ctx, previous.GuildId, previous.Id)
+	if err == nil {
+		summary.Transcript = &transcript
+	} else if !errors.Is(err, archiverclient.ErrNotFound) {
+		cmd.HandleWarning(err)
+	}
+
+	e := utils.BuildEmbedRaw(
+		cmd.GetColour(customisation.Blue),
+		fmt.Sprintf("Reopened from ticket #%d", previous.Id),
+		fmt.Sprintf("This ticket continues ticket #%d, which was opened by <@%d>.", previous.Id, previous.UserId),
+		summary.fields(),
+		cmd.PremiumTier(),
+	)
+
+	_, err = cmd.Worker().CreateMessageEmbed(channelId, e)
+	return err
+}
+
+func (s reopenSummary) fields() []embed.EmbedField {
+	var fields []embed.EmbedField
+
+	if s.CloseReason != nil {
+		if s.CloseReason.Reason != nil {
+			fields = append(fields, utils.EmbedFieldRaw("Close Reason", utils.StringMax(*s.CloseReason.Reason, 1024, "..."), false))
+		}
+
+		if s.CloseReason.ClosedBy != nil {
+			fields = append(fields, utils.EmbedFieldRaw("Closed By", fmt.Sprintf("<@%d>", *s.CloseReason.ClosedBy), true))
+		}
+	}
+
+	if s.Rating != nil {
+		fields = append(fields, utils.EmbedFieldRaw("Rating", fmt.Sprintf("%s (%d/5)", strings.Repeat("⭐", int(*s.Rating)), *s.Rating), true))
+	}
+
+	if lines := s.lastMessages(); len(lines) > 0 {
+		fields = append(fields, utils.EmbedFieldRaw("Last Messages", utils.StringMax(strings.Join(lines, "\n"), 1024, "..."), false))
+	}
+
+	return fields
+}
+
+// lastMessages renders the final messages with text content from the transcript, oldest first. Messages from bots are
+// skipped, as they are mostly the ticket's own embeds.
+func (s reopenSummary) lastMessages() []string {
+	if s.Transcript == nil {
+		return nil
+	}
+
+	var lines []string
+	for i := len(s.Transcript.Messages) - 1; i >= 0 && len(lines) < reopenSummaryMessageCount; i-- {
+		msg := s.Transcript.Messages[i]
+		if msg.Content == "" || s.Transcript.Entities.Users[msg.AuthorId].Bot {
+			continue
+		}
+
+		content := strings.ReplaceAll(msg.Content, "\n", " ")
+		lines = append(lines, fmt.Sprintf("<@%d> (<t:%d:R>): %s", msg.AuthorId, msg.Timestamp.Unix(), utils.StringMax(content, reopenSummaryMessageLength, "...")))
+	}
+
+	// Messages were collected newest first
+	slices.Reverse(lines)
+	return lines
+}
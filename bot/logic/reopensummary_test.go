@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReopenSummaryLastMessages(t *testing.T) {
+	sent := time.Unix(1719837000, 0)
+
+	transcript := &v2.Transcript{
+		Entities: v2.Entities{
+			Users: map[uint64]v2.User{
+				1: {Id: 1, Username: "opener"},
+				2: {Id: 2, Username: "bot", Bot: true},
+			},
+		},
+	}
+
+	for i := range reopenSummaryMessageCount + 2 {
+		transcript.Messages = append(transcript.Messages, v2.Message{AuthorId: 1, Content: string(rune('a' + i)), Timestamp: sent})
+	}
+
+	// Bot messages and messages without text are skipped
+	transcript.Messages = append(transcript.Messages,
+		v2.Message{AuthorId: 2, Content: "Ticket closed", Timestamp: sent},
+		v2.Message{AuthorId: 1, Timestamp: sent},
+		v2.Message{AuthorId: 1, Content: "line one\nline two " + strings.Repeat("x", reopenSummaryMessageLength), Timestamp: sent},
+	)
+
+	lines := reopenSummary{Transcript: transcript}.lastMessages()
+	require.Len(t, lines, reopenSummaryMessageCount)
+	require.Equal(t, "<@1> (<t:1719837000:R>): d", lines[0])
+	require.Equal(t, "<@1> (<t:1719837000:R>): g", lines[3])
+	require.True(t, strings.HasPrefix(lines[4], "<@1> (<t:1719837000:R>): line one line two "))
+	require.True(t, strings.HasSuffix(lines[4], "..."))
+
+	require.Empty(t, reopenSummary{}.lastMessages())
+}
+
+func TestReopenSummaryFields(t *testing.T) {
+	require.Empty(t, reopenSummary{}.fields())
+
+	summary := reopenSummary{
+		CloseReason: &database.CloseMetadata{Reason: utils.Ptr("Resolved"), ClosedBy: utils.Ptr(uint64(5))},
+		Rating:      utils.Ptr(uint8(4)),
+	}
+
+	fields := summary.fields()
+	require.Len(t, fields, 3)
+	require.Equal(t, "Resolved", fields[0].Value)
+	require.Equal(t, "<@5>", fields[1].Value)
+	require.Equal(t, "⭐⭐⭐⭐ (4/5)", fields[2].Value)
+}
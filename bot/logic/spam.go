@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+)
+
+const (
+	// SpamCloseReason is used when a ticket is closed as spam without a reason
+	SpamCloseReason = "Spam"
+	// MaxSpamBlacklistDuration is the longest an opener can be blacklisted for after their ticket is closed as spam.
+	// Staff should use the permanent blacklist for anything longer.
+	MaxSpamBlacklistDuration = time.Hour * 24 * 365
+	// SpamStatsDays is the number of days of spam stats which are shown
+	SpamStatsDays = 30
+	// spamStatsRetention is how long each day's spam stats are kept for
+	spamStatsRetention = time.Hour * 24 * (SpamStatsDays + 1)
+)
+
+// handleSpamClose blacklists the opener of a ticket which has been closed as spam, if the guild has configured a
+// blacklist duration, and records the close in the guild's spam stats. Staff are never blacklisted.
+func handleSpamClose(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) error {
+	config, err := cmd.Worker().Services.Database.SpamConfig.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	var blacklisted bool
+	if config.BlacklistDuration > 0 {
+		isStaff, err := isOpenerStaff(ctx, cmd, ticket)
+		if err != nil {
+			return err
+		}
+
+		if !isStaff {
+			if err := cmd.Worker().Services.Database.SpamBlacklist.Set(ctx, ticket.GuildId, ticket.UserId, time.Now().Add(config.BlacklistDuration)); err != nil {
+				return err
+			}
+
+			blacklisted = true
+		}
+	}
+
+	now := time.Now()
+	return cmd.Worker().Services.Database.SpamStats.RecordClose(ctx, ticket.GuildId, blacklisted, now, now.Add(spamStatsRetention))
+}
+
+// GetSpamStats sums the guild's spam stats over the last SpamStatsDays days, including today
+func GetSpamStats(ctx context.Context, worker *worker.Context, guildId uint64) (dbclient.SpamStats, error) {
+	now := time.Now()
+	return worker.Services.Database.SpamStats.Get(ctx, guildId, now.AddDate(0, 0, -(SpamStatsDays-1)), now)
+}
+
+func isOpenerStaff(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) (bool, error) {
+	member, err := cmd.Worker().GetGuildMember(ticket.GuildId, ticket.UserId)
+	if err != nil {
+		// The opener has left the server, so they cannot be staff
+		var restError request.RestError
+		if errors.As(err, &restError) && restError.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	permLevel, err := permission.GetPermissionLevel(ctx, utils.ToRetriever(cmd.Worker()), member, ticket.GuildId)
+	if err != nil {
+		return false, err
+	}
+
+	return permLevel > permission.Everyone, nil
+}
+
+// FormatSpamBlacklistDuration renders the blacklist duration in whole days where possible, otherwise in hours
+func FormatSpamBlacklistDuration(duration time.Duration) string {
+	hours := int(duration.Hours())
+	switch {
+	case hours <= 0:
+		return "Disabled"
+	case hours%24 == 0:
+		return pluralise(hours/24, "day")
+	default:
+		return pluralise(hours, "hour")
+	}
+}
+
+func pluralise(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, unit)
+	}
+
+	return fmt.Sprintf("%d %ss", count, unit)
+}
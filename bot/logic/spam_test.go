@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSpamBlacklistDuration(t *testing.T) {
+	require.Equal(t, "Disabled", FormatSpamBlacklistDuration(0))
+	require.Equal(t, "1 hour", FormatSpamBlacklistDuration(time.Hour))
+	require.Equal(t, "36 hours", FormatSpamBlacklistDuration(time.Hour*36))
+	require.Equal(t, "1 day", FormatSpamBlacklistDuration(time.Hour*24))
+	require.Equal(t, "7 days", FormatSpamBlacklistDuration(time.Hour*24*7))
+}
+
+func TestCloseTicketAsSpam(t *testing.T) {
+	tests := []struct {
+		name              string
+		blacklistDuration time.Duration
+		openerLevel       permcache.PermissionLevel
+		expectBlacklisted bool
+	}{
+		{
+			name:        "opener is not blacklisted by default",
+			openerLevel: permcache.Everyone,
+		},
+		{
+			name:              "opener is blacklisted for the configured duration",
+			blacklistDuration: time.Hour * 48,
+			openerLevel:       permcache.Everyone,
+			expectBlacklisted: true,
+		},
+		{
+			name:              "staff openers are never blacklisted",
+			blacklistDuration: time.Hour * 48,
+			openerLevel:       permcache.Support,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			config.Conf.Transcripts.SpoolDirectory = t.TempDir()
+
+			g := newTicketGuild(t, h, test.openerLevel)
+			ticket := g.openTicket(t, h, false)
+
+			require.NoError(t, h.Database.SpamConfig.Set(t.Context(), g.GuildId, dbclient.SpamConfig{
+				BlacklistDuration: test.blacklistDuration,
+			}))
+
+			staffId := g.addMember(t, h, permcache.Support)
+			ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, staffId, permcache.Support)
+			ctx.SettingsValue.StoreTranscripts = true
+
+			CloseTicketAsSpam(t.Context(), ctx, nil)
+			require.Empty(t, ctx.Errors())
+
+			stored, err := h.Database.Tickets.Get(t.Context(), ticket.Id, g.GuildId)
+			require.NoError(t, err)
+			require.False(t, stored.Open)
+
+			// Spam is not worth keeping a transcript of, and the opener is not sent a DM
+			_, hasTranscript := h.Archiver.Transcript(g.GuildId, ticket.Id)
+			require.False(t, hasTranscript)
+
+			_, hasDm := h.Discord.DMChannel(g.UserId)
+			require.False(t, hasDm)
+
+			expiry, blacklisted, err := h.Database.SpamBlacklist.GetExpiry(t.Context(), g.GuildId, g.UserId)
+			require.NoError(t, err)
+			require.Equal(t, test.expectBlacklisted, blacklisted)
+
+			if test.expectBlacklisted {
+				require.WithinDuration(t, time.Now().Add(test.blacklistDuration), expiry, time.Minute)
+			}
+
+			expectedBlacklists := 0
+			if test.expectBlacklisted {
+				expectedBlacklists = 1
+			}
+
+			stats, err := GetSpamStats(t.Context(), h.Worker, g.GuildId)
+			require.NoError(t, err)
+			require.Equal(t, dbclient.SpamStats{Closes: 1, Blacklists: expectedBlacklists}, stats)
+		})
+	}
+}
+
+func TestGetSpamStats(t *testing.T) {
+	h := testharness.New(t)
+	guildId := h.Discord.NextId()
+	now := time.Now()
+
+	require.NoError(t, h.Database.SpamStats.RecordClose(t.Context(), guildId, true, now, now.Add(spamStatsRetention)))
+	require.NoError(t, h.Database.SpamStats.RecordClose(t.Context(), guildId, false, now.AddDate(0, 0, -(SpamStatsDays-1)), now))
+	// Outside of the window which is shown
+	require.NoError(t, h.Database.SpamStats.RecordClose(t.Context(), guildId, true, now.AddDate(0, 0, -SpamStatsDays), now))
+
+	stats, err := GetSpamStats(t.Context(), h.Worker, guildId)
+	require.NoError(t, err)
+	require.Equal(t, dbclient.SpamStats{Closes: 2, Blacklists: 1}, stats)
+}
@@ -0,0 +1,267 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// SwitchPanel moves the ticket to newPanel, renaming the channel and recalculating its permissions for the new panel.
+// The caller is responsible for checking that newPanel belongs to the ticket's guild.
+func SwitchPanel(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket, newPanel database.Panel) {
+	originalPanelId := ticket.PanelId
+	var oldPanel *database.Panel
+	if originalPanelId != nil {
+		tmp, err := cmd.Worker().Services.Database.Panel.GetById(ctx, *originalPanelId)
+		if err == nil && tmp.PanelId != 0 {
+			oldPanel = &tmp
+		}
+	}
+
+	if !ticket.IsThread && newPanel.UseThreads {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageSwitchPanelNonThreadToThread)
+		return
+	}
+
+	// Get ticket claimer
+	claimer, err := cmd.Worker().Services.Database.TicketClaims.Get(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	// Check if claimer has access to new panel
+	autoUnclaimed := false
+	originalClaimer := claimer
+	if claimer != 0 {
+		claimerHasAccess, err := HasPermissionForPanel(ctx, cmd.Worker(), cmd.GuildId(), &newPanel, claimer)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		if !claimerHasAccess {
+			claimSettings, err := cmd.Worker().Services.Database.ClaimSettings.Get(ctx, cmd.GuildId())
+			if err != nil {
+				cmd.HandleError(err)
+				return
+			}
+
+			switch claimSettings.SwitchPanelClaimBehavior {
+			case database.SwitchPanelBlockSwitch:
+				cmd.Reply(customisation.Red, i18n.MessageSwitchPanelClaimerNoAccessTitle, i18n.MessageSwitchPanelClaimerNoAccess, claimer)
+				return
+			case database.SwitchPanelAutoUnclaim:
+				if err := cmd.Worker().Services.Database.TicketClaims.Delete(ctx, ticket.GuildId, ticket.Id); err != nil {
+					cmd.HandleError(err)
+					return
+				}
+				claimer = 0
+				autoUnclaimed = true
+			case database.SwitchPanelRemoveOnUnclaim, database.SwitchPanelKeepAccess:
+				// Handled in unclaim
+			}
+		}
+	}
+
+	// Generate new channel name
+	newChannelName, err := GenerateChannelName(ctx, cmd.Worker(), &newPanel, ticket.GuildId, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	// Fetch current channel name
+	currentChannel, err := cmd.Worker().GetChannel(*ticket.ChannelId)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	// Always update the name to match the new panel's naming scheme
+	shouldUpdateName := true
+	if oldPanel != nil {
+		// But skip if the user has manually renamed the channel (doesn't match old panel's generated name)
+		oldChannelName, _ := GenerateChannelName(ctx, cmd.Worker(), oldPanel, ticket.GuildId, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
+		if currentChannel.Name != oldChannelName {
+			shouldUpdateName = false
+		}
+	}
+
+	// Update panel assigned to ticket in database
+	if err := cmd.Worker().Services.Database.Tickets.SetPanelId(ctx, cmd.GuildId(), ticket.Id, newPanel.PanelId); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	// Update welcome message
+	if ticket.WelcomeMessageId != nil {
+		msg, err := cmd.Worker().GetChannelMessage(*ticket.ChannelId, *ticket.WelcomeMessageId)
+
+		// Error is likely to be due to message being deleted, we want to continue further even if it is
+		if err == nil {
+			var subject string
+
+			embeds := utils.PtrElems(msg.Embeds) // TODO: Fix types
+			if len(embeds) == 0 {
+				embeds = make([]*embed.Embed, 1)
+				subject = "No subject given"
+			} else {
+				subject = embeds[0].Title // TODO: Store subjects in database
+			}
+
+			embeds[0], err = BuildWelcomeMessageEmbed(ctx, cmd, ticket, subject, &newPanel, nil)
+			if err != nil {
+				cmd.HandleError(err)
+				return
+			}
+
+			for i := 1; i < len(embeds); i++ {
+				embeds[i].Color = embeds[0].Color
+			}
+
+			editData := rest.EditMessageData{
+				Content:    msg.Content,
+				Embeds:     embeds,
+				Flags:      uint(msg.Flags),
+				Components: msg.Components,
+			}
+
+			if _, err = cmd.Worker().EditMessage(*ticket.ChannelId, *ticket.WelcomeMessageId, editData); err != nil {
+				cmd.HandleWarning(err)
+			}
+		}
+	}
+
+	// If the ticket is a thread, we cannot update the permissions (possibly remove a small amount of  members in the
+	// future), or the parent channel (user may not have access to it. can you even move threads anyway?)
+	if ticket.IsThread {
+		settings, err := cmd.Settings()
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		data := rest.ModifyChannelData{}
+		if shouldUpdateName {
+			data.Name = newChannelName
+		}
+
+		member, err := cmd.Member()
+		auditReason := fmt.Sprintf("Switched ticket %d to panel '%s'", ticket.Id, newPanel.Title)
+		if err == nil {
+			auditReason = fmt.Sprintf("Switched ticket %d to panel '%s' by %s", ticket.Id, newPanel.Title, member.User.Username)
+		}
+
+		reasonCtx := request.WithAuditReason(ctx, auditReason)
+		if _, err := cmd.Worker().ModifyChannel(reasonCtx, *ticket.ChannelId, data); err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		cmd.ReplyRaw(customisation.Green, "Success", fmt.Sprintf("This ticket has been switched to the panel **%s**.\n\nNote: As this is a thread, the permissions could not be bulk updated.", newPanel.Title))
+
+		// Modify join message
+		if ticket.JoinMessageId != nil {
+			var notificationChannel *uint64
+			if newPanel.TicketNotificationChannel != nil {
+				notificationChannel = newPanel.TicketNotificationChannel
+			} else if settings.TicketNotificationChannel != nil {
+				notificationChannel = settings.TicketNotificationChannel
+			}
+
+			if notificationChannel != nil {
+				threadStaff, err := GetStaffInThread(ctx, cmd.Worker(), ticket, *ticket.ChannelId)
+				if err != nil {
+					sentry.ErrorWithContext(err, cmd.ToErrorContext()) // Only log
+					return
+				}
+
+				msg := BuildJoinThreadMessage(ctx, cmd.Worker(), cmd.GuildId(), ticket.UserId, newChannelName, ticket.Id, &newPanel, threadStaff, cmd.PremiumTier())
+				if _, err := cmd.Worker().EditMessage(*notificationChannel, *ticket.JoinMessageId, msg.IntoEditMessageData()); err != nil {
+					sentry.ErrorWithContext(err, cmd.ToErrorContext()) // Only log
+					return
+				}
+			}
+		}
+
+		return
+	}
+
+	// Append additional ticket members to overwrites
+	members, err := cmd.Worker().Services.Database.TicketMembers.Get(ctx, cmd.GuildId(), ticket.Id)
+	if err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	// Calculate new channel permissions
+	var overwrites []channel.PermissionOverwrite
+	if claimer == 0 {
+		overwrites, err = CreateOverwrites(ctx, cmd, ticket.UserId, &newPanel, newPanel.TargetCategory, members...)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+	} else {
+		ticket.PanelId = &newPanel.PanelId
+		overwrites, err = GenerateClaimedOverwrites(ctx, cmd.Worker(), ticket, claimer)
+		if err != nil {
+			cmd.HandleError(err)
+			return
+		}
+
+		// GenerateClaimedOverwrites returns nil if the permissions are the same as an unclaimed ticket
+		// so if this is the case, we still need to calculate permissions
+		if overwrites == nil {
+			membersWithClaimer := append(members, claimer)
+			overwrites, err = CreateOverwrites(ctx, cmd, ticket.UserId, &newPanel, newPanel.TargetCategory, membersWithClaimer...)
+			if err != nil {
+				cmd.HandleError(err)
+				return
+			}
+		}
+	}
+
+	// Update channel permissions
+	data := rest.ModifyChannelData{
+		PermissionOverwrites: overwrites,
+		ParentId:             newPanel.TargetCategory,
+		Topic:                newPanel.Title,
+	}
+	if shouldUpdateName {
+		data.Name = newChannelName
+	}
+
+	member, err := cmd.Member()
+	auditReason := fmt.Sprintf("Switched ticket %d to panel '%s'", ticket.Id, newPanel.Title)
+	if err == nil {
+		auditReason = fmt.Sprintf("Switched ticket %d to panel '%s' by %s", ticket.Id, newPanel.Title, member.User.Username)
+	}
+
+	reasonCtx := request.WithAuditReason(ctx, auditReason)
+	if _, err = cmd.Worker().ModifyChannel(reasonCtx, *ticket.ChannelId, data); err != nil {
+		cmd.HandleError(err)
+		return
+	}
+
+	// If the ticket was auto-unclaimed, update the welcome message claim button
+	if autoUnclaimed {
+		if err := UpdateWelcomeMessageClaimButton(ctx, cmd.Worker(), cmd, ticket, false); err != nil {
+			cmd.HandleWarning(err)
+		}
+		cmd.ReplyPermanent(customisation.Green, i18n.TitlePanelSwitched, i18n.MessageSwitchPanelAutoUnclaimed, newPanel.Title, cmd.UserId(), originalClaimer)
+	} else {
+		cmd.ReplyPermanent(customisation.Green, i18n.TitlePanelSwitched, i18n.MessageSwitchPanelSuccess, newPanel.Title, cmd.UserId())
+	}
+}
@@ -0,0 +1,182 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+const historyPerPage = 5
+
+func BuildTicketHistoryButtons(userId uint64, page, totalPages int) component.Component {
+	return component.BuildActionRow(
+		component.BuildButton(component.Button{
+			CustomId: fmt.Sprintf("history_%d_%d", userId, page-1),
+			Style:    component.ButtonStyleDanger,
+			Label:    "<",
+			Disabled: page <= 0,
+		}),
+		component.BuildButton(component.Button{
+			CustomId: "history_page_count",
+			Style:    component.ButtonStyleSecondary,
+			Label:    fmt.Sprintf("%d/%d", page+1, totalPages),
+			Disabled: true,
+		}),
+		component.BuildButton(component.Button{
+			CustomId: fmt.Sprintf("history_%d_%d", userId, page+1),
+			Style:    component.ButtonStyleSuccess,
+			Label:    ">",
+			Disabled: page >= totalPages-1,
+		}),
+	)
+}
+
+// BuildTicketHistoryMessage lists the tickets that userId has opened in the guild, newest first, returning the
+// clamped page number and the total number of pages alongside the container
+func BuildTicketHistoryMessage(ctx context.Context, cmd registry.CommandContext, userId uint64, page int) (component.Component, int, int, error) {
+	total, err := cmd.Worker().Services.Database.Tickets.GetTotalCountByUser(ctx, cmd.GuildId(), userId)
+	if err != nil {
+		return component.Component{}, 0, 0, err
+	}
+
+	totalPages := (total + historyPerPage - 1) / historyPerPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	if page < 0 {
+		page = 0
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+
+	title := cmd.GetMessage(i18n.MessageTicketHistoryCount, total)
+
+	if total == 0 {
+		return utils.BuildContainerRaw(cmd, customisation.Green, title, cmd.GetMessage(i18n.MessageTicketHistoryEmpty, userId)), page, totalPages, nil
+	}
+
+	tickets, err := cmd.Worker().Services.Database.Tickets.GetByOptions(ctx, database.TicketQueryOptions{
+		GuildId: cmd.GuildId(),
+		UserIds: []uint64{userId},
+		Order:   database.OrderTypeDescending,
+		Limit:   historyPerPage,
+		Offset:  page * historyPerPage,
+	})
+	if err != nil {
+		return component.Component{}, 0, 0, err
+	}
+
+	ticketIds := make([]int, len(tickets))
+	for i, ticket := range tickets {
+		ticketIds[i] = ticket.Id
+	}
+
+	closeReasons, err := cmd.Worker().Services.Database.CloseReason.GetMulti(ctx, cmd.GuildId(), ticketIds)
+	if err != nil {
+		return component.Component{}, 0, 0, err
+	}
+
+	ratings, err := cmd.Worker().Services.Database.ServiceRatings.GetMulti(ctx, cmd.GuildId(), ticketIds)
+	if err != nil {
+		return component.Component{}, 0, 0, err
+	}
+
+	panels, err := cmd.Worker().Services.Database.Panel.GetByGuild(ctx, cmd.GuildId())
+	if err != nil {
+		return component.Component{}, 0, 0, err
+	}
+
+	panelTitles := make(map[int]string, len(panels))
+	for _, panel := range panels {
+		panelTitles[panel.PanelId] = panel.Title
+	}
+
+	innerComponents := []component.Component{
+		component.BuildTextDisplay(component.TextDisplay{Content: fmt.Sprintf("Tickets opened by <@%d>", userId)}),
+	}
+
+	for _, ticket := range tickets {
+		claimedBy, err := cmd.Worker().Services.Database.TicketClaims.Get(ctx, cmd.GuildId(), ticket.Id)
+		if err != nil {
+			return component.Component{}, 0, 0, err
+		}
+
+		var rating *uint8
+		if r, ok := ratings[ticket.Id]; ok {
+			rating = &r
+		}
+
+		var closeReason *string
+		if metadata, ok := closeReasons[ticket.Id]; ok {
+			closeReason = metadata.Reason
+		}
+
+		entry := buildTicketHistoryEntry(cmd.GuildId(), ticket, panelTitles, closeReason, rating, claimedBy)
+
+		innerComponents = append(innerComponents,
+			component.BuildSeparator(component.Separator{Divider: utils.Ptr(true), Spacing: utils.Ptr(1)}),
+			component.BuildTextDisplay(component.TextDisplay{Content: entry}),
+		)
+	}
+
+	return utils.BuildContainerWithComponents(cmd, customisation.Green, title, innerComponents), page, totalPages, nil
+}
+
+func buildTicketHistoryEntry(guildId uint64, ticket database.Ticket, panelTitles map[int]string, closeReason *string, rating *uint8, claimedBy uint64) string {
+	var content strings.Builder
+
+	panelTitle := "None"
+	if ticket.PanelId != nil {
+		if title, ok := panelTitles[*ticket.PanelId]; ok {
+			panelTitle = utils.EscapeMarkdown(title)
+		}
+	}
+
+	content.WriteString(fmt.Sprintf("**Ticket #%d** • %s\n", ticket.Id, panelTitle))
+	content.WriteString(fmt.Sprintf("**Opened:** <t:%d:f>\n", ticket.OpenTime.Unix()))
+
+	if ticket.Open {
+		if ticket.ChannelId != nil {
+			content.WriteString(fmt.Sprintf("**Status:** Open in <#%d>\n", *ticket.ChannelId))
+		} else {
+			content.WriteString("**Status:** Open\n")
+		}
+	} else if ticket.CloseTime != nil {
+		content.WriteString(fmt.Sprintf("**Closed:** <t:%d:f>\n", ticket.CloseTime.Unix()))
+	}
+
+	if closeReason != nil && *closeReason != "" {
+		content.WriteString(fmt.Sprintf("**Close Reason:** %s\n", utils.EscapeMarkdown(*closeReason)))
+	}
+
+	if rating != nil {
+		content.WriteString(fmt.Sprintf("**Rating:** %d ⭐\n", *rating))
+	}
+
+	if claimedBy != 0 {
+		content.WriteString(fmt.Sprintf("**Claimed By:** <@%d>\n", claimedBy))
+	}
+
+	if ticket.HasTranscript {
+		transcriptLink := fmt.Sprintf("%s/manage/%d/transcripts/view/%d", config.Conf.Bot.DashboardUrl, guildId, ticket.Id)
+		content.WriteString(fmt.Sprintf("[View Transcript](%s)\n", transcriptLink))
+	}
+
+	return strings.TrimSuffix(content.String(), "\n")
+}
+
+// CountPreviousTickets returns the number of tickets the opener of ticket had opened in the guild before it
+func CountPreviousTickets(ctx context.Context, worker *worker.Context, ticket database.Ticket) (int, error) {
+	return worker.Services.Database.TicketCounts.GetOpenedBefore(ctx, ticket.GuildId, ticket.UserId, ticket.Id)
+}
@@ -0,0 +1,223 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketHistoryWelcomeButton(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		h := testharness.New(t)
+		g := newTicketGuild(t, h, permcache.Everyone)
+
+		require.NoError(t, h.Database.TicketHistoryConfig.Set(t.Context(), g.GuildId, dbclient.TicketHistoryConfig{
+			WelcomeButton: enabled,
+		}))
+
+		ticket := g.openTicket(t, h, false)
+
+		messages := h.Discord.Messages(*ticket.ChannelId)
+		require.NotEmpty(t, messages)
+		require.Equal(t, enabled, collectButtonIds(messages[0].Components)["history"])
+	}
+}
+
+func TestReturningUserNotice(t *testing.T) {
+	tests := []struct {
+		name            string
+		enabled         bool
+		previousTickets int
+		expectNotice    string
+	}{
+		{
+			name:            "disabled by default",
+			previousTickets: 2,
+		},
+		{
+			name:            "counts previous tickets",
+			enabled:         true,
+			previousTickets: 2,
+			expectNotice:    "2 previous tickets",
+		},
+		{
+			name:            "singular for one ticket",
+			enabled:         true,
+			previousTickets: 1,
+			expectNotice:    "1 previous ticket",
+		},
+		{
+			name:    "first time openers have no notice",
+			enabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			g := newTicketGuild(t, h, permcache.Everyone)
+
+			require.NoError(t, h.Database.TicketHistoryConfig.Set(t.Context(), g.GuildId, dbclient.TicketHistoryConfig{
+				ReturningUserNotice: test.enabled,
+			}))
+
+			for i := 0; i < test.previousTickets; i++ {
+				h.Database.Tickets.Add(database.Ticket{GuildId: g.GuildId, UserId: g.UserId, OpenTime: time.Now()})
+			}
+
+			ticket := g.openTicket(t, h, false)
+
+			messages := h.Discord.Messages(*ticket.ChannelId)
+			require.NotEmpty(t, messages)
+			require.NotEmpty(t, messages[0].Embeds)
+
+			var notice string
+			for _, field := range messages[0].Embeds[0].Fields {
+				if field.Name == "Returning User" {
+					notice = field.Value
+				}
+			}
+
+			require.Equal(t, test.expectNotice, notice)
+		})
+	}
+}
+
+func TestBuildTicketHistoryMessage(t *testing.T) {
+	h := testharness.New(t)
+	g := newTicketGuild(t, h, permcache.Support)
+	otherUserId := h.Discord.NextId()
+
+	// Seven tickets, so that the history spans two pages, and one opened by someone else
+	openTime := time.Now().Add(-time.Hour * 24)
+	for i := 0; i < 7; i++ {
+		h.Database.Tickets.Add(database.Ticket{
+			GuildId:   g.GuildId,
+			UserId:    g.UserId,
+			OpenTime:  openTime,
+			CloseTime: utils.Ptr(openTime.Add(time.Hour)),
+		})
+	}
+
+	h.Database.Tickets.Add(database.Ticket{GuildId: g.GuildId, UserId: otherUserId, Open: true, OpenTime: openTime})
+
+	ctx := h.NewCommandContext(g.GuildId, g.ChannelId, g.UserId, permcache.Support)
+
+	tests := []struct {
+		name            string
+		userId          uint64
+		page            int
+		expectPage      int
+		expectPages     int
+		expectTicketIds []int
+	}{
+		{
+			name:            "newest tickets first",
+			userId:          g.UserId,
+			page:            0,
+			expectPage:      0,
+			expectPages:     2,
+			expectTicketIds: []int{7, 6, 5, 4, 3},
+		},
+		{
+			name:            "second page",
+			userId:          g.UserId,
+			page:            1,
+			expectPage:      1,
+			expectPages:     2,
+			expectTicketIds: []int{2, 1},
+		},
+		{
+			name:            "pages past the end are clamped",
+			userId:          g.UserId,
+			page:            5,
+			expectPage:      1,
+			expectPages:     2,
+			expectTicketIds: []int{2, 1},
+		},
+		{
+			name:            "only the user's tickets are listed",
+			userId:          otherUserId,
+			expectPages:     1,
+			expectTicketIds: []int{8},
+		},
+		{
+			name:        "user without tickets",
+			userId:      h.Discord.NextId(),
+			expectPages: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			container, page, pages, err := BuildTicketHistoryMessage(t.Context(), ctx, test.userId, test.page)
+			require.NoError(t, err)
+			require.Equal(t, test.expectPage, page)
+			require.Equal(t, test.expectPages, pages)
+
+			text := collectText(container)
+
+			var ticketIds []int
+			for _, line := range strings.Split(text, "\n") {
+				var ticketId int
+				if n, _ := fmt.Sscanf(line, "**Ticket #%d**", &ticketId); n == 1 {
+					ticketIds = append(ticketIds, ticketId)
+				}
+			}
+
+			require.Equal(t, test.expectTicketIds, ticketIds)
+
+			if len(test.expectTicketIds) == 0 {
+				require.Contains(t, text, ctx.GetMessage(i18n.MessageTicketHistoryEmpty, test.userId))
+			}
+		})
+	}
+}
+
+// collectText joins the content of every text display in the component tree
+func collectText(c component.Component) string {
+	switch data := c.ComponentData.(type) {
+	case component.TextDisplay:
+		return data.Content
+	case component.Container:
+		var parts []string
+		for _, child := range data.Components {
+			if text := collectText(child); text != "" {
+				parts = append(parts, text)
+			}
+		}
+
+		return strings.Join(parts, "\n")
+	default:
+		return ""
+	}
+}
+
+// collectButtonIds returns the custom IDs of the buttons in the action rows
+func collectButtonIds(components []component.Component) map[string]bool {
+	ids := make(map[string]bool)
+	for _, c := range components {
+		row, ok := c.ComponentData.(component.ActionRow)
+		if !ok {
+			continue
+		}
+
+		for _, child := range row.Components {
+			if button, ok := child.ComponentData.(component.Button); ok {
+				ids[button.CustomId] = true
+			}
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,159 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/transcript"
+)
+
+// maxTranscriptExportSize is the largest transcript file that will be attached to a message, matching Discord's
+// default upload limit
+const maxTranscriptExportSize = 10 * 1024 * 1024
+
+// DefaultTranscriptExportConfig renders HTML transcripts in UTC, but does not attach them anywhere
+var DefaultTranscriptExportConfig = dbclient.TranscriptExportConfig{
+	Format:   string(transcript.FormatHtml),
+	Timezone: "UTC",
+}
+
+func GetTranscriptExportConfig(ctx context.Context, worker *worker.Context, guildId uint64) (dbclient.TranscriptExportConfig, error) {
+	config, ok, err := worker.Services.Database.TranscriptExportConfig.Get(ctx, guildId)
+	if err != nil {
+		return dbclient.TranscriptExportConfig{}, err
+	}
+
+	if !ok {
+		return DefaultTranscriptExportConfig, nil
+	}
+
+	return config, nil
+}
+
+func TranscriptExportEnabled(config dbclient.TranscriptExportConfig) bool {
+	return config.ArchiveChannel || config.DirectMessage
+}
+
+// TranscriptExports holds the transcript files rendered for a closed ticket. A file is nil if it was not requested, or
+// if it was too large to be uploaded.
+type TranscriptExports struct {
+	// Opener is attached to the DM sent to the ticket opener, and never includes the staff notes
+	Opener *transcript.File
+	// Staff is attached to the archive channel message, and includes a section for the staff notes if there are any
+	Staff *transcript.File
+}
+
+// BuildTranscriptExports renders the ticket's messages in the guild's chosen format, for each destination enabled in
+// the config
+func BuildTranscriptExports(
+	ctx context.Context,
+	cmd registry.CommandContext,
+	ticket database.Ticket,
+	config dbclient.TranscriptExportConfig,
+	msgs []message.Message,
+	notes []message.Message,
+) (TranscriptExports, error) {
+	format, ok := transcript.ParseFormat(config.Format)
+	if !ok {
+		format = transcript.FormatHtml
+	}
+
+	location, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	guild, err := cmd.Guild()
+	if err != nil {
+		return TranscriptExports{}, err
+	}
+
+	channels, err := cmd.Worker().GetGuildChannels(ticket.GuildId)
+	if err != nil {
+		return TranscriptExports{}, err
+	}
+
+	roles, err := cmd.Worker().GetGuildRoles(ticket.GuildId)
+	if err != nil {
+		return TranscriptExports{}, err
+	}
+
+	reopenedFrom, ok, err := cmd.Worker().Services.Database.ReopenLinks.GetReopenedFrom(ctx, ticket.GuildId, ticket.Id)
+	if err != nil {
+		return TranscriptExports{}, err
+	}
+
+	t := transcript.Transcript{
+		GuildId:   ticket.GuildId,
+		GuildName: guild.Name,
+		TicketId:  ticket.Id,
+		Location:  location,
+		Messages:  msgs,
+		Channels:  make(map[uint64]string, len(channels)),
+		Roles:     make(map[uint64]string, len(roles)),
+	}
+
+	if ok {
+		t.ReopenedFrom = &reopenedFrom
+	}
+
+	for _, ch := range channels {
+		t.Channels[ch.Id] = ch.Name
+	}
+
+	for _, role := range roles {
+		t.Roles[role.Id] = role.Name
+	}
+
+	var exports TranscriptExports
+	if config.DirectMessage {
+		if exports.Opener, err = exportTranscriptFile(t, format); err != nil {
+			return TranscriptExports{}, err
+		}
+	}
+
+	if config.ArchiveChannel {
+		if len(notes) == 0 && config.DirectMessage {
+			exports.Staff = exports.Opener
+		} else {
+			t.Notes = notes
+			if exports.Staff, err = exportTranscriptFile(t, format); err != nil {
+				return TranscriptExports{}, err
+			}
+		}
+	}
+
+	return exports, nil
+}
+
+// exportTranscriptFile renders the transcript, returning nil if the file is too large to be uploaded
+func exportTranscriptFile(t transcript.Transcript, format transcript.Format) (*transcript.File, error) {
+	file, err := t.Export(format)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(file.Data) > maxTranscriptExportSize {
+		return nil, nil
+	}
+
+	return &file, nil
+}
+
+func transcriptExportAttachment(file transcript.File) request.Attachment {
+	return request.Attachment{
+		Id:       0,
+		FileName: file.Name,
+		File: request.File{
+			ContentType: file.ContentType,
+			Reader:      bytes.NewReader(file.Data),
+		},
+	}
+}
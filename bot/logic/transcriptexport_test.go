@@ -0,0 +1,126 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseTicketTranscriptExport(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           *dbclient.TranscriptExportConfig
+		expectAttachment string
+	}{
+		{
+			name: "transcripts are not attached by default",
+		},
+		{
+			name: "attaches the transcript to the opener's DM",
+			config: &dbclient.TranscriptExportConfig{
+				Format:        "markdown",
+				DirectMessage: true,
+				Timezone:      "UTC",
+			},
+			expectAttachment: ".md",
+		},
+		{
+			name: "only the archive channel export is enabled",
+			config: &dbclient.TranscriptExportConfig{
+				Format:         "json",
+				ArchiveChannel: true,
+				Timezone:       "UTC",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := testharness.New(t)
+			config.Conf.Transcripts.SpoolDirectory = t.TempDir()
+
+			g := newTicketGuild(t, h, permcache.Everyone)
+			ticket := g.openTicket(t, h, false)
+
+			if test.config != nil {
+				require.NoError(t, h.Database.TranscriptExportConfig.Set(t.Context(), g.GuildId, *test.config))
+			}
+
+			staffId := g.addMember(t, h, permcache.Support)
+			ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, staffId, permcache.Support)
+
+			CloseTicket(t.Context(), ctx, nil, false)
+			require.Empty(t, ctx.Errors())
+
+			dm, ok := h.Discord.DMChannel(g.UserId)
+			require.True(t, ok)
+
+			messages := h.Discord.Messages(dm.Id)
+			require.Len(t, messages, 1)
+
+			if test.expectAttachment == "" {
+				require.Empty(t, messages[0].Attachments)
+				return
+			}
+
+			require.Len(t, messages[0].Attachments, 1)
+			require.True(t, strings.HasSuffix(messages[0].Attachments[0].Filename, test.expectAttachment))
+		})
+	}
+}
+
+func TestBuildTranscriptExports(t *testing.T) {
+	h := testharness.New(t)
+	g := newTicketGuild(t, h, permcache.Everyone)
+	ticket := g.openTicket(t, h, false)
+
+	msgs := []message.Message{{
+		Id:      h.Discord.NextId(),
+		Author:  user.User{Id: g.UserId, Username: "opener"},
+		Content: "My server is down",
+	}}
+
+	notes := []message.Message{{
+		Id:      h.Discord.NextId(),
+		Author:  user.User{Id: h.Discord.NextId(), Username: "staff"},
+		Content: "Check the logs first",
+	}}
+
+	exportConfig := dbclient.TranscriptExportConfig{
+		Format:         "markdown",
+		ArchiveChannel: true,
+		DirectMessage:  true,
+		Timezone:       "UTC",
+	}
+
+	ctx := h.NewCommandContext(g.GuildId, *ticket.ChannelId, g.UserId, permcache.Everyone)
+
+	// Without notes, the opener and staff receive the same file
+	exports, err := BuildTranscriptExports(t.Context(), ctx, ticket, exportConfig, msgs, nil)
+	require.NoError(t, err)
+	require.NotNil(t, exports.Opener)
+	require.Same(t, exports.Opener, exports.Staff)
+	require.Contains(t, string(exports.Opener.Data), "My server is down")
+
+	// Notes are only included in the staff file
+	exports, err = BuildTranscriptExports(t.Context(), ctx, ticket, exportConfig, msgs, notes)
+	require.NoError(t, err)
+	require.NotNil(t, exports.Opener)
+	require.NotNil(t, exports.Staff)
+	require.NotContains(t, string(exports.Opener.Data), "Check the logs first")
+	require.Contains(t, string(exports.Staff.Data), "Check the logs first")
+
+	// Only the enabled destinations are rendered
+	exportConfig.DirectMessage = false
+	exports, err = BuildTranscriptExports(t.Context(), ctx, ticket, exportConfig, msgs, notes)
+	require.NoError(t, err)
+	require.Nil(t, exports.Opener)
+	require.NotNil(t, exports.Staff)
+}
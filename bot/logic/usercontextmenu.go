@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"context"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// DefaultUserContextMenuConfig restricts every staff user context menu command to support representatives
+var DefaultUserContextMenuConfig = dbclient.UserContextMenuConfig{
+	OpenLevel:        permcache.Support.Int(),
+	ViewTicketsLevel: permcache.Support.Int(),
+	BlacklistLevel:   permcache.Support.Int(),
+}
+
+func GetUserContextMenuConfig(ctx context.Context, worker *worker.Context, guildId uint64) (dbclient.UserContextMenuConfig, error) {
+	config, ok, err := worker.Services.Database.UserContextMenuConfig.Get(ctx, guildId)
+	if err != nil {
+		return dbclient.UserContextMenuConfig{}, err
+	}
+
+	if !ok {
+		return DefaultUserContextMenuConfig, nil
+	}
+
+	return config, nil
+}
+
+// CheckUserContextMenuPermission replies with an error and returns false if the user is below the level selected by
+// level from the guild's user context menu config
+func CheckUserContextMenuPermission(cmd registry.CommandContext, level func(dbclient.UserContextMenuConfig) int) bool {
+	config, err := GetUserContextMenuConfig(cmd, cmd.Worker(), cmd.GuildId())
+	if err != nil {
+		cmd.HandleError(err)
+		return false
+	}
+
+	permLevel, err := cmd.UserPermissionLevel(cmd)
+	if err != nil {
+		cmd.HandleError(err)
+		return false
+	}
+
+	if permLevel < permcache.PermissionLevel(level(config)) {
+		cmd.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker"
+)
+
+// BuildUserTicketSummary describes a user's ticket counts and open tickets, checking whether each ticket's channel
+// still exists. openTickets may contain the open tickets of other users, which are ignored.
+func BuildUserTicketSummary(ctx context.Context, worker *worker.Context, guildId, userId uint64, openTickets []database.Ticket) string {
+	var lines []string
+
+	// Get open ticket count
+	openCount, err := worker.Services.Database.Tickets.GetOpenCountByUser(ctx, guildId, userId)
+	if err != nil {
+		openCount = 0
+	}
+
+	// Get total ticket count
+	totalCount, err := worker.Services.Database.Tickets.GetTotalCountByUser(ctx, guildId, userId)
+	if err != nil {
+		totalCount = 0
+	}
+
+	// Calculate closed count
+	closedCount := totalCount - openCount
+
+	lines = append(lines, fmt.Sprintf("**Open Tickets:** %d", openCount))
+	lines = append(lines, fmt.Sprintf("**Closed Tickets:** %d", closedCount))
+	lines = append(lines, fmt.Sprintf("**Total Tickets:** %d", totalCount))
+
+	// Filter open tickets for this user
+	var userOpenTickets []database.Ticket
+	for _, ticket := range openTickets {
+		if ticket.UserId == userId {
+			userOpenTickets = append(userOpenTickets, ticket)
+		}
+	}
+
+	// Show open ticket details
+	if len(userOpenTickets) > 0 {
+		var ticketDetails []string
+		for _, ticket := range userOpenTickets {
+			// Check if channel/thread still exists
+			channelExists := false
+			channelMention := "No channel"
+
+			if ticket.ChannelId != nil {
+				channelMention = fmt.Sprintf("`%d`", *ticket.ChannelId)
+
+				_, err := worker.GetChannel(*ticket.ChannelId)
+				if err == nil {
+					channelExists = true
+				}
+			}
+
+			existsStatus := "exists"
+			if !channelExists {
+				existsStatus = "deleted"
+			}
+
+			ticketDetails = append(ticketDetails, fmt.Sprintf("  • ID `%d` - %s %s", ticket.Id, channelMention, existsStatus))
+		}
+
+		if len(ticketDetails) > 10 {
+			// Limit to first 10 tickets
+			ticketDetails = ticketDetails[:10]
+			ticketDetails = append(ticketDetails, fmt.Sprintf("  • ... and %d more", len(userOpenTickets)-10))
+		}
+
+		lines = append(lines, fmt.Sprintf("\n**Open Ticket Details:**\n%s", strings.Join(ticketDetails, "\n")))
+	}
+
+	return fmt.Sprintf("**User:** <@%d>\n%s", userId, strings.Join(lines, "\n"))
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -26,20 +25,20 @@ func ValidatePanelAccess(ctx registry.InteractionContext, panel database.Panel)
 	var outOfHoursWarningColour *int
 
 	// Check support hours
-	hasSupportHours, err := dbclient.Client.PanelSupportHours.HasSupportHours(ctx, panel.PanelId)
+	hasSupportHours, err := ctx.Worker().Services.Database.PanelSupportHours.HasSupportHours(ctx, panel.PanelId)
 	if err != nil {
 		return false, nil, nil, nil, err
 	}
 
 	if hasSupportHours {
-		isActive, err := dbclient.Client.PanelSupportHours.IsActiveNow(ctx, panel.PanelId)
+		isActive, err := ctx.Worker().Services.Database.PanelSupportHours.IsActiveNow(ctx, panel.PanelId)
 		if err != nil {
 			return false, nil, nil, nil, err
 		}
 
 		if !isActive {
 			// Fetch behaviour settings for this panel
-			settings, exists, err := dbclient.Client.PanelSupportHoursSettings.Get(ctx, panel.PanelId)
+			settings, exists, err := ctx.Worker().Services.Database.PanelSupportHoursSettings.Get(ctx, panel.PanelId)
 			if err != nil {
 				return false, nil, nil, nil, err
 			}
@@ -117,7 +116,7 @@ func ValidatePanelAccess(ctx registry.InteractionContext, panel database.Panel)
 		return false, nil, nil, nil, err
 	}
 
-	matchedRole, action, err := dbclient.Client.PanelAccessControlRules.GetFirstMatched(
+	matchedRole, action, err := ctx.Worker().Services.Database.PanelAccessControlRules.GetFirstMatched(
 		ctx,
 		panel.PanelId,
 		append(member.Roles, ctx.GuildId()),
@@ -140,7 +139,7 @@ func ValidatePanelAccess(ctx registry.InteractionContext, panel database.Panel)
 }
 
 func sendAccessControlDeniedMessage(ctx context.Context, cmd registry.InteractionContext, panelId int, matchedRole uint64) error {
-	rules, err := dbclient.Client.PanelAccessControlRules.GetAll(ctx, panelId)
+	rules, err := cmd.Worker().Services.Database.PanelAccessControlRules.GetAll(ctx, panelId)
 	if err != nil {
 		return err
 	}
@@ -9,7 +9,6 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -69,22 +68,22 @@ func buildPaginatedField(cmd registry.CommandContext, entries []uint64, page int
 func BuildViewStaffMessage(ctx context.Context, cmd registry.CommandContext, page int) (component.Component, int, int) {
 	innerComponents := []component.Component{}
 
-	adminUsers, err := dbclient.Client.Permissions.GetAdmins(ctx, cmd.GuildId())
+	adminUsers, err := cmd.Worker().Services.Database.Permissions.GetAdmins(ctx, cmd.GuildId())
 	if err != nil {
 		sentry.ErrorWithContext(err, cmd.ToErrorContext())
 	}
 
-	adminRoles, err := dbclient.Client.RolePermissions.GetAdminRoles(ctx, cmd.GuildId())
+	adminRoles, err := cmd.Worker().Services.Database.RolePermissions.GetAdminRoles(ctx, cmd.GuildId())
 	if err != nil {
 		sentry.ErrorWithContext(err, cmd.ToErrorContext())
 	}
 
-	supportUsers, err := dbclient.Client.Permissions.GetSupportOnly(ctx, cmd.GuildId())
+	supportUsers, err := cmd.Worker().Services.Database.Permissions.GetSupportOnly(ctx, cmd.GuildId())
 	if err != nil {
 		sentry.ErrorWithContext(err, cmd.ToErrorContext())
 	}
 
-	supportRoles, err := dbclient.Client.RolePermissions.GetSupportRolesOnly(ctx, cmd.GuildId())
+	supportRoles, err := cmd.Worker().Services.Database.RolePermissions.GetSupportRolesOnly(ctx, cmd.GuildId())
 	if err != nil {
 		sentry.ErrorWithContext(err, cmd.ToErrorContext())
 	}
@@ -20,7 +20,6 @@ import (
 	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/integrations"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
@@ -39,7 +38,7 @@ func SendWelcomeMessage(
 	// Only custom integration placeholders for now - prevent making duplicate requests
 	additionalPlaceholders map[string]string,
 ) (uint64, error) {
-	settings, err := dbclient.Client.Settings.Get(ctx, ticket.GuildId)
+	settings, err := cmd.Worker().Services.Database.Settings.Get(ctx, ticket.GuildId)
 	if err != nil {
 		return 0, err
 	}
@@ -52,6 +51,15 @@ func SendWelcomeMessage(
 
 	embeds := utils.Slice(welcomeMessageEmbed)
 
+	// Note who opened the ticket if staff opened it on behalf of the user
+	if onBehalfOf, ok := cmd.(*OnBehalfOfContext); ok {
+		onBehalfOfEmbed := embed.NewEmbed().
+			SetColor(welcomeMessageEmbed.Color).
+			SetDescription(cmd.GetMessage(i18n.MessageOpenedOnBehalf, onBehalfOf.StaffId(), ticket.UserId))
+
+		embeds = append(embeds, onBehalfOfEmbed)
+	}
+
 	// Put form fields in a separate embed
 	fields := getFormDataFields(formData)
 	if len(fields) > 0 {
@@ -105,6 +113,34 @@ func SendWelcomeMessage(
 		}))
 	}
 
+	spamConfig, err := cmd.Worker().Services.Database.SpamConfig.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return 0, err
+	}
+
+	if spamConfig.WelcomeButton {
+		buttons = append(buttons, component.BuildButton(component.Button{
+			Label:    "Close as Spam",
+			CustomId: "close_spam",
+			Style:    component.ButtonStyleSecondary,
+			Emoji:    &emoji.Emoji{Name: "🚫"},
+		}))
+	}
+
+	historyConfig, err := cmd.Worker().Services.Database.TicketHistoryConfig.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return 0, err
+	}
+
+	if historyConfig.WelcomeButton {
+		buttons = append(buttons, component.BuildButton(component.Button{
+			Label:    "History",
+			CustomId: "history",
+			Style:    component.ButtonStyleSecondary,
+			Emoji:    &emoji.Emoji{Name: "📜"},
+		}))
+	}
+
 	data := rest.CreateMessageData{
 		Embeds: embeds,
 	}
@@ -138,7 +174,7 @@ func BuildWelcomeMessageEmbed(
 	additionalPlaceholders map[string]string,
 ) (*embed.Embed, error) {
 	if panel == nil || panel.WelcomeMessageEmbed == nil {
-		welcomeMessage, err := dbclient.Client.WelcomeMessages.Get(ctx, ticket.GuildId)
+		welcomeMessage, err := cmd.Worker().Services.Database.WelcomeMessages.Get(ctx, ticket.GuildId)
 		if err != nil {
 			return nil, err
 		}
@@ -150,23 +186,62 @@ func BuildWelcomeMessageEmbed(
 		// Replace variables
 		welcomeMessage = DoPlaceholderSubstitutions(ctx, welcomeMessage, cmd.Worker(), ticket, additionalPlaceholders)
 
-		return utils.BuildEmbedRaw(cmd.GetColour(customisation.Green), subject, welcomeMessage, nil, cmd.PremiumTier()), nil
+		e := utils.BuildEmbedRaw(cmd.GetColour(customisation.Green), subject, welcomeMessage, nil, cmd.PremiumTier())
+		if err := addReturningUserNotice(ctx, cmd.Worker(), e, ticket); err != nil {
+			return nil, err
+		}
+
+		return e, nil
 	} else {
-		data, err := dbclient.Client.Embeds.GetEmbed(ctx, *panel.WelcomeMessageEmbed)
+		data, err := cmd.Worker().Services.Database.Embeds.GetEmbed(ctx, *panel.WelcomeMessageEmbed)
 		if err != nil {
 			return nil, err
 		}
 
-		fields, err := dbclient.Client.EmbedFields.GetFieldsForEmbed(ctx, *panel.WelcomeMessageEmbed)
+		fields, err := cmd.Worker().Services.Database.EmbedFields.GetFieldsForEmbed(ctx, *panel.WelcomeMessageEmbed)
 		if err != nil {
 			return nil, err
 		}
 
 		e := BuildCustomEmbed(ctx, cmd.Worker(), ticket, data, fields, cmd.PremiumTier() == premium.None, additionalPlaceholders)
+		if err := addReturningUserNotice(ctx, cmd.Worker(), e, ticket); err != nil {
+			return nil, err
+		}
+
 		return e, nil
 	}
 }
 
+// addReturningUserNotice adds a field to the welcome message stating how many tickets the opener has previously
+// opened, if the guild has enabled it
+func addReturningUserNotice(ctx context.Context, worker *worker.Context, e *embed.Embed, ticket database.Ticket) error {
+	historyConfig, err := worker.Services.Database.TicketHistoryConfig.Get(ctx, ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	if !historyConfig.ReturningUserNotice {
+		return nil
+	}
+
+	previous, err := CountPreviousTickets(ctx, worker, ticket)
+	if err != nil {
+		return err
+	}
+
+	if previous == 0 {
+		return nil
+	}
+
+	noun := "tickets"
+	if previous == 1 {
+		noun = "ticket"
+	}
+
+	e.AddField("Returning User", fmt.Sprintf("%d previous %s", previous, noun), false)
+	return nil
+}
+
 func DoPlaceholderSubstitutions(
 	ctx context.Context,
 	message string,
@@ -238,11 +313,12 @@ func DoPlaceholderSubstitutions(
 
 func fetchCustomIntegrationPlaceholders(
 	ctx context.Context,
+	worker *worker.Context,
 	ticket database.Ticket,
 	formAnswers map[string]*string,
 ) (map[string]string, error) {
 	// Custom integrations
-	guildIntegrations, err := dbclient.Client.CustomIntegrationGuilds.GetGuildIntegrations(ctx, ticket.GuildId)
+	guildIntegrations, err := worker.Services.Database.CustomIntegrationGuilds.GetGuildIntegrations(ctx, ticket.GuildId)
 	if err != nil {
 		return nil, err
 	}
@@ -254,7 +330,7 @@ func fetchCustomIntegrationPlaceholders(
 			integrationIds[i] = integration.Id
 		}
 
-		placeholders, err := dbclient.Client.CustomIntegrationPlaceholders.GetAllActivatedInGuild(ctx, ticket.GuildId)
+		placeholders, err := worker.Services.Database.CustomIntegrationPlaceholders.GetAllActivatedInGuild(ctx, ticket.GuildId)
 		if err != nil {
 			return nil, err
 		}
@@ -269,12 +345,12 @@ func fetchCustomIntegrationPlaceholders(
 			placeholderMap[placeholder.IntegrationId] = append(placeholderMap[placeholder.IntegrationId], placeholder)
 		}
 
-		secrets, err := dbclient.Client.CustomIntegrationSecretValues.GetAll(ctx, ticket.GuildId, integrationIds)
+		secrets, err := worker.Services.Database.CustomIntegrationSecretValues.GetAll(ctx, ticket.GuildId, integrationIds)
 		if err != nil {
 			return nil, err
 		}
 
-		headers, err := dbclient.Client.CustomIntegrationHeaders.GetAll(ctx, integrationIds)
+		headers, err := worker.Services.Database.CustomIntegrationHeaders.GetAll(ctx, integrationIds)
 		if err != nil {
 			return nil, err
 		}
@@ -290,7 +366,7 @@ func fetchCustomIntegrationPlaceholders(
 			integrationSecrets := secrets[integration.Id]
 
 			group.Go(func() error {
-				response, err := integrations.Fetch(ctx, integration, ticket, integrationSecrets, headers[integration.Id], placeholderMap[integration.Id], formAnswers)
+				response, err := integrations.Fetch(ctx, worker.Services.IntegrationProxy, integration, ticket, integrationSecrets, headers[integration.Id], placeholderMap[integration.Id], formAnswers)
 				if err != nil {
 					return err
 				}
@@ -474,35 +550,35 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 		guild, _ := worker.GetGuild(ticket.GuildId)
 		return guild.Name
 	},
-	"open_tickets": func(ctx context.Context, _ *worker.Context, ticket database.Ticket) string {
-		open, _ := dbclient.Client.Tickets.GetGuildOpenTickets(ctx, ticket.GuildId)
+	"open_tickets": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
+		open, _ := worker.Services.Database.Tickets.GetGuildOpenTickets(ctx, ticket.GuildId)
 		return strconv.Itoa(len(open))
 	},
-	"total_tickets": func(ctx context.Context, _ *worker.Context, ticket database.Ticket) string {
-		count, _ := dbclient.Analytics.GetTotalTicketCount(ctx, ticket.GuildId)
+	"total_tickets": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
+		count, _ := worker.Services.Analytics.GetTotalTicketCount(ctx, ticket.GuildId)
 		return strconv.FormatUint(count, 10)
 	},
 	"user_open_tickets": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
-		count, _ := dbclient.Client.Tickets.GetOpenCountByUser(ctx, ticket.GuildId, ticket.UserId)
+		count, _ := worker.Services.Database.Tickets.GetOpenCountByUser(ctx, ticket.GuildId, ticket.UserId)
 		return strconv.Itoa(count)
 	},
 	"user_total_tickets": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
-		tickets, _ := dbclient.Client.Tickets.GetTotalCountByUser(ctx, ticket.GuildId, ticket.UserId)
+		tickets, _ := worker.Services.Database.Tickets.GetTotalCountByUser(ctx, ticket.GuildId, ticket.UserId)
 		return strconv.Itoa(tickets)
 	},
 	"ticket_limit": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
-		limit, _ := dbclient.Client.TicketLimit.Get(ctx, ticket.GuildId)
+		limit, _ := worker.Services.Database.TicketLimit.Get(ctx, ticket.GuildId)
 		return strconv.Itoa(int(limit))
 	},
-	"rating_count": func(ctx context.Context, _ *worker.Context, ticket database.Ticket) string {
+	"rating_count": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
 		ctx, cancel := context.WithTimeout(context.Background(), substitutionTimeout)
 		defer cancel()
 
-		ratingCount, _ := dbclient.Analytics.GetFeedbackCountGuild(ctx, ticket.GuildId)
+		ratingCount, _ := worker.Services.Analytics.GetFeedbackCountGuild(ctx, ticket.GuildId)
 		return strconv.FormatUint(ratingCount, 10)
 	},
-	"average_rating": func(ctx context.Context, _ *worker.Context, ticket database.Ticket) string {
-		average, _ := dbclient.Analytics.GetAverageFeedbackRatingGuild(ctx, ticket.GuildId)
+	"average_rating": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
+		average, _ := worker.Services.Analytics.GetAverageFeedbackRatingGuild(ctx, ticket.GuildId)
 		return fmt.Sprintf("%.1f", average)
 	},
 	"time": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
@@ -519,7 +595,7 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 	},
 	"first_response_time_weekly": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
 		if !worker.IsWhitelabel { // If whitelabel, the bot must be premium, so we don't need to do extra checks
-			premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+			premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
 			if err != nil {
 				sentry.Error(err)
 				return ""
@@ -530,7 +606,7 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 			}
 		}
 
-		data, err := dbclient.Analytics.GetFirstResponseTimeStats(ctx, ticket.GuildId)
+		data, err := worker.Services.Analytics.GetFirstResponseTimeStats(ctx, ticket.GuildId)
 		if err != nil {
 			sentry.Error(err)
 			return ""
@@ -540,7 +616,7 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 	},
 	"first_response_time_monthly": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
 		if !worker.IsWhitelabel { // If whitelabel, the bot must be premium, so we don't need to do extra checks
-			premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+			premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
 			if err != nil {
 				sentry.Error(err)
 				return ""
@@ -551,7 +627,7 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 			}
 		}
 
-		data, err := dbclient.Analytics.GetFirstResponseTimeStats(ctx, ticket.GuildId)
+		data, err := worker.Services.Analytics.GetFirstResponseTimeStats(ctx, ticket.GuildId)
 		if err != nil {
 			sentry.Error(err)
 			return ""
@@ -561,7 +637,7 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 	},
 	"first_response_time_all_time": func(ctx context.Context, worker *worker.Context, ticket database.Ticket) string {
 		if !worker.IsWhitelabel { // If whitelabel, the bot must be premium, so we don't need to do extra checks
-			premiumTier, err := utils.PremiumClient.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
+			premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, ticket.GuildId, true, worker.Token, worker.RateLimiter)
 			if err != nil {
 				sentry.Error(err)
 				return ""
@@ -575,7 +651,7 @@ var substitutions = map[string]PlaceholderSubstitutionFunc{
 		context, cancel := context.WithTimeout(context.Background(), time.Millisecond*1500)
 		defer cancel()
 
-		data, err := dbclient.Analytics.GetFirstResponseTimeStats(context, ticket.GuildId)
+		data, err := worker.Services.Analytics.GetFirstResponseTimeStats(context, ticket.GuildId)
 		if err != nil {
 			sentry.Error(err)
 			return ""
@@ -1,9 +1,12 @@
 package prometheus
 
 import (
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -39,8 +42,18 @@ var (
 	StreamMessages  = newHistogramVec("stream_messages", "stream")
 
 	CategoryUpdates = newCounter("category_updates")
+
+	AttachmentBytesPreserved = newCounterVec("attachment_bytes_preserved", "tier")
+
+	// Ticket time histograms are labelled by guild, so are only recorded for guilds that have been opted in, to bound
+	// the label cardinality
+	TicketFirstResponseTime = newHistogramVecWithBuckets("ticket_first_response_time", ticketTimeBuckets, "guild_id")
+	TicketResolutionTime    = newHistogramVecWithBuckets("ticket_resolution_time", ticketTimeBuckets, "guild_id")
 )
 
+// ticketTimeBuckets range from 1 minute to 1 week, in seconds
+var ticketTimeBuckets = []float64{60, 300, 900, 1800, 3600, 10800, 21600, 43200, 86400, 172800, 604800}
+
 func newCounter(name string) prometheus.Counter {
 	return promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: Namespace,
@@ -73,6 +86,15 @@ func newHistogramVec(name string, labels ...string) *prometheus.HistogramVec {
 	}, labels)
 }
 
+func newHistogramVecWithBuckets(name string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	return promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: Subsystem,
+		Name:      name,
+		Buckets:   buckets,
+	}, labels)
+}
+
 func newGauge(name string) prometheus.Gauge {
 	return promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: Namespace,
@@ -96,3 +118,24 @@ func LogCommand(command string) {
 func LogOnMessageTicketLookup(isTicket, cacheHit bool) {
 	OnMessageTicketLookup.WithLabelValues(strconv.FormatBool(isTicket), strconv.FormatBool(cacheHit)).Inc()
 }
+
+func LogAttachmentPreserved(tier string, size int) {
+	AttachmentBytesPreserved.WithLabelValues(tier).Add(float64(size))
+}
+
+// HasGuildHistograms returns whether the guild has been opted in to the per-guild ticket time histograms
+func HasGuildHistograms(guildId uint64) bool {
+	return slices.Contains(config.Conf.Prometheus.GuildHistograms, guildId)
+}
+
+func ObserveFirstResponseTime(guildId uint64, responseTime time.Duration) {
+	if HasGuildHistograms(guildId) {
+		TicketFirstResponseTime.WithLabelValues(strconv.FormatUint(guildId, 10)).Observe(responseTime.Seconds())
+	}
+}
+
+func ObserveResolutionTime(guildId uint64, resolutionTime time.Duration) {
+	if HasGuildHistograms(guildId) {
+		TicketResolutionTime.WithLabelValues(strconv.FormatUint(guildId, 10)).Observe(resolutionTime.Seconds())
+	}
+}
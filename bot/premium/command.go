@@ -11,7 +11,7 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
@@ -20,14 +20,14 @@ import (
 
 const PremiumStoreSku uint64 = 1274473638065606656
 
-func BuildKeyModal(guildId uint64) interaction.ModalResponseData {
+func BuildKeyModal(services *services.Services, guildId uint64) interaction.ModalResponseData {
 	return interaction.ModalResponseData{
 		CustomId: "premium_key_modal",
-		Title:    i18n.GetMessageFromGuild(guildId, i18n.MessagePremiumActivateKey),
+		Title:    i18n.GetMessageFromGuild(services, guildId, i18n.MessagePremiumActivateKey),
 		Components: []component.Component{
 			component.BuildLabel(component.Label{
-				Label:       i18n.GetMessageFromGuild(guildId, i18n.MessagePremiumKey),
-				Description: utils.Ptr(i18n.GetMessageFromGuild(guildId, i18n.MessagePremiumKey)),
+				Label:       i18n.GetMessageFromGuild(services, guildId, i18n.MessagePremiumKey),
+				Description: utils.Ptr(i18n.GetMessageFromGuild(services, guildId, i18n.MessagePremiumKey)),
 				Component: component.BuildInputText(component.InputText{
 					Style:       component.TextStyleShort,
 					CustomId:    "key",
@@ -51,8 +51,8 @@ func BuildPatreonSubscriptionFoundMessage(ctx registry.CommandContext, legacyEnt
 	}
 
 	var sku *model.SubscriptionSku
-	if err := dbclient.Client.WithTx(ctx, func(tx pgx.Tx) (err error) {
-		sku, err = dbclient.Client.SubscriptionSkus.GetSku(ctx, tx, legacyEntitlement.SkuId)
+	if err := ctx.Worker().Services.Database.WithTx(ctx, func(tx pgx.Tx) (err error) {
+		sku, err = ctx.Worker().Services.Database.SubscriptionSkus.GetSku(ctx, tx, legacyEntitlement.SkuId)
 		return
 	}); err != nil {
 		return command.MessageResponse{}, err
@@ -0,0 +1,111 @@
+package recording
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/eventforwarding"
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	StoreDisk  = "disk"
+	StoreRedis = "redis"
+)
+
+// Recorder saves received payloads to a Store. A nil Recorder records nothing, so callers do not need to check whether
+// recording is enabled.
+type Recorder struct {
+	store  Store
+	guilds map[uint64]struct{}
+}
+
+// NewRecorder creates a recorder which saves payloads for the given guilds, or for all payloads if no guilds are given
+func NewRecorder(store Store, guildIds []uint64) *Recorder {
+	guilds := make(map[uint64]struct{}, len(guildIds))
+	for _, guildId := range guildIds {
+		guilds[guildId] = struct{}{}
+	}
+
+	return &Recorder{
+		store:  store,
+		guilds: guilds,
+	}
+}
+
+// NewRecorderFromConfig returns the recorder configured by WORKER_RECORDING_*, or nil if recording is disabled
+func NewRecorderFromConfig(redisClient *redis.Client) (*Recorder, error) {
+	if !config.Conf.Recording.Enabled {
+		return nil, nil
+	}
+
+	store, err := StoreFromConfig(redisClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRecorder(store, config.Conf.Recording.Guilds), nil
+}
+
+func StoreFromConfig(redisClient *redis.Client) (Store, error) {
+	switch config.Conf.Recording.Store {
+	case StoreDisk:
+		return NewDiskStore(config.Conf.Recording.Directory), nil
+	case StoreRedis:
+		return NewRedisStore(redisClient, config.Conf.Recording.Expiry), nil
+	default:
+		return nil, fmt.Errorf("unknown recording store: %s", config.Conf.Recording.Store)
+	}
+}
+
+func (r *Recorder) RecordInteraction(payload eventforwarding.Interaction) {
+	if r == nil {
+		return
+	}
+
+	recording, err := NewInteractionRecording(payload)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	r.save(recording)
+}
+
+func (r *Recorder) RecordEvent(payload eventforwarding.Event) {
+	if r == nil {
+		return
+	}
+
+	recording, err := NewEventRecording(payload)
+	if err != nil {
+		sentry.Error(err)
+		return
+	}
+
+	r.save(recording)
+}
+
+func (r *Recorder) save(recording Recording) {
+	if len(r.guilds) > 0 {
+		if _, ok := r.guilds[recording.GuildId]; !ok {
+			return
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		if err := r.store.Save(ctx, recording); err != nil {
+			sentry.Error(err)
+			return
+		}
+
+		logrus.Infof("recorded %s %s (guild: %d)", recording.Kind, recording.Id, recording.GuildId)
+	}()
+}
@@ -0,0 +1,138 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/eventforwarding"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindInteraction Kind = "interaction"
+	KindEvent       Kind = "event"
+)
+
+// Recording is a sanitized copy of a payload received by the worker. The bot token is not stored, and any field named
+// "token" (such as interaction tokens) is removed from the payload, so the recording cannot be used to act as the bot.
+type Recording struct {
+	Id              string                      `json:"id"`
+	Kind            Kind                        `json:"kind"`
+	RecordedAt      time.Time                   `json:"recorded_at"`
+	GuildId         uint64                      `json:"guild_id,string,omitempty"`
+	BotId           uint64                      `json:"bot_id,string"`
+	IsWhitelabel    bool                        `json:"is_whitelabel"`
+	ShardId         int                         `json:"shard_id"`
+	InteractionType interaction.InteractionType `json:"interaction_type,omitempty"`
+	Payload         json.RawMessage             `json:"payload"`
+}
+
+func NewInteractionRecording(payload eventforwarding.Interaction) (Recording, error) {
+	sanitized, guildId, err := sanitize(payload.Event, false)
+	if err != nil {
+		return Recording{}, err
+	}
+
+	return Recording{
+		Id:              uuid.NewString(),
+		Kind:            KindInteraction,
+		RecordedAt:      time.Now(),
+		GuildId:         guildId,
+		BotId:           payload.BotId,
+		IsWhitelabel:    payload.IsWhitelabel,
+		InteractionType: payload.InteractionType,
+		Payload:         sanitized,
+	}, nil
+}
+
+func NewEventRecording(payload eventforwarding.Event) (Recording, error) {
+	sanitized, guildId, err := sanitize(payload.Event, true)
+	if err != nil {
+		return Recording{}, err
+	}
+
+	return Recording{
+		Id:           uuid.NewString(),
+		Kind:         KindEvent,
+		RecordedAt:   time.Now(),
+		GuildId:      guildId,
+		BotId:        payload.BotId,
+		IsWhitelabel: payload.IsWhitelabel,
+		ShardId:      payload.ShardId,
+		Payload:      sanitized,
+	}, nil
+}
+
+// Interaction rebuilds the forwarded interaction, using the given bot token in place of the stripped one
+func (r Recording) Interaction(token string) eventforwarding.Interaction {
+	return eventforwarding.Interaction{
+		BotToken:        token,
+		BotId:           r.BotId,
+		IsWhitelabel:    r.IsWhitelabel,
+		InteractionType: r.InteractionType,
+		Event:           r.Payload,
+	}
+}
+
+// Event rebuilds the forwarded gateway event, using the given bot token in place of the stripped one
+func (r Recording) Event(token string) eventforwarding.Event {
+	return eventforwarding.Event{
+		BotToken:     token,
+		BotId:        r.BotId,
+		IsWhitelabel: r.IsWhitelabel,
+		ShardId:      r.ShardId,
+		Event:        r.Payload,
+	}
+}
+
+// sanitize removes all token fields from the payload, returning the guild ID if one is present. Gateway events nest
+// the guild ID inside the d object, while interactions have it at the top level.
+func sanitize(payload []byte, isGatewayEvent bool) (json.RawMessage, uint64, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber() // Preserve snowflakes which are sent as numbers
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, 0, err
+	}
+
+	stripTokens(decoded)
+
+	var guildId uint64
+	if obj, ok := decoded.(map[string]any); ok {
+		if isGatewayEvent {
+			obj, _ = obj["d"].(map[string]any)
+		}
+
+		if raw, ok := obj["guild_id"].(string); ok {
+			guildId, _ = strconv.ParseUint(raw, 10, 64)
+		}
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return encoded, guildId, nil
+}
+
+func stripTokens(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, "token")
+
+		for _, child := range v {
+			stripTokens(child)
+		}
+	case []any:
+		for _, child := range v {
+			stripTokens(child)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package recording
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TicketsBot-cloud/common/eventforwarding"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteractionRecordingStripsTokens(t *testing.T) {
+	payload := eventforwarding.Interaction{
+		BotToken:        "bot-token",
+		BotId:           1,
+		InteractionType: interaction.InteractionTypeApplicationCommand,
+		Event:           json.RawMessage(`{"id":"2","guild_id":"3","token":"interaction-token","data":{"options":[{"name":"token","value":12345678901234567890}]}}`),
+	}
+
+	rec, err := NewInteractionRecording(payload)
+	require.NoError(t, err)
+	require.Equal(t, KindInteraction, rec.Kind)
+	require.Equal(t, uint64(3), rec.GuildId)
+	require.NotContains(t, string(rec.Payload), "interaction-token")
+	require.Contains(t, string(rec.Payload), "12345678901234567890")
+
+	replayed := rec.Interaction("replay-token")
+	require.Equal(t, "replay-token", replayed.BotToken)
+	require.Equal(t, payload.BotId, replayed.BotId)
+	require.Equal(t, payload.InteractionType, replayed.InteractionType)
+}
+
+func TestEventRecordingGuildId(t *testing.T) {
+	rec, err := NewEventRecording(eventforwarding.Event{
+		BotToken: "bot-token",
+		BotId:    1,
+		ShardId:  4,
+		Event:    json.RawMessage(`{"op":0,"t":"VOICE_SERVER_UPDATE","d":{"guild_id":"5","token":"voice-token"}}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), rec.GuildId)
+	require.NotContains(t, string(rec.Payload), "voice-token")
+	require.Equal(t, 4, rec.Event("").ShardId)
+}
+
+func TestDiskStore(t *testing.T) {
+	store := NewDiskStore(t.TempDir())
+
+	rec, err := NewEventRecording(eventforwarding.Event{
+		Event: json.RawMessage(`{"op":0,"t":"GUILD_DELETE","d":{"id":"5"}}`),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Save(t.Context(), rec))
+
+	loaded, err := store.Load(t.Context(), rec.Id)
+	require.NoError(t, err)
+	require.Equal(t, rec.Id, loaded.Id)
+	require.JSONEq(t, string(rec.Payload), string(loaded.Payload))
+
+	_, err = store.Load(t.Context(), "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,110 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+)
+
+var ErrNotFound = errors.New("recording not found")
+
+type Store interface {
+	Save(ctx context.Context, recording Recording) error
+	Load(ctx context.Context, id string) (Recording, error)
+}
+
+// DiskStore writes each recording to its own JSON file in Directory
+type DiskStore struct {
+	Directory string
+}
+
+var _ Store = (*DiskStore)(nil)
+
+func NewDiskStore(directory string) *DiskStore {
+	return &DiskStore{
+		Directory: directory,
+	}
+}
+
+func (s *DiskStore) Save(ctx context.Context, recording Recording) error {
+	if err := os.MkdirAll(s.Directory, 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(recording.Id), data, 0o600)
+}
+
+func (s *DiskStore) Load(ctx context.Context, id string) (Recording, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Recording{}, ErrNotFound
+		}
+
+		return Recording{}, err
+	}
+
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return Recording{}, err
+	}
+
+	return recording, nil
+}
+
+func (s *DiskStore) path(id string) string {
+	return filepath.Join(s.Directory, fmt.Sprintf("%s.json", filepath.Base(id)))
+}
+
+// RedisStore keeps recordings in Redis, so they can be retrieved from any worker instance until they expire
+type RedisStore struct {
+	Client *redis.Client
+	Expiry time.Duration
+}
+
+var _ Store = (*RedisStore)(nil)
+
+func NewRedisStore(client *redis.Client, expiry time.Duration) *RedisStore {
+	return &RedisStore{
+		Client: client,
+		Expiry: expiry,
+	}
+}
+
+func (s *RedisStore) Save(ctx context.Context, recording Recording) error {
+	data, err := json.Marshal(recording)
+	if err != nil {
+		return err
+	}
+
+	return s.Client.SetRecording(ctx, recording.Id, data, s.Expiry)
+}
+
+func (s *RedisStore) Load(ctx context.Context, id string) (Recording, error) {
+	data, ok, err := s.Client.GetRecording(ctx, id)
+	if err != nil {
+		return Recording{}, err
+	}
+
+	if !ok {
+		return Recording{}, ErrNotFound
+	}
+
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return Recording{}, err
+	}
+
+	return recording, nil
+}
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// BulkAction is a bulk action which has been previewed, and is waiting for the staff member to confirm it
+type BulkAction struct {
+	GuildId   uint64 `json:"guild_id,string"`
+	UserId    uint64 `json:"user_id,string"`
+	Action    string `json:"action"`
+	TicketIds []int  `json:"ticket_ids"`
+	// Reason is the close reason, for bulk closes
+	Reason *string `json:"reason,omitempty"`
+	// TargetId is the claimer, panel or label which the tickets should be claimed by, moved to or labelled with
+	TargetId uint64 `json:"target_id,string,omitempty"`
+}
+
+const (
+	// bulkActionExpiry is how long staff have to confirm a bulk action after previewing it
+	bulkActionExpiry = time.Minute * 10
+	// bulkActionLockExpiry bounds how long a guild is blocked from starting another bulk action if a worker dies
+	// partway through one
+	bulkActionLockExpiry = time.Minute * 30
+)
+
+func (c *Client) SetBulkAction(ctx context.Context, id string, action BulkAction) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+
+	return c.Set(ctx, buildBulkActionKey(id), data, bulkActionExpiry).Err()
+}
+
+// TakeBulkAction fetches and removes the pending bulk action, so that it can only be confirmed once. False is returned
+// if it has expired, been cancelled, or already been taken.
+func (c *Client) TakeBulkAction(ctx context.Context, id string) (BulkAction, bool, error) {
+	var action BulkAction
+	ok, err := c.getJson(ctx, buildBulkActionKey(id), &action)
+	if err != nil || !ok {
+		return action, false, err
+	}
+
+	deleted, err := c.Del(ctx, buildBulkActionKey(id)).Result()
+	if err != nil {
+		return action, false, err
+	}
+
+	return action, deleted == 1, nil
+}
+
+// TakeBulkActionLock marks a bulk action as running in the guild, returning false if one already is
+func (c *Client) TakeBulkActionLock(ctx context.Context, guildId uint64) (bool, error) {
+	return c.SetNX(ctx, buildBulkActionLockKey(guildId), 1, bulkActionLockExpiry).Result()
+}
+
+func (c *Client) ReleaseBulkActionLock(ctx context.Context, guildId uint64) error {
+	return c.Del(ctx, buildBulkActionLockKey(guildId)).Err()
+}
+
+func buildBulkActionKey(id string) string {
+	return fmt.Sprintf("tickets:bulk:%s", id)
+}
+
+func buildBulkActionLockKey(guildId uint64) string {
+	return fmt.Sprintf("tickets:bulk:lock:%d", guildId)
+}
@@ -0,0 +1,49 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeBulkActionOnce(t *testing.T) {
+	client := testharness.New(t).Services.Redis
+
+	action := redis.BulkAction{
+		GuildId:   1,
+		UserId:    2,
+		Action:    "close",
+		TicketIds: []int{3, 4},
+	}
+
+	require.NoError(t, client.SetBulkAction(t.Context(), "id", action))
+
+	taken, ok, err := client.TakeBulkAction(t.Context(), "id")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, action, taken)
+
+	_, ok, err = client.TakeBulkAction(t.Context(), "id")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestBulkActionLock(t *testing.T) {
+	client := testharness.New(t).Services.Redis
+
+	locked, err := client.TakeBulkActionLock(t.Context(), 1)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	locked, err = client.TakeBulkActionLock(t.Context(), 1)
+	require.NoError(t, err)
+	require.False(t, locked)
+
+	require.NoError(t, client.ReleaseBulkActionLock(t.Context(), 1))
+
+	locked, err = client.TakeBulkActionLock(t.Context(), 1)
+	require.NoError(t, err)
+	require.True(t, locked)
+}
@@ -8,7 +8,7 @@ import (
 
 const channelRefetchBackoff = time.Minute * 5
 
-func TakeChannelRefetchToken(ctx context.Context, guildId uint64) (bool, error) {
+func (c *Client) TakeChannelRefetchToken(ctx context.Context, guildId uint64) (bool, error) {
 	key := fmt.Sprintf("channelrefetch:%d", guildId)
-	return Client.SetNX(ctx, key, 1, channelRefetchBackoff).Result()
+	return c.SetNX(ctx, key, 1, channelRefetchBackoff).Result()
 }
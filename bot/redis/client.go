@@ -7,24 +7,27 @@ import (
 	redsyncredis "github.com/go-redsync/redsync/v4/redis/goredis/v8"
 )
 
-var (
-	Client *redis.Client
-	rs     *redsync.Redsync
-)
+// Client is the Redis connection, along with the worker's own keys and the locks which are taken through it
+type Client struct {
+	*redis.Client
+	rs *redsync.Redsync
+}
 
 var ErrNil = redis.Nil
 
-func Connect() error {
-	Client = redis.NewClient(&redis.Options{
+func Connect() *Client {
+	return NewClient(redis.NewClient(&redis.Options{
 		Network:      "tcp",
 		Addr:         config.Conf.Redis.Address,
 		Password:     config.Conf.Redis.Password,
 		PoolSize:     config.Conf.Redis.Threads,
 		MinIdleConns: config.Conf.Redis.Threads,
-	})
-
-	pool := redsyncredis.NewPool(Client)
-	rs = redsync.New(pool)
+	}))
+}
 
-	return nil
+func NewClient(client *redis.Client) *Client {
+	return &Client{
+		Client: client,
+		rs:     redsync.New(redsyncredis.NewPool(client)),
+	}
 }
@@ -0,0 +1,277 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CloseOutboxEntry records the steps of a ticket close which still need to be carried out after the ticket has been
+// marked as closed in the database
+type CloseOutboxEntry struct {
+	// Id identifies the close, so that messages already sent for it are not sent again when it is retried, while a
+	// later close of the same ticket after it has been reopened sends them again
+	Id       string `json:"id"`
+	GuildId  uint64 `json:"guild_id,string"`
+	TicketId int    `json:"ticket_id"`
+	ClosedBy uint64 `json:"closed_by,string"`
+	// ClosedByName is used in the audit log reason when closing the channel
+	ClosedByName string    `json:"closed_by_name"`
+	Reason       *string   `json:"reason,omitempty"`
+	Steps        []string  `json:"steps"`
+	Attempts     int       `json:"attempts"`
+	LastError    string    `json:"last_error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// Abandoned is set once the entry has run out of attempts. Abandoned entries are no longer retried, but are kept
+	// so that they can be inspected.
+	Abandoned bool `json:"abandoned"`
+	// Spam is set if the ticket was closed as spam, in which case no transcript was stored and the opener is not sent
+	// a DM
+	Spam bool `json:"spam,omitempty"`
+}
+
+const (
+	closeOutboxScheduleKey = "tickets:closeoutbox:schedule"
+	closeOutboxIndexKey    = "tickets:closeoutbox:index"
+	closeOutboxExpiry      = time.Hour * 24 * 7
+	// closeOutboxListBatchSize is how many entries are fetched at once when listing the outbox
+	closeOutboxListBatchSize = 100
+)
+
+// claimCloseOutboxScript pushes an entry's next attempt back, only if it is still scheduled for the expected time, so
+// that only one worker processes each attempt
+var claimCloseOutboxScript = redis.NewScript(`
+local current = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if current ~= ARGV[2] then
+	return 0
+end
+
+redis.call("ZADD", KEYS[1], ARGV[3], ARGV[1])
+return 1
+`)
+
+// SaveCloseOutboxEntry stores the entry, scheduling its next attempt for nextAttempt. Abandoned entries are stored but
+// not scheduled.
+func (c *Client) SaveCloseOutboxEntry(ctx context.Context, entry CloseOutboxEntry, nextAttempt time.Time) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	member := buildCloseOutboxMember(entry.GuildId, entry.TicketId)
+
+	tx := c.TxPipeline()
+	tx.Set(ctx, buildCloseOutboxKey(entry.GuildId, entry.TicketId), data, closeOutboxExpiry)
+	tx.ZAdd(ctx, closeOutboxIndexKey, &redis.Z{
+		Score:  float64(entry.CreatedAt.Unix()),
+		Member: member,
+	})
+
+	if entry.Abandoned {
+		tx.ZRem(ctx, closeOutboxScheduleKey, member)
+	} else {
+		tx.ZAdd(ctx, closeOutboxScheduleKey, &redis.Z{
+			Score:  float64(nextAttempt.Unix()),
+			Member: member,
+		})
+	}
+
+	_, err = tx.Exec(ctx)
+	return err
+}
+
+func (c *Client) GetCloseOutboxEntry(ctx context.Context, guildId uint64, ticketId int) (CloseOutboxEntry, bool, error) {
+	var entry CloseOutboxEntry
+	ok, err := c.getJson(ctx, buildCloseOutboxKey(guildId, ticketId), &entry)
+	return entry, ok, err
+}
+
+// DeleteCloseOutboxEntry removes the entry once all of its steps have completed
+func (c *Client) DeleteCloseOutboxEntry(ctx context.Context, guildId uint64, ticketId int) error {
+	member := buildCloseOutboxMember(guildId, ticketId)
+
+	tx := c.TxPipeline()
+	tx.Del(ctx, buildCloseOutboxKey(guildId, ticketId))
+	tx.ZRem(ctx, closeOutboxScheduleKey, member)
+	tx.ZRem(ctx, closeOutboxIndexKey, member)
+
+	_, err := tx.Exec(ctx)
+	return err
+}
+
+type ScheduledCloseOutboxEntry struct {
+	GuildId     uint64
+	TicketId    int
+	NextAttempt time.Time
+}
+
+// GetDueCloseOutboxEntries returns up to limit entries whose next attempt was scheduled at or before now
+func (c *Client) GetDueCloseOutboxEntries(ctx context.Context, now time.Time, limit int64) ([]ScheduledCloseOutboxEntry, error) {
+	res, err := c.ZRangeByScoreWithScores(ctx, closeOutboxScheduleKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: limit,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ScheduledCloseOutboxEntry, 0, len(res))
+	for _, z := range res {
+		guildId, ticketId, err := parseCloseOutboxMember(z.Member)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, ScheduledCloseOutboxEntry{
+			GuildId:     guildId,
+			TicketId:    ticketId,
+			NextAttempt: time.Unix(int64(z.Score), 0).UTC(),
+		})
+	}
+
+	return entries, nil
+}
+
+// ClaimCloseOutboxEntry pushes the entry's next attempt back to retryAt, in case the worker processing it dies,
+// returning false if another worker has already claimed this attempt
+func (c *Client) ClaimCloseOutboxEntry(ctx context.Context, entry ScheduledCloseOutboxEntry, retryAt time.Time) (bool, error) {
+	res, err := claimCloseOutboxScript.Run(
+		ctx,
+		c.Client,
+		[]string{closeOutboxScheduleKey},
+		buildCloseOutboxMember(entry.GuildId, entry.TicketId),
+		strconv.FormatInt(entry.NextAttempt.Unix(), 10),
+		retryAt.Unix(),
+	).Result()
+	if err != nil {
+		return false, err
+	}
+
+	i, ok := res.(int64)
+	if !ok {
+		return false, fmt.Errorf("close outbox claim returned %v, not an int64", res)
+	}
+
+	return i == 1, nil
+}
+
+// ListCloseOutboxEntries returns up to limit outstanding entries, oldest first. If guildId is non-zero, only entries
+// for that guild are returned. Index members whose entry has expired are pruned.
+func (c *Client) ListCloseOutboxEntries(ctx context.Context, guildId uint64, limit int) ([]CloseOutboxEntry, error) {
+	members, err := c.ZRange(ctx, closeOutboxIndexKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	type indexEntry struct {
+		guildId  uint64
+		ticketId int
+	}
+
+	var indexed []indexEntry
+	var keys []string
+	for _, member := range members {
+		memberGuildId, ticketId, err := parseCloseOutboxMember(member)
+		if err != nil {
+			return nil, err
+		}
+
+		if guildId != 0 && memberGuildId != guildId {
+			continue
+		}
+
+		indexed = append(indexed, indexEntry{memberGuildId, ticketId})
+		keys = append(keys, buildCloseOutboxKey(memberGuildId, ticketId))
+	}
+
+	var entries []CloseOutboxEntry
+	for start := 0; start < len(keys) && len(entries) < limit; start += closeOutboxListBatchSize {
+		end := min(start+closeOutboxListBatchSize, len(keys))
+
+		values, err := c.MGet(ctx, keys[start:end]...).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for i, value := range values {
+			raw, ok := value.(string)
+			if !ok {
+				if err := c.DeleteCloseOutboxEntry(ctx, indexed[start+i].guildId, indexed[start+i].ticketId); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			var entry CloseOutboxEntry
+			if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, entry)
+			if len(entries) >= limit {
+				break
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// MarkCloseOutboxStepSent records that the message of the step has been sent for the entry
+func (c *Client) MarkCloseOutboxStepSent(ctx context.Context, entryId, step string) error {
+	return c.Set(ctx, buildCloseOutboxSentKey(entryId, step), 1, closeOutboxExpiry).Err()
+}
+
+// IsCloseOutboxStepSent returns whether the message of the step has already been sent for the entry
+func (c *Client) IsCloseOutboxStepSent(ctx context.Context, entryId, step string) (bool, error) {
+	count, err := c.Exists(ctx, buildCloseOutboxSentKey(entryId, step)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func buildCloseOutboxKey(guildId uint64, ticketId int) string {
+	return fmt.Sprintf("tickets:closeoutbox:%d:%d", guildId, ticketId)
+}
+
+func buildCloseOutboxSentKey(entryId, step string) string {
+	return fmt.Sprintf("tickets:closeoutbox:sent:%s:%s", entryId, step)
+}
+
+func buildCloseOutboxMember(guildId uint64, ticketId int) string {
+	return fmt.Sprintf("%d:%d", guildId, ticketId)
+}
+
+func parseCloseOutboxMember(member interface{}) (uint64, int, error) {
+	s, ok := member.(string)
+	if !ok {
+		return 0, 0, fmt.Errorf("close outbox member %v was not a string", member)
+	}
+
+	guildRaw, ticketRaw, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("close outbox member %s was malformed", s)
+	}
+
+	guildId, err := strconv.ParseUint(guildRaw, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ticketId, err := strconv.Atoi(ticketRaw)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return guildId, ticketId, nil
+}
@@ -0,0 +1,69 @@
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCloseOutboxEntries(t *testing.T) {
+	client := testharness.New(t).Services.Redis
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	for i, key := range []struct {
+		guildId  uint64
+		ticketId int
+	}{{1, 1}, {2, 1}, {1, 2}, {1, 3}} {
+		entry := redis.CloseOutboxEntry{
+			Id:        "entry",
+			GuildId:   key.guildId,
+			TicketId:  key.ticketId,
+			Steps:     []string{"channel"},
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+		}
+
+		require.NoError(t, client.SaveCloseOutboxEntry(t.Context(), entry, time.Now()))
+	}
+
+	// The entry has expired, but is still in the index
+	require.NoError(t, client.Del(t.Context(), "tickets:closeoutbox:1:2").Err())
+
+	entries, err := client.ListCloseOutboxEntries(t.Context(), 1, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 1, entries[0].TicketId)
+	require.Equal(t, 3, entries[1].TicketId)
+
+	entries, err = client.ListCloseOutboxEntries(t.Context(), 0, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, uint64(1), entries[0].GuildId)
+	require.Equal(t, uint64(2), entries[1].GuildId)
+
+	// The expired entry was pruned from the index
+	scheduled, err := client.GetDueCloseOutboxEntries(t.Context(), time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, scheduled, 3)
+}
+
+func TestCloseOutboxStepSent(t *testing.T) {
+	client := testharness.New(t).Services.Redis
+
+	sent, err := client.IsCloseOutboxStepSent(t.Context(), "first", "archive_message")
+	require.NoError(t, err)
+	require.False(t, sent)
+
+	require.NoError(t, client.MarkCloseOutboxStepSent(t.Context(), "first", "archive_message"))
+
+	sent, err = client.IsCloseOutboxStepSent(t.Context(), "first", "archive_message")
+	require.NoError(t, err)
+	require.True(t, sent)
+
+	// A later close of the same ticket has its own entry
+	sent, err = client.IsCloseOutboxStepSent(t.Context(), "second", "archive_message")
+	require.NoError(t, err)
+	require.False(t, sent)
+}
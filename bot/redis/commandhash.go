@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// SetCommandHash stores the hash of the commands which were last deployed for the application
+func (c *Client) SetCommandHash(ctx context.Context, applicationId uint64, hash string) error {
+	return c.Set(ctx, buildCommandHashKey(applicationId), hash, 0).Err()
+}
+
+func (c *Client) GetCommandHash(ctx context.Context, applicationId uint64) (string, bool, error) {
+	hash, err := c.Get(ctx, buildCommandHashKey(applicationId)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	return hash, true, nil
+}
+
+func buildCommandHashKey(applicationId uint64) string {
+	return fmt.Sprintf("tickets:commands:hash:%d", applicationId)
+}
@@ -7,8 +7,8 @@ import (
 	"time"
 )
 
-func LoadCommandIds(botId uint64) (map[string]uint64, error) {
-	data, err := Client.HGetAll(context.Background(), buildCommandIdKey(botId)).Result()
+func (c *Client) LoadCommandIds(botId uint64) (map[string]uint64, error) {
+	data, err := c.HGetAll(context.Background(), buildCommandIdKey(botId)).Result()
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ func LoadCommandIds(botId uint64) (map[string]uint64, error) {
 	return parsed, nil
 }
 
-func StoreCommandIds(botId uint64, commandIds map[string]uint64) error {
+func (c *Client) StoreCommandIds(botId uint64, commandIds map[string]uint64) error {
 	key := buildCommandIdKey(botId)
 
 	mapped := make(map[string]interface{})
@@ -34,7 +34,7 @@ func StoreCommandIds(botId uint64, commandIds map[string]uint64) error {
 		mapped[name] = id
 	}
 
-	tx := Client.TxPipeline()
+	tx := c.TxPipeline()
 	tx.HSet(context.Background(), key, mapped)
 	tx.Expire(context.Background(), key, time.Minute*5)
 
@@ -14,10 +14,10 @@ var ErrNotCached = errors.New("channel not cached")
 
 // Returns nil if we cannot create a channel
 // Returns ErrNotCached if not cached
-func GetDMChannel(userId, botId uint64) (*uint64, error) {
+func (c *Client) GetDMChannel(userId, botId uint64) (*uint64, error) {
 	key := fmt.Sprintf("dmchannel:%d:%d", botId, userId)
 
-	res, err := Client.Get(utils.DefaultContext(), key).Result()
+	res, err := c.Get(utils.DefaultContext(), key).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, ErrNotCached
@@ -38,12 +38,12 @@ func GetDMChannel(userId, botId uint64) (*uint64, error) {
 	return &parsed, nil
 }
 
-func StoreNullDMChannel(userId, botId uint64) error {
+func (c *Client) StoreNullDMChannel(userId, botId uint64) error {
 	key := fmt.Sprintf("dmchannel:%d:%d", botId, userId)
-	return Client.Set(utils.DefaultContext(), key, "null", time.Hour*6).Err()
+	return c.Set(utils.DefaultContext(), key, "null", time.Hour*6).Err()
 }
 
-func StoreDMChannel(userId, channelId, botId uint64) error {
+func (c *Client) StoreDMChannel(userId, channelId, botId uint64) error {
 	key := fmt.Sprintf("dmchannel:%d:%d", botId, userId)
-	return Client.Set(utils.DefaultContext(), key, strconv.FormatUint(channelId, 10), 0).Err()
+	return c.Set(utils.DefaultContext(), key, strconv.FormatUint(channelId, 10), 0).Err()
 }
@@ -6,10 +6,10 @@ import (
 	"time"
 )
 
-func GetExperimentRolloutPercentage(ctx context.Context, experiment string) (int, error) {
+func (c *Client) GetExperimentRolloutPercentage(ctx context.Context, experiment string) (int, error) {
 	key := fmt.Sprintf("experiment:rollout:%s", experiment)
 
-	percentage, err := Client.Get(ctx, key).Int()
+	percentage, err := c.Get(ctx, key).Int()
 	if err != nil {
 		return 0, err
 	}
@@ -17,7 +17,7 @@ func GetExperimentRolloutPercentage(ctx context.Context, experiment string) (int
 	return percentage, nil
 }
 
-func SetExperimentRolloutPercentage(ctx context.Context, experiment string, percentage int) error {
+func (c *Client) SetExperimentRolloutPercentage(ctx context.Context, experiment string, percentage int) error {
 	key := fmt.Sprintf("experiment:rollout:%s", experiment)
-	return Client.Set(ctx, key, percentage, 5*time.Minute).Err()
+	return c.Set(ctx, key, percentage, 5*time.Minute).Err()
 }
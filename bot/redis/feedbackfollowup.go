@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type FeedbackFollowUp struct {
+	ChannelId  uint64     `json:"channel_id,string"`
+	MessageId  uint64     `json:"message_id,string"`
+	Rating     uint8      `json:"rating"`
+	Resolved   bool       `json:"resolved"`
+	ResolvedBy *uint64    `json:"resolved_by,string,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// feedbackFollowUpExpiry bounds how long follow-ups are tracked for after the alert was posted
+const feedbackFollowUpExpiry = time.Hour * 24 * 90
+
+func (c *Client) GetFeedbackFollowUp(ctx context.Context, guildId uint64, ticketId int) (FeedbackFollowUp, bool, error) {
+	var followUp FeedbackFollowUp
+	ok, err := c.getJson(ctx, buildFeedbackFollowUpKey(guildId, ticketId), &followUp)
+	return followUp, ok, err
+}
+
+// SetFeedbackFollowUp stores the follow-up, keeping the expiry of any existing follow-up for the ticket
+func (c *Client) SetFeedbackFollowUp(ctx context.Context, guildId uint64, ticketId int, followUp FeedbackFollowUp) error {
+	data, err := json.Marshal(followUp)
+	if err != nil {
+		return err
+	}
+
+	key := buildFeedbackFollowUpKey(guildId, ticketId)
+
+	ttl, err := c.TTL(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if ttl <= 0 {
+		ttl = feedbackFollowUpExpiry
+	}
+
+	return c.Set(ctx, key, data, ttl).Err()
+}
+
+// MarkClaimerThanked records that the claimer has been thanked for the ticket, returning false if they already had been
+func (c *Client) MarkClaimerThanked(ctx context.Context, guildId uint64, ticketId int) (bool, error) {
+	return c.SetNX(ctx, buildClaimerThankedKey(guildId, ticketId), 1, feedbackFollowUpExpiry).Result()
+}
+
+func buildFeedbackFollowUpKey(guildId uint64, ticketId int) string {
+	return fmt.Sprintf("tickets:feedbackfollowup:%d:%d", guildId, ticketId)
+}
+
+func buildClaimerThankedKey(guildId uint64, ticketId int) string {
+	return fmt.Sprintf("tickets:feedbackfollowup:thanked:%d:%d", guildId, ticketId)
+}
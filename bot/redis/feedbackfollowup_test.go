@@ -0,0 +1,54 @@
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkClaimerThanked(t *testing.T) {
+	client := testharness.New(t).Services.Redis
+
+	first, err := client.MarkClaimerThanked(t.Context(), 1, 1)
+	require.NoError(t, err)
+	require.True(t, first)
+
+	second, err := client.MarkClaimerThanked(t.Context(), 1, 1)
+	require.NoError(t, err)
+	require.False(t, second)
+
+	other, err := client.MarkClaimerThanked(t.Context(), 1, 2)
+	require.NoError(t, err)
+	require.True(t, other)
+}
+
+func TestSetFeedbackFollowUpKeepsExpiry(t *testing.T) {
+	h := testharness.New(t)
+	client := h.Services.Redis
+
+	followUp := redis.FeedbackFollowUp{
+		ChannelId: 10,
+		MessageId: 20,
+		Rating:    1,
+	}
+
+	require.NoError(t, client.SetFeedbackFollowUp(t.Context(), 1, 1, followUp))
+
+	key := h.Redis.Keys()[0]
+	require.NoError(t, client.Expire(t.Context(), key, time.Hour).Err())
+
+	followUp.Resolved = true
+	require.NoError(t, client.SetFeedbackFollowUp(t.Context(), 1, 1, followUp))
+
+	ttl, err := client.TTL(t.Context(), key).Result()
+	require.NoError(t, err)
+	require.LessOrEqual(t, ttl, time.Hour)
+
+	stored, ok, err := client.GetFeedbackFollowUp(t.Context(), 1, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, followUp, stored)
+}
@@ -12,9 +12,9 @@ const IntegrationRoleCacheExpiry = time.Minute * 5
 
 var ErrIntegrationRoleNotCached = errors.New("integration role not cached")
 
-func GetIntegrationRole(ctx context.Context, guildId, botId uint64) (uint64, error) {
+func (c *Client) GetIntegrationRole(ctx context.Context, guildId, botId uint64) (uint64, error) {
 	key := fmt.Sprintf("integrationrole:%d:%d", guildId, botId)
-	res, err := Client.Get(ctx, key).Result()
+	res, err := c.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, ErrNil) {
 			return 0, ErrIntegrationRoleNotCached
@@ -31,12 +31,12 @@ func GetIntegrationRole(ctx context.Context, guildId, botId uint64) (uint64, err
 	return roleId, nil
 }
 
-func SetIntegrationRole(ctx context.Context, guildId, botId, roleId uint64) error {
+func (c *Client) SetIntegrationRole(ctx context.Context, guildId, botId, roleId uint64) error {
 	key := fmt.Sprintf("integrationrole:%d:%d", guildId, botId)
-	return Client.Set(ctx, key, roleId, IntegrationRoleCacheExpiry).Err()
+	return c.Set(ctx, key, roleId, IntegrationRoleCacheExpiry).Err()
 }
 
-func DeleteIntegrationRole(ctx context.Context, guildId, botId uint64) error {
+func (c *Client) DeleteIntegrationRole(ctx context.Context, guildId, botId uint64) error {
 	key := fmt.Sprintf("integrationrole:%d:%d", guildId, botId)
-	return Client.Del(ctx, key).Err()
+	return c.Del(ctx, key).Err()
 }
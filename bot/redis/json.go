@@ -0,0 +1,25 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// getJson unmarshals the value stored at key into v, returning false if there is no value
+func (c *Client) getJson(ctx context.Context, key string, v any) (bool, error) {
+	data, err := c.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, ErrNil) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
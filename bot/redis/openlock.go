@@ -16,8 +16,8 @@ const TicketOpenLockExpiry = time.Second * 3
 
 var ErrLockExpired = redsync.ErrLockAlreadyExpired
 
-func TakeTicketOpenLock(ctx context.Context, guildId uint64) (Mutex, error) {
-	mu := rs.NewMutex(fmt.Sprintf("tickets:openlock:%d", guildId), redsync.WithExpiry(TicketOpenLockExpiry))
+func (c *Client) TakeTicketOpenLock(ctx context.Context, guildId uint64) (Mutex, error) {
+	mu := c.rs.NewMutex(fmt.Sprintf("tickets:openlock:%d", guildId), redsync.WithExpiry(TicketOpenLockExpiry))
 	if err := mu.LockContext(ctx); err != nil {
 		return nil, err
 	}
@@ -8,10 +8,10 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-func GetRecacheCooldown(guildId uint64) (bool, time.Time) {
+func (c *Client) GetRecacheCooldown(guildId uint64) (bool, time.Time) {
 	key := fmt.Sprintf("admin:recache:%d", guildId)
 
-	isOnCooldown, err := Client.Get(utils.DefaultContext(), key).Bool()
+	isOnCooldown, err := c.Get(utils.DefaultContext(), key).Bool()
 	if err != nil {
 		if err == redis.Nil {
 			return false, time.Time{}
@@ -21,7 +21,7 @@ func GetRecacheCooldown(guildId uint64) (bool, time.Time) {
 	}
 
 	if isOnCooldown {
-		res, err := Client.TTL(utils.DefaultContext(), key).Result()
+		res, err := c.TTL(utils.DefaultContext(), key).Result()
 		if err != nil {
 			return false, time.Time{}
 		}
@@ -36,9 +36,9 @@ func GetRecacheCooldown(guildId uint64) (bool, time.Time) {
 	return false, time.Time{}
 }
 
-func SetRecacheCooldown(guildId uint64, duration time.Duration) error {
+func (c *Client) SetRecacheCooldown(guildId uint64, duration time.Duration) error {
 	key := fmt.Sprintf("admin:recache:%d", guildId)
 
 	// Set the cooldown to true and set the expiration time
-	return Client.Set(utils.DefaultContext(), key, true, duration).Err()
+	return c.Set(utils.DefaultContext(), key, true, duration).Err()
 }
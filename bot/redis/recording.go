@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func (c *Client) SetRecording(ctx context.Context, id string, data []byte, expiry time.Duration) error {
+	return c.Set(ctx, buildRecordingKey(id), data, expiry).Err()
+}
+
+func (c *Client) GetRecording(ctx context.Context, id string) ([]byte, bool, error) {
+	data, err := c.Get(ctx, buildRecordingKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+func buildRecordingKey(id string) string {
+	return fmt.Sprintf("tickets:recording:%s", id)
+}
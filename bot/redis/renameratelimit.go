@@ -11,10 +11,10 @@ const (
 	renameRatelimitTokens = 2
 )
 
-func TakeRenameRatelimit(ctx context.Context, channelId uint64) (bool, error) {
+func (c *Client) TakeRenameRatelimit(ctx context.Context, channelId uint64) (bool, error) {
 	key := fmt.Sprintf("tickets:rename_ratelimit:%d", channelId)
 
-	tx := Client.TxPipeline()
+	tx := c.TxPipeline()
 	tx.SetNX(ctx, key, "0", renameRatelimitExpiry)
 	incr := tx.Incr(ctx, key)
 
@@ -11,9 +11,9 @@ const TicketStatusCacheExpiry = time.Second * 90
 
 var ErrTicketStatusNotCached = errors.New("ticket status not cached")
 
-func IsTicketChannel(ctx context.Context, channelId uint64) (bool, error) {
+func (c *Client) IsTicketChannel(ctx context.Context, channelId uint64) (bool, error) {
 	key := fmt.Sprintf("isticket:%d", channelId)
-	res, err := Client.Get(ctx, key).Result()
+	res, err := c.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, ErrNil) {
 			return false, ErrTicketStatusNotCached
@@ -25,7 +25,7 @@ func IsTicketChannel(ctx context.Context, channelId uint64) (bool, error) {
 	return res == "1", nil
 }
 
-func SetTicketChannelStatus(ctx context.Context, channelId uint64, isTicket bool) error {
+func (c *Client) SetTicketChannelStatus(ctx context.Context, channelId uint64, isTicket bool) error {
 	key := fmt.Sprintf("isticket:%d", channelId)
 
 	var value string
@@ -35,5 +35,5 @@ func SetTicketChannelStatus(ctx context.Context, channelId uint64, isTicket bool
 		value = "0"
 	}
 
-	return Client.Set(ctx, key, value, TicketStatusCacheExpiry).Err()
+	return c.Set(ctx, key, value, TicketStatusCacheExpiry).Err()
 }
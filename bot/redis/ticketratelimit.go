@@ -35,10 +35,10 @@ return success
 var TicketOpenLimit = 10
 var TicketOpenLimitInterval = time.Second * 30
 
-func TakeTicketRateLimitToken(client *redis.Client, guildId uint64) (bool, error) {
+func (c *Client) TakeTicketRateLimitToken(guildId uint64) (bool, error) {
 	key := fmt.Sprintf("tickets:openratelimit:%d", guildId)
 
-	res, err := script.Run(utils.DefaultContext(), client, []string{key}, TicketOpenLimit, TicketOpenLimitInterval.Seconds()).Result()
+	res, err := script.Run(utils.DefaultContext(), c.Client, []string{key}, TicketOpenLimit, TicketOpenLimitInterval.Seconds()).Result()
 	if err != nil {
 		return false, err
 	}
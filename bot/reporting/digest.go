@@ -0,0 +1,226 @@
+package reporting
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"golang.org/x/sync/errgroup"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+)
+
+var Frequencies = []Frequency{FrequencyDaily, FrequencyWeekly, FrequencyMonthly}
+
+const (
+	// DefaultResponseLimit is used to count breaching tickets when the guild has not configured its own limit
+	DefaultResponseLimit = time.Hour
+	topResponderCount    = 3
+)
+
+func (f Frequency) IsValid() bool {
+	for _, frequency := range Frequencies {
+		if f == frequency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextRun returns the first scheduled run strictly after the given time. Digests run at midnight UTC: every day,
+// every Monday or on the 1st of each month.
+func (f Frequency) NextRun(after time.Time) time.Time {
+	day := truncateDay(after)
+
+	switch f {
+	case FrequencyWeekly:
+		daysUntilMonday := (int(time.Monday) - int(day.Weekday()) + 7) % 7
+		if daysUntilMonday == 0 {
+			daysUntilMonday = 7
+		}
+
+		return day.AddDate(0, 0, daysUntilMonday)
+	case FrequencyMonthly:
+		return time.Date(day.Year(), day.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day.AddDate(0, 0, 1)
+	}
+}
+
+// Periods returns the period covered by a digest sent at runAt, and the equal length period before it
+func (f Frequency) Periods(runAt time.Time) (current, previous Range) {
+	end := truncateDay(runAt)
+
+	var step func(time.Time) time.Time
+	switch f {
+	case FrequencyWeekly:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, -7) }
+	case FrequencyMonthly:
+		step = func(t time.Time) time.Time { return t.AddDate(0, -1, 0) }
+	default:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, -1) }
+	}
+
+	current = Range{From: step(end), To: end}
+	previous = Range{From: step(current.From), To: current.From}
+	return
+}
+
+type Comparison[T any] struct {
+	Current  T
+	Previous T
+}
+
+type Digest struct {
+	Frequency         Frequency
+	Current           Range
+	Previous          Range
+	Truncated         bool
+	Opened            Comparison[int]
+	Closed            Comparison[int]
+	FirstResponseTime Comparison[*time.Duration]
+	ResolutionTime    Comparison[*time.Duration]
+	Rating            Comparison[*float64]
+	RatingCount       Comparison[int]
+	TopResponders     []StaffStats
+	ResponseLimit     time.Duration
+	Breaches          Comparison[int]
+}
+
+// GenerateDigest loads both periods of the digest sent at runAt with a single query, and compares them
+func GenerateDigest(ctx context.Context, services *services.Services, guildId uint64, frequency Frequency, runAt time.Time, responseLimit time.Duration) (Digest, error) {
+	current, previous := frequency.Periods(runAt)
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	var records []TicketRecord
+	group.Go(func() (err error) {
+		records, err = FetchTickets(ctx, services, guildId, Range{From: previous.From, To: current.To}, Filter{})
+		return
+	})
+
+	var participation map[uint64]int
+	group.Go(func() (err error) {
+		participation, err = FetchParticipation(ctx, services, guildId, current, Filter{})
+		return
+	})
+
+	if err := group.Wait(); err != nil {
+		return Digest{}, err
+	}
+
+	return BuildDigest(guildId, frequency, current, previous, records, participation, responseLimit), nil
+}
+
+// BuildDigest compares the current period against the previous one. records must cover both periods, while
+// participation need only cover the current period.
+func BuildDigest(
+	guildId uint64,
+	frequency Frequency,
+	current, previous Range,
+	records []TicketRecord,
+	participation map[uint64]int,
+	responseLimit time.Duration,
+) Digest {
+	if responseLimit <= 0 {
+		responseLimit = DefaultResponseLimit
+	}
+
+	currentReport := BuildReport(guildId, current, records, participation, nil)
+	previousReport := BuildReport(guildId, previous, records, nil, nil)
+
+	digest := Digest{
+		Frequency: frequency,
+		Current:   current,
+		Previous:  previous,
+		Truncated: len(records) >= MaxTickets,
+		Opened: Comparison[int]{
+			Current:  currentReport.Summary.Opened,
+			Previous: previousReport.Summary.Opened,
+		},
+		Closed: Comparison[int]{
+			Current:  currentReport.Summary.Closed,
+			Previous: previousReport.Summary.Closed,
+		},
+		FirstResponseTime: Comparison[*time.Duration]{
+			Current:  currentReport.Summary.FirstResponseTime.P50,
+			Previous: previousReport.Summary.FirstResponseTime.P50,
+		},
+		ResolutionTime: Comparison[*time.Duration]{
+			Current:  currentReport.Summary.ResolutionTime.P50,
+			Previous: previousReport.Summary.ResolutionTime.P50,
+		},
+		Rating: Comparison[*float64]{
+			Current:  currentReport.Summary.Ratings.Average,
+			Previous: previousReport.Summary.Ratings.Average,
+		},
+		RatingCount: Comparison[int]{
+			Current:  currentReport.Summary.Ratings.Count,
+			Previous: previousReport.Summary.Ratings.Count,
+		},
+		ResponseLimit: responseLimit,
+		Breaches: Comparison[int]{
+			Current:  countBreaches(records, current, responseLimit),
+			Previous: countBreaches(records, previous, responseLimit),
+		},
+	}
+
+	for _, staff := range currentReport.Staff {
+		if staff.FirstResponses > 0 || staff.Claimed > 0 {
+			digest.TopResponders = append(digest.TopResponders, staff)
+		}
+	}
+
+	sort.SliceStable(digest.TopResponders, func(i, j int) bool {
+		a, b := digest.TopResponders[i], digest.TopResponders[j]
+		if a.FirstResponses != b.FirstResponses {
+			return a.FirstResponses > b.FirstResponses
+		}
+
+		return a.Claimed > b.Claimed
+	})
+
+	if len(digest.TopResponders) > topResponderCount {
+		digest.TopResponders = digest.TopResponders[:topResponderCount]
+	}
+
+	return digest
+}
+
+// countBreaches counts the tickets opened within the range which either received their first response after the
+// limit, or went unanswered for longer than the limit
+func countBreaches(records []TicketRecord, r Range, limit time.Duration) int {
+	var count int
+	for _, record := range records {
+		if !r.Contains(record.OpenTime) {
+			continue
+		}
+
+		if record.FirstResponseTime != nil {
+			if *record.FirstResponseTime > limit {
+				count++
+			}
+
+			continue
+		}
+
+		end := r.To
+		if record.CloseTime != nil && record.CloseTime.Before(end) {
+			end = *record.CloseTime
+		}
+
+		if end.Sub(record.OpenTime) > limit {
+			count++
+		}
+	}
+
+	return count
+}
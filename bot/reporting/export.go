@@ -0,0 +1,181 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCsv  Format = "csv"
+	FormatJson Format = "json"
+)
+
+var Formats = []Format{FormatCsv, FormatJson}
+
+// File is a named export, ready to be attached to a message
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+func (r Report) Export(format Format) ([]File, error) {
+	switch format {
+	case FormatCsv:
+		return r.exportCsv()
+	case FormatJson:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return []File{{Name: r.fileName("report", "json"), ContentType: "application/json", Data: data}}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %s", format)
+	}
+}
+
+func (r Report) exportCsv() ([]File, error) {
+	daily := [][]string{{"date", "opened", "closed"}}
+	for _, counts := range r.Daily {
+		daily = append(daily, []string{counts.Date, strconv.Itoa(counts.Opened), strconv.Itoa(counts.Closed)})
+	}
+
+	staffHeader := []string{"user_id", "claimed", "participated", "first_responses"}
+	staffHeader = append(staffHeader, percentileHeaders("first_response")...)
+	staffHeader = append(staffHeader, percentileHeaders("resolution")...)
+	staffHeader = append(staffHeader, ratingHeaders()...)
+
+	staff := [][]string{staffHeader}
+	for _, s := range r.Staff {
+		row := []string{
+			strconv.FormatUint(s.UserId, 10),
+			strconv.Itoa(s.Claimed),
+			strconv.Itoa(s.Participated),
+			strconv.Itoa(s.FirstResponses),
+		}
+
+		row = append(row, percentileColumns(s.FirstResponseTime)...)
+		row = append(row, percentileColumns(s.ResolutionTime)...)
+		row = append(row, ratingColumns(s.Ratings)...)
+		staff = append(staff, row)
+	}
+
+	panelHeader := []string{"panel_id", "name", "opened", "closed"}
+	panelHeader = append(panelHeader, percentileHeaders("first_response")...)
+	panelHeader = append(panelHeader, percentileHeaders("resolution")...)
+	panelHeader = append(panelHeader, ratingHeaders()...)
+
+	panels := [][]string{panelHeader}
+	for _, p := range r.Panels {
+		var panelId string
+		if p.PanelId != nil {
+			panelId = strconv.Itoa(*p.PanelId)
+		}
+
+		row := []string{panelId, p.Name, strconv.Itoa(p.Opened), strconv.Itoa(p.Closed)}
+		row = append(row, percentileColumns(p.FirstResponseTime)...)
+		row = append(row, percentileColumns(p.ResolutionTime)...)
+		row = append(row, ratingColumns(p.Ratings)...)
+		panels = append(panels, row)
+	}
+
+	reopens := [][]string{{"previous_id", "ticket_id"}}
+	for _, reopen := range r.Reopens {
+		reopens = append(reopens, []string{strconv.Itoa(reopen.PreviousId), strconv.Itoa(reopen.TicketId)})
+	}
+
+	var files []File
+	for _, table := range []struct {
+		name string
+		rows [][]string
+	}{
+		{"daily", daily},
+		{"staff", staff},
+		{"panels", panels},
+		{"reopens", reopens},
+	} {
+		data, err := encodeCsv(table.rows)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, File{Name: r.fileName(table.name, "csv"), ContentType: "text/csv", Data: data})
+	}
+
+	return files, nil
+}
+
+func (r Report) fileName(table, extension string) string {
+	return fmt.Sprintf(
+		"%d-%s-%s-%s.%s",
+		r.GuildId,
+		table,
+		r.Range.From.Format(dateLayout),
+		r.Range.To.Add(-time.Nanosecond).Format(dateLayout),
+		extension,
+	)
+}
+
+func encodeCsv(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func percentileHeaders(prefix string) []string {
+	return []string{
+		prefix + "_count",
+		prefix + "_mean_seconds",
+		prefix + "_p50_seconds",
+		prefix + "_p90_seconds",
+		prefix + "_p99_seconds",
+	}
+}
+
+func percentileColumns(p Percentiles) []string {
+	return []string{
+		strconv.Itoa(p.Count),
+		formatSeconds(p.Mean),
+		formatSeconds(p.P50),
+		formatSeconds(p.P90),
+		formatSeconds(p.P99),
+	}
+}
+
+func ratingHeaders() []string {
+	return []string{"rating_count", "rating_average", "rating_1", "rating_2", "rating_3", "rating_4", "rating_5"}
+}
+
+func ratingColumns(r RatingStats) []string {
+	var average string
+	if r.Average != nil {
+		average = strconv.FormatFloat(*r.Average, 'f', 2, 64)
+	}
+
+	columns := []string{strconv.Itoa(r.Count), average}
+	for _, count := range r.Distribution {
+		columns = append(columns, strconv.Itoa(count))
+	}
+
+	return columns
+}
+
+func formatSeconds(duration *time.Duration) string {
+	if duration == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(int64(duration.Seconds()), 10)
+}
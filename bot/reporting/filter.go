@@ -0,0 +1,11 @@
+package reporting
+
+// Filter narrows a report down to the tickets opened from a single panel, and/or from any panel assigned to a team
+type Filter struct {
+	PanelId *int
+	TeamId  *int
+}
+
+func (f Filter) IsEmpty() bool {
+	return f.PanelId == nil && f.TeamId == nil
+}
@@ -0,0 +1,123 @@
+package reporting
+
+import (
+	"context"
+
+	"github.com/TicketsBot-cloud/analytics-client"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/logic"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"golang.org/x/sync/errgroup"
+)
+
+// Generate loads the data for, and builds, a report covering the given range. Lifetime figures are not filtered.
+func Generate(ctx context.Context, services *services.Services, guildId uint64, r Range, filter Filter) (Report, error) {
+	group, ctx := errgroup.WithContext(ctx)
+
+	var report Report
+	group.Go(func() (err error) {
+		report, err = GenerateRange(ctx, services, guildId, r, filter)
+		return
+	})
+
+	var totalTickets, ratingCount uint64
+	var averageRating float64
+	var firstResponseTime, ticketDuration analytics.TripleWindow
+
+	group.Go(func() (err error) {
+		totalTickets, err = services.Analytics.GetTotalTicketCount(ctx, guildId)
+		return
+	})
+
+	group.Go(func() (err error) {
+		averageRating, err = services.Analytics.GetAverageFeedbackRatingGuild(ctx, guildId)
+		return
+	})
+
+	group.Go(func() (err error) {
+		ratingCount, err = services.Analytics.GetFeedbackCountGuild(ctx, guildId)
+		return
+	})
+
+	group.Go(func() (err error) {
+		firstResponseTime, err = services.Analytics.GetFirstResponseTimeStats(ctx, guildId)
+		return
+	})
+
+	group.Go(func() (err error) {
+		ticketDuration, err = services.Analytics.GetTicketDurationStats(ctx, guildId)
+		return
+	})
+
+	if err := group.Wait(); err != nil {
+		return Report{}, err
+	}
+
+	report.Lifetime = NewLifetime(totalTickets, averageRating, ratingCount, firstResponseTime, ticketDuration)
+	return report, nil
+}
+
+// GenerateRange builds a report covering only the given range, without the lifetime figures
+func GenerateRange(ctx context.Context, services *services.Services, guildId uint64, r Range, filter Filter) (Report, error) {
+	group, ctx := errgroup.WithContext(ctx)
+
+	var records []TicketRecord
+	group.Go(func() (err error) {
+		records, err = FetchTickets(ctx, services, guildId, r, filter)
+		return
+	})
+
+	var participation map[uint64]int
+	group.Go(func() (err error) {
+		participation, err = FetchParticipation(ctx, services, guildId, r, filter)
+		return
+	})
+
+	panelNames := make(map[int]string)
+	group.Go(func() error {
+		panels, err := services.Database.Panel.GetByGuild(ctx, guildId)
+		if err != nil {
+			return err
+		}
+
+		for _, panel := range panels {
+			panelNames[panel.PanelId] = panel.Title
+		}
+
+		return nil
+	})
+
+	var closeReasons map[int]dbclient.CloseReasonConfig
+	group.Go(func() (err error) {
+		closeReasons, err = services.Database.CloseReasonConfig.GetByGuild(ctx, guildId)
+		return
+	})
+
+	if err := group.Wait(); err != nil {
+		return Report{}, err
+	}
+
+	CategoriseCloseReasons(records, closeReasons)
+
+	return BuildReport(guildId, r, records, participation, panelNames), nil
+}
+
+// CategoriseCloseReasons sets the close category of each record whose close reason was built from one of its panel's
+// close reason presets. Presets are matched by their current label, so renamed presets are not counted for tickets
+// closed before they were renamed.
+func CategoriseCloseReasons(records []TicketRecord, closeReasons map[int]dbclient.CloseReasonConfig) {
+	for i, record := range records {
+		if record.CloseReason == nil || record.PanelId == nil {
+			continue
+		}
+
+		config, ok := closeReasons[*record.PanelId]
+		if !ok {
+			continue
+		}
+
+		if preset, ok := logic.MatchCloseReasonPreset(config, *record.CloseReason); ok {
+			records[i].CloseCategory = &preset.Category
+		}
+	}
+}
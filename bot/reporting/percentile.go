@@ -0,0 +1,77 @@
+package reporting
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+type Percentiles struct {
+	Count int
+	Mean  *time.Duration
+	P50   *time.Duration
+	P90   *time.Duration
+	P99   *time.Duration
+}
+
+// CalculatePercentiles uses the nearest-rank method. durations is sorted in place.
+func CalculatePercentiles(durations []time.Duration) Percentiles {
+	if len(durations) == 0 {
+		return Percentiles{}
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+
+	mean := total / time.Duration(len(durations))
+
+	return Percentiles{
+		Count: len(durations),
+		Mean:  &mean,
+		P50:   nearestRank(durations, 50),
+		P90:   nearestRank(durations, 90),
+		P99:   nearestRank(durations, 99),
+	}
+}
+
+func nearestRank(sorted []time.Duration, percentile float64) *time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	value := sorted[rank-1]
+	return &value
+}
+
+func (p Percentiles) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Count int      `json:"count"`
+		Mean  *float64 `json:"mean_seconds"`
+		P50   *float64 `json:"p50_seconds"`
+		P90   *float64 `json:"p90_seconds"`
+		P99   *float64 `json:"p99_seconds"`
+	}{
+		Count: p.Count,
+		Mean:  toSeconds(p.Mean),
+		P50:   toSeconds(p.P50),
+		P90:   toSeconds(p.P90),
+		P99:   toSeconds(p.P99),
+	})
+}
+
+func toSeconds(duration *time.Duration) *float64 {
+	if duration == nil {
+		return nil
+	}
+
+	seconds := duration.Seconds()
+	return &seconds
+}
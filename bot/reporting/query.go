@@ -0,0 +1,154 @@
+package reporting
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"golang.org/x/sync/errgroup"
+)
+
+// MaxTickets caps the number of tickets loaded into a single report, to bound memory usage
+const MaxTickets = 50000
+
+type TicketRecord struct {
+	TicketId          int            `json:"ticket_id"`
+	PanelId           *int           `json:"panel_id"`
+	OpenerId          uint64         `json:"opener_id,string"`
+	OpenTime          time.Time      `json:"open_time"`
+	CloseTime         *time.Time     `json:"close_time"`
+	FirstResponseTime *time.Duration `json:"first_response_time"`
+	FirstResponderId  *uint64        `json:"first_responder_id,string"`
+	ClaimedBy         *uint64        `json:"claimed_by,string"`
+	Rating            *uint8         `json:"rating"`
+	CloseReason       *string        `json:"close_reason"`
+	// CloseCategory is the category of the close reason preset which the ticket was closed with, if any
+	CloseCategory *string `json:"close_category"`
+	// ReopenedFrom is the closed ticket which this ticket was opened to continue, if any
+	ReopenedFrom *int `json:"reopened_from"`
+}
+
+// ResolutionTime returns the time between the ticket being opened and closed, or nil if it is still open
+func (t TicketRecord) ResolutionTime() *time.Duration {
+	if t.CloseTime == nil {
+		return nil
+	}
+
+	duration := t.CloseTime.Sub(t.OpenTime)
+	return &duration
+}
+
+// FetchTickets loads the tickets opened or closed within the range and matching the filter, along with their first
+// response time, claimer, rating, close reason and the ticket they were reopened from, oldest first. If there are more
+// than MaxTickets, the most recent are kept.
+func FetchTickets(ctx context.Context, services *services.Services, guildId uint64, r Range, filter Filter) ([]TicketRecord, error) {
+	panelIds, ok, err := resolvePanelIds(ctx, services, filter)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	tickets, err := services.Database.Reporting.GetTickets(ctx, guildId, r.From, r.To, MaxTickets, panelIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketIds := make([]int, len(tickets))
+	for i, ticket := range tickets {
+		ticketIds[i] = ticket.TicketId
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	var ratings map[int]uint8
+	group.Go(func() (err error) {
+		ratings, err = services.Database.ServiceRatings.GetMulti(groupCtx, guildId, ticketIds)
+		return
+	})
+
+	var closeReasons map[int]database.CloseMetadata
+	group.Go(func() (err error) {
+		closeReasons, err = services.Database.CloseReason.GetMulti(groupCtx, guildId, ticketIds)
+		return
+	})
+
+	var reopenedFrom map[int]int
+	group.Go(func() (err error) {
+		reopenedFrom, err = services.Database.ReopenLinks.GetReopenedFromMulti(groupCtx, guildId, ticketIds)
+		return
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Tickets are fetched newest first, so that the oldest are the ones dropped
+	records := make([]TicketRecord, len(tickets))
+	for i, ticket := range tickets {
+		record := TicketRecord{
+			TicketId:          ticket.TicketId,
+			PanelId:           ticket.PanelId,
+			OpenerId:          ticket.OpenerId,
+			OpenTime:          ticket.OpenTime,
+			CloseTime:         ticket.CloseTime,
+			FirstResponseTime: ticket.FirstResponseTime,
+			FirstResponderId:  ticket.FirstResponderId,
+			ClaimedBy:         ticket.ClaimedBy,
+		}
+
+		if rating, ok := ratings[ticket.TicketId]; ok {
+			record.Rating = &rating
+		}
+
+		if closeMetadata, ok := closeReasons[ticket.TicketId]; ok {
+			record.CloseReason = closeMetadata.Reason
+		}
+
+		if previousId, ok := reopenedFrom[ticket.TicketId]; ok {
+			record.ReopenedFrom = &previousId
+		}
+
+		records[len(tickets)-1-i] = record
+	}
+
+	return records, nil
+}
+
+// FetchParticipation returns the number of tickets opened within the range and matching the filter that each user
+// has sent a message in
+func FetchParticipation(ctx context.Context, services *services.Services, guildId uint64, r Range, filter Filter) (map[uint64]int, error) {
+	panelIds, ok, err := resolvePanelIds(ctx, services, filter)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return services.Database.Reporting.GetParticipation(ctx, guildId, r.From, r.To, panelIds)
+}
+
+// resolvePanelIds returns the panels which tickets must be from to match the filter, or nil if tickets from any panel
+// match. If no panel can match, e.g. as the team is not assigned to any panels, false is returned.
+func resolvePanelIds(ctx context.Context, services *services.Services, filter Filter) ([]int, bool, error) {
+	if filter.TeamId == nil {
+		if filter.PanelId == nil {
+			return nil, true, nil
+		}
+
+		return []int{*filter.PanelId}, true, nil
+	}
+
+	panelIds, err := services.Database.PanelTeams.GetPanelsByTeams(ctx, []int{*filter.TeamId})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if filter.PanelId != nil {
+		if !slices.Contains(panelIds, *filter.PanelId) {
+			return nil, false, nil
+		}
+
+		panelIds = []int{*filter.PanelId}
+	}
+
+	return panelIds, len(panelIds) > 0, nil
+}
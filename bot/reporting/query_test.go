@@ -0,0 +1,78 @@
+package reporting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/testharness"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type reportingStore struct {
+	dbclient.ReportingStore
+	tickets  []dbclient.ReportTicket
+	panelIds []int
+}
+
+func (s *reportingStore) GetTickets(ctx context.Context, guildId uint64, from, to time.Time, limit int, panelIds []int) ([]dbclient.ReportTicket, error) {
+	s.panelIds = panelIds
+	return s.tickets, nil
+}
+
+type panelTeamsStore struct {
+	dbclient.PanelTeamsStore
+	panels map[int][]int
+}
+
+func (s panelTeamsStore) GetPanelsByTeams(ctx context.Context, teamIds []int) ([]int, error) {
+	var panelIds []int
+	for _, teamId := range teamIds {
+		panelIds = append(panelIds, s.panels[teamId]...)
+	}
+
+	return panelIds, nil
+}
+
+func TestFetchTickets(t *testing.T) {
+	h := testharness.New(t)
+	guildId := uint64(1)
+	now := time.Now()
+
+	// Tickets are returned newest first
+	store := &reportingStore{tickets: []dbclient.ReportTicket{
+		{TicketId: 3, OpenTime: now},
+		{TicketId: 2, OpenTime: now.Add(-time.Hour)},
+	}}
+	h.Database.Client.Reporting = store
+	h.Database.Client.PanelTeams = panelTeamsStore{PanelTeamsStore: h.Database.Client.PanelTeams, panels: map[int][]int{1: {10, 11}}}
+	require.NoError(t, h.Database.ReopenLinks.Set(t.Context(), guildId, 1, 3))
+
+	r := Range{From: now.Add(-time.Hour * 24), To: now.Add(time.Hour)}
+
+	records, err := FetchTickets(t.Context(), h.Services, guildId, r, Filter{})
+	require.NoError(t, err)
+	require.Nil(t, store.panelIds)
+	require.Len(t, records, 2)
+	require.Equal(t, 2, records[0].TicketId)
+	require.Nil(t, records[0].ReopenedFrom)
+	require.Equal(t, 3, records[1].TicketId)
+	require.Equal(t, utils.Ptr(1), records[1].ReopenedFrom)
+
+	_, err = FetchTickets(t.Context(), h.Services, guildId, r, Filter{PanelId: utils.Ptr(10)})
+	require.NoError(t, err)
+	require.Equal(t, []int{10}, store.panelIds)
+
+	_, err = FetchTickets(t.Context(), h.Services, guildId, r, Filter{TeamId: utils.Ptr(1)})
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 11}, store.panelIds)
+
+	// Teams which are not assigned to the panel, or to any panel, match no tickets
+	for _, filter := range []Filter{{PanelId: utils.Ptr(12), TeamId: utils.Ptr(1)}, {TeamId: utils.Ptr(2)}} {
+		records, err = FetchTickets(t.Context(), h.Services, guildId, r, filter)
+		require.NoError(t, err)
+		require.Empty(t, records)
+	}
+}
@@ -0,0 +1,121 @@
+package reporting
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	DefaultRangeLength = time.Hour * 24 * 30
+	MaxRangeLength     = time.Hour * 24 * 366
+)
+
+var (
+	ErrInvalidDate    = errors.New("invalid date")
+	ErrRangeInverted  = errors.New("range ends before it starts")
+	ErrRangeTooLarge  = errors.New("range is too large")
+	ErrInvalidPreset  = errors.New("invalid preset")
+	ErrPresetConflict = errors.New("preset cannot be combined with dates")
+)
+
+// Presets are the windows accepted by ParsePeriod, each ending at the end of today
+var Presets = []Preset{
+	{Name: "7d", Days: 7},
+	{Name: "30d", Days: 30},
+	{Name: "90d", Days: 90},
+}
+
+type Preset struct {
+	Name string
+	Days int
+}
+
+// Range is a half-open interval of [From, To)
+type Range struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ParseRange parses optional YYYY-MM-DD dates, where both ends are inclusive of the whole day in UTC. If from is
+// omitted, the range defaults to DefaultRangeLength before to. If to is omitted, the range ends at the end of today.
+func ParseRange(from, to *string, now time.Time) (Range, error) {
+	var r Range
+
+	if to == nil {
+		r.To = truncateDay(now).Add(time.Hour * 24)
+	} else {
+		parsed, err := time.Parse(dateLayout, *to)
+		if err != nil {
+			return Range{}, ErrInvalidDate
+		}
+
+		r.To = parsed.Add(time.Hour * 24)
+	}
+
+	if from == nil {
+		r.From = r.To.Add(-DefaultRangeLength)
+	} else {
+		parsed, err := time.Parse(dateLayout, *from)
+		if err != nil {
+			return Range{}, ErrInvalidDate
+		}
+
+		r.From = parsed
+	}
+
+	if !r.From.Before(r.To) {
+		return Range{}, ErrRangeInverted
+	}
+
+	if r.To.Sub(r.From) > MaxRangeLength {
+		return Range{}, ErrRangeTooLarge
+	}
+
+	return r, nil
+}
+
+// ParsePeriod accepts either a preset window, such as 7d, or the dates accepted by ParseRange
+func ParsePeriod(preset, from, to *string, now time.Time) (Range, error) {
+	if preset == nil {
+		return ParseRange(from, to, now)
+	}
+
+	if from != nil || to != nil {
+		return Range{}, ErrPresetConflict
+	}
+
+	for _, p := range Presets {
+		if strings.EqualFold(p.Name, *preset) {
+			end := truncateDay(now).Add(time.Hour * 24)
+			return Range{From: end.AddDate(0, 0, -p.Days), To: end}, nil
+		}
+	}
+
+	return Range{}, ErrInvalidPreset
+}
+
+// Days returns the start of each UTC day covered by the range
+func (r Range) Days() []time.Time {
+	var days []time.Time
+	for day := truncateDay(r.From); day.Before(r.To); day = day.Add(time.Hour * 24) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+func (r Range) Contains(t time.Time) bool {
+	return !t.Before(r.From) && t.Before(r.To)
+}
+
+func (r Range) String() string {
+	return r.From.Format(dateLayout) + " - " + r.To.Add(-time.Nanosecond).Format(dateLayout)
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,345 @@
+package reporting
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TicketsBot-cloud/analytics-client"
+)
+
+type (
+	Report struct {
+		GuildId     uint64        `json:"guild_id,string"`
+		Range       Range         `json:"range"`
+		GeneratedAt time.Time     `json:"generated_at"`
+		Truncated   bool          `json:"truncated"`
+		Summary     Summary       `json:"summary"`
+		Lifetime    *Lifetime     `json:"lifetime,omitempty"`
+		Daily       []DailyCounts `json:"daily"`
+		Staff       []StaffStats  `json:"staff"`
+		Panels      []PanelStats  `json:"panels"`
+		// Reopens links each ticket opened within the range by reopening a closed ticket to the ticket it continues
+		Reopens []Reopen `json:"reopens"`
+	}
+
+	Summary struct {
+		Opened            int         `json:"opened"`
+		Closed            int         `json:"closed"`
+		FirstResponseTime Percentiles `json:"first_response_time"`
+		ResolutionTime    Percentiles `json:"resolution_time"`
+		Ratings           RatingStats `json:"ratings"`
+		UnansweredTickets int         `json:"unanswered_tickets"`
+		UnclaimedTickets  int         `json:"unclaimed_tickets"`
+		ReopenedTickets   int         `json:"reopened_tickets"`
+		// CloseCategories counts the tickets closed within the range with a close reason preset, by its category
+		CloseCategories map[string]int `json:"close_categories"`
+		// OpenedByHour and OpenedByWeekday are histograms of when tickets were opened, in UTC. Weekdays start on Sunday.
+		OpenedByHour    [24]int `json:"opened_by_hour"`
+		OpenedByWeekday [7]int  `json:"opened_by_weekday"`
+	}
+
+	// Lifetime holds the all-time figures from the analytics database, which are not range limited
+	Lifetime struct {
+		TotalTickets          uint64   `json:"total_tickets"`
+		AverageRating         float64  `json:"average_rating"`
+		RatingCount           uint64   `json:"rating_count"`
+		FirstResponseAllTime  *float64 `json:"first_response_all_time_seconds"`
+		FirstResponseMonthly  *float64 `json:"first_response_monthly_seconds"`
+		FirstResponseWeekly   *float64 `json:"first_response_weekly_seconds"`
+		TicketDurationAllTime *float64 `json:"ticket_duration_all_time_seconds"`
+		TicketDurationMonthly *float64 `json:"ticket_duration_monthly_seconds"`
+		TicketDurationWeekly  *float64 `json:"ticket_duration_weekly_seconds"`
+	}
+
+	DailyCounts struct {
+		Date   string `json:"date"`
+		Opened int    `json:"opened"`
+		Closed int    `json:"closed"`
+	}
+
+	Reopen struct {
+		PreviousId int `json:"previous_id"`
+		TicketId   int `json:"ticket_id"`
+	}
+
+	RatingStats struct {
+		Count        int      `json:"count"`
+		Average      *float64 `json:"average"`
+		Distribution [5]int   `json:"distribution"`
+	}
+
+	StaffStats struct {
+		UserId            uint64      `json:"user_id,string"`
+		Claimed           int         `json:"claimed"`
+		Participated      int         `json:"participated"`
+		FirstResponses    int         `json:"first_responses"`
+		FirstResponseTime Percentiles `json:"first_response_time"`
+		// ResolutionTime covers the tickets claimed by the staff member which were closed within the range
+		ResolutionTime Percentiles `json:"resolution_time"`
+		Ratings        RatingStats `json:"ratings"`
+	}
+
+	PanelStats struct {
+		PanelId           *int        `json:"panel_id"`
+		Name              string      `json:"name"`
+		Opened            int         `json:"opened"`
+		Closed            int         `json:"closed"`
+		FirstResponseTime Percentiles `json:"first_response_time"`
+		ResolutionTime    Percentiles `json:"resolution_time"`
+		Ratings           RatingStats `json:"ratings"`
+	}
+)
+
+// BuildReport aggregates the ticket records into a report. Tickets count towards the opened figures, first response
+// times and ratings if they were opened within the range, and towards the closed figures and resolution times if they
+// were closed within the range. panelNames is used to label the panel breakdown, and may be nil.
+func BuildReport(guildId uint64, r Range, records []TicketRecord, participation map[uint64]int, panelNames map[int]string) Report {
+	report := Report{
+		GuildId:     guildId,
+		Range:       r,
+		GeneratedAt: time.Now().UTC(),
+		Truncated:   len(records) >= MaxTickets,
+		Summary: Summary{
+			CloseCategories: make(map[string]int),
+		},
+	}
+
+	days := r.Days()
+	daily := make(map[string]*DailyCounts, len(days))
+	for _, day := range days {
+		date := day.Format(dateLayout)
+		daily[date] = &DailyCounts{Date: date}
+	}
+
+	var summary collector
+	staff := make(map[uint64]*staffCollector)
+	panels := make(map[int]*collector)
+	var noPanel collector
+
+	getStaff := func(userId uint64) *staffCollector {
+		s, ok := staff[userId]
+		if !ok {
+			s = &staffCollector{}
+			staff[userId] = s
+		}
+
+		return s
+	}
+
+	for _, record := range records {
+		panel := &noPanel
+		if record.PanelId != nil {
+			var ok bool
+			panel, ok = panels[*record.PanelId]
+			if !ok {
+				panel = &collector{}
+				panels[*record.PanelId] = panel
+			}
+		}
+
+		if r.Contains(record.OpenTime) {
+			if counts, ok := daily[truncateDay(record.OpenTime).Format(dateLayout)]; ok {
+				counts.Opened++
+			}
+
+			summary.addOpened(record)
+			panel.addOpened(record)
+
+			openTime := record.OpenTime.UTC()
+			report.Summary.OpenedByHour[openTime.Hour()]++
+			report.Summary.OpenedByWeekday[openTime.Weekday()]++
+
+			if record.FirstResponseTime == nil {
+				report.Summary.UnansweredTickets++
+			} else if record.FirstResponderId != nil {
+				s := getStaff(*record.FirstResponderId)
+				s.firstResponses = append(s.firstResponses, *record.FirstResponseTime)
+			}
+
+			if record.ReopenedFrom != nil {
+				report.Summary.ReopenedTickets++
+				report.Reopens = append(report.Reopens, Reopen{PreviousId: *record.ReopenedFrom, TicketId: record.TicketId})
+			}
+
+			if record.ClaimedBy == nil {
+				report.Summary.UnclaimedTickets++
+			} else {
+				s := getStaff(*record.ClaimedBy)
+				s.claimed++
+
+				if record.Rating != nil {
+					s.ratings.add(*record.Rating)
+				}
+			}
+		}
+
+		if record.CloseTime != nil && r.Contains(*record.CloseTime) {
+			if counts, ok := daily[truncateDay(*record.CloseTime).Format(dateLayout)]; ok {
+				counts.Closed++
+			}
+
+			summary.addClosed(record)
+			panel.addClosed(record)
+
+			if record.CloseCategory != nil {
+				report.Summary.CloseCategories[*record.CloseCategory]++
+			}
+
+			if resolution := record.ResolutionTime(); resolution != nil && record.ClaimedBy != nil {
+				s := getStaff(*record.ClaimedBy)
+				s.resolutions = append(s.resolutions, *resolution)
+			}
+		}
+	}
+
+	for _, day := range days {
+		report.Daily = append(report.Daily, *daily[day.Format(dateLayout)])
+	}
+
+	report.Summary.Opened = summary.opened
+	report.Summary.Closed = summary.closed
+	report.Summary.FirstResponseTime = CalculatePercentiles(summary.firstResponses)
+	report.Summary.ResolutionTime = CalculatePercentiles(summary.resolutions)
+	report.Summary.Ratings = summary.ratings.build()
+
+	for userId, count := range participation {
+		getStaff(userId).participated = count
+	}
+
+	for userId, s := range staff {
+		report.Staff = append(report.Staff, StaffStats{
+			UserId:            userId,
+			Claimed:           s.claimed,
+			Participated:      s.participated,
+			FirstResponses:    len(s.firstResponses),
+			FirstResponseTime: CalculatePercentiles(s.firstResponses),
+			ResolutionTime:    CalculatePercentiles(s.resolutions),
+			Ratings:           s.ratings.build(),
+		})
+	}
+
+	sort.Slice(report.Staff, func(i, j int) bool {
+		if report.Staff[i].Claimed != report.Staff[j].Claimed {
+			return report.Staff[i].Claimed > report.Staff[j].Claimed
+		}
+
+		return report.Staff[i].UserId < report.Staff[j].UserId
+	})
+
+	for panelId, c := range panels {
+		panelId := panelId
+
+		name, ok := panelNames[panelId]
+		if !ok {
+			name = "Deleted Panel"
+		}
+
+		report.Panels = append(report.Panels, c.buildPanel(&panelId, name))
+	}
+
+	sort.Slice(report.Panels, func(i, j int) bool {
+		return *report.Panels[i].PanelId < *report.Panels[j].PanelId
+	})
+
+	if noPanel.opened > 0 || noPanel.closed > 0 {
+		report.Panels = append(report.Panels, noPanel.buildPanel(nil, "No Panel"))
+	}
+
+	return report
+}
+
+type collector struct {
+	opened, closed int
+	firstResponses []time.Duration
+	resolutions    []time.Duration
+	ratings        ratingCollector
+}
+
+func (c *collector) addOpened(record TicketRecord) {
+	c.opened++
+
+	if record.FirstResponseTime != nil {
+		c.firstResponses = append(c.firstResponses, *record.FirstResponseTime)
+	}
+
+	if record.Rating != nil {
+		c.ratings.add(*record.Rating)
+	}
+}
+
+func (c *collector) addClosed(record TicketRecord) {
+	c.closed++
+
+	if resolution := record.ResolutionTime(); resolution != nil {
+		c.resolutions = append(c.resolutions, *resolution)
+	}
+}
+
+func (c *collector) buildPanel(panelId *int, name string) PanelStats {
+	return PanelStats{
+		PanelId:           panelId,
+		Name:              name,
+		Opened:            c.opened,
+		Closed:            c.closed,
+		FirstResponseTime: CalculatePercentiles(c.firstResponses),
+		ResolutionTime:    CalculatePercentiles(c.resolutions),
+		Ratings:           c.ratings.build(),
+	}
+}
+
+type staffCollector struct {
+	claimed, participated int
+	firstResponses        []time.Duration
+	resolutions           []time.Duration
+	ratings               ratingCollector
+}
+
+type ratingCollector struct {
+	count        int
+	total        int
+	distribution [5]int
+}
+
+func (c *ratingCollector) add(rating uint8) {
+	if rating < 1 || rating > 5 {
+		return
+	}
+
+	c.count++
+	c.total += int(rating)
+	c.distribution[rating-1]++
+}
+
+func (c *ratingCollector) build() RatingStats {
+	stats := RatingStats{
+		Count:        c.count,
+		Distribution: c.distribution,
+	}
+
+	if c.count > 0 {
+		average := float64(c.total) / float64(c.count)
+		stats.Average = &average
+	}
+
+	return stats
+}
+
+// NewLifetime converts the analytics figures into a Lifetime, which may then be attached to a Report
+func NewLifetime(
+	totalTickets uint64,
+	averageRating float64,
+	ratingCount uint64,
+	firstResponseTime, ticketDuration analytics.TripleWindow,
+) *Lifetime {
+	return &Lifetime{
+		TotalTickets:          totalTickets,
+		AverageRating:         averageRating,
+		RatingCount:           ratingCount,
+		FirstResponseAllTime:  toSeconds(firstResponseTime.AllTime),
+		FirstResponseMonthly:  toSeconds(firstResponseTime.Monthly),
+		FirstResponseWeekly:   toSeconds(firstResponseTime.Weekly),
+		TicketDurationAllTime: toSeconds(ticketDuration.AllTime),
+		TicketDurationMonthly: toSeconds(ticketDuration.Monthly),
+		TicketDurationWeekly:  toSeconds(ticketDuration.Weekly),
+	}
+}
@@ -0,0 +1,231 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRangeDefault(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)
+
+	r, err := ParseRange(nil, nil, now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC), r.To)
+	require.Equal(t, r.To.Add(-DefaultRangeLength), r.From)
+}
+
+func TestParseRangeInclusive(t *testing.T) {
+	from, to := "2024-03-01", "2024-03-07"
+
+	r, err := ParseRange(&from, &to, time.Now())
+	require.NoError(t, err)
+	require.Len(t, r.Days(), 7)
+	require.Equal(t, "2024-03-01 - 2024-03-07", r.String())
+}
+
+func TestParseRangeInvalid(t *testing.T) {
+	from, to := "2024-03-07", "2024-03-01"
+	_, err := ParseRange(&from, &to, time.Now())
+	require.ErrorIs(t, err, ErrRangeInverted)
+
+	invalid := "07/03/2024"
+	_, err = ParseRange(&invalid, nil, time.Now())
+	require.ErrorIs(t, err, ErrInvalidDate)
+
+	from = "2020-01-01"
+	_, err = ParseRange(&from, &to, time.Now())
+	require.ErrorIs(t, err, ErrRangeTooLarge)
+}
+
+func TestPercentiles(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+
+	p := CalculatePercentiles(durations)
+	require.Equal(t, 100, p.Count)
+	require.Equal(t, 50*time.Second, *p.P50)
+	require.Equal(t, 90*time.Second, *p.P90)
+	require.Equal(t, 99*time.Second, *p.P99)
+	require.Equal(t, 50500*time.Millisecond, *p.Mean)
+}
+
+func TestPercentilesEmpty(t *testing.T) {
+	p := CalculatePercentiles(nil)
+	require.Zero(t, p.Count)
+	require.Nil(t, p.P50)
+}
+
+func TestBuildReport(t *testing.T) {
+	from, to := "2024-03-01", "2024-03-02"
+	r, err := ParseRange(&from, &to, time.Now())
+	require.NoError(t, err)
+
+	panelId := 1
+	staffId := uint64(10)
+	rating := uint8(4)
+	responseTime := time.Minute * 5
+
+	day1 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.Add(time.Hour * 24)
+
+	records := []TicketRecord{
+		// Opened before the range, closed within it
+		{TicketId: 1, OpenTime: day1.Add(-time.Hour * 48), CloseTime: &day1},
+		// Opened and closed within the range
+		{
+			TicketId:          2,
+			PanelId:           &panelId,
+			OpenTime:          day1,
+			CloseTime:         &day2,
+			FirstResponseTime: &responseTime,
+			FirstResponderId:  &staffId,
+			ClaimedBy:         &staffId,
+			Rating:            &rating,
+		},
+		// Opened within the range by reopening ticket 1, still open
+		{TicketId: 3, PanelId: &panelId, OpenTime: day2, ReopenedFrom: utils.Ptr(1)},
+	}
+
+	report := BuildReport(1, r, records, map[uint64]int{staffId: 2}, map[int]string{panelId: "Support"})
+
+	require.Equal(t, 2, report.Summary.Opened)
+	require.Equal(t, 2, report.Summary.Closed)
+	require.Equal(t, 1, report.Summary.UnansweredTickets)
+	require.Equal(t, 1, report.Summary.UnclaimedTickets)
+	require.Equal(t, 1, report.Summary.ReopenedTickets)
+	require.Equal(t, []Reopen{{PreviousId: 1, TicketId: 3}}, report.Reopens)
+	require.Equal(t, []DailyCounts{
+		{Date: "2024-03-01", Opened: 1, Closed: 1},
+		{Date: "2024-03-02", Opened: 1, Closed: 1},
+	}, report.Daily)
+
+	require.Len(t, report.Staff, 1)
+	require.Equal(t, StaffStats{
+		UserId:            staffId,
+		Claimed:           1,
+		Participated:      2,
+		FirstResponses:    1,
+		FirstResponseTime: CalculatePercentiles([]time.Duration{responseTime}),
+		ResolutionTime:    CalculatePercentiles([]time.Duration{day2.Sub(day1)}),
+		Ratings:           report.Summary.Ratings,
+	}, report.Staff[0])
+
+	require.Equal(t, 2, report.Summary.OpenedByHour[12])
+	require.Equal(t, 1, report.Summary.OpenedByWeekday[time.Friday])
+	require.Equal(t, 1, report.Summary.OpenedByWeekday[time.Saturday])
+
+	require.Len(t, report.Panels, 2)
+	require.Equal(t, "Support", report.Panels[0].Name)
+	require.Equal(t, 2, report.Panels[0].Opened)
+	require.Nil(t, report.Panels[1].PanelId)
+	require.Equal(t, 1, report.Panels[1].Closed)
+}
+
+func TestCategoriseCloseReasons(t *testing.T) {
+	from, to := "2024-03-01", "2024-03-01"
+	r, err := ParseRange(&from, &to, time.Now())
+	require.NoError(t, err)
+
+	supportPanel, salesPanel := 1, 2
+	closeTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	closeReasons := map[int]dbclient.CloseReasonConfig{
+		supportPanel: {Presets: []dbclient.CloseReasonPreset{
+			{Label: "Fixed", Category: "resolved"},
+			{Label: "Spam", Category: "spam"},
+		}},
+	}
+
+	record := func(id int, panelId *int, reason *string) TicketRecord {
+		return TicketRecord{TicketId: id, PanelId: panelId, OpenTime: closeTime, CloseTime: &closeTime, CloseReason: reason}
+	}
+
+	records := []TicketRecord{
+		record(1, &supportPanel, utils.Ptr("Fixed")),
+		record(2, &supportPanel, utils.Ptr("fixed: restarted the server")),
+		record(3, &supportPanel, utils.Ptr("Spam")),
+		// Free text reasons, and presets from other panels, are not categorised
+		record(4, &supportPanel, utils.Ptr("Fixed it myself")),
+		record(5, &salesPanel, utils.Ptr("Fixed")),
+		record(6, nil, utils.Ptr("Fixed")),
+		record(7, &supportPanel, nil),
+	}
+
+	CategoriseCloseReasons(records, closeReasons)
+
+	require.Equal(t, "resolved", *records[1].CloseCategory)
+	for _, record := range records[3:] {
+		require.Nil(t, record.CloseCategory)
+	}
+
+	report := BuildReport(1, r, records, nil, nil)
+	require.Equal(t, map[string]int{"resolved": 2, "spam": 1}, report.Summary.CloseCategories)
+}
+
+func TestFrequencyNextRun(t *testing.T) {
+	// Friday
+	now := time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC), FrequencyDaily.NextRun(now))
+	require.Equal(t, time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC), FrequencyWeekly.NextRun(now))
+	require.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), FrequencyMonthly.NextRun(now))
+
+	monday := time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), FrequencyWeekly.NextRun(monday))
+}
+
+func TestFrequencyPeriods(t *testing.T) {
+	runAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	current, previous := FrequencyMonthly.Periods(runAt)
+	require.Equal(t, "2024-02-01 - 2024-02-29", current.String())
+	require.Equal(t, "2024-01-01 - 2024-01-31", previous.String())
+
+	current, previous = FrequencyDaily.Periods(runAt)
+	require.Equal(t, "2024-02-29 - 2024-02-29", current.String())
+	require.Equal(t, "2024-02-28 - 2024-02-28", previous.String())
+}
+
+func TestBuildDigest(t *testing.T) {
+	runAt := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	current, previous := FrequencyDaily.Periods(runAt)
+
+	staffId := uint64(10)
+	fast, slow := time.Minute*5, time.Hour*2
+
+	records := []TicketRecord{
+		{TicketId: 1, OpenTime: previous.From.Add(time.Hour)},
+		{TicketId: 2, OpenTime: current.From.Add(time.Hour), FirstResponseTime: &fast, FirstResponderId: &staffId},
+		{TicketId: 3, OpenTime: current.From.Add(time.Hour), FirstResponseTime: &slow, FirstResponderId: &staffId},
+	}
+
+	digest := BuildDigest(1, FrequencyDaily, current, previous, records, nil, 0)
+	require.Equal(t, Comparison[int]{Current: 2, Previous: 1}, digest.Opened)
+	require.Equal(t, Comparison[int]{Current: 1, Previous: 1}, digest.Breaches)
+	require.Equal(t, DefaultResponseLimit, digest.ResponseLimit)
+	require.Len(t, digest.TopResponders, 1)
+	require.Equal(t, 2, digest.TopResponders[0].FirstResponses)
+}
+
+func TestParsePeriod(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)
+
+	preset := "7d"
+	r, err := ParsePeriod(&preset, nil, nil, now)
+	require.NoError(t, err)
+	require.Equal(t, "2024-03-09 - 2024-03-15", r.String())
+
+	from := "2024-03-01"
+	_, err = ParsePeriod(&preset, &from, nil, now)
+	require.ErrorIs(t, err, ErrPresetConflict)
+
+	invalid := "1y"
+	_, err = ParsePeriod(&invalid, nil, nil, now)
+	require.ErrorIs(t, err, ErrInvalidPreset)
+}
@@ -5,21 +5,20 @@ import (
 	"errors"
 	"time"
 
-	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/config"
 )
 
 type BaseListener struct {
-	cache *cache.PgCache
+	services *services.Services
 }
 
 const Timeout = time.Second * 15
 
-func NewBaseListener(cache *cache.PgCache) *BaseListener {
+func NewBaseListener(services *services.Services) *BaseListener {
 	return &BaseListener{
-		cache: cache,
+		services: services,
 	}
 }
 
@@ -28,14 +27,14 @@ func (b *BaseListener) BuildContext() (context.Context, context.CancelFunc) {
 }
 
 func (b *BaseListener) ContextForGuild(ctx context.Context, guildId uint64) (*worker.Context, error) {
-	botId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
+	botId, isWhitelabel, err := b.services.Database.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
 
 	if isWhitelabel {
 		// TODO: Merge lookup into one query
-		bot, err := dbclient.Client.Whitelabel.GetByBotId(ctx, botId)
+		bot, err := b.services.Database.Whitelabel.GetByBotId(ctx, botId)
 		if err != nil {
 			return nil, err
 		}
@@ -49,8 +48,9 @@ func (b *BaseListener) ContextForGuild(ctx context.Context, guildId uint64) (*wo
 			BotId:        bot.BotId,
 			IsWhitelabel: true,
 			ShardId:      0,
-			Cache:        b.cache,
+			Cache:        b.services.Cache,
 			RateLimiter:  nil,
+			Services:     b.services,
 		}, nil
 	} else {
 		return &worker.Context{
@@ -58,8 +58,9 @@ func (b *BaseListener) ContextForGuild(ctx context.Context, guildId uint64) (*wo
 			BotId:        config.Conf.Discord.PublicBotId,
 			IsWhitelabel: false,
 			ShardId:      0,
-			Cache:        b.cache,
+			Cache:        b.services.Cache,
 			RateLimiter:  nil,
+			Services:     b.services,
 		}, nil
 	}
 }
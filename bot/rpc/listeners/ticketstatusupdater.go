@@ -7,14 +7,12 @@ import (
 
 	"github.com/TicketsBot-cloud/common/rpc"
 	"github.com/TicketsBot-cloud/common/rpc/model"
-	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/gdl/objects/channel"
 	"github.com/TicketsBot-cloud/gdl/rest"
 	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"go.uber.org/zap"
 )
 
@@ -25,9 +23,9 @@ type TicketStatusUpdater struct {
 
 var _ rpc.Listener = (*TicketStatusUpdater)(nil)
 
-func NewTicketStatusUpdater(cache *cache.PgCache, logger *zap.Logger) *TicketStatusUpdater {
+func NewTicketStatusUpdater(services *services.Services, logger *zap.Logger) *TicketStatusUpdater {
 	return &TicketStatusUpdater{
-		BaseListener: NewBaseListener(cache),
+		BaseListener: NewBaseListener(services),
 		logger:       logger,
 	}
 }
@@ -81,7 +79,7 @@ func (u *TicketStatusUpdater) HandleMessage(ctx context.Context, message []byte)
 		return
 	}
 
-	ticket, ok, err := dbclient.Client.Tickets.GetByChannel(ctx, event.ChannelId)
+	ticket, ok, err := u.services.Database.Tickets.GetByChannel(ctx, event.ChannelId)
 	if err != nil || !ok {
 		u.logger.Error(
 			"Failed to get ticket by channel",
@@ -112,7 +110,7 @@ func (u *TicketStatusUpdater) HandleMessage(ctx context.Context, message []byte)
 }
 
 func (u *TicketStatusUpdater) CategoryHasSpace(ctx context.Context, worker *worker.Context, event model.TicketStatusUpdate) (bool, error) {
-	channels, err := u.cache.GetGuildChannels(ctx, event.GuildId)
+	channels, err := u.services.Cache.GetGuildChannels(ctx, event.GuildId)
 	if err != nil {
 		return false, err
 	}
@@ -122,7 +120,7 @@ func (u *TicketStatusUpdater) CategoryHasSpace(ctx context.Context, worker *work
 	}
 
 	// Try refreshing the channels in the cache if it hasn't been done recently
-	canRetry, err := redis.TakeChannelRefetchToken(ctx, event.GuildId)
+	canRetry, err := worker.Services.Redis.TakeChannelRefetchToken(ctx, event.GuildId)
 	if err != nil {
 		return false, err
 	}
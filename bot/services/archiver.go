@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/TicketsBot-cloud/archiverclient"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+)
+
+// ErrNotesArchiveUnavailable is returned by StoreNotes when no archive has been configured for staff notes
+var ErrNotesArchiveUnavailable = errors.New("notes archive is not configured")
+
+// ArchiverClients stores transcripts and staff notes in separate archives
+type ArchiverClients struct {
+	Transcripts *archiverclient.ArchiverClient
+	// Notes may be nil, in which case staff notes are not archived
+	Notes *archiverclient.ArchiverClient
+	// Attachments may be nil, in which case attachments are not preserved
+	Attachments *AttachmentClient
+}
+
+var _ Archiver = (*ArchiverClients)(nil)
+
+func (a *ArchiverClients) Store(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error {
+	return a.Transcripts.Store(ctx, guildId, ticketId, messages)
+}
+
+func (a *ArchiverClients) Get(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error) {
+	return a.Transcripts.Get(ctx, guildId, ticketId)
+}
+
+func (a *ArchiverClients) StoreNotes(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error {
+	if a.Notes == nil {
+		return ErrNotesArchiveUnavailable
+	}
+
+	return a.Notes.Store(ctx, guildId, ticketId, messages)
+}
+
+func (a *ArchiverClients) GetNotes(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error) {
+	if a.Notes == nil {
+		return v2.Transcript{}, ErrNotesArchiveUnavailable
+	}
+
+	return a.Notes.Get(ctx, guildId, ticketId)
+}
+
+func (a *ArchiverClients) Replace(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	return importTranscript(ctx, a.Transcripts, guildId, ticketId, transcript)
+}
+
+func (a *ArchiverClients) ReplaceNotes(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	if a.Notes == nil {
+		return ErrNotesArchiveUnavailable
+	}
+
+	return importTranscript(ctx, a.Notes, guildId, ticketId, transcript)
+}
+
+func importTranscript(ctx context.Context, client *archiverclient.ArchiverClient, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	data, err := json.Marshal(transcript)
+	if err != nil {
+		return err
+	}
+
+	return client.ImportTranscript(ctx, guildId, ticketId, data)
+}
+
+func (a *ArchiverClients) PreservesAttachments() bool {
+	return a.Attachments != nil
+}
+
+func (a *ArchiverClients) StoreAttachment(ctx context.Context, guildId uint64, ticketId int, attachmentId uint64, filename, contentType string, data []byte) (string, error) {
+	if a.Attachments == nil {
+		return "", ErrAttachmentArchiveUnavailable
+	}
+
+	return a.Attachments.Store(ctx, guildId, ticketId, attachmentId, filename, contentType, data)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// attachmentStoreTimeout bounds each upload, so that a slow archiver cannot hold up preserving the remaining attachments
+const attachmentStoreTimeout = time.Minute
+
+// ErrAttachmentArchiveUnavailable is returned by StoreAttachment when no archive has been configured for attachments
+var ErrAttachmentArchiveUnavailable = errors.New("attachment archive is not configured")
+
+// AttachmentClient uploads copies of ticket attachments to the archiver, so that transcripts do not depend on Discord
+// CDN links, which expire
+type AttachmentClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewAttachmentClient(endpoint string) *AttachmentClient {
+	return &AttachmentClient{
+		endpoint: endpoint,
+		client: &http.Client{
+			Timeout: attachmentStoreTimeout,
+		},
+	}
+}
+
+type attachmentResponse struct {
+	Url     string `json:"url"`
+	Message string `json:"message"`
+}
+
+// Store uploads the attachment, returning the URL that the preserved copy is served from
+func (c *AttachmentClient) Store(ctx context.Context, guildId uint64, ticketId int, attachmentId uint64, filename, contentType string, data []byte) (string, error) {
+	uri, err := url.Parse(c.endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := uri.Query()
+	query.Set("guild", strconv.FormatUint(guildId, 10))
+	query.Set("id", strconv.Itoa(ticketId))
+	query.Set("attachment", strconv.FormatUint(attachmentId, 10))
+	query.Set("filename", filename)
+	uri.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	var decoded attachmentResponse
+	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error from archiver: %s", decoded.Message)
+	}
+
+	if decoded.Url == "" {
+		return "", errors.New("archiver did not return an attachment url")
+	}
+
+	return decoded.Url, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/gdl/cache"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/jackc/pgx/v4"
+)
+
+// Services holds the clients for the external services that the worker depends on. A single instance is built at
+// startup and carried on each worker.Context, so that handlers can be run against alternative implementations.
+type Services struct {
+	Database         *dbclient.Database
+	Analytics        dbclient.AnalyticsClient
+	Redis            *redis.Client
+	Cache            Cache
+	Premium          premium.IPremiumLookupClient
+	Archiver         Archiver
+	IntegrationProxy IntegrationProxy
+}
+
+// Cache is the gdl cache, along with the PgCache methods that the worker uses beyond the cache.Cache interface. Query is
+// used for lookups that the cache does not provide, such as finding the guilds owned by a user.
+type Cache interface {
+	cache.Cache
+	ReplaceChannels(ctx context.Context, guildId uint64, channels []channel.Channel) error
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Archiver stores ticket transcripts. Staff notes are stored apart from the transcript, so that they are never served
+// to the ticket opener through the transcript link. StoreAttachment returns the URL of the preserved copy, and should
+// only be called if PreservesAttachments returns true.
+type Archiver interface {
+	Store(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error
+	// Get fetches the stored transcript for the ticket, returning archiverclient.ErrNotFound if there is none
+	Get(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error)
+	StoreNotes(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error
+	// GetNotes fetches the stored staff notes for the ticket, returning archiverclient.ErrNotFound if there are none
+	GetNotes(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error)
+	// Replace and ReplaceNotes overwrite a transcript previously fetched with Get or GetNotes
+	Replace(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error
+	ReplaceNotes(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error
+	PreservesAttachments() bool
+	StoreAttachment(ctx context.Context, guildId uint64, ticketId int, attachmentId uint64, filename, contentType string, data []byte) (string, error)
+}
+
+// IntegrationProxy performs requests to third party integration endpoints on behalf of the worker, so that requests
+// cannot be made to internal services
+type IntegrationProxy interface {
+	DoRequest(ctx context.Context, method, url string, headers map[string]string, body any) ([]byte, error)
+}
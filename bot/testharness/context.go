@@ -0,0 +1,285 @@
+package testharness
+
+import (
+	"context"
+	"sync"
+
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/gdl/objects"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/guild"
+	"github.com/TicketsBot-cloud/gdl/objects/guild/emoji"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/command"
+	"github.com/TicketsBot-cloud/worker/bot/command/registry"
+	"github.com/TicketsBot-cloud/worker/bot/customisation"
+	"github.com/TicketsBot-cloud/worker/bot/errorcontext"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+	"github.com/TicketsBot-cloud/worker/i18n"
+)
+
+// Reply is a reply which was sent through a CommandContext. Replies sent with a message ID record the ID in
+// TitleId/ContentId, while raw replies record the text in Title/Content.
+type Reply struct {
+	Colour    customisation.Colour
+	TitleId   i18n.MessageId
+	ContentId i18n.MessageId
+	Title     string
+	Content   string
+	Format    []interface{}
+	Fields    []embed.EmbedField
+	Permanent bool
+
+	// Response is set for replies sent with ReplyWith, ReplyWithEmbed and ReplyWithEmbedPermanent
+	Response *command.MessageResponse
+}
+
+// CommandContext is a registry.InteractionContext which records replies and errors, rather than sending them to
+// Discord. Unlike the contexts in bot/command/context, messages are not rendered, as rendering looks up the guild's
+// language in the database: use Reply.ContentId to assert on which message was sent.
+type CommandContext struct {
+	context.Context
+	worker  *worker.Context
+	discord *Discord
+
+	GuildIdValue   uint64
+	ChannelIdValue uint64
+	UserIdValue    uint64
+
+	PermissionLevel permcache.PermissionLevel
+	Premium         premium.PremiumTier
+	Blacklisted     bool
+
+	// SettingsValue is returned by Settings, in place of the guild's settings row
+	SettingsValue database.Settings
+
+	mu       sync.Mutex
+	replies  []Reply
+	errors   []error
+	warnings []error
+}
+
+var _ registry.InteractionContext = (*CommandContext)(nil)
+
+func (c *CommandContext) Worker() *worker.Context {
+	return c.worker
+}
+
+func (c *CommandContext) GuildId() uint64 {
+	return c.GuildIdValue
+}
+
+func (c *CommandContext) ChannelId() uint64 {
+	return c.ChannelIdValue
+}
+
+func (c *CommandContext) UserId() uint64 {
+	return c.UserIdValue
+}
+
+func (c *CommandContext) UserPermissionLevel(ctx context.Context) (permcache.PermissionLevel, error) {
+	return c.PermissionLevel, nil
+}
+
+func (c *CommandContext) PremiumTier() premium.PremiumTier {
+	return c.Premium
+}
+
+func (c *CommandContext) IsInteraction() bool {
+	return true
+}
+
+func (c *CommandContext) Source() registry.Source {
+	return registry.SourceDiscord
+}
+
+func (c *CommandContext) ToErrorContext() errorcontext.WorkerErrorContext {
+	return errorcontext.WorkerErrorContext{
+		Guild:   c.GuildIdValue,
+		User:    c.UserIdValue,
+		Channel: c.ChannelIdValue,
+	}
+}
+
+func (c *CommandContext) InteractionMetadata() interaction.InteractionMetadata {
+	return interaction.InteractionMetadata{
+		GuildId:   objects.NewNullableSnowflake(c.GuildIdValue),
+		ChannelId: c.ChannelIdValue,
+		User: &user.User{
+			Id: c.UserIdValue,
+		},
+		Member: &member.Member{
+			User: user.User{
+				Id: c.UserIdValue,
+			},
+		},
+		Locale: i18n.LocaleEnglish.IsoLongCode,
+	}
+}
+
+func (c *CommandContext) record(reply Reply) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.replies = append(c.replies, reply)
+}
+
+func (c *CommandContext) Reply(colour customisation.Colour, title, content i18n.MessageId, format ...interface{}) {
+	c.record(Reply{Colour: colour, TitleId: title, ContentId: content, Format: format})
+}
+
+func (c *CommandContext) ReplyPermanent(colour customisation.Colour, title, content i18n.MessageId, format ...interface{}) {
+	c.record(Reply{Colour: colour, TitleId: title, ContentId: content, Format: format, Permanent: true})
+}
+
+func (c *CommandContext) ReplyWithFields(colour customisation.Colour, title, content i18n.MessageId, fields []embed.EmbedField, format ...interface{}) {
+	c.record(Reply{Colour: colour, TitleId: title, ContentId: content, Format: format, Fields: fields})
+}
+
+func (c *CommandContext) ReplyWithFieldsPermanent(colour customisation.Colour, title, content i18n.MessageId, fields []embed.EmbedField, format ...interface{}) {
+	c.record(Reply{Colour: colour, TitleId: title, ContentId: content, Format: format, Fields: fields, Permanent: true})
+}
+
+func (c *CommandContext) ReplyWith(response command.MessageResponse) (message.Message, error) {
+	c.record(Reply{Response: &response, Content: response.Content, Permanent: response.Flags&uint(message.FlagEphemeral) == 0})
+
+	return message.Message{
+		Id:        c.discord.NextId(),
+		ChannelId: c.ChannelIdValue,
+		Content:   response.Content,
+		Embeds:    dereferenceEmbeds(response.Embeds),
+	}, nil
+}
+
+func (c *CommandContext) ReplyWithEmbed(embed *embed.Embed) {
+	_, _ = c.ReplyWith(command.NewEphemeralEmbedMessageResponse(embed))
+}
+
+func (c *CommandContext) ReplyWithEmbedPermanent(embed *embed.Embed) {
+	_, _ = c.ReplyWith(command.NewEmbedMessageResponse(embed))
+}
+
+func (c *CommandContext) ReplyRaw(colour customisation.Colour, title, content string) {
+	c.record(Reply{Colour: colour, Title: title, Content: content})
+}
+
+func (c *CommandContext) ReplyRawPermanent(colour customisation.Colour, title, content string) {
+	c.record(Reply{Colour: colour, Title: title, Content: content, Permanent: true})
+}
+
+func (c *CommandContext) ReplyPlain(content string) {
+	c.record(Reply{Content: content})
+}
+
+func (c *CommandContext) ReplyPlainPermanent(content string) {
+	c.record(Reply{Content: content, Permanent: true})
+}
+
+func (c *CommandContext) SelectValidEmoji(customEmoji customisation.CustomEmoji, fallback string) *emoji.Emoji {
+	return utils.BuildEmoji(fallback)
+}
+
+func (c *CommandContext) HandleError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errors = append(c.errors, err)
+}
+
+func (c *CommandContext) HandleWarning(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.warnings = append(c.warnings, err)
+}
+
+func (c *CommandContext) GetMessage(messageId i18n.MessageId, format ...interface{}) string {
+	return i18n.GetMessage(i18n.LocaleEnglish, messageId, format...)
+}
+
+func (c *CommandContext) GetColour(colour customisation.Colour) int {
+	return customisation.DefaultColours[colour]
+}
+
+func (c *CommandContext) Channel() (channel.PartialChannel, error) {
+	ch, err := c.worker.GetChannel(c.ChannelIdValue)
+	if err != nil {
+		return channel.PartialChannel{}, err
+	}
+
+	return ch.ToPartialChannel(), nil
+}
+
+func (c *CommandContext) Guild() (guild.Guild, error) {
+	return c.worker.GetGuild(c.GuildIdValue)
+}
+
+func (c *CommandContext) Member() (member.Member, error) {
+	return c.worker.GetGuildMember(c.GuildIdValue, c.UserIdValue)
+}
+
+func (c *CommandContext) User() (user.User, error) {
+	return c.worker.GetUser(c.UserIdValue)
+}
+
+func (c *CommandContext) Settings() (database.Settings, error) {
+	return c.SettingsValue, nil
+}
+
+func (c *CommandContext) IsBlacklisted(ctx context.Context) (bool, error) {
+	return c.Blacklisted, nil
+}
+
+// Replies returns the replies sent so far, in order
+func (c *CommandContext) Replies() []Reply {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Reply(nil), c.replies...)
+}
+
+// LastReply returns the most recent reply, or false if nothing has been sent
+func (c *CommandContext) LastReply() (Reply, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.replies) == 0 {
+		return Reply{}, false
+	}
+
+	return c.replies[len(c.replies)-1], true
+}
+
+// Errors returns the errors passed to HandleError
+func (c *CommandContext) Errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]error(nil), c.errors...)
+}
+
+// Warnings returns the errors passed to HandleWarning
+func (c *CommandContext) Warnings() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]error(nil), c.warnings...)
+}
+
+func dereferenceEmbeds(embeds []*embed.Embed) []embed.Embed {
+	res := make([]embed.Embed, 0, len(embeds))
+	for _, e := range embeds {
+		if e != nil {
+			res = append(res, *e)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,719 @@
+package testharness
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/model"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+)
+
+// Database is an in-memory stand-in for the tables which opening, claiming and closing tickets depend on. Every other
+// table stores nothing and returns zero values from queries. Tests may replace any table on Client.Tables before the
+// code under test runs.
+type Database struct {
+	Client *dbclient.Database
+
+	Tickets       *Tickets
+	TicketClaims  *TicketClaims
+	Participants  *Participants
+	Panels        *Panels
+	Blacklist     *Blacklist
+	TicketMembers *TicketMembers
+
+	ArchiveChannel    *GuildSetting[*uint64]
+	ArchiveMessages   *ArchiveMessages
+	ArchiveDmMessages *ArchiveDmMessages
+
+	TicketLimit   *GuildSetting[uint8]
+	UsersCanClose *GuildSetting[bool]
+	ClaimSettings *GuildSetting[database.ClaimSettings]
+
+	CloseReasonConfig      *PanelConfig[dbclient.CloseReasonConfig]
+	ClosedCategoryConfig   *PanelConfig[dbclient.ClosedCategoryConfig]
+	ClosedChannels         *ClosedChannels
+	FeedbackFollowUpConfig *GuildConfig[dbclient.FeedbackFollowUpConfig]
+	NotesTranscriptConfig  *GuildSetting[dbclient.NotesTranscriptConfig]
+	PreservedAttachments   *PreservedAttachments
+	ReopenLinks            *ReopenLinks
+	SpamBlacklist          *SpamBlacklist
+	SpamConfig             *GuildSetting[dbclient.SpamConfig]
+	SpamStats              *SpamStats
+	StatsDigests           *StatsDigests
+	TicketHistoryConfig    *GuildSetting[dbclient.TicketHistoryConfig]
+	TranscriptExportConfig *GuildConfig[dbclient.TranscriptExportConfig]
+	UserContextMenuConfig  *GuildConfig[dbclient.UserContextMenuConfig]
+}
+
+func NewDatabase() *Database {
+	db := &Database{
+		Tickets:       &Tickets{tickets: make(map[ticketKey]database.Ticket), counters: make(map[uint64]int)},
+		TicketClaims:  &TicketClaims{claims: make(map[ticketKey]uint64)},
+		Participants:  &Participants{participants: make(map[ticketKey]map[uint64]bool)},
+		Panels:        &Panels{panels: make(map[int]database.Panel)},
+		Blacklist:     &Blacklist{users: make(map[uint64]map[uint64]bool)},
+		TicketMembers: &TicketMembers{members: make(map[ticketKey][]uint64)},
+
+		ArchiveChannel:    NewGuildSetting[*uint64](nil),
+		ArchiveMessages:   &ArchiveMessages{messages: make(map[ticketKey]database.ArchiveMessage)},
+		ArchiveDmMessages: &ArchiveDmMessages{messages: make(map[ticketKey]database.ArchiveDmMessage)},
+		TicketLimit:       NewGuildSetting[uint8](5),
+		UsersCanClose:     NewGuildSetting(true),
+		ClaimSettings: NewGuildSetting(database.ClaimSettings{
+			SupportCanView:           true,
+			SupportCanType:           false,
+			SwitchPanelClaimBehavior: database.SwitchPanelAutoUnclaim,
+		}),
+
+		CloseReasonConfig:      NewPanelConfig[dbclient.CloseReasonConfig](),
+		ClosedCategoryConfig:   NewPanelConfig[dbclient.ClosedCategoryConfig](),
+		ClosedChannels:         &ClosedChannels{closed: make(map[ticketKey]dbclient.ClosedChannel)},
+		FeedbackFollowUpConfig: NewGuildConfig[dbclient.FeedbackFollowUpConfig](),
+		NotesTranscriptConfig:  NewGuildSetting(dbclient.NotesTranscriptConfig{}),
+		PreservedAttachments:   &PreservedAttachments{attachments: make(map[uint64]preservedAttachment)},
+		ReopenLinks:            &ReopenLinks{reopenedFrom: make(map[ticketKey]int), reopenedAs: make(map[ticketKey]int)},
+		SpamBlacklist:          &SpamBlacklist{expires: make(map[guildUserKey]time.Time)},
+		SpamConfig:             NewGuildSetting(dbclient.SpamConfig{}),
+		SpamStats:              &SpamStats{stats: make(map[spamStatsKey]dbclient.SpamStats)},
+		StatsDigests:           &StatsDigests{digests: make(map[uint64]statsDigest)},
+		TicketHistoryConfig:    NewGuildSetting(dbclient.TicketHistoryConfig{}),
+		TranscriptExportConfig: NewGuildConfig[dbclient.TranscriptExportConfig](),
+		UserContextMenuConfig:  NewGuildConfig[dbclient.UserContextMenuConfig](),
+	}
+
+	tables := zeroTables()
+	tables.Tickets = db.Tickets
+	tables.TicketClaims = db.TicketClaims
+	tables.Participants = db.Participants
+	tables.Panel = db.Panels
+	tables.Blacklist = db.Blacklist
+	tables.TicketMembers = db.TicketMembers
+	tables.ArchiveChannel = archiveChannel{db.ArchiveChannel}
+	tables.ArchiveMessages = db.ArchiveMessages
+	tables.ArchiveDmMessages = db.ArchiveDmMessages
+	tables.TicketLimit = db.TicketLimit
+	tables.UsersCanClose = db.UsersCanClose
+	tables.ClaimSettings = db.ClaimSettings
+
+	db.Client = &dbclient.Database{
+		Tables: tables,
+		WorkerTables: dbclient.WorkerTables{
+			CloseReasonConfig:      db.CloseReasonConfig,
+			ClosedCategoryConfig:   db.ClosedCategoryConfig,
+			ClosedChannels:         db.ClosedChannels,
+			FeedbackFollowUpConfig: db.FeedbackFollowUpConfig,
+			NotesTranscriptConfig:  db.NotesTranscriptConfig,
+			PreservedAttachments:   db.PreservedAttachments,
+			Reporting:              zeroReportingStore{},
+			ReopenLinks:            db.ReopenLinks,
+			SpamBlacklist:          db.SpamBlacklist,
+			SpamConfig:             db.SpamConfig,
+			SpamStats:              db.SpamStats,
+			StatsDigests:           db.StatsDigests,
+			TicketCounts:           db.Tickets,
+			TicketHistoryConfig:    db.TicketHistoryConfig,
+			TranscriptExportConfig: db.TranscriptExportConfig,
+			UserContextMenuConfig:  db.UserContextMenuConfig,
+			WhitelabelTokens:       zeroWhitelabelTokensStore{},
+		},
+	}
+
+	return db
+}
+
+type zeroReportingStore struct{}
+
+var _ dbclient.ReportingStore = zeroReportingStore{}
+
+func (zeroReportingStore) GetTickets(ctx context.Context, guildId uint64, from, to time.Time, limit int, panelIds []int) ([]dbclient.ReportTicket, error) {
+	return nil, nil
+}
+
+func (zeroReportingStore) GetParticipation(ctx context.Context, guildId uint64, from, to time.Time, panelIds []int) (map[uint64]int, error) {
+	return nil, nil
+}
+
+type zeroWhitelabelTokensStore struct{}
+
+var _ dbclient.WhitelabelTokensStore = zeroWhitelabelTokensStore{}
+
+func (zeroWhitelabelTokensStore) GetAll(ctx context.Context) ([]dbclient.WhitelabelToken, error) {
+	return nil, nil
+}
+
+type ticketKey struct {
+	guildId  uint64
+	ticketId int
+}
+
+// Tickets stores tickets in memory, numbering them per guild as the tickets table does
+type Tickets struct {
+	zeroTicketsStore
+
+	mu       sync.Mutex
+	tickets  map[ticketKey]database.Ticket
+	counters map[uint64]int
+}
+
+var (
+	_ dbclient.TicketsStore      = (*Tickets)(nil)
+	_ dbclient.TicketCountsStore = (*Tickets)(nil)
+)
+
+// Add stores the ticket, as if it had been opened before the test started
+func (t *Tickets) Add(ticket database.Ticket) database.Ticket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ticket.Id == 0 {
+		t.counters[ticket.GuildId]++
+		ticket.Id = t.counters[ticket.GuildId]
+	} else {
+		t.counters[ticket.GuildId] = max(t.counters[ticket.GuildId], ticket.Id)
+	}
+
+	t.tickets[ticketKey{ticket.GuildId, ticket.Id}] = ticket
+	return ticket
+}
+
+// All returns every ticket in the guild, ordered by ID
+func (t *Tickets) All(guildId uint64) []database.Ticket {
+	return t.filter(func(ticket database.Ticket) bool {
+		return ticket.GuildId == guildId
+	})
+}
+
+func (t *Tickets) filter(f func(ticket database.Ticket) bool) []database.Ticket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var tickets []database.Ticket
+	for _, ticket := range t.tickets {
+		if f(ticket) {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].Id < tickets[j].Id
+	})
+
+	return tickets
+}
+
+func (t *Tickets) update(guildId uint64, ticketId int, f func(ticket *database.Ticket)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := ticketKey{guildId, ticketId}
+	if ticket, ok := t.tickets[key]; ok {
+		f(&ticket)
+		t.tickets[key] = ticket
+	}
+
+	return nil
+}
+
+func (t *Tickets) Create(ctx context.Context, guildId, userId uint64, isThread bool, panelId *int) (int, error) {
+	ticket := t.Add(database.Ticket{
+		GuildId:  guildId,
+		UserId:   userId,
+		Open:     true,
+		OpenTime: time.Now(),
+		PanelId:  panelId,
+		IsThread: isThread,
+		Status:   model.TicketStatusOpen,
+	})
+
+	return ticket.Id, nil
+}
+
+func (t *Tickets) Get(ctx context.Context, ticketId int, guildId uint64) (database.Ticket, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.tickets[ticketKey{guildId, ticketId}], nil
+}
+
+func (t *Tickets) GetByChannel(ctx context.Context, channelId uint64) (database.Ticket, bool, error) {
+	tickets := t.filter(func(ticket database.Ticket) bool {
+		return ticket.ChannelId != nil && *ticket.ChannelId == channelId
+	})
+
+	if len(tickets) == 0 {
+		return database.Ticket{}, false, nil
+	}
+
+	return tickets[0], true, nil
+}
+
+func (t *Tickets) GetByChannelAndGuild(ctx context.Context, channelId, guildId uint64) (database.Ticket, error) {
+	ticket, ok, err := t.GetByChannel(ctx, channelId)
+	if err != nil || !ok || ticket.GuildId != guildId {
+		return database.Ticket{}, err
+	}
+
+	return ticket, nil
+}
+
+func (t *Tickets) GetOpenByUser(ctx context.Context, guildId, userId uint64) ([]database.Ticket, error) {
+	return t.filter(func(ticket database.Ticket) bool {
+		return ticket.GuildId == guildId && ticket.UserId == userId && ticket.Open
+	}), nil
+}
+
+func (t *Tickets) GetAllByUser(ctx context.Context, guildId, userId uint64) ([]database.Ticket, error) {
+	return t.filter(func(ticket database.Ticket) bool {
+		return ticket.GuildId == guildId && ticket.UserId == userId
+	}), nil
+}
+
+func (t *Tickets) GetGuildOpenTickets(ctx context.Context, guildId uint64) ([]database.Ticket, error) {
+	return t.filter(func(ticket database.Ticket) bool {
+		return ticket.GuildId == guildId && ticket.Open
+	}), nil
+}
+
+func (t *Tickets) GetOpenCountByUser(ctx context.Context, guildId, userId uint64) (int, error) {
+	tickets, err := t.GetOpenByUser(ctx, guildId, userId)
+	return len(tickets), err
+}
+
+func (t *Tickets) GetOpenCountByUserAndPanel(ctx context.Context, guildId, userId uint64, panelId int) (int, error) {
+	tickets := t.filter(func(ticket database.Ticket) bool {
+		return ticket.GuildId == guildId && ticket.UserId == userId && ticket.Open && ticket.PanelId != nil && *ticket.PanelId == panelId
+	})
+
+	return len(tickets), nil
+}
+
+func (t *Tickets) GetTotalCountByUser(ctx context.Context, guildId, userId uint64) (int, error) {
+	tickets, err := t.GetAllByUser(ctx, guildId, userId)
+	return len(tickets), err
+}
+
+// GetByOptions supports filtering by guild, ID, opener and open state, along with ordering and pagination. The other
+// options are ignored.
+func (t *Tickets) GetByOptions(ctx context.Context, options database.TicketQueryOptions) ([]database.Ticket, error) {
+	tickets := t.filter(func(ticket database.Ticket) bool {
+		return (options.GuildId == 0 || ticket.GuildId == options.GuildId) &&
+			(options.Id == 0 || ticket.Id == options.Id) &&
+			(len(options.UserIds) == 0 || slices.Contains(options.UserIds, ticket.UserId)) &&
+			(options.Open == nil || ticket.Open == *options.Open)
+	})
+
+	if options.Order == database.OrderTypeDescending {
+		slices.Reverse(tickets)
+	}
+
+	tickets = tickets[min(options.Offset, len(tickets)):]
+	if options.Limit > 0 && len(tickets) > options.Limit {
+		tickets = tickets[:options.Limit]
+	}
+
+	return tickets, nil
+}
+
+func (t *Tickets) GetOpenedBefore(ctx context.Context, guildId, userId uint64, ticketId int) (int, error) {
+	tickets := t.filter(func(ticket database.Ticket) bool {
+		return ticket.GuildId == guildId && ticket.UserId == userId && ticket.Id < ticketId
+	})
+
+	return len(tickets), nil
+}
+
+func (t *Tickets) Close(ctx context.Context, ticketId int, guildId uint64) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		now := time.Now()
+		ticket.Open = false
+		ticket.CloseTime = &now
+		ticket.Status = model.TicketStatusClosed
+	})
+}
+
+func (t *Tickets) SetOpen(ctx context.Context, guildId uint64, ticketId int) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.Open = true
+		ticket.CloseTime = nil
+	})
+}
+
+func (t *Tickets) SetChannelId(ctx context.Context, guildId uint64, ticketId int, channelId uint64) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.ChannelId = &channelId
+	})
+}
+
+func (t *Tickets) SetHasTranscript(ctx context.Context, guildId uint64, ticketId int, hasTranscript bool) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.HasTranscript = hasTranscript
+	})
+}
+
+func (t *Tickets) SetJoinMessageId(ctx context.Context, guildId uint64, ticketId int, joinMessageId *uint64) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.JoinMessageId = joinMessageId
+	})
+}
+
+func (t *Tickets) SetMessageIds(ctx context.Context, guildId uint64, ticketId int, welcomeMessageId uint64, joinMessageId *uint64) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.WelcomeMessageId = &welcomeMessageId
+		ticket.JoinMessageId = joinMessageId
+	})
+}
+
+func (t *Tickets) SetNotesThreadId(ctx context.Context, guildId uint64, ticketId int, notesThreadId uint64) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.NotesThreadId = &notesThreadId
+	})
+}
+
+func (t *Tickets) SetPanelId(ctx context.Context, guildId uint64, ticketId, panelId int) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.PanelId = &panelId
+	})
+}
+
+func (t *Tickets) SetStatus(ctx context.Context, guildId uint64, ticketId int, status model.TicketStatus) error {
+	return t.update(guildId, ticketId, func(ticket *database.Ticket) {
+		ticket.Status = status
+	})
+}
+
+// TicketClaims stores the claimer of each ticket in memory
+type TicketClaims struct {
+	zeroTicketClaimsStore
+
+	mu     sync.Mutex
+	claims map[ticketKey]uint64
+}
+
+var _ dbclient.TicketClaimsStore = (*TicketClaims)(nil)
+
+func (c *TicketClaims) Get(ctx context.Context, guildId uint64, ticketId int) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.claims[ticketKey{guildId, ticketId}], nil
+}
+
+func (c *TicketClaims) Set(ctx context.Context, guildId uint64, ticketId int, userId uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.claims[ticketKey{guildId, ticketId}] = userId
+	return nil
+}
+
+func (c *TicketClaims) Delete(ctx context.Context, guildId uint64, ticketId int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.claims, ticketKey{guildId, ticketId})
+	return nil
+}
+
+// Blacklist stores the users blacklisted from opening tickets in each guild in memory
+type Blacklist struct {
+	mu    sync.Mutex
+	users map[uint64]map[uint64]bool
+}
+
+var _ dbclient.BlacklistStore = (*Blacklist)(nil)
+
+func (b *Blacklist) Add(ctx context.Context, guildId, userId uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.users[guildId] == nil {
+		b.users[guildId] = make(map[uint64]bool)
+	}
+
+	b.users[guildId][userId] = true
+	return nil
+}
+
+func (b *Blacklist) GetBlacklistedCount(ctx context.Context, guildId uint64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.users[guildId]), nil
+}
+
+func (b *Blacklist) IsBlacklisted(ctx context.Context, guildId, userId uint64) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.users[guildId][userId], nil
+}
+
+func (b *Blacklist) Remove(ctx context.Context, guildId, userId uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.users[guildId], userId)
+	return nil
+}
+
+// archiveChannel uses the guild's archive channel for every panel
+type archiveChannel struct {
+	*GuildSetting[*uint64]
+}
+
+var _ dbclient.ArchiveChannelStore = archiveChannel{}
+
+func (a archiveChannel) DeleteByChannel(ctx context.Context, channelId uint64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for guildId, value := range a.values {
+		if value != nil && *value == channelId {
+			delete(a.values, guildId)
+		}
+	}
+
+	return nil
+}
+
+func (a archiveChannel) GetByPanel(ctx context.Context, guildId uint64, panelId int) (*uint64, error) {
+	return a.Get(ctx, guildId)
+}
+
+// ArchiveMessages stores the close message posted to the archive channel for each ticket in memory
+type ArchiveMessages struct {
+	mu       sync.Mutex
+	messages map[ticketKey]database.ArchiveMessage
+}
+
+var _ dbclient.ArchiveMessagesStore = (*ArchiveMessages)(nil)
+
+func (a *ArchiveMessages) Get(ctx context.Context, guildId uint64, ticketId int) (database.ArchiveMessage, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	message, ok := a.messages[ticketKey{guildId, ticketId}]
+	return message, ok, nil
+}
+
+func (a *ArchiveMessages) Set(ctx context.Context, guildId uint64, ticketId int, channelId, messageId uint64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.messages[ticketKey{guildId, ticketId}] = database.ArchiveMessage{ChannelId: channelId, MessageId: messageId}
+	return nil
+}
+
+// ArchiveDmMessages stores the close message sent to the opener of each ticket in memory
+type ArchiveDmMessages struct {
+	mu       sync.Mutex
+	messages map[ticketKey]database.ArchiveDmMessage
+}
+
+var _ dbclient.ArchiveDmMessagesStore = (*ArchiveDmMessages)(nil)
+
+func (a *ArchiveDmMessages) Get(ctx context.Context, guildId uint64, ticketId int) (database.ArchiveDmMessage, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	message, ok := a.messages[ticketKey{guildId, ticketId}]
+	return message, ok, nil
+}
+
+func (a *ArchiveDmMessages) Set(ctx context.Context, guildId uint64, ticketId int, messageId uint64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.messages[ticketKey{guildId, ticketId}] = database.ArchiveDmMessage{MessageId: messageId}
+	return nil
+}
+
+// TicketMembers stores the users which have been added to each ticket in memory
+type TicketMembers struct {
+	mu      sync.Mutex
+	members map[ticketKey][]uint64
+}
+
+var _ dbclient.TicketMembersStore = (*TicketMembers)(nil)
+
+func (m *TicketMembers) Add(ctx context.Context, guildId uint64, ticketId int, userId uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := ticketKey{guildId, ticketId}
+	if !slices.Contains(m.members[key], userId) {
+		m.members[key] = append(m.members[key], userId)
+	}
+
+	return nil
+}
+
+func (m *TicketMembers) Delete(ctx context.Context, guildId uint64, ticketId int, userId uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := ticketKey{guildId, ticketId}
+	m.members[key] = slices.DeleteFunc(m.members[key], func(id uint64) bool {
+		return id == userId
+	})
+
+	return nil
+}
+
+func (m *TicketMembers) Get(ctx context.Context, guildId uint64, ticketId int) ([]uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.members[ticketKey{guildId, ticketId}]), nil
+}
+
+// Participants stores the users who have sent messages in each ticket in memory
+type Participants struct {
+	zeroParticipantsStore
+
+	mu           sync.Mutex
+	participants map[ticketKey]map[uint64]bool
+}
+
+var _ dbclient.ParticipantsStore = (*Participants)(nil)
+
+// Get returns the participants of the ticket, ordered by ID
+func (p *Participants) Get(guildId uint64, ticketId int) []uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var userIds []uint64
+	for userId := range p.participants[ticketKey{guildId, ticketId}] {
+		userIds = append(userIds, userId)
+	}
+
+	sort.Slice(userIds, func(i, j int) bool {
+		return userIds[i] < userIds[j]
+	})
+
+	return userIds
+}
+
+func (p *Participants) HasParticipated(ctx context.Context, guildId uint64, ticketId int, userId uint64) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.participants[ticketKey{guildId, ticketId}][userId], nil
+}
+
+func (p *Participants) Set(ctx context.Context, guildId uint64, ticketId int, userId uint64) error {
+	return p.SetBulk(ctx, guildId, ticketId, []uint64{userId})
+}
+
+func (p *Participants) SetBulk(ctx context.Context, guildId uint64, ticketId int, userIds []uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := ticketKey{guildId, ticketId}
+	if p.participants[key] == nil {
+		p.participants[key] = make(map[uint64]bool)
+	}
+
+	for _, userId := range userIds {
+		p.participants[key][userId] = true
+	}
+
+	return nil
+}
+
+// Panels stores panels in memory, keyed by their ID
+type Panels struct {
+	zeroPanelStore
+
+	mu     sync.Mutex
+	panels map[int]database.Panel
+	nextId int
+}
+
+var _ dbclient.PanelStore = (*Panels)(nil)
+
+// Add stores the panel, assigning it an ID if it does not have one
+func (p *Panels) Add(panel database.Panel) database.Panel {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if panel.PanelId == 0 {
+		p.nextId++
+		panel.PanelId = p.nextId
+	}
+
+	p.panels[panel.PanelId] = panel
+	return panel
+}
+
+func (p *Panels) GetById(ctx context.Context, panelId int) (database.Panel, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.panels[panelId], nil
+}
+
+func (p *Panels) GetByGuild(ctx context.Context, guildId uint64) ([]database.Panel, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var panels []database.Panel
+	for _, panel := range p.panels {
+		if panel.GuildId == guildId {
+			panels = append(panels, panel)
+		}
+	}
+
+	sort.Slice(panels, func(i, j int) bool {
+		return panels[i].PanelId < panels[j].PanelId
+	})
+
+	return panels, nil
+}
+
+// GuildSetting stores a per-guild value in memory, returning the table's default for guilds which have not set one
+type GuildSetting[T any] struct {
+	mu           sync.Mutex
+	defaultValue T
+	values       map[uint64]T
+}
+
+var (
+	_ dbclient.TicketLimitStore   = (*GuildSetting[uint8])(nil)
+	_ dbclient.UsersCanCloseStore = (*GuildSetting[bool])(nil)
+	_ dbclient.ClaimSettingsStore = (*GuildSetting[database.ClaimSettings])(nil)
+
+	_ dbclient.NotesTranscriptConfigStore = (*GuildSetting[dbclient.NotesTranscriptConfig])(nil)
+	_ dbclient.SpamConfigStore            = (*GuildSetting[dbclient.SpamConfig])(nil)
+	_ dbclient.TicketHistoryConfigStore   = (*GuildSetting[dbclient.TicketHistoryConfig])(nil)
+)
+
+func NewGuildSetting[T any](defaultValue T) *GuildSetting[T] {
+	return &GuildSetting[T]{
+		defaultValue: defaultValue,
+		values:       make(map[uint64]T),
+	}
+}
+
+func (s *GuildSetting[T]) Get(ctx context.Context, guildId uint64) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value, ok := s.values[guildId]; ok {
+		return value, nil
+	}
+
+	return s.defaultValue, nil
+}
+
+func (s *GuildSetting[T]) Set(ctx context.Context, guildId uint64, value T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[guildId] = value
+	return nil
+}
@@ -0,0 +1,728 @@
+package testharness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/guild"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+)
+
+const discordEpoch = 1420070400000
+
+type document = map[string]any
+
+// Request is a REST request which was received by the fake Discord API
+type Request struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// Discord is an in-memory stand-in for the Discord REST API, covering the channel, member, role, message and thread
+// endpoints used through worker.Context. Objects are stored as JSON documents, so that any field sent by the worker
+// is returned on subsequent reads.
+type Discord struct {
+	mu sync.Mutex
+
+	BotUser user.User
+
+	guilds   map[uint64]document
+	channels map[uint64]document
+	messages map[uint64][]document
+	members  map[uint64]map[uint64]document
+	roles    map[uint64][]document
+	users    map[uint64]document
+
+	// threadMembers maps thread IDs to the set of users that have been added to the thread
+	threadMembers map[uint64]map[uint64]bool
+
+	requests  []Request
+	snowflake uint64
+}
+
+func NewDiscord(botUser user.User) *Discord {
+	return &Discord{
+		BotUser:       botUser,
+		guilds:        make(map[uint64]document),
+		channels:      make(map[uint64]document),
+		messages:      make(map[uint64][]document),
+		members:       make(map[uint64]map[uint64]document),
+		roles:         make(map[uint64][]document),
+		users:         make(map[uint64]document),
+		threadMembers: make(map[uint64]map[uint64]bool),
+	}
+}
+
+// Install routes gdl REST requests made with the given bot token to the fake, returning a function which removes the
+// route. The gdl HTTP client is shared, so requests are routed by token rather than by replacing its transport, which
+// allows tests using separate fakes to run in parallel.
+func (d *Discord) Install(token string) func() {
+	installTransport.Do(func() {
+		request.Client.Transport = tokenTransport{}
+	})
+
+	fakes.Store(token, d)
+	return func() {
+		fakes.Delete(token)
+	}
+}
+
+var (
+	installTransport sync.Once
+	// fakes maps bot tokens to the Discord fake which serves their requests
+	fakes sync.Map
+)
+
+// tokenTransport passes each request to the fake installed for the token in its Authorization header
+type tokenTransport struct{}
+
+func (tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bot ")
+
+	fake, ok := fakes.Load(token)
+	if !ok {
+		return nil, fmt.Errorf("no fake Discord API is installed for the token of %s %s", req.Method, req.URL.Path)
+	}
+
+	return fake.(*Discord).RoundTrip(req)
+}
+
+// NextId generates a unique snowflake with the current timestamp
+func (d *Discord) NextId() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.nextId()
+}
+
+func (d *Discord) nextId() uint64 {
+	d.snowflake++
+	return uint64(time.Now().UnixMilli()-discordEpoch)<<22 | (d.snowflake & 0x3FFFFF)
+}
+
+func (d *Discord) AddGuild(g guild.Guild) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.guilds[g.Id] = toDocument(g)
+}
+
+func (d *Discord) AddChannel(ch channel.Channel) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.channels[ch.Id] = toDocument(ch)
+}
+
+func (d *Discord) AddUser(u user.User) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.users[u.Id] = toDocument(u)
+}
+
+func (d *Discord) AddMember(guildId uint64, m member.Member) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.members[guildId]; !ok {
+		d.members[guildId] = make(map[uint64]document)
+	}
+
+	d.members[guildId][m.User.Id] = toDocument(m)
+	d.users[m.User.Id] = toDocument(m.User)
+}
+
+func (d *Discord) AddRole(guildId uint64, role guild.Role) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.roles[guildId] = append(d.roles[guildId], toDocument(role))
+}
+
+func (d *Discord) Channel(channelId uint64) (channel.Channel, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	doc, ok := d.channels[channelId]
+	if !ok {
+		return channel.Channel{}, false
+	}
+
+	var ch channel.Channel
+	fromDocument(doc, &ch)
+	return ch, true
+}
+
+// Channels returns all channels in the guild, ordered by ID
+func (d *Discord) Channels(guildId uint64) []channel.Channel {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var channels []channel.Channel
+	for _, doc := range d.channels {
+		var ch channel.Channel
+		fromDocument(doc, &ch)
+
+		if ch.GuildId == guildId {
+			channels = append(channels, ch)
+		}
+	}
+
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Id < channels[j].Id
+	})
+
+	return channels
+}
+
+// Messages returns the messages in the channel, oldest first
+func (d *Discord) Messages(channelId uint64) []message.Message {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	messages := make([]message.Message, len(d.messages[channelId]))
+	for i, doc := range d.messages[channelId] {
+		fromDocument(doc, &messages[i])
+	}
+
+	return messages
+}
+
+// AddMessage appends a message to the channel, as if it had been sent by its author. The ID and timestamp are
+// generated if they are not set.
+func (d *Discord) AddMessage(channelId uint64, msg message.Message) message.Message {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if msg.Id == 0 {
+		msg.Id = d.nextId()
+	}
+
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+
+	msg.ChannelId = channelId
+	d.messages[channelId] = append(d.messages[channelId], toDocument(msg))
+
+	return msg
+}
+
+// DMChannel returns the DM channel opened with the user, if the worker has opened one
+func (d *Discord) DMChannel(userId uint64) (channel.Channel, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, doc := range d.channels {
+		var ch channel.Channel
+		fromDocument(doc, &ch)
+
+		if ch.Type == channel.ChannelTypeDM && len(ch.Recipients) == 1 && ch.Recipients[0].Id == userId {
+			return ch, true
+		}
+	}
+
+	return channel.Channel{}, false
+}
+
+func (d *Discord) Member(guildId, userId uint64) (member.Member, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	doc, ok := d.members[guildId][userId]
+	if !ok {
+		return member.Member{}, false
+	}
+
+	var m member.Member
+	fromDocument(doc, &m)
+	return m, true
+}
+
+func (d *Discord) ThreadMembers(threadId uint64) []uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var userIds []uint64
+	for userId := range d.threadMembers[threadId] {
+		userIds = append(userIds, userId)
+	}
+
+	sort.Slice(userIds, func(i, j int) bool {
+		return userIds[i] < userIds[j]
+	})
+
+	return userIds
+}
+
+// Requests returns every request received so far, including those which were not handled
+func (d *Discord) Requests() []Request {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]Request(nil), d.requests...)
+}
+
+func (d *Discord) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if body, err = extractPayload(req.Header.Get("Content-Type"), body); err != nil {
+			return nil, err
+		}
+	}
+
+	path := req.URL.Path
+	if idx := strings.Index(path, "/api/v"); idx != -1 {
+		path = path[idx+len("/api/v"):]
+		if slash := strings.IndexByte(path, '/'); slash != -1 {
+			path = path[slash:]
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.requests = append(d.requests, Request{
+		Method: req.Method,
+		Path:   path,
+		Body:   body,
+	})
+
+	status, res := d.handle(req.Method, strings.Split(strings.Trim(path, "/"), "/"), body)
+
+	var encoded []byte
+	if res != nil {
+		var err error
+		if encoded, err = json.Marshal(res); err != nil {
+			return nil, err
+		}
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(encoded)),
+		Request:    req,
+	}, nil
+}
+
+func (d *Discord) handle(method string, segments []string, body []byte) (int, any) {
+	route := method + " " + routePattern(segments)
+
+	switch route {
+	case "GET /guilds/{id}":
+		if doc, ok := d.guilds[snowflake(segments[1])]; ok {
+			return 200, doc
+		}
+
+		return notFound(10004, "Unknown Guild")
+	case "GET /guilds/{id}/channels":
+		guildId := segments[1]
+
+		channels := make([]document, 0)
+		for _, doc := range d.channels {
+			if doc["guild_id"] == guildId {
+				channels = append(channels, doc)
+			}
+		}
+
+		return 200, channels
+	case "POST /guilds/{id}/channels":
+		doc, err := parseDocument(body)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		doc["id"] = strconv.FormatUint(d.nextId(), 10)
+		doc["guild_id"] = segments[1]
+		d.channels[snowflake(doc["id"].(string))] = doc
+
+		return 201, doc
+	case "GET /guilds/{id}/roles":
+		roles := d.roles[snowflake(segments[1])]
+		if roles == nil {
+			roles = make([]document, 0)
+		}
+
+		return 200, roles
+	case "GET /guilds/{id}/members/{id}":
+		if doc, ok := d.members[snowflake(segments[1])][snowflake(segments[3])]; ok {
+			return 200, doc
+		}
+
+		return notFound(10007, "Unknown Member")
+	case "PUT /guilds/{id}/members/{id}/roles/{id}", "DELETE /guilds/{id}/members/{id}/roles/{id}":
+		doc, ok := d.members[snowflake(segments[1])][snowflake(segments[3])]
+		if !ok {
+			return notFound(10007, "Unknown Member")
+		}
+
+		roleId := segments[5]
+
+		var roles []any
+		if existing, ok := doc["roles"].([]any); ok {
+			for _, role := range existing {
+				if role != roleId {
+					roles = append(roles, role)
+				}
+			}
+		}
+
+		if method == http.MethodPut {
+			roles = append(roles, roleId)
+		}
+
+		doc["roles"] = roles
+		return 204, nil
+	case "GET /channels/{id}":
+		if doc, ok := d.channels[snowflake(segments[1])]; ok {
+			return 200, doc
+		}
+
+		return notFound(10003, "Unknown Channel")
+	case "PATCH /channels/{id}":
+		doc, ok := d.channels[snowflake(segments[1])]
+		if !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		changes, err := parseDocument(body)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		applyChannelChanges(doc, changes)
+		return 200, doc
+	case "DELETE /channels/{id}":
+		channelId := snowflake(segments[1])
+
+		doc, ok := d.channels[channelId]
+		if !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		delete(d.channels, channelId)
+		delete(d.messages, channelId)
+		return 200, doc
+	case "PUT /channels/{id}/permissions/{id}":
+		doc, ok := d.channels[snowflake(segments[1])]
+		if !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		overwrite, err := parseDocument(body)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		overwrite["id"] = segments[3]
+
+		existing, _ := doc["permission_overwrites"].([]any)
+		overwrites := make([]any, 0, len(existing)+1)
+		for _, o := range existing {
+			if o, ok := o.(document); ok && o["id"] == segments[3] {
+				continue
+			}
+
+			overwrites = append(overwrites, o)
+		}
+
+		doc["permission_overwrites"] = append(overwrites, overwrite)
+		return 204, nil
+	case "GET /channels/{id}/messages":
+		channelId := snowflake(segments[1])
+		if _, ok := d.channels[channelId]; !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		// Discord returns the newest messages first
+		messages := make([]document, 0, len(d.messages[channelId]))
+		for i := len(d.messages[channelId]) - 1; i >= 0; i-- {
+			messages = append(messages, d.messages[channelId][i])
+		}
+
+		return 200, messages
+	case "POST /channels/{id}/messages":
+		channelId := snowflake(segments[1])
+		if _, ok := d.channels[channelId]; !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		doc, err := parseDocument(body)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		doc["id"] = strconv.FormatUint(d.nextId(), 10)
+		doc["channel_id"] = segments[1]
+		doc["author"] = toDocument(d.BotUser)
+
+		// Uploads reference their multipart file by index, and are given a snowflake once stored
+		if attachments, ok := doc["attachments"].([]any); ok {
+			for _, attachment := range attachments {
+				if attachment, ok := attachment.(document); ok {
+					attachment["id"] = strconv.FormatUint(d.nextId(), 10)
+				}
+			}
+		}
+		doc["timestamp"] = time.Now().Format(time.RFC3339)
+		d.messages[channelId] = append(d.messages[channelId], doc)
+
+		return 200, doc
+	case "GET /channels/{id}/messages/{id}", "PATCH /channels/{id}/messages/{id}", "DELETE /channels/{id}/messages/{id}":
+		channelId := snowflake(segments[1])
+
+		for i, doc := range d.messages[channelId] {
+			if doc["id"] != segments[3] {
+				continue
+			}
+
+			switch method {
+			case http.MethodPatch:
+				changes, err := parseDocument(body)
+				if err != nil {
+					return badRequest(err)
+				}
+
+				for key, value := range changes {
+					doc[key] = value
+				}
+			case http.MethodDelete:
+				d.messages[channelId] = append(d.messages[channelId][:i], d.messages[channelId][i+1:]...)
+				return 204, nil
+			}
+
+			return 200, doc
+		}
+
+		return notFound(10008, "Unknown Message")
+	case "POST /channels/{id}/threads", "POST /channels/{id}/messages/{id}/threads":
+		parent, ok := d.channels[snowflake(segments[1])]
+		if !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		doc, err := parseDocument(body)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		threadId := d.nextId()
+		doc["id"] = strconv.FormatUint(threadId, 10)
+		doc["guild_id"] = parent["guild_id"]
+		doc["parent_id"] = segments[1]
+		doc["owner_id"] = strconv.FormatUint(d.BotUser.Id, 10)
+		if _, ok := doc["type"]; !ok {
+			doc["type"] = channel.ChannelTypeGuildPublicThread
+		}
+
+		metadata := document{"archived": false, "locked": false}
+		if duration, ok := doc["auto_archive_duration"]; ok {
+			metadata["auto_archive_duration"] = duration
+			delete(doc, "auto_archive_duration")
+		}
+
+		doc["thread_metadata"] = metadata
+		d.channels[threadId] = doc
+
+		return 201, doc
+	case "PUT /channels/{id}/thread-members/{id}", "DELETE /channels/{id}/thread-members/{id}":
+		threadId := snowflake(segments[1])
+		if _, ok := d.channels[threadId]; !ok {
+			return notFound(10003, "Unknown Channel")
+		}
+
+		if _, ok := d.threadMembers[threadId]; !ok {
+			d.threadMembers[threadId] = make(map[uint64]bool)
+		}
+
+		if method == http.MethodPut {
+			d.threadMembers[threadId][snowflake(segments[3])] = true
+		} else {
+			delete(d.threadMembers[threadId], snowflake(segments[3]))
+		}
+
+		return 204, nil
+	case "POST /users/@me/channels":
+		var data struct {
+			RecipientId uint64 `json:"recipient_id,string"`
+		}
+
+		if err := json.Unmarshal(body, &data); err != nil {
+			return badRequest(err)
+		}
+
+		recipient, ok := d.users[data.RecipientId]
+		if !ok {
+			return notFound(10013, "Unknown User")
+		}
+
+		// Reuse the existing DM channel with the user, if there is one
+		for _, doc := range d.channels {
+			if recipients, ok := doc["recipients"].([]any); ok && len(recipients) == 1 {
+				if r, ok := recipients[0].(document); ok && r["id"] == recipient["id"] {
+					return 200, doc
+				}
+			}
+		}
+
+		doc := document{
+			"id":         strconv.FormatUint(d.nextId(), 10),
+			"type":       channel.ChannelTypeDM,
+			"recipients": []any{recipient},
+		}
+
+		d.channels[snowflake(doc["id"].(string))] = doc
+		return 200, doc
+	case "GET /users/{id}", "GET /users/@me":
+		userId := snowflake(segments[1])
+		if segments[1] == "@me" {
+			userId = d.BotUser.Id
+		}
+
+		if userId == d.BotUser.Id {
+			return 200, toDocument(d.BotUser)
+		}
+
+		if doc, ok := d.users[userId]; ok {
+			return 200, doc
+		}
+
+		return notFound(10013, "Unknown User")
+	default:
+		return notFound(0, fmt.Sprintf("%s is not implemented by the fake Discord API", route))
+	}
+}
+
+// routePattern replaces snowflakes in the path with {id}, so that routes can be matched with a switch
+func routePattern(segments []string) string {
+	pattern := make([]string, len(segments))
+	for i, segment := range segments {
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			pattern[i] = "{id}"
+		} else {
+			pattern[i] = segment
+		}
+	}
+
+	return "/" + strings.Join(pattern, "/")
+}
+
+// applyChannelChanges merges a modify channel request into the stored channel, moving thread fields into the thread
+// metadata object as Discord does
+func applyChannelChanges(doc, changes document) {
+	metadata, isThread := doc["thread_metadata"].(document)
+
+	for key, value := range changes {
+		switch key {
+		case "archived", "locked", "auto_archive_duration", "invitable":
+			if isThread {
+				metadata[key] = value
+				continue
+			}
+		}
+
+		doc[key] = value
+	}
+}
+
+// extractPayload returns the JSON payload of the request, which is sent in the payload_json field of multipart requests
+func extractPayload(contentType string, body []byte) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return body, nil
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "payload_json" {
+			return io.ReadAll(part)
+		}
+	}
+}
+
+func parseDocument(body []byte) (document, error) {
+	doc := make(document)
+	if len(body) == 0 {
+		return doc, nil
+	}
+
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func toDocument(v any) document {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		panic(err)
+	}
+
+	return doc
+}
+
+func fromDocument(doc document, v any) {
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(encoded, v); err != nil {
+		panic(err)
+	}
+}
+
+func snowflake(s string) uint64 {
+	id, _ := strconv.ParseUint(s, 10, 64)
+	return id
+}
+
+func notFound(code int, message string) (int, any) {
+	return 404, document{
+		"code":    code,
+		"message": message,
+	}
+}
+
+func badRequest(err error) (int, any) {
+	return 400, document{
+		"code":    50035,
+		"message": err.Error(),
+	}
+}
@@ -0,0 +1,121 @@
+package testharness
+
+import (
+	"testing"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/guild"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscordChannels(t *testing.T) {
+	h := New(t)
+
+	guildId := h.Discord.NextId()
+	h.Discord.AddGuild(guild.Guild{Id: guildId, Name: "Test"})
+
+	created, err := h.Worker.CreateGuildChannel(t.Context(), guildId, rest.CreateChannelData{
+		Name: "ticket-1",
+		Type: channel.ChannelTypeGuildText,
+	})
+	require.NoError(t, err)
+	require.Equal(t, guildId, created.GuildId)
+
+	fetched, err := h.Worker.GetChannel(created.Id)
+	require.NoError(t, err)
+	require.Equal(t, "ticket-1", fetched.Name)
+
+	name := "closed-1"
+	_, err = h.Worker.ModifyChannel(t.Context(), created.Id, rest.ModifyChannelData{Name: name})
+	require.NoError(t, err)
+
+	stored, ok := h.Discord.Channel(created.Id)
+	require.True(t, ok)
+	require.Equal(t, name, stored.Name)
+
+	_, err = h.Worker.DeleteChannel(t.Context(), created.Id)
+	require.NoError(t, err)
+
+	_, err = h.Worker.GetChannel(created.Id)
+	var restError request.RestError
+	require.ErrorAs(t, err, &restError)
+	require.Equal(t, 10003, int(restError.ApiError.Code))
+}
+
+func TestDiscordMessagesAndThreads(t *testing.T) {
+	h := New(t)
+
+	guildId := h.Discord.NextId()
+	channelId := h.Discord.NextId()
+	h.Discord.AddChannel(channel.Channel{Id: channelId, GuildId: guildId, Type: channel.ChannelTypeGuildText})
+
+	msg, err := h.Worker.CreateMessage(channelId, "hello")
+	require.NoError(t, err)
+	require.Equal(t, h.Bot.Id, msg.Author.Id)
+
+	_, err = h.Worker.EditMessage(channelId, msg.Id, rest.EditMessageData{Content: "edited"})
+	require.NoError(t, err)
+
+	messages := h.Discord.Messages(channelId)
+	require.Len(t, messages, 1)
+	require.Equal(t, "edited", messages[0].Content)
+
+	thread, err := h.Worker.CreatePrivateThread(t.Context(), channelId, "ticket-1", 10080, false)
+	require.NoError(t, err)
+	require.Equal(t, channelId, thread.ParentId.Value)
+
+	userId := h.Discord.NextId()
+	require.NoError(t, h.Worker.AddThreadMember(thread.Id, userId))
+	require.Equal(t, []uint64{userId}, h.Discord.ThreadMembers(thread.Id))
+}
+
+func TestDiscordMembers(t *testing.T) {
+	h := New(t)
+
+	guildId := h.Discord.NextId()
+	roleId := h.Discord.NextId()
+	u := user.User{Id: h.Discord.NextId(), Username: "member"}
+
+	h.Discord.AddRole(guildId, guild.Role{Id: roleId, Name: "Support"})
+	h.Discord.AddMember(guildId, member.Member{User: u})
+
+	require.NoError(t, h.Worker.AddGuildMemberRole(t.Context(), guildId, u.Id, roleId))
+
+	m, err := h.Worker.GetGuildMember(guildId, u.Id)
+	require.NoError(t, err)
+	require.Equal(t, "member", m.User.Username)
+	require.Contains(t, m.Roles, roleId)
+
+	roles, err := h.Worker.GetGuildRoles(guildId)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+
+	dm, err := h.Worker.CreateDM(u.Id)
+	require.NoError(t, err)
+
+	_, err = h.Worker.CreateMessage(dm.Id, "hello")
+	require.NoError(t, err)
+	require.Len(t, h.Discord.Messages(dm.Id), 1)
+}
+
+func TestDiscordRoutesByToken(t *testing.T) {
+	t.Parallel()
+
+	first, second := New(t), New(t)
+
+	channelId := first.Discord.NextId()
+	first.Discord.AddChannel(channel.Channel{Id: channelId, Type: channel.ChannelTypeGuildText})
+
+	_, err := first.Worker.CreateMessage(channelId, "hello")
+	require.NoError(t, err)
+
+	// The channel only exists in the first harness's fake
+	_, err = second.Worker.CreateMessage(channelId, "hello")
+	require.Error(t, err)
+	require.Len(t, first.Discord.Requests(), 1)
+	require.Len(t, second.Discord.Requests(), 1)
+}
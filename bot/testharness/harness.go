@@ -0,0 +1,115 @@
+// Package testharness provides in-memory stand-ins for Discord, Redis, the database and the services on worker.Context,
+// so that commands and logic can be tested without network access.
+//
+// The database stand-in keeps state for the tables listed on Database, and every other table returns zero values.
+// Libraries which take the database module's client directly, such as permission lookups, cannot reach it: seed their
+// caches instead, for example with SetPermissionLevel.
+package testharness
+
+import (
+	"context"
+	"testing"
+
+	"fmt"
+	permcache "github.com/TicketsBot-cloud/common/permission"
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/worker"
+	botredis "github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"sync/atomic"
+)
+
+// nextToken numbers the bot token of each harness, so that requests to the fake Discord API can be routed by token
+var nextToken atomic.Uint64
+
+type Harness struct {
+	Discord          *Discord
+	Redis            *Redis
+	Database         *Database
+	Cache            *Cache
+	Premium          *Premium
+	Archiver         *Archiver
+	IntegrationProxy *IntegrationProxy
+	Services         *services.Services
+	Worker           *worker.Context
+
+	Bot user.User
+}
+
+// New creates a harness, installing the fake Discord API, Redis server and database until the test completes. Each
+// harness has its own bot token and services, so tests using separate harnesses may run in parallel.
+func New(t testing.TB) *Harness {
+	t.Helper()
+
+	h := &Harness{
+		Redis:            NewRedis(),
+		Database:         NewDatabase(),
+		Premium:          NewPremium(premium.None),
+		Archiver:         NewArchiver(),
+		IntegrationProxy: &IntegrationProxy{},
+	}
+
+	h.Discord = NewDiscord(user.User{})
+	h.Bot = user.User{
+		Id:       h.Discord.NextId(),
+		Username: "Tickets",
+		Bot:      true,
+	}
+
+	h.Discord.BotUser = h.Bot
+	h.Discord.AddUser(h.Bot)
+
+	token := fmt.Sprintf("test-token-%d", nextToken.Add(1))
+	t.Cleanup(h.Discord.Install(token))
+
+	redisClient := botredis.NewClient(h.Redis.Client())
+	t.Cleanup(func() {
+		_ = redisClient.Close()
+	})
+
+	h.Cache = NewCache()
+	h.Services = &services.Services{
+		Database:         h.Database.Client,
+		Redis:            redisClient,
+		Cache:            h.Cache,
+		Premium:          h.Premium,
+		Archiver:         h.Archiver,
+		IntegrationProxy: h.IntegrationProxy,
+	}
+
+	h.Worker = &worker.Context{
+		Token:        token,
+		BotId:        h.Bot.Id,
+		IsWhitelabel: false,
+		Cache:        h.Cache,
+		Services:     h.Services,
+	}
+
+	return h
+}
+
+// NewCommandContext creates a context for a user invoking a command in the given channel
+func (h *Harness) NewCommandContext(guildId, channelId, userId uint64, permissionLevel permcache.PermissionLevel) *CommandContext {
+	return &CommandContext{
+		Context:         context.Background(),
+		worker:          h.Worker,
+		discord:         h.Discord,
+		GuildIdValue:    guildId,
+		ChannelIdValue:  channelId,
+		UserIdValue:     userId,
+		PermissionLevel: permissionLevel,
+		Premium:         premium.None,
+	}
+}
+
+// SetPermissionLevel caches the user's permission level in the guild, so that permission lookups do not need to query
+// the database
+func (h *Harness) SetPermissionLevel(t testing.TB, guildId, userId uint64, level permcache.PermissionLevel) {
+	t.Helper()
+
+	cache := permcache.NewRedisCache(h.Services.Redis.Client)
+	if err := cache.SetCachedPermissionLevel(context.Background(), guildId, userId, level); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,616 @@
+package testharness
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis is an in-memory stand-in for a Redis server, supporting the string and sorted set commands used by the worker,
+// transactions, and the Lua scripts listed in redisscripts.go. Clients are connected to it over net.Pipe, so no network
+// access is required.
+type Redis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	zsets   map[string]map[string]float64
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+type redisReply interface{}
+
+type redisStatus string
+
+type redisError string
+
+func NewRedis() *Redis {
+	return &Redis{
+		values:  make(map[string]string),
+		zsets:   make(map[string]map[string]float64),
+		expires: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Client returns a go-redis client connected to the in-memory server
+func (r *Redis) Client() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go r.serve(server)
+			return client, nil
+		},
+		PoolSize: 1,
+	})
+}
+
+// Keys returns all keys which have not expired
+func (r *Redis) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]string, 0, len(r.values)+len(r.zsets))
+	for key := range r.values {
+		if r.exists(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	for key := range r.zsets {
+		if r.exists(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func (r *Redis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	// Commands sent after MULTI are queued, and run together on EXEC
+	var queued [][]string
+	var inMulti bool
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		var reply redisReply
+		switch command := strings.ToUpper(args[0]); {
+		case command == "MULTI":
+			inMulti, queued = true, nil
+			reply = redisStatus("OK")
+		case command == "EXEC":
+			replies := make([]redisReply, len(queued))
+
+			r.mu.Lock()
+			for i, queuedArgs := range queued {
+				replies[i] = r.execute(queuedArgs)
+			}
+			r.mu.Unlock()
+
+			inMulti, queued = false, nil
+			reply = replies
+		case command == "DISCARD":
+			inMulti, queued = false, nil
+			reply = redisStatus("OK")
+		case inMulti:
+			queued = append(queued, args)
+			reply = redisStatus("QUEUED")
+		default:
+			r.mu.Lock()
+			reply = r.execute(args)
+			r.mu.Unlock()
+		}
+
+		writeReply(writer, reply)
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// execute runs a single command. r.mu must be held.
+func (r *Redis) execute(args []string) redisReply {
+	if len(args) == 0 {
+		return redisError("ERR empty command")
+	}
+
+	command, args := strings.ToUpper(args[0]), args[1:]
+	switch command {
+	case "PING":
+		return redisStatus("PONG")
+	case "GET", "GETDEL":
+		if len(args) != 1 {
+			return wrongArgs(command)
+		}
+
+		if !r.exists(args[0]) {
+			return nil
+		}
+
+		value := r.values[args[0]]
+		if command == "GETDEL" {
+			r.delete(args[0])
+		}
+
+		return value
+	case "MGET":
+		if len(args) == 0 {
+			return wrongArgs(command)
+		}
+
+		values := make([]redisReply, len(args))
+		for i, key := range args {
+			if r.exists(key) {
+				values[i] = r.values[key]
+			}
+		}
+
+		return values
+	case "SET":
+		return r.set(args)
+	case "SETNX":
+		if len(args) != 2 {
+			return wrongArgs(command)
+		}
+
+		if r.exists(args[0]) {
+			return int64(0)
+		}
+
+		r.values[args[0]] = args[1]
+		delete(r.expires, args[0])
+		return int64(1)
+	case "DEL", "EXISTS":
+		var count int64
+		for _, key := range args {
+			if r.exists(key) {
+				count++
+
+				if command == "DEL" {
+					r.delete(key)
+				}
+			}
+		}
+
+		return count
+	case "TTL", "PTTL":
+		if len(args) != 1 {
+			return wrongArgs(command)
+		}
+
+		if !r.exists(args[0]) {
+			return int64(-2)
+		}
+
+		expiry, ok := r.expires[args[0]]
+		if !ok {
+			return int64(-1)
+		}
+
+		remaining := expiry.Sub(r.now())
+		if command == "TTL" {
+			return int64(remaining.Round(time.Second) / time.Second)
+		}
+
+		return remaining.Milliseconds()
+	case "EXPIRE":
+		if len(args) != 2 {
+			return wrongArgs(command)
+		}
+
+		seconds, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return redisError("ERR value is not an integer or out of range")
+		}
+
+		if !r.exists(args[0]) {
+			return int64(0)
+		}
+
+		r.expires[args[0]] = r.now().Add(time.Duration(seconds) * time.Second)
+		return int64(1)
+	case "INCR", "INCRBY":
+		if (command == "INCR" && len(args) != 1) || (command == "INCRBY" && len(args) != 2) {
+			return wrongArgs(command)
+		}
+
+		by := int64(1)
+		if command == "INCRBY" {
+			parsed, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return redisError("ERR value is not an integer or out of range")
+			}
+
+			by = parsed
+		}
+
+		var current int64
+		if r.exists(args[0]) {
+			parsed, err := strconv.ParseInt(r.values[args[0]], 10, 64)
+			if err != nil {
+				return redisError("ERR value is not an integer or out of range")
+			}
+
+			current = parsed
+		}
+
+		current += by
+		r.values[args[0]] = strconv.FormatInt(current, 10)
+		return current
+	case "ZADD", "ZREM", "ZSCORE", "ZRANGE", "ZRANGEBYSCORE":
+		return r.executeSortedSet(command, args)
+	case "EVAL":
+		return r.eval(args)
+	case "EVALSHA":
+		// Scripts are never cached, so that clients fall back to sending the source with EVAL
+		return redisError("NOSCRIPT No matching script. Please use EVAL.")
+	default:
+		return redisError(fmt.Sprintf("ERR unknown command '%s'", command))
+	}
+}
+
+// set implements SET key value [EX seconds | PX milliseconds | KEEPTTL] [NX | XX]
+func (r *Redis) set(args []string) redisReply {
+	if len(args) < 2 {
+		return wrongArgs("SET")
+	}
+
+	key, value := args[0], args[1]
+
+	var ttl time.Duration
+	var keepTtl, nx, xx bool
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "EX", "PX":
+			if i+1 >= len(args) {
+				return redisError("ERR syntax error")
+			}
+
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return redisError("ERR value is not an integer or out of range")
+			}
+
+			if strings.ToUpper(args[i]) == "EX" {
+				ttl = time.Duration(n) * time.Second
+			} else {
+				ttl = time.Duration(n) * time.Millisecond
+			}
+
+			i++
+		case "KEEPTTL":
+			keepTtl = true
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		default:
+			return redisError("ERR syntax error")
+		}
+	}
+
+	exists := r.exists(key)
+	if (nx && exists) || (xx && !exists) {
+		return nil
+	}
+
+	r.values[key] = value
+	delete(r.zsets, key)
+	if ttl > 0 {
+		r.expires[key] = r.now().Add(ttl)
+	} else if !keepTtl {
+		delete(r.expires, key)
+	}
+
+	return redisStatus("OK")
+}
+
+// executeSortedSet implements ZADD, ZREM, ZSCORE, ZRANGE and ZRANGEBYSCORE, without the optional flags which the worker
+// does not use. r.mu must be held.
+func (r *Redis) executeSortedSet(command string, args []string) redisReply {
+	if len(args) < 1 {
+		return wrongArgs(command)
+	}
+
+	key := args[0]
+	if !r.exists(key) {
+		delete(r.zsets, key)
+	}
+
+	if _, ok := r.values[key]; ok {
+		return redisError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	}
+
+	set := r.zsets[key]
+
+	switch command {
+	case "ZADD":
+		if len(args) < 3 || len(args)%2 != 1 {
+			return wrongArgs(command)
+		}
+
+		if set == nil {
+			set = make(map[string]float64)
+			r.zsets[key] = set
+		}
+
+		var added int64
+		for i := 1; i < len(args); i += 2 {
+			score, err := strconv.ParseFloat(args[i], 64)
+			if err != nil {
+				return redisError("ERR value is not a valid float")
+			}
+
+			if _, ok := set[args[i+1]]; !ok {
+				added++
+			}
+
+			set[args[i+1]] = score
+		}
+
+		return added
+	case "ZREM":
+		var removed int64
+		for _, member := range args[1:] {
+			if _, ok := set[member]; ok {
+				delete(set, member)
+				removed++
+			}
+		}
+
+		if len(set) == 0 {
+			r.delete(key)
+		}
+
+		return removed
+	case "ZSCORE":
+		if len(args) != 2 {
+			return wrongArgs(command)
+		}
+
+		score, ok := set[args[1]]
+		if !ok {
+			return nil
+		}
+
+		return formatScore(score)
+	case "ZRANGE":
+		if len(args) < 3 {
+			return wrongArgs(command)
+		}
+
+		start, err1 := strconv.Atoi(args[1])
+		stop, err2 := strconv.Atoi(args[2])
+		if err1 != nil || err2 != nil {
+			return redisError("ERR value is not an integer or out of range")
+		}
+
+		members := sortedMembers(set)
+		if start < 0 {
+			start = max(len(members)+start, 0)
+		}
+
+		if stop < 0 {
+			stop = len(members) + stop
+		}
+
+		stop = min(stop, len(members)-1)
+		if start > stop {
+			return []redisReply{}
+		}
+
+		return rangeReply(set, members[start:stop+1], len(args) > 3 && strings.ToUpper(args[3]) == "WITHSCORES")
+	default: // ZRANGEBYSCORE
+		if len(args) < 3 {
+			return wrongArgs(command)
+		}
+
+		minScore, minExclusive, err1 := parseScoreBound(args[1])
+		maxScore, maxExclusive, err2 := parseScoreBound(args[2])
+		if err1 != nil || err2 != nil {
+			return redisError("ERR min or max is not a float")
+		}
+
+		var withScores bool
+		offset, count := 0, -1
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "WITHSCORES":
+				withScores = true
+			case "LIMIT":
+				if i+2 >= len(args) {
+					return redisError("ERR syntax error")
+				}
+
+				offset, _ = strconv.Atoi(args[i+1])
+				count, _ = strconv.Atoi(args[i+2])
+				i += 2
+			default:
+				return redisError("ERR syntax error")
+			}
+		}
+
+		var members []string
+		for _, member := range sortedMembers(set) {
+			score := set[member]
+			if score < minScore || (minExclusive && score == minScore) || score > maxScore || (maxExclusive && score == maxScore) {
+				continue
+			}
+
+			members = append(members, member)
+		}
+
+		members = members[min(offset, len(members)):]
+		if count >= 0 && count < len(members) {
+			members = members[:count]
+		}
+
+		return rangeReply(set, members, withScores)
+	}
+}
+
+// sortedMembers returns the members of the set ordered by score, then lexicographically
+func sortedMembers(set map[string]float64) []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if set[members[i]] != set[members[j]] {
+			return set[members[i]] < set[members[j]]
+		}
+
+		return members[i] < members[j]
+	})
+
+	return members
+}
+
+func rangeReply(set map[string]float64, members []string, withScores bool) redisReply {
+	reply := make([]redisReply, 0, len(members)*2)
+	for _, member := range members {
+		reply = append(reply, member)
+		if withScores {
+			reply = append(reply, formatScore(set[member]))
+		}
+	}
+
+	return reply
+}
+
+func parseScoreBound(bound string) (float64, bool, error) {
+	exclusive := strings.HasPrefix(bound, "(")
+	bound = strings.TrimPrefix(bound, "(")
+
+	switch bound {
+	case "-inf":
+		return math.Inf(-1), exclusive, nil
+	case "+inf", "inf":
+		return math.Inf(1), exclusive, nil
+	}
+
+	score, err := strconv.ParseFloat(bound, 64)
+	return score, exclusive, err
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+// exists reports whether the key is present, removing it if it has expired. r.mu must be held.
+func (r *Redis) exists(key string) bool {
+	_, isString := r.values[key]
+	_, isSortedSet := r.zsets[key]
+	if !isString && !isSortedSet {
+		return false
+	}
+
+	if expiry, ok := r.expires[key]; ok && !r.now().Before(expiry) {
+		r.delete(key)
+		return false
+	}
+
+	return true
+}
+
+// delete removes the key, whatever its type. r.mu must be held.
+func (r *Redis) delete(key string) {
+	delete(r.values, key)
+	delete(r.zsets, key)
+	delete(r.expires, key)
+}
+
+func wrongArgs(command string) redisError {
+	return redisError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(command)))
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 || line[0] != '*' {
+		return nil, errors.New("expected array")
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(line) == 0 || line[0] != '$' {
+			return nil, errors.New("expected bulk string")
+		}
+
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, length+2) // Include trailing CRLF
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+
+		args[i] = string(buf[:length])
+	}
+
+	return args, nil
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+func writeReply(writer *bufio.Writer, reply redisReply) {
+	switch v := reply.(type) {
+	case nil:
+		writer.WriteString("$-1\r\n")
+	case redisStatus:
+		fmt.Fprintf(writer, "+%s\r\n", v)
+	case redisError:
+		fmt.Fprintf(writer, "-%s\r\n", v)
+	case int64:
+		fmt.Fprintf(writer, ":%d\r\n", v)
+	case string:
+		fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(v), v)
+	case []redisReply:
+		fmt.Fprintf(writer, "*%d\r\n", len(v))
+		for _, element := range v {
+			writeReply(writer, element)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package testharness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisTransaction(t *testing.T) {
+	client := NewRedis().Client()
+	defer client.Close()
+
+	pipe := client.TxPipeline()
+	pipe.Set(t.Context(), "a", "1", 0)
+	incr := pipe.Incr(t.Context(), "a")
+	_, err := pipe.Exec(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), incr.Val())
+
+	value, err := client.Get(t.Context(), "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, "2", value)
+}
+
+func TestRedisSortedSet(t *testing.T) {
+	client := NewRedis().Client()
+	defer client.Close()
+
+	require.NoError(t, client.ZAdd(t.Context(), "set", &redis.Z{Score: 3, Member: "c"}, &redis.Z{Score: 1, Member: "a"}, &redis.Z{Score: 2, Member: "b"}).Err())
+
+	members, err := client.ZRange(t.Context(), "set", 0, -1).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, members)
+
+	due, err := client.ZRangeByScore(t.Context(), "set", &redis.ZRangeBy{Min: "-inf", Max: "2", Count: 1}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, due)
+
+	require.NoError(t, client.ZRem(t.Context(), "set", "a").Err())
+
+	score, err := client.ZScore(t.Context(), "set", "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, float64(2), score)
+
+	_, err = client.ZScore(t.Context(), "set", "a").Result()
+	require.ErrorIs(t, err, redis.Nil)
+}
+
+func TestRedisScripts(t *testing.T) {
+	client := NewRedis().Client()
+	defer client.Close()
+
+	// Tokens are taken until the limit is reached
+	script := redis.NewScript(ticketRateLimitScript)
+	for i := 0; i < 3; i++ {
+		taken, err := script.Run(t.Context(), client, []string{"ratelimit"}, 2, time.Minute.Seconds()).Int()
+		require.NoError(t, err)
+		require.Equal(t, i < 2, taken == 1)
+	}
+
+	// Unknown scripts are rejected, rather than silently doing nothing
+	err := client.Eval(t.Context(), "return 1", nil).Err()
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package testharness
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The sources of the Lua scripts that the worker runs, copied from the worker and its dependencies. If one changes,
+// EVAL returns an error until it is updated here.
+const (
+	// From bot/redis/ticketratelimit.go
+	ticketRateLimitScript = `
+local current = redis.call("GET", KEYS[1])
+local notExists = (not current)
+
+if not current then
+	current = 0
+else
+	current = tonumber(current)
+end
+
+local success = 0
+if current < tonumber(ARGV[1]) then
+	redis.call("INCR", KEYS[1])
+
+	if notExists then
+		redis.call("EXPIRE", KEYS[1], ARGV[2])
+	end
+
+	success = 1
+end
+
+return success
+`
+
+	// From bot/redis/closeoutbox.go
+	claimCloseOutboxScript = `
+local current = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if current ~= ARGV[2] then
+	return 0
+end
+
+redis.call("ZADD", KEYS[1], ARGV[3], ARGV[1])
+return 1
+`
+
+	// From github.com/go-redsync/redsync/v4, used to release locks
+	releaseLockScript = `
+	local val = redis.call("GET", KEYS[1])
+	if val == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	elseif val == false then
+		return -1
+	else
+		return 0
+	end
+`
+)
+
+// redisScript implements a Lua script in Go. It is called with r.mu held.
+type redisScript func(r *Redis, keys, args []string) redisReply
+
+// redisScripts maps the source of each script, with whitespace collapsed, to its implementation. It is populated in
+// init, as the implementations call back into execute.
+var redisScripts map[string]redisScript
+
+func init() {
+	redisScripts = map[string]redisScript{
+		normaliseScript(ticketRateLimitScript):  takeTicketRateLimitToken,
+		normaliseScript(claimCloseOutboxScript): claimCloseOutboxEntry,
+		normaliseScript(releaseLockScript):      releaseLock,
+	}
+}
+
+func takeTicketRateLimitToken(r *Redis, keys, args []string) redisReply {
+	var current int64
+	notExists := !r.exists(keys[0])
+	if !notExists {
+		current, _ = strconv.ParseInt(r.values[keys[0]], 10, 64)
+	}
+
+	limit, _ := strconv.ParseInt(args[0], 10, 64)
+	if current >= limit {
+		return int64(0)
+	}
+
+	r.execute([]string{"INCR", keys[0]})
+	if notExists {
+		// The interval is passed as a float number of seconds
+		seconds, _ := strconv.ParseFloat(args[1], 64)
+		r.execute([]string{"EXPIRE", keys[0], strconv.FormatInt(int64(seconds), 10)})
+	}
+
+	return int64(1)
+}
+
+func claimCloseOutboxEntry(r *Redis, keys, args []string) redisReply {
+	if current, ok := r.executeSortedSet("ZSCORE", []string{keys[0], args[0]}).(string); !ok || current != args[1] {
+		return int64(0)
+	}
+
+	r.executeSortedSet("ZADD", []string{keys[0], args[2], args[0]})
+	return int64(1)
+}
+
+func releaseLock(r *Redis, keys, args []string) redisReply {
+	if !r.exists(keys[0]) {
+		return int64(-1)
+	}
+
+	if r.values[keys[0]] != args[0] {
+		return int64(0)
+	}
+
+	return r.execute([]string{"DEL", keys[0]})
+}
+
+// eval implements EVAL script numkeys [key ...] [arg ...] for the scripts in redisScripts. r.mu must be held.
+func (r *Redis) eval(args []string) redisReply {
+	if len(args) < 2 {
+		return wrongArgs("EVAL")
+	}
+
+	script, ok := redisScripts[normaliseScript(args[0])]
+	if !ok {
+		return redisError("ERR script is not supported by the harness")
+	}
+
+	keyCount, err := strconv.Atoi(args[1])
+	if err != nil || keyCount < 0 || keyCount > len(args)-2 {
+		return redisError("ERR Number of keys can't be greater than number of args")
+	}
+
+	return script(r, args[2:2+keyCount], args[2+keyCount:])
+}
+
+func normaliseScript(source string) string {
+	return strings.Join(strings.Fields(source), " ")
+}
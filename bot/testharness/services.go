@@ -0,0 +1,306 @@
+package testharness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/TicketsBot-cloud/archiverclient"
+	"github.com/TicketsBot-cloud/common/model"
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/gdl/cache"
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/guild"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/TicketsBot-cloud/gdl/rest/ratelimit"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/jackc/pgx/v4"
+)
+
+// Premium returns a fixed tier for every guild and user, unless overridden with SetGuildTier
+type Premium struct {
+	mu     sync.Mutex
+	Tier   premium.PremiumTier
+	Source model.EntitlementSource
+	guilds map[uint64]premium.PremiumTier
+}
+
+var _ premium.IPremiumLookupClient = (*Premium)(nil)
+
+func NewPremium(tier premium.PremiumTier) *Premium {
+	return &Premium{
+		Tier:   tier,
+		Source: model.EntitlementSourcePatreon,
+		guilds: make(map[uint64]premium.PremiumTier),
+	}
+}
+
+func (p *Premium) SetGuildTier(guildId uint64, tier premium.PremiumTier) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.guilds[guildId] = tier
+}
+
+func (p *Premium) guildTier(guildId uint64) premium.PremiumTier {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tier, ok := p.guilds[guildId]; ok {
+		return tier
+	}
+
+	return p.Tier
+}
+
+func (p *Premium) GetCachedTier(ctx context.Context, guildId uint64) (premium.CachedTier, error) {
+	return premium.CachedTier{
+		Tier:   int8(p.guildTier(guildId)),
+		Source: p.Source,
+	}, nil
+}
+
+func (p *Premium) SetCachedTier(ctx context.Context, guildId uint64, tier premium.CachedTier) error {
+	return nil
+}
+
+func (p *Premium) DeleteCachedTier(ctx context.Context, guildId uint64) error {
+	return nil
+}
+
+func (p *Premium) GetTierByGuild(ctx context.Context, guild guild.Guild) (premium.PremiumTier, model.EntitlementSource, error) {
+	return p.guildTier(guild.Id), p.Source, nil
+}
+
+func (p *Premium) GetTierByGuildId(ctx context.Context, guildId uint64, includeVoting bool, botToken string, rateLimiter *ratelimit.Ratelimiter) (premium.PremiumTier, error) {
+	return p.guildTier(guildId), nil
+}
+
+func (p *Premium) GetTierByGuildIdWithSource(ctx context.Context, guildId uint64, botToken string, rateLimiter *ratelimit.Ratelimiter) (premium.PremiumTier, model.EntitlementSource, error) {
+	return p.guildTier(guildId), p.Source, nil
+}
+
+func (p *Premium) GetTierByUser(ctx context.Context, userId uint64, includeVoting bool) (premium.PremiumTier, error) {
+	return p.Tier, nil
+}
+
+func (p *Premium) GetTierByUserWithSource(ctx context.Context, userId uint64) (premium.PremiumTier, model.EntitlementSource, error) {
+	return p.Tier, p.Source, nil
+}
+
+type transcriptKey struct {
+	guildId  uint64
+	ticketId int
+}
+
+// Archiver keeps stored transcripts in memory
+type Archiver struct {
+	mu          sync.Mutex
+	transcripts map[transcriptKey][]message.Message
+	notes       map[transcriptKey][]message.Message
+	attachments map[uint64][]byte
+	// replaced holds transcripts and notes which have been overwritten since they were stored
+	replaced      map[transcriptKey]v2.Transcript
+	replacedNotes map[transcriptKey]v2.Transcript
+}
+
+var _ services.Archiver = (*Archiver)(nil)
+
+func NewArchiver() *Archiver {
+	return &Archiver{
+		transcripts:   make(map[transcriptKey][]message.Message),
+		notes:         make(map[transcriptKey][]message.Message),
+		attachments:   make(map[uint64][]byte),
+		replaced:      make(map[transcriptKey]v2.Transcript),
+		replacedNotes: make(map[transcriptKey]v2.Transcript),
+	}
+}
+
+func (a *Archiver) Store(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := transcriptKey{guildId, ticketId}
+	a.transcripts[key] = messages
+	delete(a.replaced, key)
+	return nil
+}
+
+// Transcript returns the messages stored for the ticket, if a transcript has been stored
+func (a *Archiver) Transcript(guildId uint64, ticketId int) ([]message.Message, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	messages, ok := a.transcripts[transcriptKey{guildId, ticketId}]
+	return messages, ok
+}
+
+// Get converts the stored messages to the archived transcript format, without resolving any mentions, or returns the
+// transcript it was replaced with
+func (a *Archiver) Get(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return getTranscript(a.transcripts, a.replaced, transcriptKey{guildId, ticketId})
+}
+
+func (a *Archiver) GetNotes(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return getTranscript(a.notes, a.replacedNotes, transcriptKey{guildId, ticketId})
+}
+
+func getTranscript(stored map[transcriptKey][]message.Message, replaced map[transcriptKey]v2.Transcript, key transcriptKey) (v2.Transcript, error) {
+	if transcript, ok := replaced[key]; ok {
+		return transcript, nil
+	}
+
+	messages, ok := stored[key]
+	if !ok {
+		return v2.Transcript{}, archiverclient.ErrNotFound
+	}
+
+	return v2.NewTranscript(
+		messages,
+		func([]uint64) []user.User { return nil },
+		func([]uint64) []channel.Channel { return nil },
+		func([]uint64) []guild.Role { return nil },
+	), nil
+}
+
+func (a *Archiver) Replace(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.replaced[transcriptKey{guildId, ticketId}] = transcript
+	return nil
+}
+
+func (a *Archiver) ReplaceNotes(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.replacedNotes[transcriptKey{guildId, ticketId}] = transcript
+	return nil
+}
+
+// Delete removes the stored transcript and notes, as if they had been deleted from the dashboard
+func (a *Archiver) Delete(guildId uint64, ticketId int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := transcriptKey{guildId, ticketId}
+	delete(a.transcripts, key)
+	delete(a.notes, key)
+	delete(a.replaced, key)
+	delete(a.replacedNotes, key)
+}
+
+func (a *Archiver) StoreNotes(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := transcriptKey{guildId, ticketId}
+	a.notes[key] = messages
+	delete(a.replacedNotes, key)
+	return nil
+}
+
+func (a *Archiver) PreservesAttachments() bool {
+	return true
+}
+
+func (a *Archiver) StoreAttachment(ctx context.Context, guildId uint64, ticketId int, attachmentId uint64, filename, contentType string, data []byte) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.attachments[attachmentId] = data
+	return fmt.Sprintf("https://archive.test/attachments/%d/%d/%d/%s", guildId, ticketId, attachmentId, filename), nil
+}
+
+// Attachment returns the data stored for the attachment, if it has been preserved
+func (a *Archiver) Attachment(attachmentId uint64) ([]byte, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	data, ok := a.attachments[attachmentId]
+	return data, ok
+}
+
+// Notes returns the staff notes stored for the ticket, if any have been stored
+func (a *Archiver) Notes(guildId uint64, ticketId int) ([]message.Message, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	messages, ok := a.notes[transcriptKey{guildId, ticketId}]
+	return messages, ok
+}
+
+// IntegrationRequest is a request which was made through the IntegrationProxy
+type IntegrationRequest struct {
+	Method  string
+	Url     string
+	Headers map[string]string
+	Body    any
+}
+
+// IntegrationProxy records requests, and responds using Handler. If Handler is nil, an empty JSON object is returned.
+type IntegrationProxy struct {
+	mu       sync.Mutex
+	Handler  func(req IntegrationRequest) ([]byte, error)
+	requests []IntegrationRequest
+}
+
+var _ services.IntegrationProxy = (*IntegrationProxy)(nil)
+
+func (p *IntegrationProxy) DoRequest(ctx context.Context, method, url string, headers map[string]string, body any) ([]byte, error) {
+	req := IntegrationRequest{
+		Method:  method,
+		Url:     url,
+		Headers: headers,
+		Body:    body,
+	}
+
+	p.mu.Lock()
+	p.requests = append(p.requests, req)
+	handler := p.Handler
+	p.mu.Unlock()
+
+	if handler == nil {
+		return []byte("{}"), nil
+	}
+
+	return handler(req)
+}
+
+func (p *IntegrationProxy) Requests() []IntegrationRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]IntegrationRequest(nil), p.requests...)
+}
+
+// Cache is an in-memory cache with caching disabled for every object type, so that the worker always reads from the
+// fake REST API
+type Cache struct {
+	*cache.MemoryCache
+}
+
+var _ services.Cache = (*Cache)(nil)
+
+func NewCache() *Cache {
+	memoryCache := cache.NewMemoryCache(cache.CacheOptions{})
+	return &Cache{MemoryCache: &memoryCache}
+}
+
+func (c *Cache) ReplaceChannels(ctx context.Context, guildId uint64, channels []channel.Channel) error {
+	return nil
+}
+
+func (c *Cache) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("raw cache queries are not supported by the harness")
+}
@@ -0,0 +1,518 @@
+package testharness
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+)
+
+type statsDigest struct {
+	config  dbclient.StatsDigestConfig
+	nextRun time.Time
+}
+
+// StatsDigests stores digest configs and their schedule in memory
+type StatsDigests struct {
+	mu      sync.Mutex
+	digests map[uint64]statsDigest
+}
+
+var _ dbclient.StatsDigestStore = (*StatsDigests)(nil)
+
+func (s *StatsDigests) Get(ctx context.Context, guildId uint64) (dbclient.StatsDigestConfig, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	digest, ok := s.digests[guildId]
+	return digest.config, ok, nil
+}
+
+func (s *StatsDigests) Set(ctx context.Context, guildId uint64, config dbclient.StatsDigestConfig, nextRun time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.digests[guildId] = statsDigest{config: config, nextRun: nextRun}
+	return nil
+}
+
+func (s *StatsDigests) Delete(ctx context.Context, guildId uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.digests, guildId)
+	return nil
+}
+
+func (s *StatsDigests) GetDue(ctx context.Context, now time.Time, limit int) ([]dbclient.ScheduledStatsDigest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []dbclient.ScheduledStatsDigest
+	for guildId, digest := range s.digests {
+		if !digest.nextRun.After(now) {
+			due = append(due, dbclient.ScheduledStatsDigest{GuildId: guildId, RunAt: digest.nextRun.UTC()})
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].RunAt.Before(due[j].RunAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (s *StatsDigests) Claim(ctx context.Context, scheduled dbclient.ScheduledStatsDigest, nextRun time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	digest, ok := s.digests[scheduled.GuildId]
+	if !ok || !digest.nextRun.Equal(scheduled.RunAt) {
+		return false, nil
+	}
+
+	digest.nextRun = nextRun
+	s.digests[scheduled.GuildId] = digest
+	return true, nil
+}
+
+// GuildConfig stores a per-guild config in memory, for tables which report whether the guild has set one
+type GuildConfig[T any] struct {
+	mu      sync.Mutex
+	configs map[uint64]T
+}
+
+var (
+	_ dbclient.FeedbackFollowUpConfigStore = (*GuildConfig[dbclient.FeedbackFollowUpConfig])(nil)
+	_ dbclient.TranscriptExportConfigStore = (*GuildConfig[dbclient.TranscriptExportConfig])(nil)
+	_ dbclient.UserContextMenuConfigStore  = (*GuildConfig[dbclient.UserContextMenuConfig])(nil)
+)
+
+func NewGuildConfig[T any]() *GuildConfig[T] {
+	return &GuildConfig[T]{
+		configs: make(map[uint64]T),
+	}
+}
+
+func (c *GuildConfig[T]) Get(ctx context.Context, guildId uint64) (T, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	config, ok := c.configs[guildId]
+	return config, ok, nil
+}
+
+func (c *GuildConfig[T]) Set(ctx context.Context, guildId uint64, config T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.configs[guildId] = config
+	return nil
+}
+
+func (c *GuildConfig[T]) Delete(ctx context.Context, guildId uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.configs, guildId)
+	return nil
+}
+
+type panelKey struct {
+	guildId uint64
+	panelId int
+}
+
+// PanelConfig stores a per-panel config in memory
+type PanelConfig[T any] struct {
+	mu      sync.Mutex
+	configs map[panelKey]T
+}
+
+var (
+	_ dbclient.CloseReasonConfigStore    = (*PanelConfig[dbclient.CloseReasonConfig])(nil)
+	_ dbclient.ClosedCategoryConfigStore = (*PanelConfig[dbclient.ClosedCategoryConfig])(nil)
+)
+
+func NewPanelConfig[T any]() *PanelConfig[T] {
+	return &PanelConfig[T]{
+		configs: make(map[panelKey]T),
+	}
+}
+
+func (c *PanelConfig[T]) Get(ctx context.Context, guildId uint64, panelId int) (T, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	config, ok := c.configs[panelKey{guildId, panelId}]
+	return config, ok, nil
+}
+
+func (c *PanelConfig[T]) GetByGuild(ctx context.Context, guildId uint64) (map[int]T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	configs := make(map[int]T)
+	for key, config := range c.configs {
+		if key.guildId == guildId {
+			configs[key.panelId] = config
+		}
+	}
+
+	return configs, nil
+}
+
+func (c *PanelConfig[T]) Set(ctx context.Context, guildId uint64, panelId int, config T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.configs[panelKey{guildId, panelId}] = config
+	return nil
+}
+
+func (c *PanelConfig[T]) Delete(ctx context.Context, guildId uint64, panelId int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.configs, panelKey{guildId, panelId})
+	return nil
+}
+
+// ClosedChannels stores the channels kept in the closed category, and their scheduled deletion, in memory
+type ClosedChannels struct {
+	mu     sync.Mutex
+	closed map[ticketKey]dbclient.ClosedChannel
+}
+
+var _ dbclient.ClosedChannelStore = (*ClosedChannels)(nil)
+
+func (c *ClosedChannels) Get(ctx context.Context, guildId uint64, ticketId int) (dbclient.ClosedChannel, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	closed, ok := c.closed[ticketKey{guildId, ticketId}]
+	return closed, ok, nil
+}
+
+func (c *ClosedChannels) Set(ctx context.Context, closed dbclient.ClosedChannel) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed[ticketKey{closed.GuildId, closed.TicketId}] = closed
+	return nil
+}
+
+func (c *ClosedChannels) Delete(ctx context.Context, guildId uint64, ticketId int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.closed, ticketKey{guildId, ticketId})
+	return nil
+}
+
+func (c *ClosedChannels) GetDue(ctx context.Context, now time.Time, limit int) ([]dbclient.ClosedChannel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var due []dbclient.ClosedChannel
+	for _, closed := range c.closed {
+		if !closed.DeleteAt.After(now) {
+			due = append(due, closed)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].DeleteAt.Before(due[j].DeleteAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (c *ClosedChannels) Claim(ctx context.Context, closed dbclient.ClosedChannel, retryAt time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := ticketKey{closed.GuildId, closed.TicketId}
+
+	stored, ok := c.closed[key]
+	if !ok || !stored.DeleteAt.Equal(closed.DeleteAt) {
+		return false, nil
+	}
+
+	stored.DeleteAt = retryAt
+	c.closed[key] = stored
+	return true, nil
+}
+
+// ReopenLinks stores the links between reopened tickets and the tickets which continue them in memory
+type ReopenLinks struct {
+	mu           sync.Mutex
+	reopenedFrom map[ticketKey]int
+	reopenedAs   map[ticketKey]int
+}
+
+var _ dbclient.ReopenLinkStore = (*ReopenLinks)(nil)
+
+func (r *ReopenLinks) Set(ctx context.Context, guildId uint64, previousId, newId int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reopenedFrom[ticketKey{guildId, newId}] = previousId
+	r.reopenedAs[ticketKey{guildId, previousId}] = newId
+	return nil
+}
+
+func (r *ReopenLinks) GetReopenedFrom(ctx context.Context, guildId uint64, ticketId int) (int, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previousId, ok := r.reopenedFrom[ticketKey{guildId, ticketId}]
+	return previousId, ok, nil
+}
+
+func (r *ReopenLinks) GetReopenedFromMulti(ctx context.Context, guildId uint64, ticketIds []int) (map[int]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reopenedFrom := make(map[int]int)
+	for _, ticketId := range ticketIds {
+		if previousId, ok := r.reopenedFrom[ticketKey{guildId, ticketId}]; ok {
+			reopenedFrom[ticketId] = previousId
+		}
+	}
+
+	return reopenedFrom, nil
+}
+
+func (r *ReopenLinks) GetReopenedAs(ctx context.Context, guildId uint64, ticketId int) (int, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newId, ok := r.reopenedAs[ticketKey{guildId, ticketId}]
+	return newId, ok, nil
+}
+
+type preservedAttachment struct {
+	dbclient.PreservedAttachment
+	url       string
+	expiresAt *time.Time
+}
+
+// PreservedAttachments stores preserved attachments and their reservations in memory
+type PreservedAttachments struct {
+	mu          sync.Mutex
+	attachments map[uint64]preservedAttachment
+}
+
+var _ dbclient.PreservedAttachmentStore = (*PreservedAttachments)(nil)
+
+func (p *PreservedAttachments) Get(ctx context.Context, attachmentId uint64) (string, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	attachment, ok := p.attachments[attachmentId]
+	if !ok || attachment.url == "" || attachment.expired(time.Now()) {
+		return "", false, nil
+	}
+
+	return attachment.url, true, nil
+}
+
+func (p *PreservedAttachments) Reserve(ctx context.Context, attachment dbclient.PreservedAttachment, quota int64, expiresAt time.Time) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for id, existing := range p.attachments {
+		if existing.GuildId == attachment.GuildId && existing.expired(now) {
+			delete(p.attachments, id)
+		}
+	}
+
+	if p.usage(attachment.GuildId, now)+attachment.Size > quota {
+		return false, nil
+	}
+
+	if _, ok := p.attachments[attachment.AttachmentId]; ok {
+		return false, nil
+	}
+
+	p.attachments[attachment.AttachmentId] = preservedAttachment{PreservedAttachment: attachment, expiresAt: &expiresAt}
+	return true, nil
+}
+
+func (p *PreservedAttachments) SetUrl(ctx context.Context, attachmentId uint64, url string, expiresAt *time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if attachment, ok := p.attachments[attachmentId]; ok {
+		attachment.url = url
+		attachment.expiresAt = expiresAt
+		p.attachments[attachmentId] = attachment
+	}
+
+	return nil
+}
+
+func (p *PreservedAttachments) Release(ctx context.Context, attachmentId uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.attachments, attachmentId)
+	return nil
+}
+
+func (p *PreservedAttachments) DeleteByTicket(ctx context.Context, guildId uint64, ticketId int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, attachment := range p.attachments {
+		if attachment.GuildId == guildId && attachment.TicketId == ticketId {
+			delete(p.attachments, id)
+		}
+	}
+
+	return nil
+}
+
+func (p *PreservedAttachments) GetUsage(ctx context.Context, guildId uint64) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.usage(guildId, time.Now()), nil
+}
+
+func (p *PreservedAttachments) usage(guildId uint64, now time.Time) int64 {
+	var used int64
+	for _, attachment := range p.attachments {
+		if attachment.GuildId == guildId && !attachment.expired(now) {
+			used += attachment.Size
+		}
+	}
+
+	return used
+}
+
+func (a preservedAttachment) expired(now time.Time) bool {
+	return a.expiresAt != nil && !a.expiresAt.After(now)
+}
+
+type guildUserKey struct {
+	guildId uint64
+	userId  uint64
+}
+
+// SpamBlacklist stores temporary blacklists from tickets closed as spam in memory
+type SpamBlacklist struct {
+	mu      sync.Mutex
+	expires map[guildUserKey]time.Time
+}
+
+var _ dbclient.SpamBlacklistStore = (*SpamBlacklist)(nil)
+
+func (s *SpamBlacklist) Set(ctx context.Context, guildId, userId uint64, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expires[guildUserKey{guildId, userId}] = expiresAt
+	return nil
+}
+
+func (s *SpamBlacklist) GetExpiry(ctx context.Context, guildId, userId uint64) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.expires[guildUserKey{guildId, userId}]
+	if !ok || !expiresAt.After(time.Now()) {
+		return time.Time{}, false, nil
+	}
+
+	return expiresAt, true, nil
+}
+
+func (s *SpamBlacklist) GetActive(ctx context.Context, guildId uint64, limit int) ([]dbclient.SpamBlacklistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	var entries []dbclient.SpamBlacklistEntry
+	for key, expiresAt := range s.expires {
+		if key.guildId == guildId && expiresAt.After(now) {
+			entries = append(entries, dbclient.SpamBlacklistEntry{UserId: key.userId, ExpiresAt: expiresAt})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ExpiresAt.Before(entries[j].ExpiresAt)
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func (s *SpamBlacklist) Remove(ctx context.Context, guildId, userId uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expires, guildUserKey{guildId, userId})
+	return nil
+}
+
+type spamStatsKey struct {
+	guildId uint64
+	day     string
+}
+
+// SpamStats stores daily spam stats in memory
+type SpamStats struct {
+	mu    sync.Mutex
+	stats map[spamStatsKey]dbclient.SpamStats
+}
+
+var _ dbclient.SpamStatsStore = (*SpamStats)(nil)
+
+func (s *SpamStats) RecordClose(ctx context.Context, guildId uint64, blacklisted bool, at time.Time, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := spamStatsKey{guildId, at.UTC().Format(time.DateOnly)}
+	stats := s.stats[key]
+	stats.Closes++
+	if blacklisted {
+		stats.Blacklists++
+	}
+
+	s.stats[key] = stats
+	return nil
+}
+
+func (s *SpamStats) Get(ctx context.Context, guildId uint64, from, to time.Time) (dbclient.SpamStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fromDay, toDay := from.UTC().Format(time.DateOnly), to.UTC().Format(time.DateOnly)
+
+	var total dbclient.SpamStats
+	for key, stats := range s.stats {
+		if key.guildId == guildId && key.day >= fromDay && key.day <= toDay {
+			total.Closes += stats.Closes
+			total.Blacklists += stats.Blacklists
+		}
+	}
+
+	return total, nil
+}
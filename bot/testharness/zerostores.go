@@ -0,0 +1,1214 @@
+// Code generated by /tools/cmd/generatedbstores.go; DO NOT EDIT.
+
+package testharness
+
+import (
+	"context"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/model"
+	"github.com/TicketsBot-cloud/database"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type zeroActiveLanguageStore struct{}
+
+var _ dbclient.ActiveLanguageStore = zeroActiveLanguageStore{}
+
+func (zeroActiveLanguageStore) Get(ctx context.Context, guildId uint64) (language string, e error) {
+	return
+}
+
+func (zeroActiveLanguageStore) Set(ctx context.Context, guildId uint64, language string) (err error) {
+	return
+}
+
+type zeroArchiveChannelStore struct{}
+
+var _ dbclient.ArchiveChannelStore = zeroArchiveChannelStore{}
+
+func (zeroArchiveChannelStore) DeleteByChannel(ctx context.Context, channelId uint64) (err error) {
+	return
+}
+
+func (zeroArchiveChannelStore) Get(ctx context.Context, guildId uint64) (archiveChannel *uint64, e error) {
+	return
+}
+
+func (zeroArchiveChannelStore) GetByPanel(ctx context.Context, guildId uint64, panelId int) (archiveChannel *uint64, e error) {
+	return
+}
+
+func (zeroArchiveChannelStore) Set(ctx context.Context, guildId uint64, archiveChannel *uint64) (err error) {
+	return
+}
+
+type zeroArchiveDmMessagesStore struct{}
+
+var _ dbclient.ArchiveDmMessagesStore = zeroArchiveDmMessagesStore{}
+
+func (zeroArchiveDmMessagesStore) Get(ctx context.Context, guildId uint64, ticketId int) (_ database.ArchiveDmMessage, _ bool, _ error) {
+	return
+}
+
+func (zeroArchiveDmMessagesStore) Set(ctx context.Context, guildId uint64, ticketId int, messageId uint64) (_ error) {
+	return
+}
+
+type zeroArchiveMessagesStore struct{}
+
+var _ dbclient.ArchiveMessagesStore = zeroArchiveMessagesStore{}
+
+func (zeroArchiveMessagesStore) Get(ctx context.Context, guildId uint64, ticketId int) (_ database.ArchiveMessage, _ bool, _ error) {
+	return
+}
+
+func (zeroArchiveMessagesStore) Set(ctx context.Context, guildId uint64, ticketId int, channelId, messageId uint64) (_ error) {
+	return
+}
+
+type zeroAutoCloseStore struct{}
+
+var _ dbclient.AutoCloseStore = zeroAutoCloseStore{}
+
+func (zeroAutoCloseStore) Get(ctx context.Context, guildId uint64) (settings database.AutoCloseSettings, e error) {
+	return
+}
+
+type zeroAutoCloseExcludeStore struct{}
+
+var _ dbclient.AutoCloseExcludeStore = zeroAutoCloseExcludeStore{}
+
+func (zeroAutoCloseExcludeStore) Exclude(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	return
+}
+
+func (zeroAutoCloseExcludeStore) ExcludeAll(ctx context.Context, guildId uint64) (err error) {
+	return
+}
+
+func (zeroAutoCloseExcludeStore) IsExcluded(ctx context.Context, guildId uint64, ticketId int) (excluded bool, e error) {
+	return
+}
+
+type zeroBlacklistStore struct{}
+
+var _ dbclient.BlacklistStore = zeroBlacklistStore{}
+
+func (zeroBlacklistStore) Add(ctx context.Context, guildId, userId uint64) (err error) {
+	return
+}
+
+func (zeroBlacklistStore) GetBlacklistedCount(ctx context.Context, guildId uint64) (count int, err error) {
+	return
+}
+
+func (zeroBlacklistStore) IsBlacklisted(ctx context.Context, guildId, userId uint64) (exists bool, e error) {
+	return
+}
+
+func (zeroBlacklistStore) Remove(ctx context.Context, guildId, userId uint64) (err error) {
+	return
+}
+
+type zeroCategoryUpdateQueueStore struct{}
+
+var _ dbclient.CategoryUpdateQueueStore = zeroCategoryUpdateQueueStore{}
+
+func (zeroCategoryUpdateQueueStore) Add(ctx context.Context, guildId uint64, ticketId int, newStatus model.TicketStatus) (_ error) {
+	return
+}
+
+type zeroChannelCategoryStore struct{}
+
+var _ dbclient.ChannelCategoryStore = zeroChannelCategoryStore{}
+
+func (zeroChannelCategoryStore) Delete(ctx context.Context, guildId uint64) (err error) {
+	return
+}
+
+func (zeroChannelCategoryStore) DeleteByChannel(ctx context.Context, channelId uint64) (err error) {
+	return
+}
+
+func (zeroChannelCategoryStore) Get(ctx context.Context, guildId uint64) (channelCategory uint64, e error) {
+	return
+}
+
+func (zeroChannelCategoryStore) Set(ctx context.Context, guildId, channelCategory uint64) (err error) {
+	return
+}
+
+type zeroClaimSettingsStore struct{}
+
+var _ dbclient.ClaimSettingsStore = zeroClaimSettingsStore{}
+
+func (zeroClaimSettingsStore) Get(ctx context.Context, guildId uint64) (settings database.ClaimSettings, e error) {
+	return
+}
+
+type zeroCloseConfirmationStore struct{}
+
+var _ dbclient.CloseConfirmationStore = zeroCloseConfirmationStore{}
+
+func (zeroCloseConfirmationStore) Get(ctx context.Context, guildId uint64) (confirm bool, e error) {
+	return
+}
+
+type zeroCloseReasonStore struct{}
+
+var _ dbclient.CloseReasonStore = zeroCloseReasonStore{}
+
+func (zeroCloseReasonStore) Get(ctx context.Context, guildId uint64, ticketId int) (_ database.CloseMetadata, _ bool, _ error) {
+	return
+}
+
+func (zeroCloseReasonStore) GetMulti(ctx context.Context, guildId uint64, ticketIds []int) (_ map[int]database.CloseMetadata, _ error) {
+	return
+}
+
+func (zeroCloseReasonStore) Set(ctx context.Context, guildId uint64, ticketId int, data database.CloseMetadata) (err error) {
+	return
+}
+
+type zeroCloseRequestStore struct{}
+
+var _ dbclient.CloseRequestStore = zeroCloseRequestStore{}
+
+func (zeroCloseRequestStore) Delete(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	return
+}
+
+func (zeroCloseRequestStore) Get(ctx context.Context, guildId uint64, ticketId int) (_ database.CloseRequest, _ bool, _ error) {
+	return
+}
+
+func (zeroCloseRequestStore) Set(ctx context.Context, request database.CloseRequest) (err error) {
+	return
+}
+
+type zeroCustomColoursStore struct{}
+
+var _ dbclient.CustomColoursStore = zeroCustomColoursStore{}
+
+func (zeroCustomColoursStore) Get(ctx context.Context, guildId uint64, colourId int16) (colourCode int, ok bool, e error) {
+	return
+}
+
+func (zeroCustomColoursStore) GetAll(ctx context.Context, guildId uint64) (_ map[int16]int, _ error) {
+	return
+}
+
+type zeroCustomIntegrationGuildsStore struct{}
+
+var _ dbclient.CustomIntegrationGuildsStore = zeroCustomIntegrationGuildsStore{}
+
+func (zeroCustomIntegrationGuildsStore) GetGuildIntegrations(ctx context.Context, guildId uint64) (_ []database.CustomIntegration, _ error) {
+	return
+}
+
+type zeroCustomIntegrationHeadersStore struct{}
+
+var _ dbclient.CustomIntegrationHeadersStore = zeroCustomIntegrationHeadersStore{}
+
+func (zeroCustomIntegrationHeadersStore) GetAll(ctx context.Context, integrationIds []int) (_ map[int][]database.CustomIntegrationHeader, _ error) {
+	return
+}
+
+type zeroCustomIntegrationPlaceholdersStore struct{}
+
+var _ dbclient.CustomIntegrationPlaceholdersStore = zeroCustomIntegrationPlaceholdersStore{}
+
+func (zeroCustomIntegrationPlaceholdersStore) GetAllActivatedInGuild(ctx context.Context, guildId uint64) (_ []database.CustomIntegrationPlaceholder, _ error) {
+	return
+}
+
+type zeroCustomIntegrationSecretValuesStore struct{}
+
+var _ dbclient.CustomIntegrationSecretValuesStore = zeroCustomIntegrationSecretValuesStore{}
+
+func (zeroCustomIntegrationSecretValuesStore) GetAll(ctx context.Context, guildId uint64, integrationIds []int) (_ map[int][]database.SecretWithValue, _ error) {
+	return
+}
+
+type zeroEmbedFieldsStore struct{}
+
+var _ dbclient.EmbedFieldsStore = zeroEmbedFieldsStore{}
+
+func (zeroEmbedFieldsStore) GetFieldsForEmbed(ctx context.Context, embedId int) (_ []database.EmbedField, _ error) {
+	return
+}
+
+type zeroEmbedsStore struct{}
+
+var _ dbclient.EmbedsStore = zeroEmbedsStore{}
+
+func (zeroEmbedsStore) GetEmbed(ctx context.Context, id int) (embed database.CustomEmbed, err error) {
+	return
+}
+
+type zeroEntitlementsStore struct{}
+
+var _ dbclient.EntitlementsStore = zeroEntitlementsStore{}
+
+func (zeroEntitlementsStore) Create(ctx context.Context, tx pgx.Tx, guildId *uint64, userId *uint64, skuId uuid.UUID, source model.EntitlementSource, expiresAt *time.Time) (_ model.Entitlement, _ error) {
+	return
+}
+
+func (zeroEntitlementsStore) IncreaseExpiry(ctx context.Context, tx pgx.Tx, guildId, userId *uint64, skuId uuid.UUID, source model.EntitlementSource, duration time.Duration) (_ error) {
+	return
+}
+
+func (zeroEntitlementsStore) ListGuildSubscriptions(ctx context.Context, guildId, ownerId uint64, gracePeriod time.Duration) (_ []model.GuildEntitlementEntry, _ error) {
+	return
+}
+
+func (zeroEntitlementsStore) ListUserSubscriptions(ctx context.Context, userId uint64, gracePeriod time.Duration) (_ []model.GuildEntitlementEntry, _ error) {
+	return
+}
+
+type zeroExitSurveyResponsesStore struct{}
+
+var _ dbclient.ExitSurveyResponsesStore = zeroExitSurveyResponsesStore{}
+
+func (zeroExitSurveyResponsesStore) AddResponses(ctx context.Context, guildId uint64, ticketId int, formId int, responses map[int]string) (_ error) {
+	return
+}
+
+func (zeroExitSurveyResponsesStore) GetResponses(ctx context.Context, guildId uint64, ticketId int) (_ database.ExitSurveyResponse, _ error) {
+	return
+}
+
+func (zeroExitSurveyResponsesStore) HasResponse(ctx context.Context, guildId uint64, formId int) (_ bool, _ error) {
+	return
+}
+
+type zeroExperimentStore struct{}
+
+var _ dbclient.ExperimentStore = zeroExperimentStore{}
+
+func (zeroExperimentStore) GetByName(ctx context.Context, name string) (_ *database.Experiment, _ error) {
+	return
+}
+
+type zeroFeedbackEnabledStore struct{}
+
+var _ dbclient.FeedbackEnabledStore = zeroFeedbackEnabledStore{}
+
+func (zeroFeedbackEnabledStore) Get(ctx context.Context, guildId uint64) (feedbackEnabled bool, e error) {
+	return
+}
+
+type zeroFirstResponseTimeStore struct{}
+
+var _ dbclient.FirstResponseTimeStore = zeroFirstResponseTimeStore{}
+
+func (zeroFirstResponseTimeStore) GetAverageAllTimeUser(ctx context.Context, guildId, userId uint64) (responseTime *time.Duration, e error) {
+	return
+}
+
+func (zeroFirstResponseTimeStore) GetAverageUser(ctx context.Context, guildId, userId uint64, interval time.Duration) (responseTime *time.Duration, e error) {
+	return
+}
+
+func (zeroFirstResponseTimeStore) HasResponse(ctx context.Context, guildId uint64, ticketId int) (hasResponse bool, e error) {
+	return
+}
+
+func (zeroFirstResponseTimeStore) Set(ctx context.Context, guildId, userId uint64, ticketId int, responseTime time.Duration) (err error) {
+	return
+}
+
+type zeroFormInputStore struct{}
+
+var _ dbclient.FormInputStore = zeroFormInputStore{}
+
+func (zeroFormInputStore) GetAllInputsByCustomId(ctx context.Context, guildId uint64) (_ map[string]database.FormInput, _ error) {
+	return
+}
+
+func (zeroFormInputStore) GetInputs(ctx context.Context, formId int) (inputs []database.FormInput, e error) {
+	return
+}
+
+type zeroFormInputOptionStore struct{}
+
+var _ dbclient.FormInputOptionStore = zeroFormInputOptionStore{}
+
+func (zeroFormInputOptionStore) GetOptionsByForm(ctx context.Context, formId int) (options map[int][]database.FormInputOption, e error) {
+	return
+}
+
+type zeroFormsStore struct{}
+
+var _ dbclient.FormsStore = zeroFormsStore{}
+
+func (zeroFormsStore) Get(ctx context.Context, formId int) (form database.Form, ok bool, e error) {
+	return
+}
+
+type zeroGdprLogsStore struct{}
+
+var _ dbclient.GdprLogsStore = zeroGdprLogsStore{}
+
+func (zeroGdprLogsStore) InsertLog(requester string, requestType string, status string) (_ int, _ error) {
+	return
+}
+
+type zeroGlobalBlacklistStore struct{}
+
+var _ dbclient.GlobalBlacklistStore = zeroGlobalBlacklistStore{}
+
+func (zeroGlobalBlacklistStore) Get(ctx context.Context, userId uint64) (_ *database.GlobalBlacklistEntry, _ error) {
+	return
+}
+
+func (zeroGlobalBlacklistStore) ListAll(ctx context.Context) (users []uint64, err error) {
+	return
+}
+
+type zeroGuildLeaveTimeStore struct{}
+
+var _ dbclient.GuildLeaveTimeStore = zeroGuildLeaveTimeStore{}
+
+func (zeroGuildLeaveTimeStore) Delete(ctx context.Context, guildId uint64) (err error) {
+	return
+}
+
+func (zeroGuildLeaveTimeStore) Set(ctx context.Context, guildId uint64) (err error) {
+	return
+}
+
+type zeroGuildMetadataStore struct{}
+
+var _ dbclient.GuildMetadataStore = zeroGuildMetadataStore{}
+
+func (zeroGuildMetadataStore) Get(ctx context.Context, guildId uint64) (_ database.GuildMetadata, _ error) {
+	return
+}
+
+func (zeroGuildMetadataStore) SetOnCallRole(ctx context.Context, guildId uint64, roleId *uint64) (err error) {
+	return
+}
+
+type zeroLegacyPremiumEntitlementsStore struct{}
+
+var _ dbclient.LegacyPremiumEntitlementsStore = zeroLegacyPremiumEntitlementsStore{}
+
+func (zeroLegacyPremiumEntitlementsStore) GetUserTier(ctx context.Context, userId uint64, gracePeriod time.Duration) (_ *database.LegacyPremiumEntitlement, _ error) {
+	return
+}
+
+type zeroNamingSchemeStore struct{}
+
+var _ dbclient.NamingSchemeStore = zeroNamingSchemeStore{}
+
+func (zeroNamingSchemeStore) Get(ctx context.Context, guildId uint64) (ns database.NamingScheme, e error) {
+	return
+}
+
+type zeroOnCallStore struct{}
+
+var _ dbclient.OnCallStore = zeroOnCallStore{}
+
+func (zeroOnCallStore) Toggle(ctx context.Context, guildId, userId uint64) (onCall bool, err error) {
+	return
+}
+
+type zeroPanelStore struct{}
+
+var _ dbclient.PanelStore = zeroPanelStore{}
+
+func (zeroPanelStore) EnableAll(ctx context.Context, guildId uint64) (err error) {
+	return
+}
+
+func (zeroPanelStore) GetByCustomId(ctx context.Context, guildId uint64, customId string) (panel database.Panel, ok bool, e error) {
+	return
+}
+
+func (zeroPanelStore) GetByGuild(ctx context.Context, guildId uint64) (panels []database.Panel, e error) {
+	return
+}
+
+func (zeroPanelStore) GetById(ctx context.Context, panelId int) (panel database.Panel, e error) {
+	return
+}
+
+type zeroPanelAccessControlRulesStore struct{}
+
+var _ dbclient.PanelAccessControlRulesStore = zeroPanelAccessControlRulesStore{}
+
+func (zeroPanelAccessControlRulesStore) GetAll(ctx context.Context, panelId int) (_ []database.PanelAccessControlRule, _ error) {
+	return
+}
+
+func (zeroPanelAccessControlRulesStore) GetFirstMatched(ctx context.Context, panelId int, userRoles []uint64) (_ uint64, _ database.AccessControlAction, _ error) {
+	return
+}
+
+type zeroPanelHereMentionStore struct{}
+
+var _ dbclient.PanelHereMentionStore = zeroPanelHereMentionStore{}
+
+func (zeroPanelHereMentionStore) ShouldMentionHere(ctx context.Context, panelId int) (shouldMention bool, e error) {
+	return
+}
+
+type zeroPanelRoleMentionsStore struct{}
+
+var _ dbclient.PanelRoleMentionsStore = zeroPanelRoleMentionsStore{}
+
+func (zeroPanelRoleMentionsStore) DeleteAllRole(ctx context.Context, roleId uint64) (err error) {
+	return
+}
+
+func (zeroPanelRoleMentionsStore) GetRoles(ctx context.Context, panelId int) (roles []uint64, e error) {
+	return
+}
+
+type zeroPanelSupportHoursStore struct{}
+
+var _ dbclient.PanelSupportHoursStore = zeroPanelSupportHoursStore{}
+
+func (zeroPanelSupportHoursStore) HasSupportHours(ctx context.Context, panelId int) (_ bool, _ error) {
+	return
+}
+
+func (zeroPanelSupportHoursStore) IsActiveNow(ctx context.Context, panelId int) (_ bool, _ error) {
+	return
+}
+
+type zeroPanelSupportHoursSettingsStore struct{}
+
+var _ dbclient.PanelSupportHoursSettingsStore = zeroPanelSupportHoursSettingsStore{}
+
+func (zeroPanelSupportHoursSettingsStore) Get(ctx context.Context, panelId int) (_ database.PanelSupportHoursSettings, _ bool, _ error) {
+	return
+}
+
+type zeroPanelTeamsStore struct{}
+
+var _ dbclient.PanelTeamsStore = zeroPanelTeamsStore{}
+
+func (zeroPanelTeamsStore) GetPanelsByTeams(ctx context.Context, teamIds []int) (_ []int, _ error) {
+	return
+}
+
+func (zeroPanelTeamsStore) GetTeamIds(ctx context.Context, panelId int) (teamIds []int, e error) {
+	return
+}
+
+func (zeroPanelTeamsStore) GetTeams(ctx context.Context, panelId int) (teams []database.SupportTeam, e error) {
+	return
+}
+
+type zeroPanelTicketPermissionsStore struct{}
+
+var _ dbclient.PanelTicketPermissionsStore = zeroPanelTicketPermissionsStore{}
+
+func (zeroPanelTicketPermissionsStore) Get(ctx context.Context, panelId int) (_ database.TicketPermissions, _ error) {
+	return
+}
+
+type zeroPanelUserMentionStore struct{}
+
+var _ dbclient.PanelUserMentionStore = zeroPanelUserMentionStore{}
+
+func (zeroPanelUserMentionStore) ShouldMentionUser(ctx context.Context, panelId int) (shouldMention bool, e error) {
+	return
+}
+
+type zeroParticipantsStore struct{}
+
+var _ dbclient.ParticipantsStore = zeroParticipantsStore{}
+
+func (zeroParticipantsStore) GetParticipatedCount(ctx context.Context, guildId, userId uint64) (count int, err error) {
+	return
+}
+
+func (zeroParticipantsStore) GetParticipatedCountInterval(ctx context.Context, guildId, userId uint64, interval time.Duration) (count int, err error) {
+	return
+}
+
+func (zeroParticipantsStore) HasParticipated(ctx context.Context, guildId uint64, ticketId int, userId uint64) (hasParticipated bool, err error) {
+	return
+}
+
+func (zeroParticipantsStore) Set(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error) {
+	return
+}
+
+func (zeroParticipantsStore) SetBulk(ctx context.Context, guildId uint64, ticketId int, userId []uint64) (_ error) {
+	return
+}
+
+type zeroPermissionsStore struct{}
+
+var _ dbclient.PermissionsStore = zeroPermissionsStore{}
+
+func (zeroPermissionsStore) AddAdmin(ctx context.Context, guildId, userId uint64) (err error) {
+	return
+}
+
+func (zeroPermissionsStore) AddSupport(ctx context.Context, guildId, userId uint64) (err error) {
+	return
+}
+
+func (zeroPermissionsStore) GetAdmins(ctx context.Context, guildId uint64) (admins []uint64, e error) {
+	return
+}
+
+func (zeroPermissionsStore) GetSupport(ctx context.Context, guildId uint64) (support []uint64, e error) {
+	return
+}
+
+func (zeroPermissionsStore) GetSupportOnly(ctx context.Context, guildId uint64) (support []uint64, e error) {
+	return
+}
+
+func (zeroPermissionsStore) RemoveAdmin(ctx context.Context, guildId, userId uint64) (err error) {
+	return
+}
+
+func (zeroPermissionsStore) RemoveSupport(ctx context.Context, guildId, userId uint64) (err error) {
+	return
+}
+
+type zeroPremiumKeysStore struct{}
+
+var _ dbclient.PremiumKeysStore = zeroPremiumKeysStore{}
+
+func (zeroPremiumKeysStore) Create(ctx context.Context, key uuid.UUID, length time.Duration, skuId uuid.UUID) (err error) {
+	return
+}
+
+func (zeroPremiumKeysStore) Delete(ctx context.Context, tx pgx.Tx, key uuid.UUID) (_ time.Duration, _ uuid.UUID, _ bool, _ error) {
+	return
+}
+
+type zeroRoleBlacklistStore struct{}
+
+var _ dbclient.RoleBlacklistStore = zeroRoleBlacklistStore{}
+
+func (zeroRoleBlacklistStore) Add(ctx context.Context, guildId, roleId uint64) (err error) {
+	return
+}
+
+func (zeroRoleBlacklistStore) IsAnyBlacklisted(ctx context.Context, guildId uint64, roles []uint64) (blacklisted bool, e error) {
+	return
+}
+
+func (zeroRoleBlacklistStore) IsBlacklisted(ctx context.Context, guildId, roleId uint64) (blacklisted bool, e error) {
+	return
+}
+
+func (zeroRoleBlacklistStore) Remove(ctx context.Context, guildId, roleId uint64) (err error) {
+	return
+}
+
+type zeroRolePermissionsStore struct{}
+
+var _ dbclient.RolePermissionsStore = zeroRolePermissionsStore{}
+
+func (zeroRolePermissionsStore) AddAdmin(ctx context.Context, guildId, roleId uint64) (err error) {
+	return
+}
+
+func (zeroRolePermissionsStore) AddSupport(ctx context.Context, guildId, roleId uint64) (err error) {
+	return
+}
+
+func (zeroRolePermissionsStore) GetAdminRoles(ctx context.Context, guildId uint64) (adminRoles []uint64, e error) {
+	return
+}
+
+func (zeroRolePermissionsStore) GetSupportRoles(ctx context.Context, guildId uint64) (supportRoles []uint64, e error) {
+	return
+}
+
+func (zeroRolePermissionsStore) GetSupportRolesOnly(ctx context.Context, guildId uint64) (supportRoles []uint64, e error) {
+	return
+}
+
+func (zeroRolePermissionsStore) IsSupport(ctx context.Context, roleId uint64) (_ bool, _ error) {
+	return
+}
+
+func (zeroRolePermissionsStore) RemoveAdmin(ctx context.Context, guildId, roleId uint64) (err error) {
+	return
+}
+
+func (zeroRolePermissionsStore) RemoveSupport(ctx context.Context, guildId, roleId uint64) (err error) {
+	return
+}
+
+type zeroServerBlacklistStore struct{}
+
+var _ dbclient.ServerBlacklistStore = zeroServerBlacklistStore{}
+
+func (zeroServerBlacklistStore) Add(ctx context.Context, guildId uint64, reason *string, ownerId *uint64, realOwnerId *uint64) (err error) {
+	return
+}
+
+func (zeroServerBlacklistStore) Get(ctx context.Context, guildId uint64) (_ *database.ServerBlacklistEntry, _ error) {
+	return
+}
+
+func (zeroServerBlacklistStore) GetUserBlacklistedOwnerCounts(ctx context.Context, userId uint64) (_ int, _ int, _ error) {
+	return
+}
+
+func (zeroServerBlacklistStore) IsBlacklisted(ctx context.Context, guildId uint64) (_ bool, _ *string, _ error) {
+	return
+}
+
+func (zeroServerBlacklistStore) ListAll(ctx context.Context) (_ []uint64, _ error) {
+	return
+}
+
+type zeroServiceRatingsStore struct{}
+
+var _ dbclient.ServiceRatingsStore = zeroServiceRatingsStore{}
+
+func (zeroServiceRatingsStore) Get(ctx context.Context, guildId uint64, ticketId int) (rating uint8, ok bool, e error) {
+	return
+}
+
+func (zeroServiceRatingsStore) GetAverageClaimedBy(ctx context.Context, guildId, userId uint64) (average float32, err error) {
+	return
+}
+
+func (zeroServiceRatingsStore) GetCountClaimedBy(ctx context.Context, guildId, userId uint64) (count int, err error) {
+	return
+}
+
+func (zeroServiceRatingsStore) GetMulti(ctx context.Context, guildId uint64, ticketIds []int) (_ map[int]uint8, _ error) {
+	return
+}
+
+func (zeroServiceRatingsStore) Set(ctx context.Context, guildId uint64, ticketId int, rating uint8) (err error) {
+	return
+}
+
+type zeroSettingsStore struct{}
+
+var _ dbclient.SettingsStore = zeroSettingsStore{}
+
+func (zeroSettingsStore) DisableThreads(ctx context.Context, guildId uint64) (err error) {
+	return
+}
+
+func (zeroSettingsStore) EnableThreads(ctx context.Context, guildId uint64, ticketNotificationChannel uint64) (err error) {
+	return
+}
+
+func (zeroSettingsStore) Get(ctx context.Context, guildId uint64) (_ database.Settings, _ error) {
+	return
+}
+
+func (zeroSettingsStore) SetOverflow(ctx context.Context, guildId uint64, enabled bool, categoryId *uint64) (err error) {
+	return
+}
+
+type zeroSubscriptionSkusStore struct{}
+
+var _ dbclient.SubscriptionSkusStore = zeroSubscriptionSkusStore{}
+
+func (zeroSubscriptionSkusStore) GetSku(ctx context.Context, tx pgx.Tx, skuId uuid.UUID) (_ *model.SubscriptionSku, _ error) {
+	return
+}
+
+func (zeroSubscriptionSkusStore) Search(ctx context.Context, label string, limit int) (_ []model.SubscriptionSku, _ error) {
+	return
+}
+
+type zeroSupportTeamStore struct{}
+
+var _ dbclient.SupportTeamStore = zeroSupportTeamStore{}
+
+func (zeroSupportTeamStore) Get(ctx context.Context, guildId uint64) (teams []database.SupportTeam, e error) {
+	return
+}
+
+func (zeroSupportTeamStore) GetById(ctx context.Context, guildId uint64, id int) (_ database.SupportTeam, _ bool, _ error) {
+	return
+}
+
+func (zeroSupportTeamStore) GetMulti(ctx context.Context, guildId uint64, teamIds []int) (_ map[int]database.SupportTeam, _ error) {
+	return
+}
+
+func (zeroSupportTeamStore) SetOnCallRole(ctx context.Context, teamId int, roleId *uint64) (err error) {
+	return
+}
+
+type zeroSupportTeamMembersStore struct{}
+
+var _ dbclient.SupportTeamMembersStore = zeroSupportTeamMembersStore{}
+
+func (zeroSupportTeamMembersStore) Get(ctx context.Context, teamId int) (members []uint64, e error) {
+	return
+}
+
+func (zeroSupportTeamMembersStore) GetAllSupportMembersForPanel(ctx context.Context, panelId int) (users []uint64, err error) {
+	return
+}
+
+func (zeroSupportTeamMembersStore) GetAllTeamsForUser(ctx context.Context, guildId, userId uint64) (_ []int, _ error) {
+	return
+}
+
+type zeroSupportTeamPermissionsStore struct{}
+
+var _ dbclient.SupportTeamPermissionsStore = zeroSupportTeamPermissionsStore{}
+
+func (zeroSupportTeamPermissionsStore) GetForTeams(ctx context.Context, teamIds []int) (_ map[int]database.SupportTeamPermissions, _ error) {
+	return
+}
+
+type zeroSupportTeamRolesStore struct{}
+
+var _ dbclient.SupportTeamRolesStore = zeroSupportTeamRolesStore{}
+
+func (zeroSupportTeamRolesStore) DeleteAllRole(ctx context.Context, roleId uint64) (err error) {
+	return
+}
+
+func (zeroSupportTeamRolesStore) Get(ctx context.Context, teamId int) (roles []uint64, e error) {
+	return
+}
+
+func (zeroSupportTeamRolesStore) GetAllSupportRolesForPanel(ctx context.Context, panelId int) (roles []uint64, err error) {
+	return
+}
+
+func (zeroSupportTeamRolesStore) GetAllTeamsForRoles(ctx context.Context, guildId uint64, roleIds []uint64) (_ []int, _ error) {
+	return
+}
+
+func (zeroSupportTeamRolesStore) IsSupport(ctx context.Context, guildId, roleId uint64) (isSupport bool, err error) {
+	return
+}
+
+type zeroTagStore struct{}
+
+var _ dbclient.TagStore = zeroTagStore{}
+
+func (zeroTagStore) Delete(ctx context.Context, guildId uint64, tagId string) (err error) {
+	return
+}
+
+func (zeroTagStore) Exists(ctx context.Context, guildId uint64, tagId string) (exists bool, err error) {
+	return
+}
+
+func (zeroTagStore) Get(ctx context.Context, guildId uint64, tagId string) (_ database.Tag, _ bool, _ error) {
+	return
+}
+
+func (zeroTagStore) GetByApplicationCommandId(ctx context.Context, guildId, applicationCommandId uint64) (_ database.Tag, _ bool, _ error) {
+	return
+}
+
+func (zeroTagStore) GetContaining(ctx context.Context, guildId uint64, substring string, limit int) (tagIds []string, e error) {
+	return
+}
+
+func (zeroTagStore) GetTagCount(ctx context.Context, guildId uint64) (count int, err error) {
+	return
+}
+
+func (zeroTagStore) GetTagIds(ctx context.Context, guildId uint64) (ids []string, e error) {
+	return
+}
+
+func (zeroTagStore) Set(ctx context.Context, tag database.Tag) (_ error) {
+	return
+}
+
+type zeroTicketClaimsStore struct{}
+
+var _ dbclient.TicketClaimsStore = zeroTicketClaimsStore{}
+
+func (zeroTicketClaimsStore) Delete(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	return
+}
+
+func (zeroTicketClaimsStore) Get(ctx context.Context, guildId uint64, ticketId int) (userId uint64, e error) {
+	return
+}
+
+func (zeroTicketClaimsStore) GetClaimedCount(ctx context.Context, guildId, userId uint64) (count int, e error) {
+	return
+}
+
+func (zeroTicketClaimsStore) GetClaimedSinceCount(ctx context.Context, guildId, userId uint64, interval time.Duration) (count int, e error) {
+	return
+}
+
+func (zeroTicketClaimsStore) Set(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error) {
+	return
+}
+
+type zeroTicketLabelAssignmentsStore struct{}
+
+var _ dbclient.TicketLabelAssignmentsStore = zeroTicketLabelAssignmentsStore{}
+
+func (zeroTicketLabelAssignmentsStore) Add(ctx context.Context, guildId uint64, ticketId, labelId int) (_ error) {
+	return
+}
+
+func (zeroTicketLabelAssignmentsStore) GetByTicket(ctx context.Context, guildId uint64, ticketId int) (_ []int, _ error) {
+	return
+}
+
+func (zeroTicketLabelAssignmentsStore) GetByTickets(ctx context.Context, guildId uint64, ticketIds []int) (_ map[int][]int, _ error) {
+	return
+}
+
+func (zeroTicketLabelAssignmentsStore) Replace(ctx context.Context, guildId uint64, ticketId int, labelIds []int) (_ error) {
+	return
+}
+
+type zeroTicketLabelsStore struct{}
+
+var _ dbclient.TicketLabelsStore = zeroTicketLabelsStore{}
+
+func (zeroTicketLabelsStore) Get(ctx context.Context, guildId uint64, labelId int) (_ database.TicketLabel, _ bool, _ error) {
+	return
+}
+
+func (zeroTicketLabelsStore) GetByGuild(ctx context.Context, guildId uint64) (_ []database.TicketLabel, _ error) {
+	return
+}
+
+type zeroTicketLastMessageStore struct{}
+
+var _ dbclient.TicketLastMessageStore = zeroTicketLastMessageStore{}
+
+func (zeroTicketLastMessageStore) Get(ctx context.Context, guildId uint64, ticketId int) (lastMessage database.TicketLastMessage, e error) {
+	return
+}
+
+func (zeroTicketLastMessageStore) Set(ctx context.Context, guildId uint64, ticketId int, messageId, userId uint64, userIsStaff bool) (err error) {
+	return
+}
+
+type zeroTicketLimitStore struct{}
+
+var _ dbclient.TicketLimitStore = zeroTicketLimitStore{}
+
+func (zeroTicketLimitStore) Get(ctx context.Context, guildId uint64) (limit uint8, e error) {
+	return
+}
+
+func (zeroTicketLimitStore) Set(ctx context.Context, guildId uint64, limit uint8) (err error) {
+	return
+}
+
+type zeroTicketMembersStore struct{}
+
+var _ dbclient.TicketMembersStore = zeroTicketMembersStore{}
+
+func (zeroTicketMembersStore) Add(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error) {
+	return
+}
+
+func (zeroTicketMembersStore) Delete(ctx context.Context, guildId uint64, ticketId int, userId uint64) (err error) {
+	return
+}
+
+func (zeroTicketMembersStore) Get(ctx context.Context, guildId uint64, ticketId int) (members []uint64, e error) {
+	return
+}
+
+type zeroTicketPermissionsStore struct{}
+
+var _ dbclient.TicketPermissionsStore = zeroTicketPermissionsStore{}
+
+func (zeroTicketPermissionsStore) Get(ctx context.Context, guildId uint64) (_ database.TicketPermissions, _ error) {
+	return
+}
+
+type zeroTicketsStore struct{}
+
+var _ dbclient.TicketsStore = zeroTicketsStore{}
+
+func (zeroTicketsStore) Close(ctx context.Context, ticketId int, guildId uint64) (err error) {
+	return
+}
+
+func (zeroTicketsStore) CloseByChannel(ctx context.Context, channelId uint64) (err error) {
+	return
+}
+
+func (zeroTicketsStore) Create(ctx context.Context, guildId, userId uint64, isThread bool, panelId *int) (id int, err error) {
+	return
+}
+
+func (zeroTicketsStore) Get(ctx context.Context, ticketId int, guildId uint64) (ticket database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetAllByUser(ctx context.Context, guildId, userId uint64) (tickets []database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetByChannel(ctx context.Context, channelId uint64) (_ database.Ticket, _ bool, _ error) {
+	return
+}
+
+func (zeroTicketsStore) GetByChannelAndGuild(ctx context.Context, channelId, guildId uint64) (ticket database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetByOptions(ctx context.Context, options database.TicketQueryOptions) (tickets []database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetClosedByUserPrefixed(ctx context.Context, guildId, userId uint64, prefix string, limit int) (tickets []database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetGuildOpenTickets(ctx context.Context, guildId uint64) (tickets []database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetGuildOpenTicketsExcludeThreads(ctx context.Context, guildId uint64) (tickets []database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetGuildOpenTicketsWithMetadata(ctx context.Context, guildId uint64) (_ []database.TicketWithMetadata, _ error) {
+	return
+}
+
+func (zeroTicketsStore) GetOpenByUser(ctx context.Context, guildId, userId uint64) (tickets []database.Ticket, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetOpenCountByUser(ctx context.Context, guildId, userId uint64) (_ int, _ error) {
+	return
+}
+
+func (zeroTicketsStore) GetOpenCountByUserAndPanel(ctx context.Context, guildId, userId uint64, panelId int) (_ int, _ error) {
+	return
+}
+
+func (zeroTicketsStore) GetTotalCountByUser(ctx context.Context, guildId, userId uint64) (_ int, _ error) {
+	return
+}
+
+func (zeroTicketsStore) GetTotalTicketCount(ctx context.Context, guildId uint64) (count int, e error) {
+	return
+}
+
+func (zeroTicketsStore) GetTotalTicketCountInterval(ctx context.Context, guildId uint64, interval time.Duration) (count int, e error) {
+	return
+}
+
+func (zeroTicketsStore) SetChannelId(ctx context.Context, guildId uint64, ticketId int, channelId uint64) (err error) {
+	return
+}
+
+func (zeroTicketsStore) SetHasTranscript(ctx context.Context, guildId uint64, ticketId int, hasTranscript bool) (err error) {
+	return
+}
+
+func (zeroTicketsStore) SetJoinMessageId(ctx context.Context, guildId uint64, ticketId int, joinMessageId *uint64) (err error) {
+	return
+}
+
+func (zeroTicketsStore) SetMessageIds(ctx context.Context, guildId uint64, ticketId int, welcomeMessageId uint64, joinMessageId *uint64) (err error) {
+	return
+}
+
+func (zeroTicketsStore) SetNotesThreadId(ctx context.Context, guildId uint64, ticketId int, notesThreadId uint64) (_ error) {
+	return
+}
+
+func (zeroTicketsStore) SetOpen(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	return
+}
+
+func (zeroTicketsStore) SetPanelId(ctx context.Context, guildId uint64, ticketId, panelId int) (err error) {
+	return
+}
+
+func (zeroTicketsStore) SetStatus(ctx context.Context, guildId uint64, ticketId int, status model.TicketStatus) (_ error) {
+	return
+}
+
+func (zeroTicketsStore) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	panic("raw queries are not supported by the harness")
+}
+
+type zeroUsedKeysStore struct{}
+
+var _ dbclient.UsedKeysStore = zeroUsedKeysStore{}
+
+func (zeroUsedKeysStore) Set(ctx context.Context, tx pgx.Tx, key uuid.UUID, guildId, userId uint64) (err error) {
+	return
+}
+
+type zeroUsersCanCloseStore struct{}
+
+var _ dbclient.UsersCanCloseStore = zeroUsersCanCloseStore{}
+
+func (zeroUsersCanCloseStore) Get(ctx context.Context, guildId uint64) (usersCanClose bool, e error) {
+	return
+}
+
+type zeroVoteCreditsStore struct{}
+
+var _ dbclient.VoteCreditsStore = zeroVoteCreditsStore{}
+
+func (zeroVoteCreditsStore) Delete(ctx context.Context, tx pgx.Tx, userId uint64) (_ error) {
+	return
+}
+
+func (zeroVoteCreditsStore) Get(ctx context.Context, tx pgx.Tx, userId uint64) (_ int, _ error) {
+	return
+}
+
+type zeroWebhooksStore struct{}
+
+var _ dbclient.WebhooksStore = zeroWebhooksStore{}
+
+func (zeroWebhooksStore) Create(ctx context.Context, guildId uint64, ticketId int, webhook database.Webhook) (err error) {
+	return
+}
+
+func (zeroWebhooksStore) Delete(ctx context.Context, guildId uint64, ticketId int) (err error) {
+	return
+}
+
+type zeroWelcomeMessagesStore struct{}
+
+var _ dbclient.WelcomeMessagesStore = zeroWelcomeMessagesStore{}
+
+func (zeroWelcomeMessagesStore) Get(ctx context.Context, guildId uint64) (welcomeMessage string, e error) {
+	return
+}
+
+type zeroWhitelabelStore struct{}
+
+var _ dbclient.WhitelabelStore = zeroWhitelabelStore{}
+
+func (zeroWhitelabelStore) GetByBotId(ctx context.Context, botId uint64) (_ database.WhitelabelBot, _ error) {
+	return
+}
+
+func (zeroWhitelabelStore) GetByUserId(ctx context.Context, userId uint64) (_ database.WhitelabelBot, _ error) {
+	return
+}
+
+type zeroWhitelabelErrorsStore struct{}
+
+var _ dbclient.WhitelabelErrorsStore = zeroWhitelabelErrorsStore{}
+
+func (zeroWhitelabelErrorsStore) GetRecent(ctx context.Context, userId uint64, limit int) (errors []database.WhitelabelError, e error) {
+	return
+}
+
+type zeroWhitelabelGuildsStore struct{}
+
+var _ dbclient.WhitelabelGuildsStore = zeroWhitelabelGuildsStore{}
+
+func (zeroWhitelabelGuildsStore) Add(ctx context.Context, botId, guildId uint64) (err error) {
+	return
+}
+
+func (zeroWhitelabelGuildsStore) Delete(ctx context.Context, botId, guildId uint64) (err error) {
+	return
+}
+
+func (zeroWhitelabelGuildsStore) GetBotByGuild(ctx context.Context, guildId uint64) (botId uint64, found bool, e error) {
+	return
+}
+
+func (zeroWhitelabelGuildsStore) GetGuilds(ctx context.Context, botId uint64) (guilds []uint64, e error) {
+	return
+}
+
+// zeroTables returns tables which store nothing, and return zero values from every query
+func zeroTables() dbclient.Tables {
+	return dbclient.Tables{
+		ActiveLanguage:                zeroActiveLanguageStore{},
+		ArchiveChannel:                zeroArchiveChannelStore{},
+		ArchiveDmMessages:             zeroArchiveDmMessagesStore{},
+		ArchiveMessages:               zeroArchiveMessagesStore{},
+		AutoClose:                     zeroAutoCloseStore{},
+		AutoCloseExclude:              zeroAutoCloseExcludeStore{},
+		Blacklist:                     zeroBlacklistStore{},
+		CategoryUpdateQueue:           zeroCategoryUpdateQueueStore{},
+		ChannelCategory:               zeroChannelCategoryStore{},
+		ClaimSettings:                 zeroClaimSettingsStore{},
+		CloseConfirmation:             zeroCloseConfirmationStore{},
+		CloseReason:                   zeroCloseReasonStore{},
+		CloseRequest:                  zeroCloseRequestStore{},
+		CustomColours:                 zeroCustomColoursStore{},
+		CustomIntegrationGuilds:       zeroCustomIntegrationGuildsStore{},
+		CustomIntegrationHeaders:      zeroCustomIntegrationHeadersStore{},
+		CustomIntegrationPlaceholders: zeroCustomIntegrationPlaceholdersStore{},
+		CustomIntegrationSecretValues: zeroCustomIntegrationSecretValuesStore{},
+		EmbedFields:                   zeroEmbedFieldsStore{},
+		Embeds:                        zeroEmbedsStore{},
+		Entitlements:                  zeroEntitlementsStore{},
+		ExitSurveyResponses:           zeroExitSurveyResponsesStore{},
+		Experiment:                    zeroExperimentStore{},
+		FeedbackEnabled:               zeroFeedbackEnabledStore{},
+		FirstResponseTime:             zeroFirstResponseTimeStore{},
+		FormInput:                     zeroFormInputStore{},
+		FormInputOption:               zeroFormInputOptionStore{},
+		Forms:                         zeroFormsStore{},
+		GdprLogs:                      zeroGdprLogsStore{},
+		GlobalBlacklist:               zeroGlobalBlacklistStore{},
+		GuildLeaveTime:                zeroGuildLeaveTimeStore{},
+		GuildMetadata:                 zeroGuildMetadataStore{},
+		LegacyPremiumEntitlements:     zeroLegacyPremiumEntitlementsStore{},
+		NamingScheme:                  zeroNamingSchemeStore{},
+		OnCall:                        zeroOnCallStore{},
+		Panel:                         zeroPanelStore{},
+		PanelAccessControlRules:       zeroPanelAccessControlRulesStore{},
+		PanelHereMention:              zeroPanelHereMentionStore{},
+		PanelRoleMentions:             zeroPanelRoleMentionsStore{},
+		PanelSupportHours:             zeroPanelSupportHoursStore{},
+		PanelSupportHoursSettings:     zeroPanelSupportHoursSettingsStore{},
+		PanelTeams:                    zeroPanelTeamsStore{},
+		PanelTicketPermissions:        zeroPanelTicketPermissionsStore{},
+		PanelUserMention:              zeroPanelUserMentionStore{},
+		Participants:                  zeroParticipantsStore{},
+		Permissions:                   zeroPermissionsStore{},
+		PremiumKeys:                   zeroPremiumKeysStore{},
+		RoleBlacklist:                 zeroRoleBlacklistStore{},
+		RolePermissions:               zeroRolePermissionsStore{},
+		ServerBlacklist:               zeroServerBlacklistStore{},
+		ServiceRatings:                zeroServiceRatingsStore{},
+		Settings:                      zeroSettingsStore{},
+		SubscriptionSkus:              zeroSubscriptionSkusStore{},
+		SupportTeam:                   zeroSupportTeamStore{},
+		SupportTeamMembers:            zeroSupportTeamMembersStore{},
+		SupportTeamPermissions:        zeroSupportTeamPermissionsStore{},
+		SupportTeamRoles:              zeroSupportTeamRolesStore{},
+		Tag:                           zeroTagStore{},
+		TicketClaims:                  zeroTicketClaimsStore{},
+		TicketLabelAssignments:        zeroTicketLabelAssignmentsStore{},
+		TicketLabels:                  zeroTicketLabelsStore{},
+		TicketLastMessage:             zeroTicketLastMessageStore{},
+		TicketLimit:                   zeroTicketLimitStore{},
+		TicketMembers:                 zeroTicketMembersStore{},
+		TicketPermissions:             zeroTicketPermissionsStore{},
+		Tickets:                       zeroTicketsStore{},
+		UsedKeys:                      zeroUsedKeysStore{},
+		UsersCanClose:                 zeroUsersCanCloseStore{},
+		VoteCredits:                   zeroVoteCreditsStore{},
+		Webhooks:                      zeroWebhooksStore{},
+		WelcomeMessages:               zeroWelcomeMessagesStore{},
+		Whitelabel:                    zeroWhitelabelStore{},
+		WhitelabelErrors:              zeroWhitelabelErrorsStore{},
+		WhitelabelGuilds:              zeroWhitelabelGuildsStore{},
+	}
+}
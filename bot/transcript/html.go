@@ -0,0 +1,255 @@
+package transcript
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+)
+
+var htmlTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Ticket #{{.TicketId}} - {{.GuildName}}</title>
+<style>
+body { margin: 0; padding: 16px; background: #313338; color: #dbdee1; font-family: "gg sans", "Helvetica Neue", Helvetica, Arial, sans-serif; font-size: 15px; }
+header { border-bottom: 1px solid #3f4147; padding-bottom: 12px; margin-bottom: 12px; }
+header h1 { margin: 0; font-size: 20px; color: #f2f3f5; }
+header p { margin: 4px 0 0; color: #949ba4; font-size: 13px; }
+.message { display: flex; gap: 12px; padding: 6px 0; }
+.avatar { width: 40px; height: 40px; border-radius: 50%; flex-shrink: 0; background: #5865f2; }
+.body { min-width: 0; flex-grow: 1; }
+.author { font-weight: 600; color: #f2f3f5; }
+.bot { background: #5865f2; color: #fff; border-radius: 3px; font-size: 10px; padding: 1px 4px; margin-left: 4px; vertical-align: middle; }
+.timestamp { color: #949ba4; font-size: 12px; margin-left: 6px; }
+.reply { color: #949ba4; font-size: 13px; margin-bottom: 2px; }
+.content { white-space: pre-wrap; word-wrap: break-word; }
+.mention { background: rgba(88, 101, 242, 0.3); color: #c9cdfb; border-radius: 3px; padding: 0 2px; }
+.attachment { display: block; margin-top: 4px; }
+.attachment img { max-width: 400px; max-height: 300px; border-radius: 4px; }
+.embed { margin-top: 4px; padding: 8px 12px; background: #2b2d31; border-left: 4px solid #1e1f22; border-radius: 4px; max-width: 520px; }
+.embed-author { font-size: 13px; font-weight: 600; }
+.embed-title { font-weight: 600; color: #00a8fc; }
+.embed-description { white-space: pre-wrap; font-size: 14px; }
+.embed-field { margin-top: 6px; font-size: 14px; }
+.embed-field-name { font-weight: 600; }
+.embed-image img { max-width: 100%; border-radius: 4px; margin-top: 6px; }
+.embed-footer { margin-top: 6px; color: #949ba4; font-size: 12px; }
+.notes { margin: 24px 0 0; padding-top: 12px; border-top: 1px solid #3f4147; font-size: 18px; color: #f0b232; }
+.notes-description { margin: 4px 0 12px; color: #949ba4; font-size: 13px; }
+</style>
+</head>
+<body>
+<header>
+<h1>Ticket #{{.TicketId}}</h1>
+{{if .ReopenedFrom}}<p>Reopened from ticket #{{.ReopenedFrom}}</p>
+{{end}}<p>{{.GuildName}} &middot; {{.MessageCount}} messages &middot; Times shown in {{.Timezone}}</p>
+</header>
+{{range .Messages}}{{template "message" .}}{{end}}{{if .Notes}}<h2 class="notes">Staff Notes</h2>
+<p class="notes-description">Messages from the private staff notes thread</p>
+{{range .Notes}}{{template "message" .}}{{end}}{{end}}</body>
+</html>
+{{define "message"}}<div class="message" id="message-{{.Id}}">
+{{if .AvatarUrl}}<img class="avatar" src="{{.AvatarUrl}}" alt="">{{else}}<div class="avatar"></div>{{end}}
+<div class="body">
+{{if .Reply}}<div class="reply">&#8627; Replying to <a href="#message-{{.Reply.Id}}">{{.Reply.Author}}</a>: {{.Reply.Content}}</div>{{end}}
+<div><span class="author">{{.Author}}</span>{{if .Bot}}<span class="bot">BOT</span>{{end}}<span class="timestamp">{{.Timestamp}}{{if .Edited}} (edited){{end}}</span></div>
+{{if .Content}}<div class="content">{{.Content}}</div>{{end}}
+{{range .Attachments}}<a class="attachment" href="{{.Url}}">{{if .Image}}<img src="{{.Url}}" alt="{{.Filename}}">{{else}}&#128206; {{.Filename}}{{end}}</a>
+{{end}}{{range .Embeds}}<div class="embed"{{if .Colour}} style="border-left-color: {{.Colour}}"{{end}}>
+{{if .Author}}<div class="embed-author">{{.Author}}</div>{{end}}
+{{if .Title}}<div class="embed-title">{{if .Url}}<a href="{{.Url}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</div>{{end}}
+{{if .Description}}<div class="embed-description">{{.Description}}</div>{{end}}
+{{range .Fields}}<div class="embed-field"><div class="embed-field-name">{{.Name}}</div><div>{{.Value}}</div></div>
+{{end}}{{if .ImageUrl}}<div class="embed-image"><img src="{{.ImageUrl}}" alt=""></div>{{end}}
+{{if .Footer}}<div class="embed-footer">{{.Footer}}</div>{{end}}
+</div>
+{{end}}</div>
+</div>
+{{end}}`))
+
+type htmlTranscript struct {
+	TicketId     int
+	ReopenedFrom *int
+	GuildName    string
+	Timezone     string
+	MessageCount int
+	Messages     []htmlMessage
+	Notes        []htmlMessage
+}
+
+type htmlMessage struct {
+	Id          uint64
+	Author      string
+	AvatarUrl   string
+	Bot         bool
+	Timestamp   string
+	Edited      bool
+	Reply       *htmlReply
+	Content     template.HTML
+	Attachments []htmlAttachment
+	Embeds      []htmlEmbed
+}
+
+type htmlReply struct {
+	Id      uint64
+	Author  string
+	Content string
+}
+
+type htmlAttachment struct {
+	Filename string
+	Url      string
+	Image    bool
+}
+
+type htmlEmbed struct {
+	Colour      template.CSS
+	Author      string
+	Title       string
+	Url         string
+	Description template.HTML
+	Fields      []htmlEmbedField
+	ImageUrl    string
+	Footer      string
+}
+
+type htmlEmbedField struct {
+	Name  template.HTML
+	Value template.HTML
+}
+
+const replySnippetLength = 100
+
+func (t Transcript) renderHtml() ([]byte, error) {
+	users := t.userNames()
+	byId := t.messagesById()
+
+	data := htmlTranscript{
+		TicketId:     t.TicketId,
+		ReopenedFrom: t.ReopenedFrom,
+		GuildName:    t.GuildName,
+		Timezone:     t.location().String(),
+		MessageCount: len(t.Messages),
+		Messages:     t.renderHtmlMessages(t.Messages, users, byId),
+	}
+
+	if len(t.Notes) > 0 {
+		data.Notes = t.renderHtmlMessages(t.Notes, users, byId)
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (t Transcript) renderHtmlMessages(msgs []message.Message, users map[uint64]string, byId map[uint64]message.Message) []htmlMessage {
+	messages := make([]htmlMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		rendered := htmlMessage{
+			Id:        msg.Id,
+			Author:    users[msg.Author.Id],
+			AvatarUrl: msg.Author.AvatarUrl(64),
+			Bot:       msg.Author.Bot,
+			Timestamp: t.formatTime(msg.Timestamp),
+			Edited:    msg.EditedTimestamp != nil,
+			Content:   t.renderHtmlContent(msg.Content, users),
+		}
+
+		if replied, ok := t.findReply(msg, byId); ok {
+			rendered.Reply = &htmlReply{
+				Id:      replied.Id,
+				Author:  users[replied.Author.Id],
+				Content: truncate(t.resolveMentionsPlain(replied.Content, users), replySnippetLength),
+			}
+		}
+
+		for _, attachment := range msg.Attachments {
+			rendered.Attachments = append(rendered.Attachments, htmlAttachment{
+				Filename: attachment.Filename,
+				Url:      attachment.Url,
+				Image:    attachment.Width > 0 && attachment.Height > 0,
+			})
+		}
+
+		for _, e := range msg.Embeds {
+			rendered.Embeds = append(rendered.Embeds, t.renderHtmlEmbed(e, users))
+		}
+
+		messages = append(messages, rendered)
+	}
+
+	return messages
+}
+
+func (t Transcript) renderHtmlEmbed(e embed.Embed, users map[uint64]string) htmlEmbed {
+	rendered := htmlEmbed{
+		Title:       e.Title,
+		Url:         e.Url,
+		Description: t.renderHtmlContent(e.Description, users),
+	}
+
+	if e.Color != 0 {
+		rendered.Colour = template.CSS(fmt.Sprintf("#%06x", e.Color))
+	}
+
+	if e.Author != nil {
+		rendered.Author = e.Author.Name
+	}
+
+	for _, field := range e.Fields {
+		if field == nil {
+			continue
+		}
+
+		rendered.Fields = append(rendered.Fields, htmlEmbedField{
+			Name:  t.renderHtmlContent(field.Name, users),
+			Value: t.renderHtmlContent(field.Value, users),
+		})
+	}
+
+	if e.Image != nil {
+		rendered.ImageUrl = e.Image.Url
+	}
+
+	if e.Footer != nil {
+		rendered.Footer = e.Footer.Text
+	}
+
+	return rendered
+}
+
+// renderHtmlContent escapes content, wrapping resolved mentions so that they can be styled
+func (t Transcript) renderHtmlContent(content string, users map[uint64]string) template.HTML {
+	var buf strings.Builder
+	for _, segment := range t.resolveMentions(content, users) {
+		switch segment.Type {
+		case segmentMention:
+			buf.WriteString(`<span class="mention">`)
+			buf.WriteString(html.EscapeString(segment.Value))
+			buf.WriteString(`</span>`)
+		default:
+			buf.WriteString(html.EscapeString(segment.Value))
+		}
+	}
+
+	return template.HTML(buf.String())
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	return string(runes[:length]) + "..."
+}
@@ -0,0 +1,106 @@
+package transcript
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+)
+
+type jsonTranscript struct {
+	GuildId      uint64        `json:"guild_id,string"`
+	GuildName    string        `json:"guild_name"`
+	TicketId     int           `json:"ticket_id"`
+	ReopenedFrom *int          `json:"reopened_from,omitempty"`
+	Timezone     string        `json:"timezone"`
+	Messages     []jsonMessage `json:"messages"`
+	Notes        []jsonMessage `json:"notes,omitempty"`
+}
+
+type jsonMessage struct {
+	Id              uint64           `json:"id,string"`
+	Author          jsonAuthor       `json:"author"`
+	Timestamp       time.Time        `json:"timestamp"`
+	EditedTimestamp *time.Time       `json:"edited_timestamp,omitempty"`
+	ReplyTo         *uint64          `json:"reply_to,string,omitempty"`
+	Content         string           `json:"content"`
+	ResolvedContent string           `json:"resolved_content"`
+	Attachments     []jsonAttachment `json:"attachments"`
+	Embeds          []embed.Embed    `json:"embeds"`
+}
+
+type jsonAuthor struct {
+	Id   uint64 `json:"id,string"`
+	Name string `json:"name"`
+	Bot  bool   `json:"bot"`
+}
+
+type jsonAttachment struct {
+	Filename string `json:"filename"`
+	Url      string `json:"url"`
+	Size     int    `json:"size"`
+}
+
+func (t Transcript) renderJson() ([]byte, error) {
+	users := t.userNames()
+
+	data := jsonTranscript{
+		GuildId:      t.GuildId,
+		GuildName:    t.GuildName,
+		TicketId:     t.TicketId,
+		ReopenedFrom: t.ReopenedFrom,
+		Timezone:     t.location().String(),
+		Messages:     t.renderJsonMessages(t.Messages, users),
+	}
+
+	if len(t.Notes) > 0 {
+		data.Notes = t.renderJsonMessages(t.Notes, users)
+	}
+
+	return json.MarshalIndent(data, "", "  ")
+}
+
+func (t Transcript) renderJsonMessages(msgs []message.Message, users map[uint64]string) []jsonMessage {
+	messages := make([]jsonMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		rendered := jsonMessage{
+			Id: msg.Id,
+			Author: jsonAuthor{
+				Id:   msg.Author.Id,
+				Name: users[msg.Author.Id],
+				Bot:  msg.Author.Bot,
+			},
+			Timestamp:       msg.Timestamp.In(t.location()),
+			Content:         msg.Content,
+			ResolvedContent: t.resolveMentionsPlain(msg.Content, users),
+			Attachments:     make([]jsonAttachment, 0, len(msg.Attachments)),
+			Embeds:          msg.Embeds,
+		}
+
+		if msg.EditedTimestamp != nil {
+			edited := msg.EditedTimestamp.In(t.location())
+			rendered.EditedTimestamp = &edited
+		}
+
+		if msg.Type == message.MessageTypeReply && msg.MessageReference.MessageId != 0 {
+			rendered.ReplyTo = &msg.MessageReference.MessageId
+		}
+
+		for _, attachment := range msg.Attachments {
+			rendered.Attachments = append(rendered.Attachments, jsonAttachment{
+				Filename: attachment.Filename,
+				Url:      attachment.Url,
+				Size:     attachment.Size,
+			})
+		}
+
+		if rendered.Embeds == nil {
+			rendered.Embeds = []embed.Embed{}
+		}
+
+		messages = append(messages, rendered)
+	}
+
+	return messages
+}
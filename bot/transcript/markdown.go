@@ -0,0 +1,122 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+)
+
+func (t Transcript) renderMarkdown() []byte {
+	users := t.userNames()
+	byId := t.messagesById()
+
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("# Ticket #%d - %s\n\n", t.TicketId, t.GuildName))
+	if t.ReopenedFrom != nil {
+		buf.WriteString(fmt.Sprintf("Reopened from ticket #%d.\n", *t.ReopenedFrom))
+	}
+	buf.WriteString(fmt.Sprintf("%d messages. Times shown in %s.\n", len(t.Messages), t.location().String()))
+
+	t.renderMarkdownMessages(&buf, t.Messages, users, byId)
+
+	if len(t.Notes) > 0 {
+		buf.WriteString("\n---\n\n## Staff Notes\n\n")
+		buf.WriteString("Messages from the private staff notes thread.\n")
+		t.renderMarkdownMessages(&buf, t.Notes, users, byId)
+	}
+
+	return []byte(buf.String())
+}
+
+func (t Transcript) renderMarkdownMessages(buf *strings.Builder, msgs []message.Message, users map[uint64]string, byId map[uint64]message.Message) {
+	for _, msg := range msgs {
+		buf.WriteString("\n---\n\n")
+
+		author := users[msg.Author.Id]
+		if msg.Author.Bot {
+			author += " [BOT]"
+		}
+
+		buf.WriteString(fmt.Sprintf("**%s** - %s", author, t.formatTime(msg.Timestamp)))
+		if msg.EditedTimestamp != nil {
+			buf.WriteString(" (edited)")
+		}
+		buf.WriteString("\n\n")
+
+		if replied, ok := t.findReply(msg, byId); ok {
+			snippet := truncate(t.resolveMentionsPlain(replied.Content, users), replySnippetLength)
+			buf.WriteString(fmt.Sprintf("> Replying to **%s**: %s\n\n", users[replied.Author.Id], singleLine(snippet)))
+		}
+
+		if msg.Content != "" {
+			buf.WriteString(t.resolveMentionsPlain(msg.Content, users))
+			buf.WriteString("\n\n")
+		}
+
+		for _, attachment := range msg.Attachments {
+			buf.WriteString(fmt.Sprintf("- Attachment: [%s](%s)\n", attachment.Filename, attachment.Url))
+		}
+
+		for _, e := range msg.Embeds {
+			buf.WriteString(t.renderMarkdownEmbed(e, users))
+		}
+	}
+}
+
+// renderMarkdownEmbed renders an embed as a blockquote
+func (t Transcript) renderMarkdownEmbed(e embed.Embed, users map[uint64]string) string {
+	var lines []string
+
+	if e.Author != nil && e.Author.Name != "" {
+		lines = append(lines, fmt.Sprintf("*%s*", e.Author.Name))
+	}
+
+	if e.Title != "" {
+		if e.Url != "" {
+			lines = append(lines, fmt.Sprintf("**[%s](%s)**", e.Title, e.Url))
+		} else {
+			lines = append(lines, fmt.Sprintf("**%s**", e.Title))
+		}
+	}
+
+	if e.Description != "" {
+		lines = append(lines, strings.Split(t.resolveMentionsPlain(e.Description, users), "\n")...)
+	}
+
+	for _, field := range e.Fields {
+		if field == nil {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("**%s**", t.resolveMentionsPlain(field.Name, users)))
+		lines = append(lines, strings.Split(t.resolveMentionsPlain(field.Value, users), "\n")...)
+	}
+
+	if e.Image != nil && e.Image.Url != "" {
+		lines = append(lines, fmt.Sprintf("![](%s)", e.Image.Url))
+	}
+
+	if e.Footer != nil && e.Footer.Text != "" {
+		lines = append(lines, fmt.Sprintf("*%s*", e.Footer.Text))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString("\n")
+	for _, line := range lines {
+		buf.WriteString("> ")
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+func singleLine(s string) string {
+	return strings.ReplaceAll(s, "\n", " ")
+}
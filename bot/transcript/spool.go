@@ -0,0 +1,304 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"go.uber.org/zap"
+)
+
+const spoolPruneInterval = time.Hour
+
+// Spool collects a ticket's messages on disk as they are fetched, so that a collection which is interrupted can resume
+// from the oldest message fetched so far rather than starting over. Pages are appended in the order they are fetched
+// from Discord, newest first.
+//
+// The spool is a local file, so only a retry on the same host can resume from it. A retry on another worker starts
+// over, and the abandoned spool is deleted by PruneSpools once it expires.
+//
+// The spool does not bound memory usage: the archiver only accepts a complete transcript, as do transcript exports, so
+// Messages reads the whole spool back into memory.
+type Spool struct {
+	dataPath     string
+	progressPath string
+	file         *os.File
+	progress     SpoolProgress
+	resumed      bool
+}
+
+type SpoolProgress struct {
+	StartedAt time.Time `json:"started_at"`
+	// NewestId is the ID of the newest message in the spool, used to pick up any messages sent after the collection
+	// started
+	NewestId uint64 `json:"newest_id,string"`
+	// OldestId is the ID of the oldest message fetched so far, which the next page should be fetched from
+	OldestId uint64 `json:"oldest_id,string"`
+	Count    int    `json:"count"`
+	// Size is the length of the data file when the progress was last saved. Anything written beyond it belongs to a
+	// page that was not fully written, and is discarded when the spool is reopened.
+	Size     int64 `json:"size"`
+	Complete bool  `json:"complete"`
+}
+
+// OpenSpool opens the spool for the ticket, resuming from any progress saved by a previous attempt, unless that
+// attempt was started longer than expiry ago
+func OpenSpool(directory string, guildId uint64, ticketId int, expiry time.Duration) (*Spool, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%d-%d", guildId, ticketId)
+	s := &Spool{
+		dataPath:     filepath.Join(directory, name+".jsonl"),
+		progressPath: filepath.Join(directory, name+".progress.json"),
+	}
+
+	progress, ok, err := s.loadProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && time.Since(progress.StartedAt) < expiry {
+		s.progress = progress
+		s.resumed = progress.Count > 0
+	} else {
+		s.progress = SpoolProgress{StartedAt: time.Now()}
+	}
+
+	s.file, err = os.OpenFile(s.dataPath, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the data file has been lost or cut short, the saved progress cannot be used
+	stat, err := s.file.Stat()
+	if err != nil {
+		_ = s.file.Close()
+		return nil, err
+	}
+
+	if stat.Size() < s.progress.Size {
+		s.progress = SpoolProgress{StartedAt: time.Now()}
+		s.resumed = false
+	}
+
+	if err := s.file.Truncate(s.progress.Size); err != nil {
+		_ = s.file.Close()
+		return nil, err
+	}
+
+	if _, err := s.file.Seek(s.progress.Size, io.SeekStart); err != nil {
+		_ = s.file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Spool) Progress() SpoolProgress {
+	return s.progress
+}
+
+// Resumed returns whether the spool already contained messages from a previous attempt when it was opened
+func (s *Spool) Resumed() bool {
+	return s.resumed
+}
+
+// Append writes a page of messages, ordered newest first as returned by Discord, and saves the progress
+func (s *Spool) Append(page []message.Message) error {
+	if len(page) == 0 {
+		return nil
+	}
+
+	w := bufio.NewWriter(s.file)
+	encoder := json.NewEncoder(w)
+	for _, msg := range page {
+		if err := encoder.Encode(msg); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	size, err := s.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if s.progress.NewestId == 0 {
+		s.progress.NewestId = page[0].Id
+	}
+
+	s.progress.OldestId = page[len(page)-1].Id
+	s.progress.Count += len(page)
+	s.progress.Size = size
+
+	return s.saveProgress()
+}
+
+// MarkComplete records that every message older than NewestId has been fetched
+func (s *Spool) MarkComplete() error {
+	s.progress.Complete = true
+	return s.saveProgress()
+}
+
+// Messages reads the spooled messages back, ordered oldest first
+func (s *Spool) Messages() ([]message.Message, error) {
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	messages := make([]message.Message, s.progress.Count)
+
+	decoder := json.NewDecoder(io.LimitReader(s.file, s.progress.Size))
+	for i := s.progress.Count - 1; i >= 0; i-- {
+		if err := decoder.Decode(&messages[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.file.Seek(s.progress.Size, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (s *Spool) Close() error {
+	return s.file.Close()
+}
+
+// Remove closes and deletes the spool, once the transcript has been stored
+func (s *Spool) Remove() error {
+	_ = s.file.Close()
+
+	if err := os.Remove(s.progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Remove(s.dataPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// PruneSpools deletes the files in directory which belong to spools that have not been written to for longer than
+// expiry, returning how many were deleted. These are left behind by closes which failed and were then retried on
+// another host, or never retried at all. Spools which are still being written to are never old enough to be pruned.
+func PruneSpools(directory string, expiry time.Duration) (int, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-expiry)
+
+	var removed int
+	for _, entry := range entries {
+		if entry.IsDir() || !isSpoolFile(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return removed, err
+		}
+
+		if info.ModTime().After(cutoff) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(directory, entry.Name())); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+func StartSpoolPruneLoop(logger *zap.Logger, directory string, expiry time.Duration) {
+	prune := func() {
+		removed, err := PruneSpools(directory, expiry)
+		if err != nil {
+			logger.Error("Failed to prune transcript spools", zap.Error(err))
+			return
+		}
+
+		if removed > 0 {
+			logger.Info("Pruned abandoned transcript spool files", zap.Int("count", removed))
+		}
+	}
+
+	// Spools may have been left behind by a previous run
+	prune()
+
+	timer := time.NewTicker(spoolPruneInterval)
+	for {
+		<-timer.C
+		prune()
+	}
+}
+
+func isSpoolFile(name string) bool {
+	return strings.HasSuffix(name, ".jsonl") || strings.HasSuffix(name, ".progress.json") || strings.HasSuffix(name, ".progress.json.tmp")
+}
+
+func (s *Spool) loadProgress() (SpoolProgress, bool, error) {
+	data, err := os.ReadFile(s.progressPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return SpoolProgress{}, false, nil
+		}
+
+		return SpoolProgress{}, false, err
+	}
+
+	var progress SpoolProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		// A corrupt progress file means we cannot trust the data file either, so start over
+		return SpoolProgress{}, false, nil
+	}
+
+	return progress, true, nil
+}
+
+// saveProgress writes the progress to a temporary file and renames it into place, so that the progress file is never
+// left partially written
+func (s *Spool) saveProgress() error {
+	data, err := json.Marshal(s.progress)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.progressPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.progressPath)
+}
@@ -0,0 +1,122 @@
+package transcript
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/stretchr/testify/require"
+)
+
+func spoolPage(ids ...uint64) []message.Message {
+	page := make([]message.Message, len(ids))
+	for i, id := range ids {
+		page[i] = message.Message{Id: id, Content: fmt.Sprintf("message %d", id)}
+	}
+
+	return page
+}
+
+func spoolIds(t *testing.T, s *Spool) []uint64 {
+	messages, err := s.Messages()
+	require.NoError(t, err)
+
+	ids := make([]uint64, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.Id
+	}
+
+	return ids
+}
+
+func TestSpoolResume(t *testing.T) {
+	directory := t.TempDir()
+
+	s, err := OpenSpool(directory, 1, 2, time.Hour)
+	require.NoError(t, err)
+	require.False(t, s.Resumed())
+
+	require.NoError(t, s.Append(spoolPage(10, 9, 8)))
+	require.NoError(t, s.Append(spoolPage(7, 6)))
+	require.Equal(t, []uint64{6, 7, 8, 9, 10}, spoolIds(t, s))
+
+	// Simulate a page that was partially written before the worker was interrupted
+	_, err = s.file.WriteString(`{"id":"5","content":"trunc`)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	s, err = OpenSpool(directory, 1, 2, time.Hour)
+	require.NoError(t, err)
+	require.True(t, s.Resumed())
+	require.Equal(t, uint64(10), s.Progress().NewestId)
+	require.Equal(t, uint64(6), s.Progress().OldestId)
+	require.False(t, s.Progress().Complete)
+
+	require.NoError(t, s.Append(spoolPage(5, 4)))
+	require.NoError(t, s.MarkComplete())
+	require.Equal(t, []uint64{4, 5, 6, 7, 8, 9, 10}, spoolIds(t, s))
+	require.Equal(t, "message 4", func() string {
+		messages, err := s.Messages()
+		require.NoError(t, err)
+		return messages[0].Content
+	}())
+
+	require.NoError(t, s.Remove())
+
+	s, err = OpenSpool(directory, 1, 2, time.Hour)
+	require.NoError(t, err)
+	require.False(t, s.Resumed())
+	require.Empty(t, spoolIds(t, s))
+	require.NoError(t, s.Close())
+}
+
+func TestSpoolExpiry(t *testing.T) {
+	directory := t.TempDir()
+
+	s, err := OpenSpool(directory, 1, 2, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, s.Append(spoolPage(3, 2, 1)))
+	require.NoError(t, s.Close())
+
+	s, err = OpenSpool(directory, 1, 2, 0)
+	require.NoError(t, err)
+	require.False(t, s.Resumed())
+	require.Empty(t, spoolIds(t, s))
+	require.NoError(t, s.Close())
+}
+
+func TestPruneSpools(t *testing.T) {
+	directory := t.TempDir()
+
+	abandoned, err := OpenSpool(directory, 1, 2, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, abandoned.Append(spoolPage(3, 2, 1)))
+	require.NoError(t, abandoned.Close())
+
+	active, err := OpenSpool(directory, 1, 3, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, active.Append(spoolPage(3, 2, 1)))
+	defer active.Close()
+
+	// Files which don't belong to a spool are left alone
+	other := filepath.Join(directory, "other.txt")
+	require.NoError(t, os.WriteFile(other, nil, 0o600))
+
+	old := time.Now().Add(-time.Hour * 2)
+	for _, path := range []string{abandoned.dataPath, abandoned.progressPath, other} {
+		require.NoError(t, os.Chtimes(path, old, old))
+	}
+
+	removed, err := PruneSpools(directory, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	require.NoFileExists(t, abandoned.dataPath)
+	require.NoFileExists(t, abandoned.progressPath)
+	require.FileExists(t, active.dataPath)
+	require.FileExists(t, active.progressPath)
+	require.FileExists(t, other)
+}
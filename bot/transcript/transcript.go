@@ -0,0 +1,231 @@
+package transcript
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+)
+
+type Format string
+
+const (
+	FormatHtml     Format = "html"
+	FormatMarkdown Format = "markdown"
+	FormatJson     Format = "json"
+)
+
+var Formats = []Format{FormatHtml, FormatMarkdown, FormatJson}
+
+func ParseFormat(s string) (Format, bool) {
+	for _, format := range Formats {
+		if string(format) == s {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// File is a rendered transcript, ready to be attached to a message
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Transcript holds a ticket's messages, oldest first, along with the guild data needed to resolve mentions
+type Transcript struct {
+	GuildId   uint64
+	GuildName string
+	TicketId  int
+	// ReopenedFrom is the closed ticket which this ticket was opened to continue, if any
+	ReopenedFrom *int
+	Location     *time.Location
+	Messages     []message.Message
+	// Notes holds the messages from the staff notes thread, oldest first, which are rendered as a separate section.
+	// It must only be set for transcripts which are shown to staff.
+	Notes    []message.Message
+	Channels map[uint64]string
+	Roles    map[uint64]string
+}
+
+const timestampLayout = "2006-01-02 15:04:05 MST"
+
+func (t Transcript) Export(format Format) (File, error) {
+	switch format {
+	case FormatHtml:
+		data, err := t.renderHtml()
+		if err != nil {
+			return File{}, err
+		}
+
+		return File{Name: t.fileName("html"), ContentType: "text/html; charset=utf-8", Data: data}, nil
+	case FormatMarkdown:
+		return File{Name: t.fileName("md"), ContentType: "text/markdown; charset=utf-8", Data: t.renderMarkdown()}, nil
+	case FormatJson:
+		data, err := t.renderJson()
+		if err != nil {
+			return File{}, err
+		}
+
+		return File{Name: t.fileName("json"), ContentType: "application/json", Data: data}, nil
+	default:
+		return File{}, fmt.Errorf("unknown transcript format %s", format)
+	}
+}
+
+func (t Transcript) fileName(extension string) string {
+	return fmt.Sprintf("transcript-%d-%d.%s", t.GuildId, t.TicketId, extension)
+}
+
+func (t Transcript) location() *time.Location {
+	if t.Location == nil {
+		return time.UTC
+	}
+
+	return t.Location
+}
+
+func (t Transcript) formatTime(timestamp time.Time) string {
+	return timestamp.In(t.location()).Format(timestampLayout)
+}
+
+// userNames maps the ID of each message author and mentioned user to their display name, preferring their nickname
+func (t Transcript) userNames() map[uint64]string {
+	names := make(map[uint64]string)
+	for _, msg := range t.allMessages() {
+		if msg.Member.Nick != "" {
+			names[msg.Author.Id] = msg.Member.Nick
+		} else if _, ok := names[msg.Author.Id]; !ok {
+			names[msg.Author.Id] = msg.Author.EffectiveName()
+		}
+
+		for _, mention := range msg.Mentions {
+			if _, ok := names[mention.Id]; ok {
+				continue
+			}
+
+			if mention.Member.Nick != "" {
+				names[mention.Id] = mention.Member.Nick
+			} else {
+				names[mention.Id] = mention.User.EffectiveName()
+			}
+		}
+	}
+
+	return names
+}
+
+func (t Transcript) allMessages() []message.Message {
+	if len(t.Notes) == 0 {
+		return t.Messages
+	}
+
+	return append(t.Messages[:len(t.Messages):len(t.Messages)], t.Notes...)
+}
+
+// findReply returns the message that msg is replying to, if it is part of the transcript
+func (t Transcript) findReply(msg message.Message, byId map[uint64]message.Message) (message.Message, bool) {
+	if msg.Type != message.MessageTypeReply || msg.MessageReference.MessageId == 0 {
+		return message.Message{}, false
+	}
+
+	replied, ok := byId[msg.MessageReference.MessageId]
+	return replied, ok
+}
+
+func (t Transcript) messagesById() map[uint64]message.Message {
+	byId := make(map[uint64]message.Message, len(t.Messages)+len(t.Notes))
+	for _, msg := range t.allMessages() {
+		byId[msg.Id] = msg
+	}
+
+	return byId
+}
+
+var mentionPattern = regexp.MustCompile(`<(@!?|@&|#)(\d+)>|<t:(-?\d+)(?::[tTdDfFR])?>`)
+
+type segmentType int
+
+const (
+	segmentText segmentType = iota
+	segmentMention
+)
+
+type segment struct {
+	Type  segmentType
+	Value string
+}
+
+// resolveMentions splits content into plain text and mentions, with user, role and channel mentions replaced by
+// their names and timestamps formatted in the transcript's timezone
+func (t Transcript) resolveMentions(content string, users map[uint64]string) []segment {
+	var segments []segment
+
+	last := 0
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(content, -1) {
+		if match[0] > last {
+			segments = append(segments, segment{Type: segmentText, Value: content[last:match[0]]})
+		}
+
+		segments = append(segments, segment{Type: segmentMention, Value: t.resolveMention(content, match, users)})
+		last = match[1]
+	}
+
+	if last < len(content) {
+		segments = append(segments, segment{Type: segmentText, Value: content[last:]})
+	}
+
+	return segments
+}
+
+func (t Transcript) resolveMention(content string, match []int, users map[uint64]string) string {
+	// Timestamp
+	if match[6] != -1 {
+		unix, err := strconv.ParseInt(content[match[6]:match[7]], 10, 64)
+		if err != nil {
+			return content[match[0]:match[1]]
+		}
+
+		return t.formatTime(time.Unix(unix, 0))
+	}
+
+	id, err := strconv.ParseUint(content[match[4]:match[5]], 10, 64)
+	if err != nil {
+		return content[match[0]:match[1]]
+	}
+
+	switch content[match[2]:match[3]] {
+	case "@&":
+		if name, ok := t.Roles[id]; ok {
+			return "@" + name
+		}
+
+		return "@deleted-role"
+	case "#":
+		if name, ok := t.Channels[id]; ok {
+			return "#" + name
+		}
+
+		return "#deleted-channel"
+	default:
+		if name, ok := users[id]; ok {
+			return "@" + name
+		}
+
+		return "@Unknown User"
+	}
+}
+
+func (t Transcript) resolveMentionsPlain(content string, users map[uint64]string) string {
+	var resolved strings.Builder
+	for _, segment := range t.resolveMentions(content, users) {
+		resolved.WriteString(segment.Value)
+	}
+
+	return resolved.String()
+}
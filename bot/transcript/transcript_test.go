@@ -0,0 +1,198 @@
+package transcript
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/objects/channel"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/embed"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/gdl/objects/user"
+	"github.com/stretchr/testify/require"
+)
+
+func testTranscript(t *testing.T) Transcript {
+	location, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	sent := time.Date(2024, 7, 1, 12, 30, 0, 0, time.UTC)
+
+	return Transcript{
+		GuildId:   1,
+		GuildName: "Test Guild",
+		TicketId:  42,
+		Location:  location,
+		Channels:  map[uint64]string{20: "general"},
+		Roles:     map[uint64]string{30: "Support"},
+		Messages: []message.Message{
+			{
+				Id:        100,
+				Author:    user.User{Id: 10, Username: "opener"},
+				Member:    member.Member{Nick: "Opener Nick"},
+				Content:   "Hi <@11>, see <#20> and <@&30> <script>",
+				Timestamp: sent,
+				Mentions: []message.MessageMentionedUser{
+					{User: user.User{Id: 11, Username: "staff"}},
+				},
+				Attachments: []channel.Attachment{
+					{Filename: "log.txt", Url: "https://cdn.example.com/log.txt", Size: 12},
+				},
+			},
+			{
+				Id:               101,
+				Author:           user.User{Id: 11, Username: "staff"},
+				Content:          "Replying at <t:1719837000:f>, <@99>",
+				Timestamp:        sent.Add(time.Minute),
+				Type:             message.MessageTypeReply,
+				MessageReference: message.MessageReference{MessageId: 100},
+				Embeds: []embed.Embed{
+					{Title: "Embed Title", Description: "Embed description", Fields: []*embed.EmbedField{{Name: "Field", Value: "Value"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	tr := testTranscript(t)
+	users := tr.userNames()
+
+	require.Equal(t, "Opener Nick", users[10])
+	require.Equal(t, "Hi @staff, see #general and @Support <script>", tr.resolveMentionsPlain(tr.Messages[0].Content, users))
+	require.Equal(t, "Replying at 2024-07-01 13:30:00 BST, @Unknown User", tr.resolveMentionsPlain(tr.Messages[1].Content, users))
+	require.Equal(t, "#deleted-channel @deleted-role", tr.resolveMentionsPlain("<#21> <@&31>", users))
+}
+
+func TestExportHtml(t *testing.T) {
+	file, err := testTranscript(t).Export(FormatHtml)
+	require.NoError(t, err)
+	require.Equal(t, "transcript-1-42.html", file.Name)
+
+	html := string(file.Data)
+	require.Contains(t, html, `<span class="mention">@staff</span>`)
+	require.Contains(t, html, "&lt;script&gt;")
+	require.NotContains(t, html, "<script>")
+	require.Contains(t, html, "2024-07-01 13:30:00 BST")
+	require.Contains(t, html, `href="#message-100"`)
+	require.Contains(t, html, "https://cdn.example.com/log.txt")
+	require.Contains(t, html, "Embed Title")
+}
+
+func TestExportMarkdown(t *testing.T) {
+	file, err := testTranscript(t).Export(FormatMarkdown)
+	require.NoError(t, err)
+	require.Equal(t, "transcript-1-42.md", file.Name)
+
+	markdown := string(file.Data)
+	require.True(t, strings.HasPrefix(markdown, "# Ticket #42 - Test Guild\n"))
+	require.Contains(t, markdown, "**Opener Nick** - 2024-07-01 13:30:00 BST")
+	require.Contains(t, markdown, "> Replying to **Opener Nick**: Hi @staff")
+	require.Contains(t, markdown, "- Attachment: [log.txt](https://cdn.example.com/log.txt)")
+	require.Contains(t, markdown, "> **Embed Title**")
+}
+
+func TestExportJson(t *testing.T) {
+	file, err := testTranscript(t).Export(FormatJson)
+	require.NoError(t, err)
+
+	var decoded struct {
+		TicketId int    `json:"ticket_id"`
+		Timezone string `json:"timezone"`
+		Messages []struct {
+			Id              string `json:"id"`
+			ReplyTo         string `json:"reply_to"`
+			ResolvedContent string `json:"resolved_content"`
+			Attachments     []struct {
+				Filename string `json:"filename"`
+			} `json:"attachments"`
+		} `json:"messages"`
+	}
+
+	require.NoError(t, json.Unmarshal(file.Data, &decoded))
+	require.Equal(t, 42, decoded.TicketId)
+	require.Equal(t, "Europe/London", decoded.Timezone)
+	require.Len(t, decoded.Messages, 2)
+	require.Equal(t, "log.txt", decoded.Messages[0].Attachments[0].Filename)
+	require.Equal(t, "", decoded.Messages[0].ReplyTo)
+	require.Equal(t, "100", decoded.Messages[1].ReplyTo)
+	require.Contains(t, decoded.Messages[0].ResolvedContent, "@staff")
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	_, err := testTranscript(t).Export("pdf")
+	require.Error(t, err)
+}
+
+func TestExportNotes(t *testing.T) {
+	tr := testTranscript(t)
+
+	file, err := tr.Export(FormatMarkdown)
+	require.NoError(t, err)
+	require.NotContains(t, string(file.Data), "Staff Notes")
+
+	tr.Notes = []message.Message{
+		{
+			Id:        200,
+			Author:    user.User{Id: 11, Username: "staff"},
+			Content:   "Opener was rude in <#20>",
+			Timestamp: time.Date(2024, 7, 1, 12, 35, 0, 0, time.UTC),
+		},
+	}
+
+	file, err = tr.Export(FormatHtml)
+	require.NoError(t, err)
+	require.Contains(t, string(file.Data), `<h2 class="notes">Staff Notes</h2>`)
+	require.Contains(t, string(file.Data), `id="message-200"`)
+
+	file, err = tr.Export(FormatMarkdown)
+	require.NoError(t, err)
+	require.Contains(t, string(file.Data), "## Staff Notes")
+	require.Contains(t, string(file.Data), "Opener was rude in #general")
+
+	file, err = tr.Export(FormatJson)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Messages []json.RawMessage `json:"messages"`
+		Notes    []struct {
+			Id string `json:"id"`
+		} `json:"notes"`
+	}
+
+	require.NoError(t, json.Unmarshal(file.Data, &decoded))
+	require.Len(t, decoded.Messages, 2)
+	require.Len(t, decoded.Notes, 1)
+	require.Equal(t, "200", decoded.Notes[0].Id)
+}
+
+func TestExportReopenedFrom(t *testing.T) {
+	tr := testTranscript(t)
+
+	file, err := tr.Export(FormatHtml)
+	require.NoError(t, err)
+	require.NotContains(t, string(file.Data), "Reopened from")
+
+	reopenedFrom := 41
+	tr.ReopenedFrom = &reopenedFrom
+
+	file, err = tr.Export(FormatHtml)
+	require.NoError(t, err)
+	require.Contains(t, string(file.Data), "<p>Reopened from ticket #41</p>")
+
+	file, err = tr.Export(FormatMarkdown)
+	require.NoError(t, err)
+	require.Contains(t, string(file.Data), "Reopened from ticket #41.")
+
+	file, err = tr.Export(FormatJson)
+	require.NoError(t, err)
+
+	var decoded struct {
+		ReopenedFrom int `json:"reopened_from"`
+	}
+
+	require.NoError(t, json.Unmarshal(file.Data, &decoded))
+	require.Equal(t, 41, decoded.ReopenedFrom)
+}
@@ -1,5 +0,0 @@
-package utils
-
-import "github.com/TicketsBot-cloud/archiverclient"
-
-var ArchiverClient *archiverclient.ArchiverClient
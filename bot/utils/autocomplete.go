@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker"
+)
+
+// PanelAutoCompleteHandler suggests the guild's panels whose titles contain the value, using panel IDs as values
+func PanelAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	panels, err := worker.Services.Database.Panel.GetByGuild(ctx, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, 25)
+	for _, panel := range panels {
+		if value != "" && !strings.Contains(strings.ToLower(panel.Title), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  panel.Title,
+			Value: panel.PanelId,
+		})
+
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return choices
+}
+
+// LabelAutoCompleteHandler suggests the guild's ticket labels whose names contain the value, using label IDs as values
+func LabelAutoCompleteHandler(worker *worker.Context, data interaction.ApplicationCommandAutoCompleteInteraction, value string) []interaction.ApplicationCommandOptionChoice {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	labels, err := worker.Services.Database.TicketLabels.GetByGuild(ctx, data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	choices := make([]interaction.ApplicationCommandOptionChoice, 0, 25)
+	for _, label := range labels {
+		if value != "" && !strings.Contains(strings.ToLower(label.Name), strings.ToLower(value)) {
+			continue
+		}
+
+		choices = append(choices, interaction.ApplicationCommandOptionChoice{
+			Name:  label.Name,
+			Value: label.LabelId,
+		})
+
+		if len(choices) == 25 {
+			break
+		}
+	}
+
+	return choices
+}
@@ -5,22 +5,22 @@ import (
 
 	"github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/gdl/objects/member"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/blacklist"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"golang.org/x/sync/errgroup"
 )
 
 // Get whether the user is blacklisted at either global or server level
-func IsBlacklisted(ctx context.Context, guildId, userId uint64, member member.Member, permLevel permission.PermissionLevel) (bool, error) {
+func IsBlacklisted(ctx context.Context, worker *worker.Context, guildId, userId uint64, member member.Member, permLevel permission.PermissionLevel) (bool, error) {
 	if blacklist.IsUserBlacklisted(userId) {
 		return true, nil
 	}
 
-	var userBlacklisted, roleBlacklisted bool
+	var userBlacklisted, roleBlacklisted, spamBlacklisted bool
 
 	group, _ := errgroup.WithContext(ctx)
 	group.Go(func() error {
-		tmp, err := dbclient.Client.Blacklist.IsBlacklisted(ctx, guildId, userId)
+		tmp, err := worker.Services.Database.Blacklist.IsBlacklisted(ctx, guildId, userId)
 		if err != nil {
 			return err
 		}
@@ -33,7 +33,7 @@ func IsBlacklisted(ctx context.Context, guildId, userId uint64, member member.Me
 	})
 
 	group.Go(func() error {
-		tmp, err := dbclient.Client.RoleBlacklist.IsAnyBlacklisted(ctx, guildId, member.Roles)
+		tmp, err := worker.Services.Database.RoleBlacklist.IsAnyBlacklisted(ctx, guildId, member.Roles)
 		if err != nil {
 			return err
 		}
@@ -45,10 +45,24 @@ func IsBlacklisted(ctx context.Context, guildId, userId uint64, member member.Me
 		return nil
 	})
 
+	// Temporary blacklists from tickets closed as spam
+	group.Go(func() error {
+		_, tmp, err := worker.Services.Database.SpamBlacklist.GetExpiry(ctx, guildId, userId)
+		if err != nil {
+			return err
+		}
+
+		if tmp {
+			spamBlacklisted = true
+		}
+
+		return nil
+	})
+
 	if err := group.Wait(); err != nil {
 		return false, err
 	}
 
 	// Have staff override role blacklist
-	return permLevel < permission.Support && (userBlacklisted || roleBlacklisted), nil
+	return permLevel < permission.Support && (userBlacklisted || roleBlacklisted || spamBlacklisted), nil
 }
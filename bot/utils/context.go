@@ -6,7 +6,6 @@ import (
 	"time"
 
 	w "github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 )
 
 func ContextTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
@@ -16,7 +15,7 @@ func ContextTimeout(timeout time.Duration) (context.Context, context.CancelFunc)
 // WorkerForGuild returns a worker for the given guild.
 // For whitelabel guilds it uses the whitelabel bot's token; otherwise it returns mainWorker.
 func WorkerForGuild(ctx context.Context, mainWorker *w.Context, guildId uint64) (*w.Context, error) {
-	botId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
+	botId, isWhitelabel, err := mainWorker.Services.Database.WhitelabelGuilds.GetBotByGuild(ctx, guildId)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +24,7 @@ func WorkerForGuild(ctx context.Context, mainWorker *w.Context, guildId uint64)
 		return mainWorker, nil
 	}
 
-	bot, err := dbclient.Client.Whitelabel.GetByBotId(ctx, botId)
+	bot, err := mainWorker.Services.Database.Whitelabel.GetByBotId(ctx, botId)
 	if err != nil {
 		return nil, err
 	}
@@ -41,5 +40,6 @@ func WorkerForGuild(ctx context.Context, mainWorker *w.Context, guildId uint64)
 		ShardId:      0,
 		Cache:        mainWorker.Cache,
 		RateLimiter:  nil, // Use http-proxy ratelimit functionality
+		Services:     mainWorker.Services,
 	}, nil
 }
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker/config"
+	"go.uber.org/zap"
+)
+
+const dryRunScheme = "dryrun"
+
+var dryRunSequence atomic.Uint64
+
+// EnableDryRun stops REST requests other than GET from reaching Discord. DryRunHook redirects those requests to the
+// dryrun scheme, which is served by a transport that logs the request and synthesizes a successful response. The hook
+// must run after any hook that rewrites the URL, such as ProxyHook, so it should be registered last.
+func EnableDryRun(logger *zap.Logger) error {
+	transport, ok := request.Client.Transport.(*http.Transport)
+	if !ok {
+		return errors.New("gdl HTTP client does not use *http.Transport")
+	}
+
+	transport.RegisterProtocol(dryRunScheme, &dryRunTransport{
+		logger:   logger,
+		upstream: transport,
+	})
+
+	request.RegisterPreRequestHook(DryRunHook)
+	return nil
+}
+
+func DryRunHook(token string, req *http.Request) {
+	if req.Method != http.MethodGet {
+		req.URL.Scheme = dryRunScheme
+	}
+}
+
+type dryRunTransport struct {
+	logger   *zap.Logger
+	upstream http.RoundTripper
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := ReadDryRunPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, _ := url.QueryUnescape(req.Header.Get(request.AuditLogReasonHeader))
+
+	t.logger.Info(
+		"Dry run: intercepted REST request",
+		zap.String("method", req.Method),
+		zap.String("route", req.URL.Path),
+		zap.String("audit_reason", reason),
+		zap.ByteString("body", payload),
+	)
+
+	var current []byte
+	if req.Method == http.MethodPatch {
+		current = t.fetchCurrent(req)
+	}
+
+	return NewDryRunResponse(req, current, payload), nil
+}
+
+// fetchCurrent reads the object being modified, so that the synthesized response reflects the real object with the
+// changes applied. Failures are ignored, as the response then falls back to echoing the request.
+func (t *dryRunTransport) fetchCurrent(req *http.Request) []byte {
+	// ProxyHook sends requests to the proxy over plain HTTP
+	target := *req.URL
+	if config.Conf.Discord.ProxyUrl != "" && target.Host == config.Conf.Discord.ProxyUrl {
+		target.Scheme = "http"
+	} else {
+		target.Scheme = "https"
+	}
+
+	get, err := http.NewRequestWithContext(req.Context(), http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil
+	}
+
+	get.Header.Set("Authorization", req.Header.Get("Authorization"))
+	get.Header.Set("User-Agent", req.Header.Get("User-Agent"))
+
+	res, err := t.upstream.RoundTrip(get)
+	if err != nil {
+		return nil
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil
+	}
+
+	return body
+}
+
+// ReadDryRunPayload consumes the request body, returning the JSON payload. Multipart requests carry the payload in the
+// payload_json field.
+func ReadDryRunPayload(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return body, nil
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, nil
+		}
+
+		if part.FormName() == "payload_json" {
+			return io.ReadAll(part)
+		}
+	}
+}
+
+// NewDryRunResponse synthesizes a successful response to a request which was not sent. DELETE and PUT requests receive
+// an empty response, while other requests receive the current object (if known) with the request payload applied and
+// an ID generated if one is not present.
+func NewDryRunResponse(req *http.Request, current, payload []byte) *http.Response {
+	if req.Method == http.MethodDelete || req.Method == http.MethodPut {
+		return newSyntheticResponse(req, http.StatusNoContent, nil)
+	}
+
+	obj := make(map[string]any)
+	_ = json.Unmarshal(current, &obj)
+
+	var changes map[string]any
+	if err := json.Unmarshal(payload, &changes); err == nil {
+		for key, value := range changes {
+			obj[key] = value
+		}
+	}
+
+	if _, ok := obj["id"]; !ok {
+		sequence := dryRunSequence.Add(1) & 0xfff
+		obj["id"] = strconv.FormatUint((uint64(time.Now().UnixMilli())-DiscordEpoch)<<22|sequence, 10)
+	}
+
+	encoded, _ := json.Marshal(obj)
+	return newSyntheticResponse(req, http.StatusOK, encoded)
+}
+
+func newSyntheticResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
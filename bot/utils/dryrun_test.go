@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDryRunHook(t *testing.T) {
+	get := httptest.NewRequest(http.MethodGet, "https://discord.com/api/v10/channels/1", nil)
+	DryRunHook("", get)
+	require.Equal(t, "https", get.URL.Scheme)
+
+	post := httptest.NewRequest(http.MethodPost, "https://discord.com/api/v10/channels/1/messages", nil)
+	DryRunHook("", post)
+	require.Equal(t, dryRunScheme, post.URL.Scheme)
+}
+
+func TestDryRunTransport(t *testing.T) {
+	var writes atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+		}
+
+		_, _ = w.Write([]byte(`{"id":"1","name":"old","topic":"unchanged"}`))
+	}))
+	defer server.Close()
+
+	transport := &dryRunTransport{
+		logger:   zap.NewNop(),
+		upstream: server.Client().Transport,
+	}
+
+	t.Run("patch applies changes to current object", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, server.URL+"/api/v10/channels/1", bytes.NewReader([]byte(`{"name":"new"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.URL.Scheme = dryRunScheme
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.Equal(t, map[string]any{"id": "1", "name": "new", "topic": "unchanged"}, body)
+	})
+
+	t.Run("multipart post generates id", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		require.NoError(t, writer.WriteField("payload_json", `{"content":"hello"}`))
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, server.URL+"/api/v10/channels/1/messages", &buf)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.URL.Scheme = dryRunScheme
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.Equal(t, "hello", body["content"])
+		require.NotEmpty(t, body["id"])
+	})
+
+	t.Run("delete returns no content", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, server.URL+"/api/v10/channels/1", nil)
+		req.URL.Scheme = dryRunScheme
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	require.Zero(t, writes.Load())
+}
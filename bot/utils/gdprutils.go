@@ -6,7 +6,7 @@ import (
 	"strings"
 
 	"github.com/TicketsBot-cloud/gdl/objects/interaction/component"
-	"github.com/TicketsBot-cloud/worker/bot/cache"
+	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
 	"github.com/TicketsBot-cloud/worker/config"
@@ -116,13 +116,13 @@ func ExtractLanguageFromCustomId(customId string) *i18n.Locale {
 	return i18n.LocaleEnglish
 }
 
-func FetchGuildNames(ctx context.Context, guildIds []uint64) map[uint64]string {
+func FetchGuildNames(ctx context.Context, worker *worker.Context, guildIds []uint64) map[uint64]string {
 	if len(guildIds) == 0 {
 		return make(map[uint64]string)
 	}
 
 	query := `SELECT guild_id, data->>'name' as guild_name FROM guilds WHERE guild_id = ANY($1)`
-	rows, err := cache.Client.Query(ctx, query, guildIds)
+	rows, err := worker.Services.Cache.Query(ctx, query, guildIds)
 	if err != nil {
 		return make(map[uint64]string)
 	}
@@ -10,7 +10,7 @@ import (
 	"github.com/TicketsBot-cloud/worker"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
 )
@@ -20,8 +20,8 @@ func BuildEmbed(
 	colour customisation.Colour, titleId, contentId i18n.MessageId, fields []embed.EmbedField,
 	format ...interface{},
 ) *embed.Embed {
-	title := i18n.GetMessageFromGuild(ctx.GuildId(), titleId)
-	content := i18n.GetMessageFromGuild(ctx.GuildId(), contentId, format...)
+	title := i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), titleId)
+	content := i18n.GetMessageFromGuild(ctx.Worker().Services, ctx.GuildId(), contentId, format...)
 
 	msgEmbed := embed.NewEmbed().
 		SetColor(ctx.GetColour(colour)).
@@ -59,13 +59,13 @@ func BuildEmbedRaw(
 }
 
 func GetColourForGuild(ctx context.Context, worker *worker.Context, colour customisation.Colour, guildId uint64) (int, error) {
-	premiumTier, err := PremiumClient.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
+	premiumTier, err := worker.Services.Premium.GetTierByGuildId(ctx, guildId, true, worker.Token, worker.RateLimiter)
 	if err != nil {
 		return 0, err
 	}
 
 	if premiumTier > premium.None {
-		colourCode, ok, err := dbclient.Client.CustomColours.Get(ctx, guildId, colour.Int16())
+		colourCode, ok, err := worker.Services.Database.CustomColours.Get(ctx, guildId, colour.Int16())
 		if err != nil {
 			return 0, err
 		} else if !ok {
@@ -86,10 +86,10 @@ func EmbedFieldRaw(name, value string, inline bool) embed.EmbedField {
 	}
 }
 
-func EmbedField(guildId uint64, name string, value i18n.MessageId, inline bool, format ...interface{}) embed.EmbedField {
+func EmbedField(services *services.Services, guildId uint64, name string, value i18n.MessageId, inline bool, format ...interface{}) embed.EmbedField {
 	return embed.EmbedField{
 		Name:   name,
-		Value:  i18n.GetMessageFromGuild(guildId, value, format...),
+		Value:  i18n.GetMessageFromGuild(services, guildId, value, format...),
 		Inline: inline,
 	}
 }
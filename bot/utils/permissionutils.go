@@ -6,7 +6,6 @@ import (
 	"github.com/TicketsBot-cloud/common/permission"
 	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/worker/bot/command/registry"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 )
 
 func CanClose(ctx context.Context, cmd registry.CommandContext, ticket database.Ticket) bool {
@@ -19,7 +18,7 @@ func CanClose(ctx context.Context, cmd registry.CommandContext, ticket database.
 	}
 
 	if permissionLevel == permission.Everyone {
-		usersCanClose, err := dbclient.Client.UsersCanClose.Get(ctx, cmd.GuildId())
+		usersCanClose, err := cmd.Worker().Services.Database.UsersCanClose.Get(ctx, cmd.GuildId())
 		if err != nil {
 			cmd.HandleError(err)
 		}
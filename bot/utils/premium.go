@@ -1,5 +0,0 @@
-package utils
-
-import "github.com/TicketsBot-cloud/common/premium"
-
-var PremiumClient premium.IPremiumLookupClient
@@ -8,8 +8,6 @@ import (
 	"github.com/TicketsBot-cloud/database"
 	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/worker"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
-	"github.com/TicketsBot-cloud/worker/bot/redis"
 )
 
 func ToRetriever(worker *worker.Context) permission.Retriever {
@@ -23,11 +21,11 @@ type WorkerRetriever struct {
 }
 
 func (wr WorkerRetriever) Db() *database.Database {
-	return dbclient.Client
+	return wr.ctx.Services.Database.Raw()
 }
 
 func (wr WorkerRetriever) Cache() permission.PermissionCache {
-	return permission.NewRedisCache(redis.Client)
+	return permission.NewRedisCache(wr.ctx.Services.Redis.Client)
 }
 
 func (wr WorkerRetriever) IsBotAdmin(_ context.Context, userId uint64) bool {
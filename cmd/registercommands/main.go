@@ -6,12 +6,19 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
-	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/common/observability"
 	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/ratelimit"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker/bot/command/manager"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/i18n"
+	"go.uber.org/zap"
 )
 
 var (
@@ -19,28 +26,75 @@ var (
 	GuildId             = flag.Uint64("guild", 0, "Guild to create the commands for")
 	AdminCommandGuildId = flag.Uint64("admin-guild", 0, "Guild to create the admin commands in")
 	MergeGuildCommands  = flag.Bool("merge", true, "Merge new commands with existing ones instead of overwriting")
+	Diff                = flag.Bool("diff", false, "Print the differences from the deployed commands without modifying them, exiting with status 1 if there are any")
+	Whitelabel          = flag.Bool("whitelabel", false, "Sync the global commands of every whitelabel bot in the database")
 )
 
+// exitCodeChanged is returned in diff mode when the deployed commands differ from the registry, for CI gating
+const exitCodeChanged = 1
+
+// redisClient is used to record the deployed command hash, and is nil when no Redis address is configured
+var redisClient *redis.Client
+
 func main() {
 	flag.Parse()
+
+	i18n.Init()
+
+	commandManager := new(manager.CommandManager)
+	commandManager.RegisterCommands()
+
+	// Refuse to publish the missing translation error as a command description
+	if missing := commandManager.MissingDescriptions(); len(missing) > 0 {
+		panic(fmt.Sprintf("commands have no %s description: %v", i18n.LocaleEnglish.IsoLongCode, missing))
+	}
+
+	// The hash is stored so that workers can detect when the deployed commands are out of date
+	if config.Conf.Redis.Address != "" {
+		redisClient = redis.Connect()
+	}
+
+	if *Whitelabel {
+		os.Exit(syncWhitelabel(commandManager))
+	}
+
 	if *Token == "" {
 		panic("no token")
 	}
 
 	applicationId := must(getApplicationId(*Token))
 
-	i18n.Init()
+	data, adminCommands := commandManager.BuildCreatePayload(false, AdminCommandGuildId)
+	printCoverage(data)
 
-	commandManager := new(manager.CommandManager)
-	commandManager.RegisterCommands()
+	if *Diff {
+		changed := printDiff("global", data, must(getCommandsRaw(*Token, applicationId, *GuildId)))
+		if *AdminCommandGuildId != 0 {
+			remote := must(getCommandsRaw(*Token, applicationId, *AdminCommandGuildId))
+			if *MergeGuildCommands {
+				remote = filterRemote(remote, adminCommands)
+			}
 
-	data, adminCommands := commandManager.BuildCreatePayload(false, AdminCommandGuildId)
+			changed = printDiff("admin", adminCommands, remote) || changed
+		}
+
+		if changed {
+			os.Exit(exitCodeChanged)
+		}
+
+		return
+	}
 
 	// Register commands globally or for a specific guild
 	if *GuildId == 0 {
-		must(rest.ModifyGlobalCommands(context.Background(), *Token, nil, applicationId, data))
+		if err := modifyCommands(*Token, applicationId, 0, data); err != nil {
+			panic(err)
+		}
+		storeHash(applicationId, data)
 	} else {
-		must(rest.ModifyGuildCommands(context.Background(), *Token, nil, applicationId, *GuildId, data))
+		if err := modifyCommands(*Token, applicationId, *GuildId, data); err != nil {
+			panic(err)
+		}
 	}
 
 	// Handle admin commands for a specific guild, merging if requested
@@ -56,26 +110,156 @@ func main() {
 					}
 				}
 				if !found {
-					adminCommands = append(adminCommands, rest.CreateCommandData{
-						Id:          cmd.Id,
-						Name:        cmd.Name,
-						Description: cmd.Description,
-						Options:     cmd.Options,
-						Type:        interaction.ApplicationCommandTypeChatInput,
-						Contexts:    cmd.Contexts,
-					})
+					adminCommands = append(adminCommands, manager.CommandDataFromRemote(cmd))
 				}
 			}
 		}
-		must(rest.ModifyGuildCommands(context.Background(), *Token, nil, applicationId, *AdminCommandGuildId, adminCommands))
+		if err := modifyCommands(*Token, applicationId, *AdminCommandGuildId, adminCommands); err != nil {
+			panic(err)
+		}
 	}
 
 	// Output all global commands as JSON
-	cmds := must(rest.GetGlobalCommands(context.Background(), *Token, nil, applicationId))
+	cmds := must(getCommandsRaw(*Token, applicationId, 0))
 	marshalled := must(json.MarshalIndent(cmds, "", "    "))
 	fmt.Println(string(marshalled))
 }
 
+// syncWhitelabel syncs (or diffs) the global commands of every whitelabel bot, returning the exit code
+func syncWhitelabel(commandManager *manager.CommandManager) int {
+	logger := must(observability.Configure(nil, false, config.Conf.LogLevel))
+	db := dbclient.Connect(logger.With(zap.String("service", "database")))
+
+	bots := must(db.WhitelabelTokens.GetAll(context.Background()))
+	data, _ := commandManager.BuildCreatePayload(true, nil)
+
+	var changed, failed int
+	for _, bot := range bots {
+		label := fmt.Sprintf("whitelabel bot %d", bot.BotId)
+
+		if *Diff {
+			remote, err := getCommandsRaw(bot.Token, bot.BotId, 0)
+			if err != nil {
+				fmt.Printf("%s: failed to fetch commands: %v\n", label, err)
+				failed++
+				continue
+			}
+
+			if printDiff(label, data, remote) {
+				changed++
+			}
+
+			continue
+		}
+
+		if err := modifyCommands(bot.Token, bot.BotId, 0, data); err != nil {
+			fmt.Printf("%s: failed to sync commands: %v\n", label, err)
+			failed++
+			continue
+		}
+
+		storeHash(bot.BotId, data)
+		fmt.Printf("%s: synced %d commands\n", label, len(data))
+	}
+
+	fmt.Printf("Processed %d whitelabel bots (%d with changes, %d failed)\n", len(bots), changed, failed)
+
+	if failed > 0 {
+		return 2
+	} else if changed > 0 {
+		return exitCodeChanged
+	} else {
+		return 0
+	}
+}
+
+// getCommandsRaw fetches the registered commands without decoding them into gdl's types, which do not include every
+// field (such as localizations) that should be compared
+func getCommandsRaw(token string, applicationId, guildId uint64) ([]json.RawMessage, error) {
+	endpoint := request.Endpoint{
+		RequestType: request.GET,
+		ContentType: request.Nil,
+		Endpoint:    fmt.Sprintf("/applications/%d/commands", applicationId),
+		Route:       ratelimit.NewApplicationRoute(ratelimit.RouteGetGlobalCommands, applicationId),
+	}
+
+	if guildId != 0 {
+		endpoint.Endpoint = fmt.Sprintf("/applications/%d/guilds/%d/commands", applicationId, guildId)
+		endpoint.Route = ratelimit.NewApplicationRoute(ratelimit.RouteGetGuildCommands, applicationId)
+	}
+
+	var commands []json.RawMessage
+	err, _ := endpoint.Request(context.Background(), token, nil, &commands)
+	return commands, err
+}
+
+// modifyCommands overwrites the registered commands. gdl's payload types do not support localizations, so the request
+// is made directly.
+func modifyCommands(token string, applicationId, guildId uint64, data []manager.CommandData) error {
+	endpoint := request.Endpoint{
+		RequestType: request.PUT,
+		ContentType: request.ApplicationJson,
+		Endpoint:    fmt.Sprintf("/applications/%d/commands", applicationId),
+		Route:       ratelimit.NewApplicationRoute(ratelimit.RouteModifyGlobalCommands, applicationId),
+	}
+
+	if guildId != 0 {
+		endpoint.Endpoint = fmt.Sprintf("/applications/%d/guilds/%d/commands", applicationId, guildId)
+		endpoint.Route = ratelimit.NewGuildRoute(ratelimit.RouteModifyGuildCommands, applicationId)
+	}
+
+	err, _ := endpoint.Request(context.Background(), token, data, nil)
+	return err
+}
+
+// printCoverage prints how much of the payload is localized for each locale. Names are only localized for a command
+// when the locale translates every name in it, so a locale with partial name coverage falls back to English names.
+func printCoverage(data []manager.CommandData) {
+	for _, coverage := range manager.GetLocalizationCoverage(data) {
+		fmt.Printf("Localization %s: %d/%d names, %d/%d descriptions\n", coverage.Locale, coverage.Names, coverage.TotalNames, coverage.Descriptions, coverage.TotalDescriptions)
+	}
+}
+
+// filterRemote drops remote commands which are not in the local payload, as merging keeps them in place
+func filterRemote(remote []json.RawMessage, local []manager.CommandData) []json.RawMessage {
+	var filtered []json.RawMessage
+	for _, raw := range remote {
+		var command struct {
+			Name string `json:"name"`
+		}
+
+		if err := json.Unmarshal(raw, &command); err != nil {
+			continue
+		}
+
+		for _, localCommand := range local {
+			if localCommand.Name == command.Name {
+				filtered = append(filtered, raw)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+func printDiff(label string, local []manager.CommandData, remote []json.RawMessage) bool {
+	diff := must(manager.DiffCommands(local, remote))
+	fmt.Printf("%s:\n%s\n", label, diff.String())
+	return diff.HasChanges()
+}
+
+func storeHash(applicationId uint64, data []manager.CommandData) {
+	if redisClient == nil {
+		return
+	}
+
+	hash := must(manager.HashCommands(data))
+	if err := redisClient.SetCommandHash(context.Background(), applicationId, hash); err != nil {
+		fmt.Printf("Failed to store command hash for %d: %v\n", applicationId, err)
+	}
+}
+
 // getApplicationId fetches the application ID using the bot token
 func getApplicationId(token string) (uint64, error) {
 	parts := strings.SplitN(token, ".", 2)
@@ -0,0 +1,197 @@
+// Command replay feeds a recorded interaction or gateway event back through the worker, printing the interaction
+// response and every REST call which would have been made.
+//
+// The database, cache and Redis connections are configured from the environment in the same way as the worker, so
+// they should point at a staging copy: handlers may still write to them. Discord is never written to. In readonly mode,
+// GET requests are forwarded to Discord using -token, while other requests are printed and answered with a
+// synthesized success response. In offline mode, every request is printed and answered with a 404.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/TicketsBot-cloud/archiverclient"
+	"github.com/TicketsBot-cloud/common/observability"
+	"github.com/TicketsBot-cloud/common/premium"
+	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	v2 "github.com/TicketsBot-cloud/logarchiver/pkg/model/v2"
+	"github.com/TicketsBot-cloud/worker/bot/cache"
+	"github.com/TicketsBot-cloud/worker/bot/dbclient"
+	"github.com/TicketsBot-cloud/worker/bot/integrations"
+	"github.com/TicketsBot-cloud/worker/bot/recording"
+	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/TicketsBot-cloud/worker/event"
+	"github.com/TicketsBot-cloud/worker/i18n"
+	"go.uber.org/zap"
+
+	_ "github.com/joho/godotenv/autoload"
+)
+
+var (
+	File     = flag.String("file", "", "Path to a recording file")
+	Id       = flag.String("id", "", "ID of a recording to load from the store configured by WORKER_RECORDING_*")
+	Token    = flag.String("token", "", "Bot token to make read requests with (defaults to WORKER_PUBLIC_TOKEN)")
+	RestMode = flag.String("rest", modeReadOnly, "How REST requests are handled: readonly or offline")
+	Wait     = flag.Duration("wait", time.Second*15, "How long to wait for responses sent after deferring")
+)
+
+func main() {
+	flag.Parse()
+	config.Parse()
+
+	if *RestMode != modeReadOnly && *RestMode != modeOffline {
+		panic(fmt.Sprintf("unknown rest mode: %s", *RestMode))
+	}
+
+	token := *Token
+	if token == "" {
+		token = config.Conf.Discord.Token
+	}
+
+	logger := must(observability.Configure(nil, false, config.Conf.LogLevel))
+
+	redisClient := redis.Connect()
+
+	rec := must(loadRecording(redisClient))
+
+	db := dbclient.Connect(logger.With(zap.String("service", "database")))
+	i18n.Init()
+
+	pgCache := must(cache.Connect(logger.With(zap.String("service", "cache"))))
+
+	integrations.InitIntegrations()
+
+	svc := &services.Services{
+		Database:         db,
+		Redis:            redisClient,
+		Cache:            &pgCache,
+		Premium:          premium.NewPremiumLookupClient(redisClient.Client, &pgCache, db.Raw()),
+		Archiver:         archiver{},
+		IntegrationProxy: integrationProxy{},
+	}
+
+	request.Client.Transport = newTransport(*RestMode, request.Client.Transport)
+
+	fmt.Printf("Replaying %s %s (guild: %d, recorded at: %s)\n", rec.Kind, rec.Id, rec.GuildId, rec.RecordedAt.Format(time.RFC3339))
+
+	switch rec.Kind {
+	case recording.KindInteraction:
+		status, body := event.ReplayInteraction(svc, rec.Interaction(token))
+		fmt.Printf("Interaction response (%d):\n%s\n", status, indent(body))
+	case recording.KindEvent:
+		if err := event.ReplayEvent(svc, rec.Event(token)); err != nil {
+			fmt.Printf("Error handling event: %v\n", err)
+		}
+	default:
+		panic(fmt.Sprintf("unknown recording kind: %s", rec.Kind))
+	}
+
+	// Deferred responses and background work continue after the handler returns
+	time.Sleep(*Wait)
+}
+
+func loadRecording(redisClient *redis.Client) (recording.Recording, error) {
+	if *File != "" {
+		data, err := os.ReadFile(*File)
+		if err != nil {
+			return recording.Recording{}, err
+		}
+
+		var rec recording.Recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return recording.Recording{}, err
+		}
+
+		return rec, nil
+	}
+
+	if *Id == "" {
+		return recording.Recording{}, fmt.Errorf("either -file or -id must be provided")
+	}
+
+	store, err := recording.StoreFromConfig(redisClient)
+	if err != nil {
+		return recording.Recording{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	return store.Load(ctx, *Id)
+}
+
+// archiver prints transcripts rather than uploading them
+type archiver struct{}
+
+func (archiver) Store(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error {
+	fmt.Printf("Archiver: would store transcript for ticket %d in guild %d (%d messages)\n", ticketId, guildId, len(messages))
+	return nil
+}
+
+func (archiver) Get(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error) {
+	fmt.Printf("Archiver: would fetch transcript for ticket %d in guild %d\n", ticketId, guildId)
+	return v2.Transcript{}, archiverclient.ErrNotFound
+}
+
+func (archiver) StoreNotes(ctx context.Context, guildId uint64, ticketId int, messages []message.Message) error {
+	fmt.Printf("Archiver: would store notes for ticket %d in guild %d (%d messages)\n", ticketId, guildId, len(messages))
+	return nil
+}
+
+func (archiver) GetNotes(ctx context.Context, guildId uint64, ticketId int) (v2.Transcript, error) {
+	fmt.Printf("Archiver: would fetch notes for ticket %d in guild %d\n", ticketId, guildId)
+	return v2.Transcript{}, archiverclient.ErrNotFound
+}
+
+func (archiver) Replace(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	fmt.Printf("Archiver: would replace transcript for ticket %d in guild %d (%d messages)\n", ticketId, guildId, len(transcript.Messages))
+	return nil
+}
+
+func (archiver) ReplaceNotes(ctx context.Context, guildId uint64, ticketId int, transcript v2.Transcript) error {
+	fmt.Printf("Archiver: would replace notes for ticket %d in guild %d (%d messages)\n", ticketId, guildId, len(transcript.Messages))
+	return nil
+}
+
+func (archiver) PreservesAttachments() bool {
+	return false
+}
+
+func (archiver) StoreAttachment(ctx context.Context, guildId uint64, ticketId int, attachmentId uint64, filename, contentType string, data []byte) (string, error) {
+	fmt.Printf("Archiver: would store attachment %s for ticket %d in guild %d (%d bytes)\n", filename, ticketId, guildId, len(data))
+	return "", services.ErrAttachmentArchiveUnavailable
+}
+
+// integrationProxy prints integration requests rather than sending them
+type integrationProxy struct{}
+
+func (integrationProxy) DoRequest(ctx context.Context, method, url string, headers map[string]string, body any) ([]byte, error) {
+	fmt.Printf("Integration: would send %s %s\n", method, url)
+	return []byte("{}"), nil
+}
+
+func indent(data []byte) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return string(data)
+	}
+
+	return buf.String()
+}
+
+func must[T any](t T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
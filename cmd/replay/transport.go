@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/TicketsBot-cloud/gdl/rest/request"
+	"github.com/TicketsBot-cloud/worker/bot/utils"
+)
+
+const (
+	modeReadOnly = "readonly"
+	modeOffline  = "offline"
+)
+
+// transport prints every REST request made by the worker. Only GET requests in readonly mode reach Discord.
+type transport struct {
+	mode     string
+	upstream http.RoundTripper
+}
+
+func newTransport(mode string, upstream http.RoundTripper) *transport {
+	if upstream == nil {
+		upstream = http.DefaultTransport
+	}
+
+	return &transport{
+		mode:     mode,
+		upstream: upstream,
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := utils.ReadDryRunPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("REST: %s %s\n", req.Method, req.URL.Path)
+	if reason, _ := url.QueryUnescape(req.Header.Get(request.AuditLogReasonHeader)); reason != "" {
+		fmt.Printf("  Audit log reason: %s\n", reason)
+	}
+
+	if len(payload) > 0 {
+		fmt.Printf("  Body: %s\n", indent(payload))
+	}
+
+	if t.mode == modeOffline {
+		return &http.Response{
+			Status:     http.StatusText(http.StatusNotFound),
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"code":0,"message":"replay: offline"}`))),
+			Request:    req,
+		}, nil
+	}
+
+	if req.Method != http.MethodGet {
+		return utils.NewDryRunResponse(req, nil, payload), nil
+	}
+
+	res, err := t.upstream.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("  Response: %d\n", res.StatusCode)
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
@@ -20,6 +21,7 @@ import (
 	"github.com/TicketsBot-cloud/gdl/rest/request"
 	"github.com/TicketsBot-cloud/worker/bot/blacklist"
 	"github.com/TicketsBot-cloud/worker/bot/cache"
+	cmdmanager "github.com/TicketsBot-cloud/worker/bot/command/manager"
 	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/integrations"
 	"github.com/TicketsBot-cloud/worker/bot/listeners/messagequeue"
@@ -27,6 +29,8 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
 	"github.com/TicketsBot-cloud/worker/bot/redis"
 	"github.com/TicketsBot-cloud/worker/bot/rpc/listeners"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/TicketsBot-cloud/worker/bot/transcript"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/TicketsBot-cloud/worker/event"
@@ -81,21 +85,20 @@ func main() {
 	}
 
 	logger.Info("Connecting to Redis")
-	if err := redis.Connect(); err != nil {
-		logger.Fatal("Failed to connect to Redis", zap.Error(err))
-		return
-	}
+	redisClient := redis.Connect()
 
 	logger.Info("Connected to Redis")
 
 	logger.Info("Connecting to DB")
-	dbclient.Connect(logger.With(zap.String("service", "database")))
+	db := dbclient.Connect(logger.With(zap.String("service", "database")))
 	logger.Info("Connected to DB")
 
 	logger.Info("Loading i18n files")
 	i18n.Init()
 	logger.Info("Loaded i18n files")
 
+	go checkCommandRegistry(logger.With(zap.String("service", "command-registry")), redisClient)
+
 	logger.Info("Connecting to cache")
 	pgCache, err := cache.Connect(logger.With(zap.String("service", "cache")))
 	if err != nil {
@@ -103,11 +106,10 @@ func main() {
 		return
 	}
 
-	cache.Client = &pgCache
 	logger.Info("Connected to cache")
 
 	logger.Info("Connecting to clickhouse")
-	dbclient.ConnectAnalytics(logger.With(zap.String("service", "clickhouse")))
+	analytics := dbclient.ConnectAnalytics(logger.With(zap.String("service", "clickhouse")))
 	logger.Info("Connected to clickhouse")
 
 	// Configure HTTP proxy
@@ -118,19 +120,43 @@ func main() {
 	}
 
 	logger.Info("Configuring microservice clients (no I/O)")
+	var premiumClient premium.IPremiumLookupClient
 	if config.Conf.DebugMode == "" {
-		utils.PremiumClient = premium.NewPremiumLookupClient(redis.Client, &pgCache, dbclient.Client)
+		premiumClient = premium.NewPremiumLookupClient(redisClient.Client, &pgCache, db.Raw())
 	} else {
 		c := premium.NewMockLookupClient(premium.Whitelabel, model.EntitlementSourcePatreon)
-		utils.PremiumClient = &c
+		premiumClient = &c
 
 		request.Client.Timeout = time.Second * 10
 	}
 
-	utils.ArchiverClient = archiverclient.NewArchiverClient(
-		archiverclient.NewProxyRetriever(config.Conf.Archiver.Url),
-		[]byte(config.Conf.Archiver.AesKey),
-	)
+	archiver := &services.ArchiverClients{
+		Transcripts: archiverclient.NewArchiverClient(
+			archiverclient.NewProxyRetriever(config.Conf.Archiver.Url),
+			[]byte(config.Conf.Archiver.AesKey),
+		),
+	}
+
+	if config.Conf.Archiver.NotesUrl != "" {
+		archiver.Notes = archiverclient.NewArchiverClient(
+			archiverclient.NewProxyRetriever(config.Conf.Archiver.NotesUrl),
+			[]byte(config.Conf.Archiver.AesKey),
+		)
+	}
+
+	if config.Conf.Archiver.AttachmentsUrl != "" {
+		archiver.Attachments = services.NewAttachmentClient(config.Conf.Archiver.AttachmentsUrl)
+	}
+
+	svc := &services.Services{
+		Database:         db,
+		Analytics:        analytics,
+		Redis:            redisClient,
+		Cache:            &pgCache,
+		Premium:          premiumClient,
+		Archiver:         archiver,
+		IntegrationProxy: integrations.NewSecureProxy(config.Conf.Integrations.SecureProxyUrl),
+	}
 
 	logger.Info("Starting Prometheus server")
 	prometheus.StartServer(config.Conf.Prometheus.Address)
@@ -150,24 +176,41 @@ func main() {
 	request.RegisterPreRequestHook(prometheus.PreRequestHook)
 	request.RegisterPostRequestHook(prometheus.PostRequestHook)
 
+	// Registered after all other pre-request hooks, as the proxy hook rewrites the URL
+	if config.Conf.DryRun {
+		logger.Warn("Dry run mode enabled: REST requests other than GET will not be sent to Discord")
+		if err := utils.EnableDryRun(logger.With(zap.String("service", "dry-run"))); err != nil {
+			logger.Fatal("Failed to enable dry run mode", zap.Error(err))
+			return
+		}
+	}
+
 	logger.Info("Initialising integrations")
 	integrations.InitIntegrations()
 
-	go messagequeue.ListenTicketClose()
-	go messagequeue.ListenAutoClose(logger.With(zap.String("service", "autoclose")))
-	go messagequeue.ListenCloseRequestTimer(logger.With(zap.String("service", "close-request-timer")))
-	go messagequeue.ListenCloseReasonUpdate()
-
-	go blacklist.StartCacheRefreshLoop(logger.With(zap.String("service", "blacklist_refresh")))
+	go messagequeue.ListenTicketClose(svc)
+	go messagequeue.ListenAutoClose(logger.With(zap.String("service", "autoclose")), svc)
+	go messagequeue.ListenCloseRequestTimer(logger.With(zap.String("service", "close-request-timer")), svc)
+	go messagequeue.ListenCloseReasonUpdate(svc)
+	go messagequeue.ListenStatisticsDigest(logger.With(zap.String("service", "stats-digest")), svc)
+	go messagequeue.ListenCloseOutbox(logger.With(zap.String("service", "close-outbox")), svc)
+	go messagequeue.ListenClosedChannels(logger.With(zap.String("service", "closed-channels")), svc)
+
+	go blacklist.StartCacheRefreshLoop(logger.With(zap.String("service", "blacklist_refresh")), svc)
+	go transcript.StartSpoolPruneLoop(
+		logger.With(zap.String("service", "spool_prune")),
+		config.Conf.Transcripts.SpoolDirectory,
+		config.Conf.Transcripts.SpoolExpiry,
+	)
 
 	if config.Conf.WorkerMode == config.WorkerModeInteractions {
 		logger.Info("Starting HTTP server", zap.String("mode", string(config.Conf.WorkerMode)))
 
-		event.HttpListen(redis.Client, &pgCache)
+		event.HttpListen(svc)
 	} else if config.Conf.WorkerMode == config.WorkerModeGateway {
 		logger.Info("Starting event listeners", zap.String("mode", string(config.Conf.WorkerMode)))
 
-		go event.HttpListen(redis.Client, &pgCache)
+		go event.HttpListen(svc)
 
 		var wg sync.WaitGroup
 
@@ -176,7 +219,7 @@ func main() {
 		rpcClient, err := rpc.NewClient(
 			logger.With(zap.String("service", "rpc")),
 			rpc.Config{
-				Redis:               redis.Client,
+				Redis:               svc.Redis.Client,
 				ConsumerGroup:       "worker",
 				ConsumerName:        hostname,
 				ConsumerConcurrency: config.Conf.Streams.GoroutineLimit,
@@ -185,9 +228,9 @@ func main() {
 			map[string]rpc.Listener{
 				"stream:gateway-events": event.NewEventListener(
 					logger.With(zap.String("service", "gateway-events")),
-					&pgCache,
+					svc,
 				),
-				"stream:rpc:categoryupdate": listeners.NewTicketStatusUpdater(&pgCache, logger),
+				"stream:rpc:categoryupdate": listeners.NewTicketStatusUpdater(svc, logger),
 			})
 
 		if err != nil {
@@ -223,6 +266,24 @@ func main() {
 	}
 }
 
+// checkCommandRegistry warns if the global commands deployed for the public bot differ from this build's registry
+func checkCommandRegistry(logger *zap.Logger, redisClient *redis.Client) {
+	commandManager := new(cmdmanager.CommandManager)
+	commandManager.RegisterCommands()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	inSync, found, err := commandManager.CheckDeployedHash(ctx, redisClient, config.Conf.Discord.PublicBotId, false)
+	if err != nil {
+		logger.Error("Failed to check deployed command hash", zap.Error(err))
+	} else if !found {
+		logger.Warn("No deployed command hash found, run registercommands to record one")
+	} else if !inSync {
+		logger.Warn("Deployed commands are out of sync with the command registry, run registercommands to update them")
+	}
+}
+
 func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
 	ch := make(chan struct{})
 	go func() {
@@ -16,12 +16,14 @@ type (
 		JsonLogs    bool          `env:"WORKER_JSON_LOGS" envDefault:"false"`
 		LogLevel    zapcore.Level `env:"WORKER_LOG_LEVEL" envDefault:"info"`
 		PremiumOnly bool          `env:"WORKER_PREMIUM_ONLY" envDefault:"false"`
+		DryRun      bool          `env:"WORKER_DRY_RUN" envDefault:"false"`
 
 		WorkerMode WorkerMode `env:"WORKER_MODE"`
 
 		Discord struct {
 			Token            string        `env:"WORKER_PUBLIC_TOKEN"`
 			PublicBotId      uint64        `env:"WORKER_PUBLIC_ID"`
+			PublicKey        string        `env:"WORKER_PUBLIC_KEY"`
 			ProxyUrl         string        `env:"DISCORD_PROXY_URL"`
 			RequestTimeout   time.Duration `env:"DISCORD_REQUEST_TIMEOUT" envDefault:"15s"`
 			CallbackTimeout  time.Duration `env:"DISCORD_CALLBACK_TIMEOUT" envDefault:"2000ms"`
@@ -52,6 +54,15 @@ type (
 		Archiver struct {
 			Url    string `env:"URL"`
 			AesKey string `env:"AES_KEY"`
+			// NotesUrl is the archive that staff notes threads are stored in, kept apart from transcripts so that
+			// they are never served to the ticket opener. Notes are not archived if it is unset.
+			NotesUrl string `env:"NOTES_URL"`
+			// AttachmentsUrl is where copies of ticket attachments are uploaded when a ticket is closed. Transcripts
+			// keep the Discord CDN links if it is unset.
+			AttachmentsUrl string `env:"ATTACHMENTS_URL"`
+			// AttachmentRetention is how long preserved attachments count towards a guild's quota, and should match
+			// how long the attachment archive keeps them. Zero keeps them indefinitely.
+			AttachmentRetention time.Duration `env:"ATTACHMENT_RETENTION" envDefault:"0"`
 		} `envPrefix:"WORKER_ARCHIVER_"`
 
 		WebProxy struct {
@@ -94,12 +105,30 @@ type (
 			Threads  int    `env:"THREADS"`
 		} `envPrefix:"WORKER_REDIS_"`
 
+		// Recording writes sanitized copies of received interactions and events, for use with cmd/replay
+		Recording struct {
+			Enabled   bool          `env:"ENABLED" envDefault:"false"`
+			Store     string        `env:"STORE" envDefault:"disk"`
+			Directory string        `env:"DIRECTORY" envDefault:"recordings"`
+			Expiry    time.Duration `env:"EXPIRY" envDefault:"72h"`
+			Guilds    []uint64      `env:"GUILDS"`
+		} `envPrefix:"WORKER_RECORDING_"`
+
+		// Transcripts configures where ticket messages are spooled while they are collected on close. The directory is
+		// relative to the working directory and local to the host, so a close can only resume from its spool when it
+		// is retried on the same host. Spools which have not been written to for SpoolExpiry are deleted.
+		Transcripts struct {
+			SpoolDirectory string        `env:"SPOOL_DIRECTORY" envDefault:"spool"`
+			SpoolExpiry    time.Duration `env:"SPOOL_EXPIRY" envDefault:"24h"`
+		} `envPrefix:"WORKER_TRANSCRIPTS_"`
+
 		Streams struct {
 			GoroutineLimit int    `env:"STREAMS_GOROUTINE_LIMIT" envDefault:"1000"`
 		}
 
 		Prometheus struct {
-			Address string `env:"PROMETHEUS_SERVER_ADDR"`
+			Address         string   `env:"PROMETHEUS_SERVER_ADDR"`
+			GuildHistograms []uint64 `env:"PROMETHEUS_GUILD_HISTOGRAMS"`
 		}
 
 		Statsd struct {
@@ -1,9 +1,9 @@
 package worker
 
 import (
-	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/gdl/objects/user"
 	"github.com/TicketsBot-cloud/gdl/rest/ratelimit"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 )
 
 type Context struct {
@@ -11,8 +11,9 @@ type Context struct {
 	BotId        uint64
 	IsWhitelabel bool
 	ShardId      int
-	Cache        *cache.PgCache
+	Cache        services.Cache
 	RateLimiter  *ratelimit.Ratelimiter
+	Services     *services.Services
 }
 
 func (ctx *Context) Self() (user.User, error) {
@@ -194,6 +194,21 @@ func callCommand(
 			arg0 = argValue
 		}
 
+		v.Execute(ctx, arg0)
+	case debug.AdminDebugClosesCommand:
+		var arg0 *string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = &argValue
+		}
+
 		v.Execute(ctx, arg0)
 	case debug.AdminDebugCommand:
 
@@ -312,62 +327,64 @@ func callCommand(
 		}
 
 		v.Execute(ctx, arg0)
-	case settings.LanguageCommand:
-
-		v.Execute(ctx)
-	case settings.PanelCommand:
-
-		v.Execute(ctx)
-	case settings.PremiumCommand:
+	case settings.BlacklistUserCommand:
 
 		v.Execute(ctx)
-	case settings.RemoveAdminCommand:
-		var arg0 uint64
+	case settings.CloseReasonsAddCommand:
+		var arg0 int
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
 			return ErrArgumentNotFound
 		} else {
-			raw, ok := opt0.Value.(string)
+			argValue, ok := opt0.Value.(float64)
 			if !ok {
-				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
 			}
+			arg0 = int(argValue)
+		}
+		var arg1 string
 
-			argValue, err := strconv.ParseUint(raw, 10, 64)
-			if err != nil {
-				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt1.Name)
 			}
-			arg0 = argValue
+			arg1 = argValue
 		}
+		var arg2 string
 
-		v.Execute(ctx, arg0)
-	case settings.RemoveSupportCommand:
-		var arg0 uint64
-
-		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-		if !ok0 {
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
 			return ErrArgumentNotFound
 		} else {
-			raw, ok := opt0.Value.(string)
+			argValue, ok := opt2.Value.(string)
 			if !ok {
-				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+				return fmt.Errorf("option %s was not a string", opt2.Name)
 			}
+			arg2 = argValue
+		}
+		var arg3 *string
 
-			argValue, err := strconv.ParseUint(raw, 10, 64)
-			if err != nil {
-				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			argValue, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt3.Name)
 			}
-			arg0 = argValue
+			arg3 = &argValue
 		}
 
-		v.Execute(ctx, arg0)
-	case settings.ViewStaffCommand:
-
-		v.Execute(ctx)
-	case setup.AutoSetupCommand:
+		v.Execute(ctx, arg0, arg1, arg2, arg3)
+	case settings.CloseReasonsCommand:
 
 		v.Execute(ctx)
-	case setup.LimitSetupCommand:
+	case settings.CloseReasonsListCommand:
 		var arg0 int
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
@@ -382,189 +399,1227 @@ func callCommand(
 		}
 
 		v.Execute(ctx, arg0)
-	case setup.SetupCommand:
-
-		v.Execute(ctx)
-	case setup.ThreadsSetupCommand:
-		var arg0 bool
+	case settings.CloseReasonsRemoveCommand:
+		var arg0 int
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
 			return ErrArgumentNotFound
 		} else {
-			argValue, ok := opt0.Value.(bool)
+			argValue, ok := opt0.Value.(float64)
 			if !ok {
-				return fmt.Errorf("option %s was not a bool", opt0.Name)
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
 			}
-			arg0 = argValue
-
+			arg0 = int(argValue)
 		}
-		var arg1 *uint64
+		var arg1 string
 
 		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
 		if !ok1 {
-			arg1 = nil
+			return ErrArgumentNotFound
 		} else {
-			raw, ok := opt1.Value.(string)
+			argValue, ok := opt1.Value.(string)
 			if !ok {
-				return fmt.Errorf("option %s was not a snowflake", opt1.Name)
-			}
-
-			argValue, err := strconv.ParseUint(raw, 10, 64)
-			if err != nil {
-				return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+				return fmt.Errorf("option %s was not a string", opt1.Name)
 			}
-			arg1 = &argValue
+			arg1 = argValue
 		}
 
 		v.Execute(ctx, arg0, arg1)
-	case setup.TranscriptsSetupCommand:
-		var arg0 uint64
+	case settings.CloseReasonsRequireCommand:
+		var arg0 int
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
 			return ErrArgumentNotFound
 		} else {
-			raw, ok := opt0.Value.(string)
+			argValue, ok := opt0.Value.(float64)
 			if !ok {
-				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
-			}
-
-			argValue, err := strconv.ParseUint(raw, 10, 64)
-			if err != nil {
-				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
 			}
-			arg0 = argValue
+			arg0 = int(argValue)
 		}
+		var arg1 bool
 
-		v.Execute(ctx, arg0)
-	case statistics.StatsCommand:
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt1.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt1.Name)
+			}
+			arg1 = argValue
 
-		v.Execute(ctx)
-	case statistics.StatsServerCommand:
+		}
 
-		v.Execute(ctx)
-	case statistics.StatsUserCommand:
-		var arg0 uint64
+		v.Execute(ctx, arg0, arg1)
+	case settings.ClosedCategoryCommand:
+		var arg0 int
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
 			return ErrArgumentNotFound
 		} else {
-			raw, ok := opt0.Value.(string)
+			argValue, ok := opt0.Value.(float64)
 			if !ok {
-				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
+			}
+			arg0 = int(argValue)
+		}
+		var arg1 *uint64
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			raw, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt1.Name)
 			}
 
 			argValue, err := strconv.ParseUint(raw, 10, 64)
 			if err != nil {
-				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+				return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
 			}
-			arg0 = argValue
+			arg1 = &argValue
 		}
+		var arg2 *int
 
-		v.Execute(ctx, arg0)
-	case tags.ManageTagsAddCommand:
-		var arg0 string
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2)
+	case settings.ContextMenusCommand:
+		var arg0 *string
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
-			return ErrArgumentNotFound
+			arg0 = nil
 		} else {
 			argValue, ok := opt0.Value.(string)
 			if !ok {
 				return fmt.Errorf("option %s was not a string", opt0.Name)
 			}
-			arg0 = argValue
+			arg0 = &argValue
 		}
-		var arg1 string
+		var arg1 *string
 
 		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
 		if !ok1 {
-			return ErrArgumentNotFound
+			arg1 = nil
 		} else {
 			argValue, ok := opt1.Value.(string)
 			if !ok {
 				return fmt.Errorf("option %s was not a string", opt1.Name)
 			}
-			arg1 = argValue
+			arg1 = &argValue
 		}
+		var arg2 *string
 
-		v.Execute(ctx, arg0, arg1)
-	case tags.ManageTagsCommand:
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt2.Name)
+			}
+			arg2 = &argValue
+		}
 
-		v.Execute(ctx)
-	case tags.ManageTagsDeleteCommand:
-		var arg0 string
+		v.Execute(ctx, arg0, arg1, arg2)
+	case settings.FeedbackFollowUpCommand:
+		var arg0 bool
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
 			return ErrArgumentNotFound
 		} else {
-			argValue, ok := opt0.Value.(string)
+			argValue, ok := opt0.Value.(bool)
 			if !ok {
-				return fmt.Errorf("option %s was not a string", opt0.Name)
+				return fmt.Errorf("option %s was not a bool", opt0.Name)
 			}
 			arg0 = argValue
+
 		}
+		var arg1 *uint64
 
-		v.Execute(ctx, arg0)
-	case tags.ManageTagsListCommand:
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			raw, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+			}
 
-		v.Execute(ctx)
-	case tags.TagCommand:
-		var arg0 string
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+			}
+			arg1 = &argValue
+		}
+		var arg2 *int
 
-		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-		if !ok0 {
-			return ErrArgumentNotFound
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
 		} else {
-			argValue, ok := opt0.Value.(string)
+			argValue, ok := opt2.Value.(float64)
 			if !ok {
-				return fmt.Errorf("option %s was not a string", opt0.Name)
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
 			}
-			arg0 = argValue
+			tmp := int(argValue)
+			arg2 = &tmp
 		}
+		var arg3 *bool
 
-		v.Execute(ctx, arg0)
-	case tickets.AddCommand:
-		var arg0 uint64
-
-		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
-		if !ok0 {
-			return ErrArgumentNotFound
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
 		} else {
-			raw, ok := opt0.Value.(string)
+			argValue, ok := opt3.Value.(bool)
 			if !ok {
-				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+				return fmt.Errorf("option %s was not a bool", opt3.Name)
 			}
+			arg3 = &argValue
 
-			argValue, err := strconv.ParseUint(raw, 10, 64)
-			if err != nil {
-				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+		}
+		var arg4 *bool
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt4.Name)
 			}
-			arg0 = argValue
+			arg4 = &argValue
+
 		}
 
-		v.Execute(ctx, arg0)
-	case tickets.ClaimCommand:
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4)
+	case settings.LanguageCommand:
 
 		v.Execute(ctx)
-	case tickets.CloseCommand:
-		var arg0 *string
+	case settings.NotesTranscriptCommand:
+		var arg0 *bool
 
 		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
 		if !ok0 {
 			arg0 = nil
 		} else {
-			argValue, ok := opt0.Value.(string)
+			argValue, ok := opt0.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt0.Name)
+			}
+			arg0 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0)
+	case settings.PanelCommand:
+
+		v.Execute(ctx)
+	case settings.PremiumCommand:
+
+		v.Execute(ctx)
+	case settings.RemoveAdminCommand:
+		var arg0 uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = argValue
+		}
+
+		v.Execute(ctx, arg0)
+	case settings.RemoveSupportCommand:
+		var arg0 uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = argValue
+		}
+
+		v.Execute(ctx, arg0)
+	case settings.SpamCloseCommand:
+		var arg0 *bool
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt0.Name)
+			}
+			arg0 = &argValue
+
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+
+		v.Execute(ctx, arg0, arg1)
+	case settings.TicketHistoryCommand:
+		var arg0 *bool
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt0.Name)
+			}
+			arg0 = &argValue
+
+		}
+		var arg1 *bool
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt1.Name)
+			}
+			arg1 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0, arg1)
+	case settings.TranscriptExportCommand:
+		var arg0 *string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = &argValue
+		}
+		var arg1 *bool
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt1.Name)
+			}
+			arg1 = &argValue
+
+		}
+		var arg2 *bool
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt2.Name)
+			}
+			arg2 = &argValue
+
+		}
+		var arg3 *string
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			argValue, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3)
+	case settings.ViewStaffCommand:
+
+		v.Execute(ctx)
+	case setup.AutoSetupCommand:
+
+		v.Execute(ctx)
+	case setup.LimitSetupCommand:
+		var arg0 int
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
+			}
+			arg0 = int(argValue)
+		}
+
+		v.Execute(ctx, arg0)
+	case setup.SetupCommand:
+
+		v.Execute(ctx)
+	case setup.ThreadsSetupCommand:
+		var arg0 bool
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt0.Name)
+			}
+			arg0 = argValue
+
+		}
+		var arg1 *uint64
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			raw, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+			}
+			arg1 = &argValue
+		}
+
+		v.Execute(ctx, arg0, arg1)
+	case setup.TranscriptsSetupCommand:
+		var arg0 uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = argValue
+		}
+
+		v.Execute(ctx, arg0)
+	case statistics.StatsCommand:
+
+		v.Execute(ctx)
+	case statistics.StatsDigestCommand:
+		var arg0 string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = argValue
+		}
+		var arg1 *uint64
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			raw, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+			}
+			arg1 = &argValue
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2)
+	case statistics.StatsDistributionCommand:
+		var arg0 *int
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
+			}
+			tmp := int(argValue)
+			arg0 = &tmp
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *string
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt2.Name)
+			}
+			arg2 = &argValue
+		}
+		var arg3 *string
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			argValue, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *string
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt4.Name)
+			}
+			arg4 = &argValue
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4)
+	case statistics.StatsExportCommand:
+		var arg0 *string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = &argValue
+		}
+		var arg1 *string
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt1.Name)
+			}
+			arg1 = &argValue
+		}
+		var arg2 *string
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt2.Name)
+			}
+			arg2 = &argValue
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2)
+	case statistics.StatsServerCommand:
+		var arg0 *int
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
+			}
+			tmp := int(argValue)
+			arg0 = &tmp
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *string
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt2.Name)
+			}
+			arg2 = &argValue
+		}
+		var arg3 *string
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			argValue, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *string
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt4.Name)
+			}
+			arg4 = &argValue
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4)
+	case statistics.StatsUserCommand:
+		var arg0 uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = argValue
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+		var arg3 *string
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			argValue, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *string
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt4.Name)
+			}
+			arg4 = &argValue
+		}
+		var arg5 *string
+
+		opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+		if !ok5 {
+			arg5 = nil
+		} else {
+			argValue, ok := opt5.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt5.Name)
+			}
+			arg5 = &argValue
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5)
+	case tags.ManageTagsAddCommand:
+		var arg0 string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = argValue
+		}
+		var arg1 string
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt1.Name)
+			}
+			arg1 = argValue
+		}
+
+		v.Execute(ctx, arg0, arg1)
+	case tags.ManageTagsCommand:
+
+		v.Execute(ctx)
+	case tags.ManageTagsDeleteCommand:
+		var arg0 string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = argValue
+		}
+
+		v.Execute(ctx, arg0)
+	case tags.ManageTagsListCommand:
+
+		v.Execute(ctx)
+	case tags.TagCommand:
+		var arg0 string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = argValue
+		}
+
+		v.Execute(ctx, arg0)
+	case tickets.AddCommand:
+		var arg0 uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = argValue
+		}
+
+		v.Execute(ctx, arg0)
+	case tickets.BulkAddLabelCommand:
+		var arg0 int
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
+			}
+			arg0 = int(argValue)
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+		var arg3 *uint64
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			raw, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt3.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *int
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt4.Name)
+			}
+			tmp := int(argValue)
+			arg4 = &tmp
+		}
+		var arg5 *bool
+
+		opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+		if !ok5 {
+			arg5 = nil
+		} else {
+			argValue, ok := opt5.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt5.Name)
+			}
+			arg5 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5)
+	case tickets.BulkClaimCommand:
+		var arg0 *uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = &argValue
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+		var arg3 *uint64
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			raw, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt3.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *int
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt4.Name)
+			}
+			tmp := int(argValue)
+			arg4 = &tmp
+		}
+		var arg5 *bool
+
+		opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+		if !ok5 {
+			arg5 = nil
+		} else {
+			argValue, ok := opt5.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt5.Name)
+			}
+			arg5 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5)
+	case tickets.BulkCloseCommand:
+		var arg0 *string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(string)
 			if !ok {
 				return fmt.Errorf("option %s was not a string", opt0.Name)
 			}
 			arg0 = &argValue
 		}
+		var arg1 *int
 
-		v.Execute(ctx, arg0)
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+		var arg3 *uint64
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			raw, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt3.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *int
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt4.Name)
+			}
+			tmp := int(argValue)
+			arg4 = &tmp
+		}
+		var arg5 *bool
+
+		opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+		if !ok5 {
+			arg5 = nil
+		} else {
+			argValue, ok := opt5.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt5.Name)
+			}
+			arg5 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5)
+	case tickets.BulkCommand:
+
+		v.Execute(ctx)
+	case tickets.BulkMoveCommand:
+		var arg0 int
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			return ErrArgumentNotFound
+		} else {
+			argValue, ok := opt0.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt0.Name)
+			}
+			arg0 = int(argValue)
+		}
+		var arg1 *int
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt1.Name)
+			}
+			tmp := int(argValue)
+			arg1 = &tmp
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+		var arg3 *uint64
+
+		opt3, ok3 := findOption(cmd.Properties().Arguments[3], options)
+		if !ok3 {
+			arg3 = nil
+		} else {
+			raw, ok := opt3.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt3.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt3.Name)
+			}
+			arg3 = &argValue
+		}
+		var arg4 *int
+
+		opt4, ok4 := findOption(cmd.Properties().Arguments[4], options)
+		if !ok4 {
+			arg4 = nil
+		} else {
+			argValue, ok := opt4.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt4.Name)
+			}
+			tmp := int(argValue)
+			arg4 = &tmp
+		}
+		var arg5 *bool
+
+		opt5, ok5 := findOption(cmd.Properties().Arguments[5], options)
+		if !ok5 {
+			arg5 = nil
+		} else {
+			argValue, ok := opt5.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt5.Name)
+			}
+			arg5 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2, arg3, arg4, arg5)
+	case tickets.ClaimCommand:
+
+		v.Execute(ctx)
+	case tickets.CloseCommand:
+		var arg0 *string
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			argValue, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a string", opt0.Name)
+			}
+			arg0 = &argValue
+		}
+		var arg1 *bool
+
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			argValue, ok := opt1.Value.(bool)
+			if !ok {
+				return fmt.Errorf("option %s was not a bool", opt1.Name)
+			}
+			arg1 = &argValue
+
+		}
+
+		v.Execute(ctx, arg0, arg1)
 	case tickets.CloseRequestCommand:
 		var arg0 *int
 
@@ -596,6 +1651,26 @@ func callCommand(
 	case tickets.EditCommand:
 
 		v.Execute(ctx)
+	case tickets.HistoryCommand:
+		var arg0 *uint64
+
+		opt0, ok0 := findOption(cmd.Properties().Arguments[0], options)
+		if !ok0 {
+			arg0 = nil
+		} else {
+			raw, ok := opt0.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt0.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt0.Name)
+			}
+			arg0 = &argValue
+		}
+
+		v.Execute(ctx, arg0)
 	case tickets.NotesCommand:
 
 		v.Execute(ctx)
@@ -615,8 +1690,41 @@ func callCommand(
 			}
 			arg0 = &argValue
 		}
+		var arg1 *uint64
 
-		v.Execute(ctx, arg0)
+		opt1, ok1 := findOption(cmd.Properties().Arguments[1], options)
+		if !ok1 {
+			arg1 = nil
+		} else {
+			raw, ok := opt1.Value.(string)
+			if !ok {
+				return fmt.Errorf("option %s was not a snowflake", opt1.Name)
+			}
+
+			argValue, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("option %s was not a valid snowflake", opt1.Name)
+			}
+			arg1 = &argValue
+		}
+		var arg2 *int
+
+		opt2, ok2 := findOption(cmd.Properties().Arguments[2], options)
+		if !ok2 {
+			arg2 = nil
+		} else {
+			argValue, ok := opt2.Value.(float64)
+			if !ok {
+				return fmt.Errorf("option %s was not a float64", opt2.Name)
+			}
+			tmp := int(argValue)
+			arg2 = &tmp
+		}
+
+		v.Execute(ctx, arg0, arg1, arg2)
+	case tickets.OpenForUserCommand:
+
+		v.Execute(ctx)
 	case tickets.RemoveCommand:
 		var arg0 uint64
 
@@ -708,6 +1816,9 @@ func callCommand(
 	case tickets.UnclaimCommand:
 
 		v.Execute(ctx)
+	case tickets.ViewUserTicketsCommand:
+
+		v.Execute(ctx)
 
 	case tags.TagAliasCommand:
 		v.Execute(ctx)
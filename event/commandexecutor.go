@@ -18,7 +18,6 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command/impl/tags"
 	cmdregistry "github.com/TicketsBot-cloud/worker/bot/command/registry"
 	"github.com/TicketsBot-cloud/worker/bot/customisation"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/statsd"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
@@ -39,7 +38,7 @@ func executeCommand(
 	cmd, ok := registry[data.Data.Name]
 	if !ok {
 		// If a registered command is not found, check for a tag alias
-		tag, exists, err := dbclient.Client.Tag.GetByApplicationCommandId(ctx, data.GuildId.Value, data.Data.Id)
+		tag, exists, err := worker.Services.Database.Tag.GetByApplicationCommandId(ctx, data.GuildId.Value, data.Data.Id)
 		if err != nil {
 			sentry.Error(err)
 			return false, false, err
@@ -135,7 +134,7 @@ func executeCommand(
 			group, _ := errgroup.WithContext(lookupCtx)
 
 			group.Go(func() error {
-				tier, err := utils.PremiumClient.GetTierByGuildId(lookupCtx, data.GuildId.Value, true, worker.Token, worker.RateLimiter)
+				tier, err := worker.Services.Premium.GetTierByGuildId(lookupCtx, data.GuildId.Value, true, worker.Token, worker.RateLimiter)
 				if err != nil {
 					// TODO: Better error handling
 					// But do not hard fail, as Patreon / premium proxy may be experiencing some issues
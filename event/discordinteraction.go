@@ -0,0 +1,238 @@
+package event
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/eventforwarding"
+	"github.com/TicketsBot-cloud/common/sentry"
+	"github.com/TicketsBot-cloud/gdl/objects/interaction"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/TicketsBot-cloud/worker/config"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Discord interaction payloads are bounded by the message size limits, so anything larger is not genuine
+	maxInteractionSize = 1 << 20
+
+	// maxSignatureAge bounds how old a signed request may be, to limit replays of captured requests
+	maxSignatureAge = time.Minute * 5
+
+	// whitelabelBotCacheTime is how long a whitelabel bot's token and public key are reused for before being looked up
+	// again, so that genuine interactions rarely reach the database
+	whitelabelBotCacheTime = time.Minute * 5
+)
+
+var (
+	errInvalidSignature = errors.New("invalid request signature")
+	errUnknownBot       = errors.New("unknown bot")
+	errRateLimited      = errors.New("too many requests")
+	errInternal         = errors.New("internal server error")
+)
+
+// whitelabelBotLookups bounds the rate of database lookups for bots which are not cached. Requests are only signed with
+// the key being looked up, so unauthenticated requests can cause lookups until they are rejected.
+var whitelabelBotLookups = rate.NewLimiter(50, 100)
+
+var whitelabelBotCache = struct {
+	sync.Mutex
+	bots map[uint64]cachedInteractionBot
+}{bots: make(map[uint64]cachedInteractionBot)}
+
+type cachedInteractionBot struct {
+	bot       interactionBot
+	expiresAt time.Time
+}
+
+type interactionEnvelope struct {
+	Type interaction.InteractionType `json:"type"`
+}
+
+type interactionBot struct {
+	Token        string
+	BotId        uint64
+	IsWhitelabel bool
+	PublicKey    ed25519.PublicKey
+}
+
+// discordInteractionHandler accepts interaction webhooks directly from Discord, rather than from the forwarding
+// service. The interactions endpoint URL of each application should be set to /interactions/{bot_id}.
+func discordInteractionHandler(services *services.Services, processInteraction func(*gin.Context, eventforwarding.Interaction)) func(*gin.Context) {
+	return func(ctx *gin.Context) {
+		botId, err := strconv.ParseUint(ctx.Param("bot_id"), 10, 64)
+		if err != nil {
+			ctx.JSON(400, newErrorResponse(err))
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxInteractionSize))
+		if err != nil {
+			ctx.JSON(400, newErrorResponse(err))
+			return
+		}
+
+		// The bot's public key is needed to verify the signature itself, but malformed and stale requests are rejected
+		// before looking it up
+		signature := ctx.GetHeader("X-Signature-Ed25519")
+		timestamp := ctx.GetHeader("X-Signature-Timestamp")
+		if _, ok := parseInteractionSignature(signature, timestamp, time.Now()); !ok {
+			ctx.JSON(401, newErrorResponse(errInvalidSignature))
+			return
+		}
+
+		bot, err := getInteractionBot(ctx, services, botId)
+		if err != nil {
+			switch {
+			case errors.Is(err, errUnknownBot):
+				ctx.JSON(404, newErrorResponse(err))
+			case errors.Is(err, errRateLimited):
+				ctx.JSON(429, newErrorResponse(err))
+			default:
+				sentry.Error(err)
+				ctx.JSON(500, newErrorResponse(errInternal))
+			}
+
+			return
+		}
+
+		if !verifyInteractionSignature(bot.PublicKey, signature, timestamp, body, time.Now()) {
+			ctx.JSON(401, newErrorResponse(errInvalidSignature))
+			return
+		}
+
+		var envelope interactionEnvelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			ctx.JSON(400, newErrorResponse(err))
+			return
+		}
+
+		if envelope.Type == interaction.InteractionTypePing {
+			ctx.JSON(200, interaction.NewResponsePong())
+			return
+		}
+
+		processInteraction(ctx, eventforwarding.Interaction{
+			BotToken:        bot.Token,
+			BotId:           bot.BotId,
+			IsWhitelabel:    bot.IsWhitelabel,
+			InteractionType: envelope.Type,
+			Event:           body,
+		})
+	}
+}
+
+func getInteractionBot(ctx context.Context, services *services.Services, botId uint64) (interactionBot, error) {
+	if botId == config.Conf.Discord.PublicBotId {
+		publicKey, err := parsePublicKey(config.Conf.Discord.PublicKey)
+		if err != nil {
+			return interactionBot{}, err
+		}
+
+		return interactionBot{
+			Token:        config.Conf.Discord.Token,
+			BotId:        botId,
+			IsWhitelabel: false,
+			PublicKey:    publicKey,
+		}, nil
+	}
+
+	whitelabelBotCache.Lock()
+	cached, ok := whitelabelBotCache.bots[botId]
+	whitelabelBotCache.Unlock()
+
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.bot, nil
+	}
+
+	if !whitelabelBotLookups.Allow() {
+		return interactionBot{}, errRateLimited
+	}
+
+	bot, err := services.Database.Whitelabel.GetByBotId(ctx, botId)
+	if err != nil {
+		return interactionBot{}, err
+	}
+
+	if bot.BotId == 0 {
+		return interactionBot{}, errUnknownBot
+	}
+
+	publicKey, err := parsePublicKey(bot.PublicKey)
+	if err != nil {
+		return interactionBot{}, err
+	}
+
+	result := interactionBot{
+		Token:        bot.Token,
+		BotId:        bot.BotId,
+		IsWhitelabel: true,
+		PublicKey:    publicKey,
+	}
+
+	// Only bots which exist are cached, so the cache is bounded by the number of whitelabel bots
+	whitelabelBotCache.Lock()
+	whitelabelBotCache.bots[botId] = cachedInteractionBot{bot: result, expiresAt: time.Now().Add(whitelabelBotCacheTime)}
+	whitelabelBotCache.Unlock()
+
+	return result, nil
+}
+
+func parsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded) != ed25519.PublicKeySize {
+		return nil, errors.New("public key has an invalid length")
+	}
+
+	return decoded, nil
+}
+
+// verifyInteractionSignature checks the signature which Discord computes over the timestamp followed by the body
+func verifyInteractionSignature(publicKey ed25519.PublicKey, signature, timestamp string, body []byte, now time.Time) bool {
+	decoded, ok := parseInteractionSignature(signature, timestamp, now)
+	if !ok {
+		return false
+	}
+
+	return ed25519.Verify(publicKey, signedMessage(timestamp, body), decoded)
+}
+
+// parseInteractionSignature decodes the signature, and checks that the timestamp is recent
+func parseInteractionSignature(signature, timestamp string, now time.Time) ([]byte, bool) {
+	if signature == "" || timestamp == "" {
+		return nil, false
+	}
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || len(decoded) != ed25519.SignatureSize {
+		return nil, false
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	if age := now.Sub(time.Unix(unix, 0)); age > maxSignatureAge || age < -maxSignatureAge {
+		return nil, false
+	}
+
+	return decoded, true
+}
+
+func signedMessage(timestamp string, body []byte) []byte {
+	message := make([]byte, 0, len(timestamp)+len(body))
+	message = append(message, timestamp...)
+	return append(message, body...)
+}
@@ -0,0 +1,127 @@
+package event
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TicketsBot-cloud/common/eventforwarding"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func signInteraction(privateKey ed25519.PrivateKey, timestamp string, body []byte) string {
+	return hex.EncodeToString(ed25519.Sign(privateKey, append([]byte(timestamp), body...)))
+}
+
+func TestVerifyInteractionSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"type":1}`)
+	signature := signInteraction(privateKey, timestamp, body)
+
+	require.True(t, verifyInteractionSignature(publicKey, signature, timestamp, body, now))
+	require.False(t, verifyInteractionSignature(publicKey, signature, timestamp, []byte(`{"type":2}`), now))
+	require.False(t, verifyInteractionSignature(publicKey, signature, strconv.FormatInt(now.Unix()+1, 10), body, now))
+	require.False(t, verifyInteractionSignature(publicKey, "", timestamp, body, now))
+	require.False(t, verifyInteractionSignature(publicKey, "zz", timestamp, body, now))
+}
+
+func TestVerifyInteractionSignatureWrongKey(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"type":1}`)
+
+	require.False(t, verifyInteractionSignature(otherKey, signInteraction(privateKey, timestamp, body), timestamp, body, now))
+}
+
+func TestVerifyInteractionSignatureExpired(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signedAt := time.Now().Add(-maxSignatureAge - time.Minute)
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	body := []byte(`{"type":1}`)
+
+	require.False(t, verifyInteractionSignature(publicKey, signInteraction(privateKey, timestamp, body), timestamp, body, time.Now()))
+}
+
+func newDiscordInteractionRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/interactions/:bot_id", discordInteractionHandler(nil, func(*gin.Context, eventforwarding.Interaction) {
+		t.Fatal("interaction should not be processed")
+	}))
+
+	return router
+}
+
+func TestDiscordInteractionHandlerUnsigned(t *testing.T) {
+	router := newDiscordInteractionRouter(t)
+
+	// No services are given in tests, so this would panic if the bot were looked up before the headers are
+	// checked
+	req := httptest.NewRequest(http.MethodPost, "/interactions/123", strings.NewReader(`{"type":1}`))
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusUnauthorized, res.Code)
+}
+
+func TestDiscordInteractionHandlerCachedBot(t *testing.T) {
+	router := newDiscordInteractionRouter(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	botId := uint64(456)
+	whitelabelBotCache.Lock()
+	whitelabelBotCache.bots[botId] = cachedInteractionBot{
+		bot:       interactionBot{BotId: botId, IsWhitelabel: true, PublicKey: publicKey},
+		expiresAt: time.Now().Add(time.Minute),
+	}
+	whitelabelBotCache.Unlock()
+
+	t.Cleanup(func() {
+		whitelabelBotCache.Lock()
+		delete(whitelabelBotCache.bots, botId)
+		whitelabelBotCache.Unlock()
+	})
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := []byte(`{"type":1}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/interactions/456", bytes.NewReader(body))
+	req.Header.Set("X-Signature-Ed25519", signInteraction(privateKey, timestamp, body))
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code)
+	require.JSONEq(t, `{"type":1}`, res.Body.String())
+
+	// A forged signature is rejected, even though the bot is known
+	req = httptest.NewRequest(http.MethodPost, "/interactions/456", bytes.NewReader(body))
+	req.Header.Set("X-Signature-Ed25519", signInteraction(privateKey, timestamp, []byte(`{"type":2}`)))
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusUnauthorized, res.Code)
+}
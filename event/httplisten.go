@@ -8,7 +8,6 @@ import (
 
 	"github.com/TicketsBot-cloud/common/eventforwarding"
 	"github.com/TicketsBot-cloud/common/sentry"
-	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/gdl/objects/channel/message"
 	"github.com/TicketsBot-cloud/gdl/objects/interaction"
 	"github.com/TicketsBot-cloud/gdl/rest"
@@ -18,10 +17,11 @@ import (
 	"github.com/TicketsBot-cloud/worker/bot/command"
 	cmd_manager "github.com/TicketsBot-cloud/worker/bot/command/manager"
 	"github.com/TicketsBot-cloud/worker/bot/metrics/prometheus"
+	"github.com/TicketsBot-cloud/worker/bot/recording"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"github.com/TicketsBot-cloud/worker/bot/utils"
 	"github.com/TicketsBot-cloud/worker/config"
 	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 )
 
@@ -47,7 +47,7 @@ var successResponse = response{
 	Success: true,
 }
 
-func HttpListen(redis *redis.Client, cache *cache.PgCache) {
+func HttpListen(services *services.Services) {
 	router := gin.New()
 
 	// Middleware
@@ -58,9 +58,17 @@ func HttpListen(redis *redis.Client, cache *cache.PgCache) {
 		router.Use(gin.Logger())
 	}
 
+	recorder, err := recording.NewRecorderFromConfig(services.Redis)
+	if err != nil {
+		panic(err)
+	}
+
+	processInteraction := interactionProcessor(services, recorder)
+
 	// Routes
-	router.POST("/event", eventHandler(cache))
-	router.POST("/interaction", interactionHandler(redis, cache))
+	router.POST("/event", eventHandler(services, recorder))
+	router.POST("/interaction", interactionHandler(processInteraction))
+	router.POST("/interactions/:bot_id", discordInteractionHandler(services, processInteraction))
 
 	if err := router.Run(config.Conf.Bot.HttpAddress); err != nil {
 		panic(err)
@@ -72,7 +80,7 @@ func metricsMiddleware(c *gin.Context) {
 	c.Next()
 }
 
-func eventHandler(cache *cache.PgCache) func(*gin.Context) {
+func eventHandler(services *services.Services, recorder *recording.Recorder) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var event eventforwarding.Event
 		if err := c.BindJSON(&event); err != nil {
@@ -81,32 +89,30 @@ func eventHandler(cache *cache.PgCache) func(*gin.Context) {
 			return
 		}
 
-		workerCtx := &worker.Context{
-			Token:        event.BotToken,
-			BotId:        event.BotId,
-			IsWhitelabel: event.IsWhitelabel,
-			ShardId:      event.ShardId,
-			Cache:        cache,
-			RateLimiter:  nil, // Use http-proxy ratelimit functionality
-		}
+		recorder.RecordEvent(event)
 
 		c.AbortWithStatusJSON(200, successResponse)
 
-		if err := execute(workerCtx, event.Event); err != nil {
+		if err := execute(newEventWorkerContext(services, event), event.Event); err != nil {
 			marshalled, _ := json.Marshal(event)
 			logrus.Warnf("error executing event: %v (payload: %s)", err, string(marshalled))
 		}
 	}
 }
 
-func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Context) {
-	commandManager := new(cmd_manager.CommandManager)
-	commandManager.RegisterCommands()
-	commandManager.RunSetupFuncs()
-
-	buttonManager := btn_manager.NewButtonManager()
-	buttonManager.RegisterCommands()
+func newEventWorkerContext(services *services.Services, event eventforwarding.Event) *worker.Context {
+	return &worker.Context{
+		Token:        event.BotToken,
+		BotId:        event.BotId,
+		IsWhitelabel: event.IsWhitelabel,
+		ShardId:      event.ShardId,
+		Cache:        services.Cache,
+		RateLimiter:  nil, // Use http-proxy ratelimit functionality
+		Services:     services,
+	}
+}
 
+func interactionHandler(processInteraction func(*gin.Context, eventforwarding.Interaction)) func(*gin.Context) {
 	return func(ctx *gin.Context) {
 		var payload eventforwarding.Interaction
 		if err := ctx.BindJSON(&payload); err != nil {
@@ -114,12 +120,30 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 			return
 		}
 
+		processInteraction(ctx, payload)
+	}
+}
+
+// interactionProcessor returns a function which executes the interaction and writes the response, shared by the
+// forwarded and native interaction routes. Interactions are recorded first if a recorder is given.
+func interactionProcessor(services *services.Services, recorder *recording.Recorder) func(*gin.Context, eventforwarding.Interaction) {
+	commandManager := new(cmd_manager.CommandManager)
+	commandManager.RegisterCommands()
+	commandManager.RunSetupFuncs()
+
+	buttonManager := btn_manager.NewButtonManager()
+	buttonManager.RegisterCommands()
+
+	return func(ctx *gin.Context, payload eventforwarding.Interaction) {
+		recorder.RecordInteraction(payload)
+
 		worker := &worker.Context{
 			Token:        payload.BotToken,
 			BotId:        payload.BotId,
 			IsWhitelabel: payload.IsWhitelabel,
-			Cache:        cache,
+			Cache:        services.Cache,
 			RateLimiter:  nil, // Use http-proxy ratelimit functionality
+			Services:     services,
 		}
 
 		switch payload.InteractionType {
@@ -259,7 +283,7 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 				return
 			}
 
-			choices := handler(interactionData, fmt.Sprintf("%v", focused.Value))
+			choices := handler(worker, interactionData, fmt.Sprintf("%v", focused.Value))
 			res := interaction.NewApplicationCommandAutoCompleteResultResponse(choices)
 			ctx.JSON(200, res)
 			ctx.Writer.Flush()
@@ -309,7 +333,8 @@ func handleApplicationCommandResponseAfterDefer(interactionData interaction.Appl
 
 			switch resp.CommandResponseType() {
 			case command.CommandResponseTypeMessage:
-				data := resp.(command.ResponseMessage).Data
+				res := resp.(command.ResponseMessage)
+				data := res.Data
 
 				if hasReplied {
 					restData := rest.WebhookBody{
@@ -318,6 +343,7 @@ func handleApplicationCommandResponseAfterDefer(interactionData interaction.Appl
 						AllowedMentions: data.AllowedMentions,
 						Components:      data.Components,
 						Flags:           data.Flags,
+						Attachments:     res.Attachments,
 					}
 
 					if _, err := rest.CreateFollowupMessage(context.Background(), interactionData.Token, worker.RateLimiter, worker.BotId, restData); err != nil {
@@ -333,6 +359,7 @@ func handleApplicationCommandResponseAfterDefer(interactionData interaction.Appl
 						AllowedMentions: data.AllowedMentions,
 						Components:      data.Components,
 						Flags:           data.Flags,
+						Attachments:     res.Attachments,
 					}
 
 					if _, err := rest.EditOriginalInteractionResponse(context.Background(), interactionData.Token, worker.RateLimiter, worker.BotId, restData); err != nil {
@@ -5,22 +5,22 @@ import (
 
 	"github.com/TicketsBot-cloud/common/eventforwarding"
 	"github.com/TicketsBot-cloud/common/rpc"
-	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/worker"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 	"go.uber.org/zap"
 )
 
 type EventListener struct {
-	logger *zap.Logger
-	cache  *cache.PgCache
+	logger   *zap.Logger
+	services *services.Services
 }
 
 var _ rpc.Listener = (*EventListener)(nil)
 
-func NewEventListener(logger *zap.Logger, cache *cache.PgCache) *EventListener {
+func NewEventListener(logger *zap.Logger, services *services.Services) *EventListener {
 	return &EventListener{
-		logger: logger,
-		cache:  cache,
+		logger:   logger,
+		services: services,
 	}
 }
 
@@ -40,8 +40,9 @@ func (k *EventListener) HandleMessage(ctx context.Context, message []byte) {
 		BotId:        event.BotId,
 		IsWhitelabel: event.IsWhitelabel,
 		ShardId:      event.ShardId,
-		Cache:        k.cache,
+		Cache:        k.services.Cache,
 		RateLimiter:  nil, // Use http-proxy ratelimit functionality
+		Services:     k.services,
 	}
 
 	if err := execute(workerCtx, event.Event); err != nil {
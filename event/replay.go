@@ -0,0 +1,29 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/TicketsBot-cloud/common/eventforwarding"
+	"github.com/TicketsBot-cloud/worker/bot/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ReplayInteraction runs a recorded interaction through the same pipeline as the HTTP routes, returning the status
+// code and body of the initial interaction response. Responses sent after deferring are made through REST in the
+// background, so callers should wait before exiting if they want to observe them.
+func ReplayInteraction(services *services.Services, payload eventforwarding.Interaction) (int, []byte) {
+	recorder := httptest.NewRecorder()
+
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/interaction", nil)
+
+	interactionProcessor(services, nil)(ctx, payload)
+
+	return recorder.Code, recorder.Body.Bytes()
+}
+
+// ReplayEvent runs a recorded gateway event through the event listeners
+func ReplayEvent(services *services.Services, payload eventforwarding.Event) error {
+	return execute(newEventWorkerContext(services, payload), payload.Event)
+}
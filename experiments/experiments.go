@@ -7,8 +7,8 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
 	"github.com/TicketsBot-cloud/worker/bot/redis"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 )
 
 type Experiment string
@@ -21,7 +21,7 @@ var List = []Experiment{
 	COMPONENTS_V2_STATISTICS,
 }
 
-func HasFeature(ctx context.Context, guildId uint64, experiment Experiment) bool {
+func HasFeature(ctx context.Context, services *services.Services, guildId uint64, experiment Experiment) bool {
 	if os.Getenv("ENABLE_ALL_EXPERIMENTS") == "true" {
 		return true
 	}
@@ -33,13 +33,13 @@ func HasFeature(ctx context.Context, guildId uint64, experiment Experiment) bool
 
 	rolloutPercentage := 0
 
-	redisPercentage, err := redis.GetExperimentRolloutPercentage(ctx, strings.ToLower(string(experiment)))
+	redisPercentage, err := services.Redis.GetExperimentRolloutPercentage(ctx, strings.ToLower(string(experiment)))
 	if err == nil {
 		rolloutPercentage = redisPercentage
 	} else {
 		if err == redis.ErrNil {
 			// Key does not exist, check database
-			dbExperiment, dbErr := dbclient.Client.Experiment.GetByName(ctx, string(experiment))
+			dbExperiment, dbErr := services.Database.Experiment.GetByName(ctx, string(experiment))
 			if dbErr != nil || dbExperiment == nil {
 				// If we can't find it in the database, default to 0%
 				rolloutPercentage = 0
@@ -47,7 +47,7 @@ func HasFeature(ctx context.Context, guildId uint64, experiment Experiment) bool
 				rolloutPercentage = dbExperiment.RolloutPercentage
 
 				// Cache in Redis for future use
-				_ = redis.SetExperimentRolloutPercentage(ctx, strings.ToLower(string(experiment)), rolloutPercentage)
+				_ = services.Redis.SetExperimentRolloutPercentage(ctx, strings.ToLower(string(experiment)), rolloutPercentage)
 			}
 		} else {
 			rolloutPercentage = 0
@@ -3,14 +3,14 @@ package i18n
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/TicketsBot-cloud/common/sentry"
-	"github.com/TicketsBot-cloud/worker/bot/cache"
-	"github.com/TicketsBot-cloud/worker/bot/dbclient"
-	"github.com/jackc/pgx/v4"
+	gdlcache "github.com/TicketsBot-cloud/gdl/cache"
+	"github.com/TicketsBot-cloud/worker/bot/services"
 )
 
 func LoadMessages() {
@@ -101,9 +101,32 @@ func GetMessage(locale *Locale, id MessageId, format ...interface{}) string {
 	return fmt.Sprintf(strings.Replace(value, "\\n", "\n", -1), format...)
 }
 
-func GetMessageFromGuild(guildId uint64, id MessageId, format ...interface{}) string {
+// GetTranslation returns the locale's own translation of the message, or its parent language's, only if it differs from
+// the English text. Unlike GetMessage, it does not fall back to English, so that callers can tell whether a message has
+// been translated. english is used as the source text if the message is not in the English locale file.
+func GetTranslation(locale *Locale, id MessageId, english string) (string, bool) {
+	if locale == nil || locale == LocaleEnglish {
+		return "", false
+	}
+
+	if englishValue, ok := LocaleEnglish.Messages[id]; ok {
+		english = englishValue
+	}
+
+	if value, ok := locale.Messages[id]; ok && value != "" && value != english {
+		return strings.Replace(value, "\\n", "\n", -1), true
+	}
+
+	if locale.ParentIsoShortCode != nil {
+		return GetTranslation(MappedByIsoShortCode[*locale.ParentIsoShortCode], id, english)
+	}
+
+	return "", false
+}
+
+func GetMessageFromGuild(services *services.Services, guildId uint64, id MessageId, format ...interface{}) string {
 	// TODO: Propagate context
-	activeLanguage, err := dbclient.Client.ActiveLanguage.Get(context.Background(), guildId)
+	activeLanguage, err := services.Database.ActiveLanguage.Get(context.Background(), guildId)
 	if err != nil {
 		sentry.Error(err)
 	}
@@ -113,31 +136,21 @@ func GetMessageFromGuild(guildId uint64, id MessageId, format ...interface{}) st
 	}
 
 	// check preferred locale
-	preferredLocale, err := getPreferredLocale(guildId)
+	guild, err := services.Cache.GetGuild(context.Background(), guildId)
 	if err != nil {
-		if err != pgx.ErrNoRows {
+		if !errors.Is(err, gdlcache.ErrNotFound) {
 			sentry.Error(err)
 		}
 
 		return GetMessage(LocaleEnglish, id, format...)
 	}
 
-	if preferredLocale == nil {
-		return GetMessage(LocaleEnglish, id, format...)
-	} else {
-		language, ok := DiscordLocales[*preferredLocale]
-		if !ok {
-			language = LocaleEnglish
-		}
-
-		return GetMessage(language, id, format...)
+	language, ok := DiscordLocales[guild.PreferredLocale]
+	if !ok {
+		language = LocaleEnglish
 	}
-}
 
-func getPreferredLocale(guildId uint64) (locale *string, err error) {
-	query := `SELECT "data"->'preferred_locale' FROM guilds WHERE "guild_id" = $1;`
-	err = cache.Client.QueryRow(context.Background(), query, guildId).Scan(&locale)
-	return
+	return GetMessage(language, id, format...)
 }
 
 func parseCrowdInFile(data []byte) (map[MessageId]string, error) {
@@ -1,9 +1,11 @@
 package i18n
 
+import "github.com/TicketsBot-cloud/worker/bot/services"
+
 type MessageId string
 
-func (m MessageId) GetFromGuild(guildId uint64, format ...interface{}) string {
-	return GetMessageFromGuild(guildId, m, format...)
+func (m MessageId) GetFromGuild(services *services.Services, guildId uint64, format ...interface{}) string {
+	return GetMessageFromGuild(services, guildId, m, format...)
 }
 
 // note: %s = a placeholder
@@ -18,6 +20,9 @@ var (
 	Reason    MessageId = "generic.reason"
 	ClickHere MessageId = "generic.click_here"
 	Confirm   MessageId = "generic.confirm"
+	Cancel    MessageId = "generic.cancel"
+	Enabled   MessageId = "generic.enabled"
+	Disabled  MessageId = "generic.disabled"
 	Website   MessageId = "generic.website"
 
 	TitlePremiumOnly       MessageId = "generic.title.premium_only"
@@ -52,6 +57,20 @@ var (
 	TitlePanelSwitched     MessageId = "generic.title.panel_switched"
 	TitleJumpToTop         MessageId = "generic.title.jump_to_top"
 	TitleReopened          MessageId = "generic.title.reopened"
+	TitleCloseReasons      MessageId = "generic.title.close_reasons"
+	TitleBulkAction        MessageId = "generic.title.bulk_action"
+	TitleBulkActionRunning MessageId = "generic.title.bulk_action.running"
+	TitleBulkActionDone    MessageId = "generic.title.bulk_action.complete"
+	TitleFeedbackFollowUp  MessageId = "generic.title.feedback_followup"
+	TitleClosedCategory    MessageId = "generic.title.closed_category"
+	TitleStatsDigest       MessageId = "generic.title.stats_digest"
+	TitleCloseSpam         MessageId = "generic.title.close_spam"
+	TitleSpamClose         MessageId = "generic.title.spam_close"
+	TitleTranscriptExport  MessageId = "generic.title.transcript_export"
+	TitleContextMenus      MessageId = "generic.title.context_menus"
+	TitleUserTickets       MessageId = "generic.title.user_tickets"
+	TitleTicketHistory     MessageId = "generic.title.ticket_history"
+	TitleNotesTranscript   MessageId = "generic.title.notes_transcript"
 
 	MessageAbout   MessageId = "commands.about"
 	MessagePremium MessageId = "commands.premium"
@@ -72,6 +91,13 @@ var (
 	MessageCloseSuccess              MessageId = "close.success"
 	MessageCloseCantRateStaff        MessageId = "close.rate.not_allowed.staff"
 	MessageCloseCantRateEmpty        MessageId = "close.rate.not_allowed.empty"
+	MessageCloseReasonRequired       MessageId = "close.reason_required"
+	MessageCloseSpamNoPermission     MessageId = "close.spam.no_permission"
+	MessageCloseSpamConfirm          MessageId = "close.spam.confirm"
+	MessageCloseSpamConfirmBlacklist MessageId = "close.spam.confirm.blacklist"
+	MessagePanelNotFound             MessageId = "generic.panel_not_found"
+	MessageLabelNotFound             MessageId = "generic.label_not_found"
+	MessageTeamNotFound              MessageId = "generic.team_not_found"
 
 	MessageTag                       MessageId = "commands.tag.generic"
 	MessageTagCreateInvalidArguments MessageId = "commands.tags.create.invalid_arguments"
@@ -118,18 +144,19 @@ var (
 	MessageAddSuccess      MessageId = "commands.add.success"
 	MessageAddRoleThread   MessageId = "commands.add.role_thread"
 
-	MessageBlacklisted         MessageId = "generic.error.blacklisted"
-	MessageGuildBlacklisted    MessageId = "generic.error.guild_blacklisted"
-	MessageUserBlacklisted     MessageId = "generic.error.user_blacklisted"
-	MessageBlacklistNoMembers  MessageId = "commands.blacklist.no_members"
-	MessageBlacklistSelf       MessageId = "commands.blacklist.self"
-	MessageBlacklistStaff      MessageId = "commands.blacklist.staff"
-	MessageBlacklistLimit      MessageId = "commands.blacklist.add.limit"
-	MessageBlacklistAdd        MessageId = "commands.blacklist.add.success"
-	MessageBlacklistRoleLimit  MessageId = "commands.blacklist.add_role.limit"
-	MessageBlacklistAddRole    MessageId = "commands.blacklist.add_role.success"
-	MessageBlacklistRemove     MessageId = "commands.blacklist.remove.success"
-	MessageBlacklistRemoveRole MessageId = "commands.blacklist.remove_role.success"
+	MessageBlacklisted              MessageId = "generic.error.blacklisted"
+	MessageGuildBlacklisted         MessageId = "generic.error.guild_blacklisted"
+	MessageUserBlacklisted          MessageId = "generic.error.user_blacklisted"
+	MessageBlacklistNoMembers       MessageId = "commands.blacklist.no_members"
+	MessageBlacklistSelf            MessageId = "commands.blacklist.self"
+	MessageBlacklistStaff           MessageId = "commands.blacklist.staff"
+	MessageBlacklistLimit           MessageId = "commands.blacklist.add.limit"
+	MessageBlacklistAdd             MessageId = "commands.blacklist.add.success"
+	MessageBlacklistRoleLimit       MessageId = "commands.blacklist.add_role.limit"
+	MessageBlacklistAddRole         MessageId = "commands.blacklist.add_role.success"
+	MessageBlacklistRemove          MessageId = "commands.blacklist.remove.success"
+	MessageBlacklistRemoveRole      MessageId = "commands.blacklist.remove_role.success"
+	MessageBlacklistRemoveTemporary MessageId = "commands.blacklist.remove_temporary.success"
 
 	MessageClaimed           MessageId = "commands.claim.success"
 	MessageClaimNoPermission MessageId = "commands.claim.no_permission"
@@ -217,6 +244,105 @@ var (
 	MessageSwitchPanelClaimerNoAccess      MessageId = "commands.switch_panel.claimer_no_access"
 	MessageSwitchPanelAutoUnclaimed        MessageId = "commands.switch_panel.auto_unclaimed"
 
+	MessageCloseReasonsLabelLength       MessageId = "commands.closereasons.add.label_length"
+	MessageCloseReasonsDescriptionLength MessageId = "commands.closereasons.add.description_length"
+	MessageCloseReasonsInvalidCategory   MessageId = "commands.closereasons.add.invalid_category"
+	MessageCloseReasonsAlreadyExists     MessageId = "commands.closereasons.add.already_exists"
+	MessageCloseReasonsLimit             MessageId = "commands.closereasons.add.limit"
+	MessageCloseReasonsAddSuccess        MessageId = "commands.closereasons.add.success"
+	MessageCloseReasonsNotFound          MessageId = "commands.closereasons.remove.not_found"
+	MessageCloseReasonsRemoveSuccess     MessageId = "commands.closereasons.remove.success"
+	MessageCloseReasonsRequired          MessageId = "commands.closereasons.require.enabled"
+	MessageCloseReasonsNotRequired       MessageId = "commands.closereasons.require.disabled"
+	MessageCloseReasonsListRequired      MessageId = "commands.closereasons.list.required"
+	MessageCloseReasonsListNotRequired   MessageId = "commands.closereasons.list.not_required"
+	MessageCloseReasonsListEmpty         MessageId = "commands.closereasons.list.empty"
+
+	MessageBulkInactiveDays    MessageId = "commands.bulk.inactive_days"
+	MessageBulkNoTickets       MessageId = "commands.bulk.no_tickets"
+	MessageBulkPreviewClose    MessageId = "commands.bulk.preview.close"
+	MessageBulkPreviewClaim    MessageId = "commands.bulk.preview.claim"
+	MessageBulkPreviewMove     MessageId = "commands.bulk.preview.move"
+	MessageBulkPreviewAddLabel MessageId = "commands.bulk.preview.add_label"
+	MessageBulkPreviewTicket   MessageId = "commands.bulk.preview.ticket"
+	MessageBulkPreviewMore     MessageId = "commands.bulk.preview.more"
+	MessageBulkPreviewLimit    MessageId = "commands.bulk.preview.limit"
+	MessageBulkPreviewExpiry   MessageId = "commands.bulk.preview.expiry"
+	MessageBulkExpired         MessageId = "commands.bulk.expired"
+	MessageBulkAlreadyRunning  MessageId = "commands.bulk.already_running"
+	MessageBulkCancelled       MessageId = "commands.bulk.cancelled"
+	MessageBulkStopped         MessageId = "commands.bulk.stopped"
+	MessageBulkProgress        MessageId = "commands.bulk.progress"
+
+	MessageFeedbackFollowUpDisabled       MessageId = "commands.feedbackfollowup.disabled"
+	MessageFeedbackFollowUpChannelMissing MessageId = "commands.feedbackfollowup.channel_missing"
+	MessageFeedbackFollowUpChannelAccess  MessageId = "commands.feedbackfollowup.channel_access"
+	MessageFeedbackFollowUpThreshold      MessageId = "commands.feedbackfollowup.threshold"
+	MessageFeedbackFollowUpAlerts         MessageId = "commands.feedbackfollowup.summary.alerts"
+	MessageFeedbackFollowUpReopenButton   MessageId = "commands.feedbackfollowup.summary.reopen_button"
+	MessageFeedbackFollowUpThankClaimer   MessageId = "commands.feedbackfollowup.summary.thank_claimer"
+	MessageFeedbackFollowUpExpired        MessageId = "feedback_followup.expired"
+	MessageFeedbackFollowUpResolved       MessageId = "feedback_followup.already_resolved"
+	MessageFeedbackFollowUpResolveSuccess MessageId = "feedback_followup.resolve_success"
+	MessageFeedbackFollowUpThankTitle     MessageId = "feedback_followup.thank.title"
+	MessageFeedbackFollowUpThank          MessageId = "feedback_followup.thank.content"
+
+	MessageClosedCategoryDisabled    MessageId = "commands.closedcategory.disabled"
+	MessageClosedCategoryRetention   MessageId = "commands.closedcategory.retention"
+	MessageClosedCategoryAccess      MessageId = "commands.closedcategory.access"
+	MessageClosedCategoryNotCategory MessageId = "commands.closedcategory.not_category"
+	MessageClosedCategorySuccess     MessageId = "commands.closedcategory.success"
+
+	MessageStatsInvalidDate    MessageId = "commands.stats.filters.invalid_date"
+	MessageStatsRangeInverted  MessageId = "commands.stats.filters.range_inverted"
+	MessageStatsRangeTooLarge  MessageId = "commands.stats.filters.range_too_large"
+	MessageStatsInvalidPeriod  MessageId = "commands.stats.filters.invalid_period"
+	MessageStatsPeriodConflict MessageId = "commands.stats.filters.period_conflict"
+
+	MessageStatsDigestDisabled       MessageId = "commands.stats.digest.disabled"
+	MessageStatsDigestFrequency      MessageId = "commands.stats.digest.frequency"
+	MessageStatsDigestChannelMissing MessageId = "commands.stats.digest.channel_missing"
+	MessageStatsDigestChannelAccess  MessageId = "commands.stats.digest.channel_access"
+	MessageStatsDigestResponseLimit  MessageId = "commands.stats.digest.response_limit"
+	MessageStatsDigestSuccess        MessageId = "commands.stats.digest.success"
+
+	MessageStatsExportFormat        MessageId = "commands.stats.export.format"
+	MessageStatsExportRangeTooLarge MessageId = "commands.stats.export.range_too_large"
+
+	MessageSpamCloseDuration          MessageId = "commands.spamclose.duration"
+	MessageSpamCloseButton            MessageId = "commands.spamclose.summary.button"
+	MessageSpamCloseBlacklistDuration MessageId = "commands.spamclose.summary.blacklist_duration"
+	MessageSpamCloseCloses            MessageId = "commands.spamclose.summary.closes"
+	MessageSpamCloseBlacklists        MessageId = "commands.spamclose.summary.blacklists"
+	MessageSpamCloseBlacklisted       MessageId = "commands.spamclose.summary.blacklisted"
+	MessageSpamCloseBlacklistedEntry  MessageId = "commands.spamclose.summary.blacklisted_entry"
+
+	MessageTranscriptExportFormat         MessageId = "commands.transcriptexport.format"
+	MessageTranscriptExportTimezone       MessageId = "commands.transcriptexport.timezone"
+	MessageTranscriptExportSummaryFormat  MessageId = "commands.transcriptexport.summary.format"
+	MessageTranscriptExportSummaryZone    MessageId = "commands.transcriptexport.summary.timezone"
+	MessageTranscriptExportSummaryArchive MessageId = "commands.transcriptexport.summary.archive_channel"
+	MessageTranscriptExportSummaryDM      MessageId = "commands.transcriptexport.summary.direct_message"
+
+	MessageOpenPanelOnBehalfOnly MessageId = "commands.open.panel_on_behalf_only"
+	MessageOpenForBot            MessageId = "commands.open.for_bot"
+	MessageOpenedOnBehalf        MessageId = "commands.open.opened_on_behalf"
+
+	MessageContextMenusInvalidLevel MessageId = "commands.contextmenus.invalid_level"
+	MessageContextMenusOpen         MessageId = "commands.contextmenus.summary.open"
+	MessageContextMenusViewTickets  MessageId = "commands.contextmenus.summary.view_tickets"
+	MessageContextMenusBlacklist    MessageId = "commands.contextmenus.summary.blacklist"
+	MessageContextMenusLevelAdmin   MessageId = "commands.contextmenus.level.admin"
+	MessageContextMenusLevelSupport MessageId = "commands.contextmenus.level.support"
+
+	MessageTicketHistoryUserRequired MessageId = "commands.history.user_required"
+	MessageTicketHistoryEmpty        MessageId = "commands.history.empty"
+	MessageTicketHistoryCount        MessageId = "commands.history.count"
+	MessageTicketHistoryButton       MessageId = "commands.tickethistory.summary.button"
+	MessageTicketHistoryNotice       MessageId = "commands.tickethistory.summary.returning_notice"
+
+	MessageNotesTranscriptSummary MessageId = "commands.notestranscript.summary"
+
 	MessageAutoCloseConfigure MessageId = "commands.autoclose.configure"
 	MessageAutoCloseExclude   MessageId = "commands.autoclose.exclude.success"
 
@@ -232,13 +358,15 @@ var (
 	MessageOnCallSuccess       MessageId = "commands.on_call.success"
 	MessageOnCallRemoveSuccess MessageId = "commands.on_call.remove_success"
 
-	MessageReopenTicketNotFound MessageId = "commands.reopen.not_found"
-	MessageReopenNoPermission   MessageId = "commands.reopen.no_permission"
-	MessageReopenAlreadyOpen    MessageId = "commands.reopen.already_open"
-	MessageReopenNotThread      MessageId = "commands.reopen.not_thread"
-	MessageReopenThreadDeleted  MessageId = "commands.reopen.thread_deleted"
-	MessageReopenSuccess        MessageId = "commands.reopen.success"
-	MessageReopenedTicket       MessageId = "commands.reopen.in_ticket"
+	MessageReopenTicketNotFound  MessageId = "commands.reopen.not_found"
+	MessageReopenNoPermission    MessageId = "commands.reopen.no_permission"
+	MessageReopenAlreadyOpen     MessageId = "commands.reopen.already_open"
+	MessageReopenNotThread       MessageId = "commands.reopen.not_thread"
+	MessageReopenThreadDeleted   MessageId = "commands.reopen.thread_deleted"
+	MessageReopenSuccess         MessageId = "commands.reopen.success"
+	MessageReopenedTicket        MessageId = "commands.reopen.in_ticket"
+	MessageReopenAlreadyReopened MessageId = "commands.reopen.already_reopened"
+	MessageReopenOpenerLeft      MessageId = "commands.reopen.opener_left"
 
 	MessageNotesChannelModeOnly MessageId = "commands.notes.channel_mode_only"
 	MessageNotesThreadName      MessageId = "commands.notes.thread_name"
@@ -326,12 +454,15 @@ var (
 	HelpAdmin              MessageId = "help.admin"
 	HelpAdminDebug         MessageId = "help.admin.debug"
 	HelpAdminDebugServer   MessageId = "help.admin.debug.server"
+	HelpAdminDebugCloses   MessageId = "help.admin.debug.closes"
 	HelpAdminGenPremium    MessageId = "help.admin.generate_premium"
 	HelpAbout              MessageId = "help.about"
 	HelpAutoClose          MessageId = "help.autoclose"
 	HelpAutoCloseExclude   MessageId = "help.autoclose.exclude"
 	HelpAutoCloseConfigure MessageId = "help.autoclose.configure"
 	HelpVote               MessageId = "help.vote"
+	HelpFeedbackFollowUp   MessageId = "help.feedbackfollowup"
+	HelpContextMenus       MessageId = "help.contextmenus"
 	HelpAddAdmin           MessageId = "help.addadmin"
 	HelpAddSupport         MessageId = "help.addsupport"
 	HelpBlacklist          MessageId = "help.blacklist"
@@ -340,8 +471,26 @@ var (
 	HelpRemoveSupport      MessageId = "help.removesupport"
 	HelpSetup              MessageId = "help.setup"
 	HelpViewStaff          MessageId = "help.viewstaff"
+	HelpTicketHistory      MessageId = "help.tickethistory"
+	HelpTranscriptExport   MessageId = "help.transcriptexport"
+	HelpNotesTranscript    MessageId = "help.notestranscript"
+	HelpClosedCategory     MessageId = "help.closedcategory"
+	HelpCloseReasons       MessageId = "help.closereasons"
+	HelpCloseReasonAdd     MessageId = "help.closereasons.add"
+	HelpCloseReasonRemove  MessageId = "help.closereasons.remove"
+	HelpCloseReasonList    MessageId = "help.closereasons.list"
+	HelpCloseReasonRequire MessageId = "help.closereasons.require"
+	HelpSpamClose          MessageId = "help.spamclose"
+	HelpBulk               MessageId = "help.bulk"
+	HelpBulkClose          MessageId = "help.bulk.close"
+	HelpBulkClaim          MessageId = "help.bulk.claim"
+	HelpBulkMove           MessageId = "help.bulk.move"
+	HelpBulkAddLabel       MessageId = "help.bulk.add_label"
 	HelpStats              MessageId = "help.stats"
 	HelpStatsServer        MessageId = "help.statsserver"
+	HelpStatsExport        MessageId = "help.statsexport"
+	HelpStatsDigest        MessageId = "help.statsdigest"
+	HelpStatsDistribution  MessageId = "help.statsdistribution"
 	HelpManageTags         MessageId = "help.managetags"
 	HelpTagAdd             MessageId = "help.taggadd"
 	HelpTagDelete          MessageId = "help.tagdelete"
@@ -351,6 +500,7 @@ var (
 	HelpClaim              MessageId = "help.claim"
 	HelpClose              MessageId = "help.close"
 	HelpCloseRequest       MessageId = "help.close_request"
+	HelpHistory            MessageId = "help.history"
 	HelpNotes              MessageId = "help.notes"
 	HelpOpen               MessageId = "help.open"
 	HelpRemove             MessageId = "help.remove"
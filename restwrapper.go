@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/TicketsBot-cloud/gdl/cache"
 	"github.com/TicketsBot-cloud/gdl/objects/auditlog"
@@ -17,6 +18,8 @@ import (
 	"github.com/TicketsBot-cloud/gdl/objects/member"
 	"github.com/TicketsBot-cloud/gdl/objects/user"
 	"github.com/TicketsBot-cloud/gdl/rest"
+	"github.com/TicketsBot-cloud/gdl/rest/ratelimit"
+	"github.com/TicketsBot-cloud/gdl/rest/request"
 )
 
 func (ctx *Context) GetChannel(channelId uint64) (channel.Channel, error) {
@@ -49,6 +52,33 @@ func (ctx *Context) ModifyChannel(reqCtx context.Context, channelId uint64, data
 	return channel, err
 }
 
+// removeChannelParentData sends an explicit null parent, which ModifyChannelData cannot do as it omits a zero ParentId
+type removeChannelParentData struct {
+	ParentId *uint64 `json:"parent_id,string"`
+}
+
+// RemoveChannelParent moves the channel out of its category, to the top level of the guild's channel list
+func (ctx *Context) RemoveChannelParent(reqCtx context.Context, channelId uint64) (channel.Channel, error) {
+	endpoint := request.Endpoint{
+		RequestType: request.PATCH,
+		ContentType: request.ApplicationJson,
+		Endpoint:    fmt.Sprintf("/channels/%d", channelId),
+		Route:       ratelimit.NewChannelRoute(ratelimit.RouteModifyChannel, channelId),
+		RateLimiter: ctx.RateLimiter,
+	}
+
+	var ch channel.Channel
+	if err, _ := endpoint.Request(reqCtx, ctx.Token, removeChannelParentData{}, &ch); err != nil {
+		return ch, err
+	}
+
+	if ctx.Cache.Options().Channels {
+		go ctx.Cache.StoreChannel(context.Background(), ch)
+	}
+
+	return ch, nil
+}
+
 func (ctx *Context) DeleteChannel(requestCtx context.Context, channelId uint64) (channel.Channel, error) {
 	return rest.DeleteChannel(requestCtx, ctx.Token, ctx.RateLimiter, channelId)
 }
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generatedbstores writes bot/dbclient/stores.go, which declares an interface for each table of the database module
+// that the worker uses, containing the methods that the worker calls, and bot/testharness/zerostores.go, which
+// implements each interface with a store that returns zero values. It is run from bot/dbclient.
+
+const databaseModule = "github.com/TicketsBot-cloud/database"
+
+var callRegex = regexp.MustCompile(`dbclient\.Client\.([A-Z]\w*)\.([A-Z]\w*)\b`)
+
+// poolMethods are the methods that tables promote from their embedded *pgxpool.Pool, and the imports they need
+var poolMethods = map[string]string{
+	"Query":    "Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)",
+	"QueryRow": "QueryRow(ctx context.Context, sql string, args ...any) pgx.Row",
+	"Exec":     "Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)",
+}
+
+var poolImports = map[string]string{
+	"context": "context",
+	"pgx":     "github.com/jackc/pgx/v4",
+	"pgconn":  "github.com/jackc/pgconn",
+}
+
+type table struct {
+	Field       string
+	TypeName    string
+	Methods     []*ast.FuncDecl
+	PoolMethods []string
+}
+
+func main() {
+	moduleDir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", databaseModule).Output()
+	if err != nil {
+		panic(err)
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, strings.TrimSpace(string(moduleDir)), func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	pkg, ok := pkgs["database"]
+	if !ok {
+		panic("database package not found")
+	}
+
+	// Find the type of each table on the Database struct, the methods of each type, and the exported types that
+	// signatures may refer to
+	fields := make(map[string]string)
+	methods := make(map[string]map[string]*ast.FuncDecl)
+	exportedTypes := make(map[string]bool)
+	importPaths := make(map[string]string)
+
+	for _, file := range pkg.Files {
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			importPaths[importName(spec, path)] = path
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+
+					if typeSpec.Name.IsExported() {
+						exportedTypes[typeSpec.Name.Name] = true
+					}
+
+					if typeSpec.Name.Name != "Database" {
+						continue
+					}
+
+					for _, field := range typeSpec.Type.(*ast.StructType).Fields.List {
+						star, ok := field.Type.(*ast.StarExpr)
+						if !ok {
+							continue
+						}
+
+						ident, ok := star.X.(*ast.Ident)
+						if !ok {
+							continue
+						}
+
+						for _, name := range field.Names {
+							fields[name.Name] = ident.Name
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || !decl.Name.IsExported() {
+					continue
+				}
+
+				receiver := decl.Recv.List[0].Type
+				if star, ok := receiver.(*ast.StarExpr); ok {
+					receiver = star.X
+				}
+
+				typeName := receiver.(*ast.Ident).Name
+				if methods[typeName] == nil {
+					methods[typeName] = make(map[string]*ast.FuncDecl)
+				}
+
+				methods[typeName][decl.Name.Name] = decl
+			}
+		}
+	}
+
+	used, err := findUsedMethods()
+	if err != nil {
+		panic(err)
+	}
+
+	var tables []table
+	for field, methodNames := range used {
+		// Fields which are not in the database module are the worker's own tables, from workertables.go
+		typeName, ok := fields[field]
+		if !ok {
+			continue
+		}
+
+		t := table{Field: field, TypeName: typeName}
+		for _, name := range methodNames {
+			method, ok := methods[typeName][name]
+			if !ok {
+				if _, ok := poolMethods[name]; ok {
+					t.PoolMethods = append(t.PoolMethods, name)
+					continue
+				}
+
+				panic(fmt.Sprintf("database.%s has no method %s", typeName, name))
+			}
+
+			t.Methods = append(t.Methods, method)
+		}
+
+		tables = append(tables, t)
+	}
+
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Field < tables[j].Field
+	})
+
+	imports := map[string]string{"database": databaseModule}
+	zeroImports := map[string]string{
+		"database": databaseModule,
+		"dbclient": "github.com/TicketsBot-cloud/worker/bot/dbclient",
+	}
+
+	var body, zeroBody bytes.Buffer
+	for _, t := range tables {
+		fmt.Fprintf(&body, "type %sStore interface {\n", t.Field)
+		fmt.Fprintf(&zeroBody, "type zero%sStore struct{}\n\n", t.Field)
+		fmt.Fprintf(&zeroBody, "var _ dbclient.%sStore = zero%sStore{}\n\n", t.Field, t.Field)
+
+		for _, method := range t.Methods {
+			funcType := qualify(method.Type, exportedTypes, importPaths, imports).(*ast.FuncType)
+			fmt.Fprintf(&body, "\t%s%s\n", method.Name.Name, printSignature(fset, funcType))
+
+			// Naming the results allows every method to return zero values with a bare return
+			zeroType := qualify(method.Type, exportedTypes, importPaths, zeroImports).(*ast.FuncType)
+			zeroType.Results = nameResults(zeroType.Results)
+			fmt.Fprintf(&zeroBody, "func (zero%sStore) %s%s {\n\treturn\n}\n\n", t.Field, method.Name.Name, printSignature(fset, zeroType))
+		}
+
+		for _, name := range t.PoolMethods {
+			fmt.Fprintf(&body, "\t%s\n", poolMethods[name])
+			fmt.Fprintf(&zeroBody, "func (zero%sStore) %s {\n\tpanic(\"raw queries are not supported by the harness\")\n}\n\n", t.Field, poolMethods[name])
+
+			for pkgName, path := range poolImports {
+				if strings.Contains(poolMethods[name], pkgName+".") {
+					imports[pkgName] = path
+					zeroImports[pkgName] = path
+				}
+			}
+		}
+		body.WriteString("}\n\n")
+	}
+
+	body.WriteString("// Tables is the set of database module tables used by the worker\n")
+	body.WriteString("type Tables struct {\n")
+	for _, t := range tables {
+		fmt.Fprintf(&body, "\t%s %sStore\n", t.Field, t.Field)
+	}
+	body.WriteString("}\n\n")
+
+	body.WriteString("func NewTables(db *database.Database) Tables {\n\treturn Tables{\n")
+	for _, t := range tables {
+		fmt.Fprintf(&body, "\t\t%s: db.%s,\n", t.Field, t.Field)
+	}
+	body.WriteString("\t}\n}\n")
+
+	zeroBody.WriteString("// zeroTables returns tables which store nothing, and return zero values from every query\n")
+	zeroBody.WriteString("func zeroTables() dbclient.Tables {\n\treturn dbclient.Tables{\n")
+	for _, t := range tables {
+		fmt.Fprintf(&zeroBody, "\t\t%s: zero%sStore{},\n", t.Field, t.Field)
+	}
+	zeroBody.WriteString("\t}\n}\n")
+
+	writeFile("stores.go", "dbclient", imports, body.Bytes())
+	writeFile(filepath.Join("..", "testharness", "zerostores.go"), "testharness", zeroImports, zeroBody.Bytes())
+}
+
+func writeFile(path, pkgName string, imports map[string]string, body []byte) {
+	var stdImports, moduleImports []string
+	for name, path := range imports {
+		spec := fmt.Sprintf("%q", path)
+		if defaultImportName(path) != name {
+			spec = fmt.Sprintf("%s %q", name, path)
+		}
+
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			moduleImports = append(moduleImports, spec)
+		} else {
+			stdImports = append(stdImports, spec)
+		}
+	}
+	sort.Strings(stdImports)
+	sort.Strings(moduleImports)
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by /tools/cmd/generatedbstores.go; DO NOT EDIT.\n")
+	if pkgName == "dbclient" {
+		out.WriteString("//go:generate go run ../../tools/cmd/generatedbstores.go\n")
+	}
+	fmt.Fprintf(&out, "\npackage %s\n\nimport (\n", pkgName)
+	for _, spec := range stdImports {
+		fmt.Fprintf(&out, "\t%s\n", spec)
+	}
+	out.WriteString("\n")
+	for _, spec := range moduleImports {
+		fmt.Fprintf(&out, "\t%s\n", spec)
+	}
+	out.WriteString(")\n\n")
+	out.Write(body)
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	if err := os.WriteFile(path, formatted, 0644); err != nil {
+		panic(err)
+	}
+}
+
+func printSignature(fset *token.FileSet, funcType *ast.FuncType) string {
+	var signature bytes.Buffer
+	if err := printer.Fprint(&signature, fset, funcType); err != nil {
+		panic(err)
+	}
+
+	return strings.TrimPrefix(signature.String(), "func")
+}
+
+// nameResults names any unnamed results with the blank identifier
+func nameResults(results *ast.FieldList) *ast.FieldList {
+	if results == nil {
+		return nil
+	}
+
+	for _, field := range results.List {
+		if len(field.Names) == 0 {
+			field.Names = []*ast.Ident{ast.NewIdent("_")}
+		}
+	}
+
+	return results
+}
+
+// findUsedMethods returns the methods called on each table of dbclient.Client, by searching the worker's source
+func findUsedMethods() (map[string][]string, error) {
+	used := make(map[string]map[string]bool)
+
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			// exportmessages is built against an older version of the database module
+			if path != root && (d.Name() == "tools" || d.Name() == "exportmessages" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			// Ignore calls that have been commented out
+			if strings.HasPrefix(strings.TrimSpace(line), "//") {
+				continue
+			}
+
+			for _, match := range callRegex.FindAllStringSubmatch(line, -1) {
+				if used[match[1]] == nil {
+					used[match[1]] = make(map[string]bool)
+				}
+
+				used[match[1]][match[2]] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make(map[string][]string)
+	for field, methods := range used {
+		for method := range methods {
+			sorted[field] = append(sorted[field], method)
+		}
+
+		sort.Strings(sorted[field])
+	}
+
+	return sorted, nil
+}
+
+// qualify returns a copy of the node in which types declared by the database package are prefixed with the package
+// name, recording the imports that the node requires
+func qualify(node ast.Node, exportedTypes map[string]bool, importPaths, imports map[string]string) ast.Node {
+	switch node := node.(type) {
+	case *ast.FuncType:
+		return &ast.FuncType{
+			Params:  qualify(node.Params, exportedTypes, importPaths, imports).(*ast.FieldList),
+			Results: qualifyFieldList(node.Results, exportedTypes, importPaths, imports),
+		}
+	case *ast.FieldList:
+		return qualifyFieldList(node, exportedTypes, importPaths, imports)
+	case *ast.Ident:
+		if exportedTypes[node.Name] {
+			return &ast.SelectorExpr{X: ast.NewIdent("database"), Sel: ast.NewIdent(node.Name)}
+		}
+
+		return ast.NewIdent(node.Name)
+	case *ast.SelectorExpr:
+		pkgName := node.X.(*ast.Ident).Name
+		imports[pkgName] = importPaths[pkgName]
+		return &ast.SelectorExpr{X: ast.NewIdent(pkgName), Sel: ast.NewIdent(node.Sel.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(node.X, exportedTypes, importPaths, imports).(ast.Expr)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: node.Len, Elt: qualify(node.Elt, exportedTypes, importPaths, imports).(ast.Expr)}
+	case *ast.MapType:
+		return &ast.MapType{
+			Key:   qualify(node.Key, exportedTypes, importPaths, imports).(ast.Expr),
+			Value: qualify(node.Value, exportedTypes, importPaths, imports).(ast.Expr),
+		}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(node.Elt, exportedTypes, importPaths, imports).(ast.Expr)}
+	case *ast.InterfaceType, *ast.ChanType:
+		return node
+	default:
+		panic(fmt.Sprintf("unsupported type %T", node))
+	}
+}
+
+func qualifyFieldList(list *ast.FieldList, exportedTypes map[string]bool, importPaths, imports map[string]string) *ast.FieldList {
+	if list == nil {
+		return nil
+	}
+
+	qualified := &ast.FieldList{}
+	for _, field := range list.List {
+		var names []*ast.Ident
+		for _, name := range field.Names {
+			names = append(names, ast.NewIdent(name.Name))
+		}
+
+		qualified.List = append(qualified.List, &ast.Field{
+			Names: names,
+			Type:  qualify(field.Type, exportedTypes, importPaths, imports).(ast.Expr),
+		})
+	}
+
+	return qualified
+}
+
+func importName(spec *ast.ImportSpec, path string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	return defaultImportName(path)
+}
+
+var majorVersionRegex = regexp.MustCompile(`^v\d+$`)
+
+// defaultImportName returns the name that a package is imported as when no name is given, assuming that the package
+// name matches the last element of its path
+func defaultImportName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && majorVersionRegex.MatchString(name) {
+		name = parts[len(parts)-2]
+	}
+
+	return name
+}